@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: cosmos/collections/reflection/v1/reflection.proto
+
+package reflectionv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SchemaReflectionService_StoreKeys_FullMethodName        = "/cosmos.collections.reflection.v1.SchemaReflectionService/StoreKeys"
+	SchemaReflectionService_SchemaDescriptor_FullMethodName = "/cosmos.collections.reflection.v1.SchemaReflectionService/SchemaDescriptor"
+)
+
+// SchemaReflectionServiceClient is the client API for SchemaReflectionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SchemaReflectionServiceClient interface {
+	// StoreKeys returns the store keys which have a schema, in ascending order.
+	StoreKeys(ctx context.Context, in *StoreKeysRequest, opts ...grpc.CallOption) (*StoreKeysResponse, error)
+	// SchemaDescriptor returns the descriptor of the schema of the given store key.
+	SchemaDescriptor(ctx context.Context, in *SchemaDescriptorRequest, opts ...grpc.CallOption) (*SchemaDescriptorResponse, error)
+}
+
+type schemaReflectionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchemaReflectionServiceClient(cc grpc.ClientConnInterface) SchemaReflectionServiceClient {
+	return &schemaReflectionServiceClient{cc}
+}
+
+func (c *schemaReflectionServiceClient) StoreKeys(ctx context.Context, in *StoreKeysRequest, opts ...grpc.CallOption) (*StoreKeysResponse, error) {
+	out := new(StoreKeysResponse)
+	err := c.cc.Invoke(ctx, SchemaReflectionService_StoreKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaReflectionServiceClient) SchemaDescriptor(ctx context.Context, in *SchemaDescriptorRequest, opts ...grpc.CallOption) (*SchemaDescriptorResponse, error) {
+	out := new(SchemaDescriptorResponse)
+	err := c.cc.Invoke(ctx, SchemaReflectionService_SchemaDescriptor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchemaReflectionServiceServer is the server API for SchemaReflectionService service.
+// All implementations must embed UnimplementedSchemaReflectionServiceServer
+// for forward compatibility
+type SchemaReflectionServiceServer interface {
+	// StoreKeys returns the store keys which have a schema, in ascending order.
+	StoreKeys(context.Context, *StoreKeysRequest) (*StoreKeysResponse, error)
+	// SchemaDescriptor returns the descriptor of the schema of the given store key.
+	SchemaDescriptor(context.Context, *SchemaDescriptorRequest) (*SchemaDescriptorResponse, error)
+	mustEmbedUnimplementedSchemaReflectionServiceServer()
+}
+
+// UnimplementedSchemaReflectionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSchemaReflectionServiceServer struct {
+}
+
+func (UnimplementedSchemaReflectionServiceServer) StoreKeys(context.Context, *StoreKeysRequest) (*StoreKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreKeys not implemented")
+}
+func (UnimplementedSchemaReflectionServiceServer) SchemaDescriptor(context.Context, *SchemaDescriptorRequest) (*SchemaDescriptorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchemaDescriptor not implemented")
+}
+func (UnimplementedSchemaReflectionServiceServer) mustEmbedUnimplementedSchemaReflectionServiceServer() {
+}
+
+// UnsafeSchemaReflectionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchemaReflectionServiceServer will
+// result in compilation errors.
+type UnsafeSchemaReflectionServiceServer interface {
+	mustEmbedUnimplementedSchemaReflectionServiceServer()
+}
+
+func RegisterSchemaReflectionServiceServer(s grpc.ServiceRegistrar, srv SchemaReflectionServiceServer) {
+	s.RegisterService(&SchemaReflectionService_ServiceDesc, srv)
+}
+
+func _SchemaReflectionService_StoreKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaReflectionServiceServer).StoreKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaReflectionService_StoreKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaReflectionServiceServer).StoreKeys(ctx, req.(*StoreKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaReflectionService_SchemaDescriptor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaDescriptorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaReflectionServiceServer).SchemaDescriptor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaReflectionService_SchemaDescriptor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaReflectionServiceServer).SchemaDescriptor(ctx, req.(*SchemaDescriptorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SchemaReflectionService_ServiceDesc is the grpc.ServiceDesc for SchemaReflectionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SchemaReflectionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.collections.reflection.v1.SchemaReflectionService",
+	HandlerType: (*SchemaReflectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StoreKeys",
+			Handler:    _SchemaReflectionService_StoreKeys_Handler,
+		},
+		{
+			MethodName: "SchemaDescriptor",
+			Handler:    _SchemaReflectionService_SchemaDescriptor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/collections/reflection/v1/reflection.proto",
+}
@@ -803,34 +803,46 @@ func (app *BaseApp) internalFinalizeBlock(ctx context.Context, req *abci.Request
 	//
 	// NOTE: Not all raw transactions may adhere to the sdk.Tx interface, e.g.
 	// vote extensions, so skip those.
-	txResults := make([]*abci.ExecTxResult, 0, len(req.Txs))
-	for _, rawTx := range req.Txs {
-		var response *abci.ExecTxResult
-
-		if _, err := app.txDecoder(rawTx); err == nil {
-			response = app.deliverTx(rawTx)
-		} else {
-			// In the case where a transaction included in a block proposal is malformed,
-			// we still want to return a default response to comet. This is because comet
-			// expects a response for each transaction included in a block proposal.
-			response = sdkerrors.ResponseExecTxResultWithEvents(
-				sdkerrors.ErrTxDecode,
-				0,
-				0,
-				nil,
-				false,
-			)
+	//
+	// The transactions are executed in parallel when enabled, unless the block
+	// must be executed sequentially.
+	var txResults []*abci.ExecTxResult
+	if app.parallelWorkers > 0 {
+		txResults, err = app.executeTxsInParallel(ctx, req.Txs)
+		if err != nil {
+			return nil, err
 		}
+	}
+	if txResults == nil {
+		txResults = make([]*abci.ExecTxResult, 0, len(req.Txs))
+		for _, rawTx := range req.Txs {
+			var response *abci.ExecTxResult
+
+			if _, err := app.txDecoder(rawTx); err == nil {
+				response = app.deliverTx(rawTx)
+			} else {
+				// In the case where a transaction included in a block proposal is malformed,
+				// we still want to return a default response to comet. This is because comet
+				// expects a response for each transaction included in a block proposal.
+				response = sdkerrors.ResponseExecTxResultWithEvents(
+					sdkerrors.ErrTxDecode,
+					0,
+					0,
+					nil,
+					false,
+				)
+			}
 
-		// check after every tx if we should abort
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			// continue
-		}
+			// check after every tx if we should abort
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+				// continue
+			}
 
-		txResults = append(txResults, response)
+			txResults = append(txResults, response)
+		}
 	}
 
 	if app.finalizeBlockState.ms.TracingEnabled() {
@@ -1252,6 +1264,7 @@ func (app *BaseApp) CreateQueryContext(height int64, prove bool) (sdk.Context, e
 		WithMinGasPrices(app.minGasPrices).
 		WithBlockHeight(height).
 		WithGasMeter(storetypes.NewGasMeter(app.queryGasLimit)).
+		WithGasProfiler(app.gasProfiler).
 		WithHeaderInfo(coreheader.Info{
 			ChainID: app.chainID,
 			Height:  height,
@@ -246,6 +246,10 @@ func (h *DefaultProposalHandler) SetTxSelector(ts TxSelector) {
 // - If no mempool is set or if the mempool is a no-op mempool, the transactions
 // requested from CometBFT will simply be returned, which, by default, are in
 // FIFO order.
+//
+// - If the mempool is a LaneMempool, the mempools of the lanes are enumerated in
+// order, and the transactions of each lane are limited to its share of
+// RequestPrepareProposal.MaxTxBytes and of the maximum block gas.
 func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
 	return func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
 		var maxBlockGas uint64
@@ -276,80 +280,141 @@ func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHan
 			return &abci.ResponsePrepareProposal{Txs: h.txSelector.SelectedTxs(ctx)}, nil
 		}
 
-		iterator := h.mempool.Select(ctx, req.Txs)
 		selectedTxsSignersSeqs := make(map[string]uint64)
-		var selectedTxsNums int
-		for iterator != nil {
-			memTx := iterator.Tx()
-			signerData, err := h.signerExtAdapter.GetSigners(memTx)
+
+		// With a LaneMempool, the proposal is filled lane by lane, each lane using
+		// at most its share of the block space.
+		laneMempool, isLaneMempool := h.mempool.(*mempool.LaneMempool)
+		if !isLaneMempool {
+			_, _, err := h.selectTxs(ctx, h.mempool.Select(ctx, req.Txs), uint64(req.MaxTxBytes), maxBlockGas, selectedTxsSignersSeqs)
 			if err != nil {
 				return nil, err
 			}
 
-			// If the signers aren't in selectedTxsSignersSeqs then we haven't seen them before
-			// so we add them and continue given that we don't need to check the sequence.
-			shouldAdd := true
-			txSignersSeqs := make(map[string]uint64)
-			for _, signer := range signerData {
-				seq, ok := selectedTxsSignersSeqs[signer.Signer.String()]
-				if !ok {
-					txSignersSeqs[signer.Signer.String()] = signer.Sequence
-					continue
-				}
+			return &abci.ResponsePrepareProposal{Txs: h.txSelector.SelectedTxs(ctx)}, nil
+		}
 
-				// If we have seen this signer before in this block, we must make
-				// sure that the current sequence is seq+1; otherwise is invalid
-				// and we skip it.
-				if seq+1 != signer.Sequence {
-					shouldAdd = false
-					break
-				}
-				txSignersSeqs[signer.Signer.String()] = signer.Sequence
-			}
-			if !shouldAdd {
-				iterator = iterator.Next()
+		var totalTxBytes, totalTxGas uint64
+		for _, lane := range laneMempool.Lanes() {
+			maxLaneBytes, maxLaneGas := laneBlockSpace(lane, uint64(req.MaxTxBytes), maxBlockGas)
+			if maxLaneBytes == 0 || (maxBlockGas > 0 && maxLaneGas == 0) {
 				continue
 			}
 
-			// NOTE: Since transaction verification was already executed in CheckTx,
-			// which calls mempool.Insert, in theory everything in the pool should be
-			// valid. But some mempool implementations may insert invalid txs, so we
-			// check again.
-			txBz, err := h.txVerifier.PrepareProposalVerifyTx(memTx)
+			// the limits of the lane are offset by the space used by the previous
+			// lanes, as the TxSelector tracks the space used by the proposal
+			maxTxBytes := min(totalTxBytes+maxLaneBytes, uint64(req.MaxTxBytes))
+			var maxTxGas uint64
+			if maxBlockGas > 0 {
+				maxTxGas = min(totalTxGas+maxLaneGas, maxBlockGas)
+			}
+
+			txBytes, txGas, err := h.selectTxs(ctx, lane.Mempool.Select(ctx, nil), maxTxBytes, maxTxGas, selectedTxsSignersSeqs)
 			if err != nil {
-				err := h.mempool.Remove(memTx)
-				if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
-					return nil, err
-				}
-			} else {
-				stop := h.txSelector.SelectTxForProposal(ctx, uint64(req.MaxTxBytes), maxBlockGas, memTx, txBz)
-				if stop {
-					break
-				}
+				return nil, err
+			}
+			totalTxBytes += txBytes
+			totalTxGas += txGas
+		}
 
-				txsLen := len(h.txSelector.SelectedTxs(ctx))
-				for sender, seq := range txSignersSeqs {
-					// If txsLen != selectedTxsNums is true, it means that we've
-					// added a new tx to the selected txs, so we need to update
-					// the sequence of the sender.
-					if txsLen != selectedTxsNums {
-						selectedTxsSignersSeqs[sender] = seq
-					} else if _, ok := selectedTxsSignersSeqs[sender]; !ok {
-						// The transaction hasn't been added but it passed the
-						// verification, so we know that the sequence is correct.
-						// So we set this sender's sequence to seq-1, in order
-						// to avoid unnecessary calls to PrepareProposalVerifyTx.
-						selectedTxsSignersSeqs[sender] = seq - 1
-					}
-				}
-				selectedTxsNums = txsLen
+		return &abci.ResponsePrepareProposal{Txs: h.txSelector.SelectedTxs(ctx)}, nil
+	}
+}
+
+// selectTxs selects the valid transactions of the mempool iterator for the
+// proposal, until the TxSelector reaches the provided limits, and returns the
+// size and gas of the selected transactions.
+func (h *DefaultProposalHandler) selectTxs(
+	ctx sdk.Context,
+	iterator mempool.Iterator,
+	maxTxBytes, maxBlockGas uint64,
+	selectedTxsSignersSeqs map[string]uint64,
+) (txBytes, txGas uint64, err error) {
+	selectedTxsNums := len(h.txSelector.SelectedTxs(ctx))
+	for iterator != nil {
+		memTx := iterator.Tx()
+		signerData, err := h.signerExtAdapter.GetSigners(memTx)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// If the signers aren't in selectedTxsSignersSeqs then we haven't seen them before
+		// so we add them and continue given that we don't need to check the sequence.
+		shouldAdd := true
+		txSignersSeqs := make(map[string]uint64)
+		for _, signer := range signerData {
+			seq, ok := selectedTxsSignersSeqs[signer.Signer.String()]
+			if !ok {
+				txSignersSeqs[signer.Signer.String()] = signer.Sequence
+				continue
 			}
 
+			// If we have seen this signer before in this block, we must make
+			// sure that the current sequence is seq+1; otherwise is invalid
+			// and we skip it.
+			if seq+1 != signer.Sequence {
+				shouldAdd = false
+				break
+			}
+			txSignersSeqs[signer.Signer.String()] = signer.Sequence
+		}
+		if !shouldAdd {
 			iterator = iterator.Next()
+			continue
 		}
 
-		return &abci.ResponsePrepareProposal{Txs: h.txSelector.SelectedTxs(ctx)}, nil
+		// NOTE: Since transaction verification was already executed in CheckTx,
+		// which calls mempool.Insert, in theory everything in the pool should be
+		// valid. But some mempool implementations may insert invalid txs, so we
+		// check again.
+		txBz, err := h.txVerifier.PrepareProposalVerifyTx(memTx)
+		if err != nil {
+			err := h.mempool.Remove(memTx)
+			if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
+				return 0, 0, err
+			}
+		} else {
+			stop := h.txSelector.SelectTxForProposal(ctx, maxTxBytes, maxBlockGas, memTx, txBz)
+
+			txsLen := len(h.txSelector.SelectedTxs(ctx))
+			if txsLen != selectedTxsNums {
+				txBytes += uint64(cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{txBz}))
+				if gasTx, ok := memTx.(GasTx); ok {
+					txGas += gasTx.GetGas()
+				}
+			}
+			for sender, seq := range txSignersSeqs {
+				// If txsLen != selectedTxsNums is true, it means that we've
+				// added a new tx to the selected txs, so we need to update
+				// the sequence of the sender.
+				if txsLen != selectedTxsNums {
+					selectedTxsSignersSeqs[sender] = seq
+				} else if _, ok := selectedTxsSignersSeqs[sender]; !ok {
+					// The transaction hasn't been added but it passed the
+					// verification, so we know that the sequence is correct.
+					// So we set this sender's sequence to seq-1, in order
+					// to avoid unnecessary calls to PrepareProposalVerifyTx.
+					selectedTxsSignersSeqs[sender] = seq - 1
+				}
+			}
+			selectedTxsNums = txsLen
+
+			if stop {
+				break
+			}
+		}
+
+		iterator = iterator.Next()
 	}
+
+	return txBytes, txGas, nil
+}
+
+// laneBlockSpace returns the maximum size and gas of the transactions of the
+// lane, given the maximum size and gas of the transactions of the block. The
+// maximum gas is zero when the block gas isn't limited.
+func laneBlockSpace(lane mempool.Lane, maxTxBytes, maxBlockGas uint64) (maxLaneBytes, maxLaneGas uint64) {
+	return maxTxBytes * lane.MaxBlockSpace / 100, maxBlockGas * lane.MaxBlockSpace / 100
 }
 
 // ProcessProposalHandler returns the default implementation for processing an
@@ -363,6 +428,10 @@ func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHan
 // DefaultPrepareProposal. It is very important that the same validation logic
 // is used in both steps, and applications must ensure that this is the case in
 // non-default handlers.
+//
+// If the mempool is a LaneMempool, the proposal is also rejected if its
+// transactions aren't grouped by lane in the order of the lanes, or if the
+// transactions of a lane exceed its share of the maximum block size or gas.
 func (h *DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHandler {
 	// If the mempool is nil or NoOp we simply return ACCEPT,
 	// because PrepareProposal may have included txs that could fail verification.
@@ -374,17 +443,47 @@ func (h *DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHan
 	return func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
 		var totalTxGas uint64
 
-		var maxBlockGas int64
+		var maxBlockGas, maxBlockBytes int64
 		if b := ctx.ConsensusParams().Block; b != nil {
 			maxBlockGas = b.MaxGas
+			maxBlockBytes = b.MaxBytes
 		}
 
+		laneMempool, isLaneMempool := h.mempool.(*mempool.LaneMempool)
+		var (
+			currentLane            int
+			laneTxBytes, laneTxGas uint64
+		)
+
 		for _, txBytes := range req.Txs {
 			tx, err := h.txVerifier.ProcessProposalVerifyTx(txBytes)
 			if err != nil {
 				return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
 			}
 
+			if isLaneMempool {
+				lane, ok := laneMempool.MatchLane(tx)
+				if !ok || lane < currentLane {
+					return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
+				}
+				if lane > currentLane {
+					currentLane, laneTxBytes, laneTxGas = lane, 0, 0
+				}
+
+				laneTxBytes += uint64(cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{txBytes}))
+				if gasTx, ok := tx.(GasTx); ok {
+					laneTxGas += gasTx.GetGas()
+				}
+
+				// the size of the proposal is bounded by the maximum block size,
+				// which is larger than the maximum size of the transactions used by
+				// PrepareProposal
+				maxLaneBytes, maxLaneGas := laneBlockSpace(laneMempool.Lanes()[lane], uint64(max(maxBlockBytes, 0)), uint64(max(maxBlockGas, 0)))
+				if (maxBlockBytes > 0 && laneTxBytes > maxLaneBytes) || (maxBlockGas > 0 && laneTxGas > maxLaneGas) {
+					return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
+				}
+			}
+
 			if maxBlockGas > 0 {
 				gasTx, ok := tx.(GasTx)
 				if ok {
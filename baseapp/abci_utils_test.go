@@ -705,6 +705,99 @@ func (s *ABCIUtilsTestSuite) TestDefaultProposalHandler_PriorityNonceMempoolTxSe
 	}
 }
 
+func (s *ABCIUtilsTestSuite) TestDefaultProposalHandler_LaneMempoolTxSelection() {
+	cdc := codectestutil.CodecOptions{}.NewCodec()
+	baseapptestutil.RegisterInterfaces(cdc.InterfaceRegistry())
+	signingCtx := cdc.InterfaceRegistry().SigningContext()
+	txConfig := authtx.NewTxConfig(cdc, signingCtx.AddressCodec(), signingCtx.ValidatorAddressCodec(), authtx.DefaultSignModes)
+
+	isOracleTx := func(tx sdk.Tx) bool {
+		return bytes.HasPrefix(tx.GetMsgs()[0].(*baseapptestutil.MsgKeyValue).Value, []byte("oracle"))
+	}
+	newLaneMempool := func() *mempool.LaneMempool {
+		newPriorityMempool := func() mempool.Mempool {
+			return mempool.NewPriorityMempool(mempool.PriorityNonceMempoolConfig[int64]{
+				TxPriority:      mempool.NewDefaultTxPriority(),
+				SignerExtractor: mempool.NewDefaultSignerExtractionAdapter(),
+			})
+		}
+		return mempool.NewLaneMempool(
+			mempool.Lane{Name: "oracle", Mempool: newPriorityMempool(), Match: isOracleTx, MaxBlockSpace: 50},
+			mempool.Lane{Name: "default", Mempool: newPriorityMempool(), MaxBlockSpace: 50},
+		)
+	}
+
+	// the spam transactions have higher priorities than the oracle ones
+	var (
+		txs  []sdk.Tx
+		txBz [][]byte
+	)
+	for i, value := range []string{"spam001", "spam002", "spam003", "oracle1", "oracle2", "oracle3"} {
+		tx := buildMsg(s.T(), txConfig, []byte(value), [][]byte{[]byte(value)}, []uint64{1})
+		bz, err := txConfig.TxEncoder()(tx)
+		s.Require().NoError(err)
+		txs = append(txs, tx)
+		txBz = append(txBz, bz)
+		if i > 0 {
+			s.Require().Len(bz, len(txBz[0]))
+		}
+	}
+	txSize := cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{txBz[0]})
+
+	ctrl := gomock.NewController(s.T())
+	app := mock.NewMockProposalTxVerifier(ctrl)
+	mp := newLaneMempool()
+	for i, tx := range txs {
+		app.EXPECT().PrepareProposalVerifyTx(tx).Return(txBz[i], nil).AnyTimes()
+		app.EXPECT().ProcessProposalVerifyTx(txBz[i]).Return(tx, nil).AnyTimes()
+		s.Require().NoError(mp.Insert(s.ctx.WithPriority(int64(10-i)), tx))
+	}
+	ph := baseapp.NewDefaultProposalHandler(mp, app)
+
+	// each lane may use half of the block, which holds 4 transactions
+	resp, err := ph.PrepareProposalHandler()(s.ctx, &abci.RequestPrepareProposal{MaxTxBytes: 4 * txSize})
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{txBz[3], txBz[4], txBz[0], txBz[1]}, resp.Txs)
+
+	// the unused space of a lane isn't used by the others
+	onlySpam := newLaneMempool()
+	for i, tx := range txs[:3] {
+		s.Require().NoError(onlySpam.Insert(s.ctx.WithPriority(int64(10-i)), tx))
+	}
+	resp, err = baseapp.NewDefaultProposalHandler(onlySpam, app).PrepareProposalHandler()(s.ctx, &abci.RequestPrepareProposal{MaxTxBytes: 4 * txSize})
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{txBz[0], txBz[1]}, resp.Txs)
+
+	ctx := s.ctx.WithConsensusParams(cmtproto.ConsensusParams{
+		Block: &cmtproto.BlockParams{MaxBytes: 4 * txSize},
+	})
+	testCases := map[string]struct {
+		txs    [][]byte
+		status abci.ResponseProcessProposal_ProposalStatus
+	}{
+		"prepared proposal": {
+			txs:    [][]byte{txBz[3], txBz[4], txBz[0], txBz[1]},
+			status: abci.ResponseProcessProposal_ACCEPT,
+		},
+		"lanes out of order": {
+			txs:    [][]byte{txBz[0], txBz[3]},
+			status: abci.ResponseProcessProposal_REJECT,
+		},
+		"lane exceeding its block space": {
+			txs:    [][]byte{txBz[0], txBz[1], txBz[2]},
+			status: abci.ResponseProcessProposal_REJECT,
+		},
+	}
+
+	for name, tc := range testCases {
+		s.Run(name, func() {
+			resp, err := ph.ProcessProposalHandler()(ctx, &abci.RequestProcessProposal{Txs: tc.txs})
+			s.Require().NoError(err)
+			s.Require().Equal(tc.status, resp.Status)
+		})
+	}
+}
+
 func marshalDelimitedFn(msg proto.Message) ([]byte, error) {
 	var buf bytes.Buffer
 	if err := protoio.NewDelimitedWriter(&buf).WriteMsg(msg); err != nil {
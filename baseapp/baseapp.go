@@ -32,6 +32,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
 	"github.com/cosmos/cosmos-sdk/types/mempool"
 )
 
@@ -133,6 +134,10 @@ type BaseApp struct {
 	// queryGasLimit defines the maximum gas for queries; unbounded if 0.
 	queryGasLimit uint64
 
+	// traceTxSem limits the number of concurrent TraceTx calls; TraceTx is
+	// disabled if nil.
+	traceTxSem chan struct{}
+
 	// The minimum gas prices a validator is willing to accept for processing a
 	// transaction. This is mainly used for DoS and spam prevention.
 	minGasPrices sdk.DecCoins
@@ -185,6 +190,19 @@ type BaseApp struct {
 	// including the goroutine handling.This is experimental and must be enabled
 	// by developers.
 	optimisticExec *oe.OptimisticExecution
+
+	// parallelWorkers is the number of workers executing the transactions of
+	// FinalizeBlock in parallel, which is disabled when zero. This is
+	// experimental and must be enabled by developers.
+	parallelWorkers int
+
+	// stateOverrideHandlers apply the typed state overrides of simulations, by
+	// type URL.
+	stateOverrideHandlers map[string]stateOverrideHandler
+
+	// gasProfiler profiles the gas consumed by the transactions of simulations
+	// and FinalizeBlock, and by queries, when set.
+	gasProfiler *gasprofile.Profiler
 }
 
 // NewBaseApp returns a reference to an initialized BaseApp. It accepts a
@@ -205,6 +223,8 @@ func NewBaseApp(
 		fauxMerkleMode:   false,
 		sigverifyTx:      true,
 		queryGasLimit:    math.MaxUint64,
+
+		stateOverrideHandlers: make(map[string]stateOverrideHandler),
 	}
 
 	for _, option := range options {
@@ -678,6 +698,10 @@ func (app *BaseApp) getContextForTx(mode execMode, txBytes []byte) sdk.Context {
 		ctx = ctx.WithIsReCheckTx(true)
 	}
 
+	if mode == execModeSimulate || mode == execModeFinalize {
+		ctx = ctx.WithGasProfiler(app.gasProfiler)
+	}
+
 	if mode == execModeSimulate {
 		ctx, _ = ctx.CacheContext()
 	}
@@ -748,7 +772,13 @@ func (app *BaseApp) beginBlock(req *abci.RequestFinalizeBlock) (sdk.BeginBlock,
 }
 
 func (app *BaseApp) deliverTx(tx []byte) *abci.ExecTxResult {
-	gInfo := sdk.GasInfo{}
+	gInfo, result, anteEvents, err := app.runTx(execModeFinalize, tx)
+	return app.execTxResult(gInfo, result, anteEvents, err)
+}
+
+// execTxResult returns the response of a transaction executed in
+// FinalizeBlock and records its telemetry.
+func (app *BaseApp) execTxResult(gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, err error) *abci.ExecTxResult {
 	resultStr := "successful"
 
 	var resp *abci.ExecTxResult
@@ -760,7 +790,6 @@ func (app *BaseApp) deliverTx(tx []byte) *abci.ExecTxResult {
 		telemetry.SetGauge(float32(gInfo.GasWanted), "tx", "gas", "wanted")
 	}()
 
-	gInfo, result, anteEvents, err := app.runTx(execModeFinalize, tx)
 	if err != nil {
 		resultStr = "failed"
 		resp = sdkerrors.ResponseExecTxResultWithEvents(
@@ -818,12 +847,20 @@ func (app *BaseApp) endBlock(ctx context.Context) (sdk.EndBlock, error) {
 // returned if the tx does not run out of gas and if all the messages are valid
 // and execute successfully. An error is returned otherwise.
 func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, err error) {
+	return app.runTxWithContext(mode, app.getContextForTx(mode, txBytes), txBytes, app.mempool.Remove)
+}
+
+// runTxWithContext runs a transaction like runTx with the provided context,
+// calling removeTx to remove the transaction from the mempool in
+// execModeFinalize.
+func (app *BaseApp) runTxWithContext(
+	mode execMode, ctx sdk.Context, txBytes []byte, removeTx func(sdk.Tx) error,
+) (gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, err error) {
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter, so we initialize upfront.
 	var gasWanted uint64
 
-	ctx := app.getContextForTx(mode, txBytes)
 	ms := ctx.MultiStore()
 
 	// only run the tx if there is block gas remaining
@@ -889,7 +926,9 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 		// writes do not happen if aborted/failed.  This may have some
 		// performance benefits, but it'll be more difficult to get right.
 		anteCtx, msCache = app.cacheTxContext(ctx, txBytes)
-		anteCtx = anteCtx.WithEventManager(sdk.NewEventManager())
+		anteCtx = anteCtx.
+			WithEventManager(sdk.NewEventManager()).
+			WithGasProfileFrame("ante")
 		if mode == execModeSimulate {
 			anteCtx = anteCtx.WithExecMode(sdk.ExecMode(execModeSimulate))
 		}
@@ -902,7 +941,7 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 			// Also, in the case of the tx aborting, we need to track gas consumed via
 			// the instantiated gas meter in the AnteHandler, so we update the context
 			// prior to returning.
-			ctx = newCtx.WithMultiStore(ms)
+			ctx = newCtx.WithMultiStore(ms).WithGasProfileStack(ctx.GasProfileStack())
 		}
 
 		events := ctx.EventManager().Events()
@@ -924,7 +963,7 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 			return gInfo, nil, anteEvents, err
 		}
 	} else if mode == execModeFinalize {
-		err = app.mempool.Remove(tx)
+		err = removeTx(tx)
 		if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
 			return gInfo, nil, anteEvents,
 				fmt.Errorf("failed to remove tx from mempool: %w", err)
@@ -951,7 +990,9 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 		// The runMsgCtx context currently contains events emitted by the ante handler.
 		// We clear this to correctly order events without duplicates.
 		// Note that the state is still preserved.
-		postCtx := runMsgCtx.WithEventManager(sdk.NewEventManager())
+		postCtx := runMsgCtx.
+			WithEventManager(sdk.NewEventManager()).
+			WithGasProfileFrame("post")
 
 		newCtx, errPostHandler := app.postHandler(postCtx, tx, mode == execModeSimulate, err == nil)
 		if errPostHandler != nil {
@@ -970,6 +1011,10 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 			// When block gas exceeds, it'll panic and won't commit the cached store.
 			consumeBlockGas()
 
+			msCache.Write()
+		} else if mode == execModeSimulate {
+			// The state of a simulation is a branch which is discarded, written
+			// so that SimulateWithOverrides can collect the state diff.
 			msCache.Write()
 		}
 
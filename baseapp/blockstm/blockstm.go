@@ -0,0 +1,159 @@
+// Package blockstm executes the transactions of a block in parallel, following
+// the Block-STM algorithm.
+//
+// Transactions are executed optimistically, each incarnation of a transaction
+// reading the values written by the preceding transactions of the block from a
+// multi-version memory, and recording its read and write sets. Once executed, a
+// transaction is validated by checking that the values it read didn't change,
+// and is otherwise executed again. Once every transaction is validated, their
+// writes are applied to the storage in the order of the block, which yields the
+// same state as executing them sequentially.
+package blockstm
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// ExecuteFn executes a transaction of the block against the provided
+// MultiStore. It is called concurrently, and possibly several times for the
+// same transaction, so it must not have side effects outside of the store
+// other than recording the result of the transaction, the result of the last
+// call for each transaction being the valid one. It must not panic.
+type ExecuteFn func(txn TxnIndex, store storetypes.MultiStore)
+
+// ExecuteBlock executes the blockSize transactions of a block with the provided
+// number of workers, and writes the changes they made to the stores of the keys
+// to the storage. The storage is only read during the execution, and must not be
+// used concurrently.
+//
+// If the context is canceled, ExecuteBlock returns its error without writing to
+// the storage.
+func ExecuteBlock(
+	ctx context.Context,
+	blockSize int,
+	keys []storetypes.StoreKey,
+	storage storetypes.MultiStore,
+	workers int,
+	executeFn ExecuteFn,
+) error {
+	if blockSize == 0 {
+		return nil
+	}
+
+	mtx := &sync.Mutex{}
+	stores := make([]storetypes.KVStore, len(keys))
+	for i, key := range keys {
+		stores[i] = lockedStore{KVStore: storage.GetKVStore(key), mtx: mtx}
+	}
+
+	e := &executor{
+		ctx:       ctx,
+		keys:      keys,
+		storage:   storage,
+		stores:    stores,
+		mv:        NewMVMemory(blockSize, len(keys)),
+		scheduler: newScheduler(blockSize),
+		executeFn: executeFn,
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			e.run()
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.commit()
+	return nil
+}
+
+type executor struct {
+	ctx       context.Context
+	keys      []storetypes.StoreKey
+	storage   storetypes.MultiStore
+	stores    []storetypes.KVStore
+	mv        *MVMemory
+	scheduler *scheduler
+	executeFn ExecuteFn
+}
+
+// run is the loop of a worker, which performs tasks until the block is done.
+func (e *executor) run() {
+	var (
+		v    version
+		task taskKind
+	)
+	for !e.scheduler.isDone() {
+		if e.ctx.Err() != nil {
+			e.scheduler.abort()
+			e.mv.abort()
+			return
+		}
+
+		switch task {
+		case taskExecution:
+			v, task = e.tryExecute(v)
+		case taskValidation:
+			v, task = e.needsReexecution(v)
+		default:
+			v, task = e.scheduler.nextTask()
+		}
+	}
+}
+
+func (e *executor) tryExecute(v version) (version, taskKind) {
+	ms := newMultiStore(e.storage, e.keys, e.stores, e.mv, v)
+	e.executeFn(v.txn, ms)
+	wroteNewLocation := e.mv.record(v, ms.reads, ms.writeSet())
+	return e.scheduler.finishExecution(v, wroteNewLocation)
+}
+
+func (e *executor) needsReexecution(v version) (version, taskKind) {
+	valid := e.mv.validateReadSet(v.txn, e.stores)
+	aborted := !valid && e.scheduler.tryValidationAbort(v)
+	if aborted {
+		e.mv.convertWritesToEstimates(v.txn)
+	}
+	return e.scheduler.finishValidation(v.txn, aborted)
+}
+
+// commit writes the write sets of the transactions to the storage in the order
+// of the block.
+func (e *executor) commit() {
+	for txn := range e.mv.lastWrites {
+		writes := e.mv.lastWriteSet(TxnIndex(txn))
+		locs := make([]location, 0, len(writes))
+		for loc := range writes {
+			locs = append(locs, loc)
+		}
+		sort.Slice(locs, func(i, j int) bool {
+			if locs[i].store != locs[j].store {
+				return locs[i].store < locs[j].store
+			}
+			return locs[i].key < locs[j].key
+		})
+
+		for _, loc := range locs {
+			store := e.storage.GetKVStore(e.keys[loc.store])
+			if value := writes[loc]; value != nil {
+				store.Set([]byte(loc.key), value)
+			} else {
+				store.Delete([]byte(loc.key))
+			}
+		}
+	}
+}
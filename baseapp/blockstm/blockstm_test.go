@@ -0,0 +1,179 @@
+package blockstm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+)
+
+type opKind int
+
+const (
+	opRead opKind = iota
+	opWrite
+	opDelete
+	opIterate
+)
+
+type op struct {
+	kind     opKind
+	store    int
+	key      []byte
+	end      []byte
+	reverse  bool
+	maxItems int
+}
+
+// randomKey returns one of few keys, so that transactions conflict.
+func randomKey(r *rand.Rand) []byte {
+	return []byte{byte('a' + r.Intn(8))}
+}
+
+func randomTxn(r *rand.Rand, numStores int) []op {
+	ops := make([]op, 1+r.Intn(8))
+	for i := range ops {
+		ops[i] = op{kind: opKind(r.Intn(4)), store: r.Intn(numStores), key: randomKey(r)}
+		if ops[i].kind == opIterate {
+			ops[i].end = randomKey(r)
+			if r.Intn(4) == 0 {
+				ops[i].key = nil
+			}
+			if r.Intn(4) == 0 {
+				ops[i].end = nil
+			}
+			ops[i].reverse = r.Intn(2) == 0
+			ops[i].maxItems = 1 + r.Intn(4)
+		}
+	}
+	return ops
+}
+
+// runTxn executes the operations of a transaction, writing values derived from
+// everything it read, and returns the hash of everything it read.
+func runTxn(txn int, ops []op, keys []storetypes.StoreKey, ms storetypes.MultiStore) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d", txn)
+	for _, o := range ops {
+		store := ms.GetKVStore(keys[o.store])
+		switch o.kind {
+		case opRead:
+			h.Write(o.key)
+			h.Write(store.Get(o.key))
+		case opWrite:
+			store.Set(o.key, h.Sum(nil)[:4])
+		case opDelete:
+			store.Delete(o.key)
+		case opIterate:
+			var it storetypes.Iterator
+			if o.reverse {
+				it = store.ReverseIterator(o.key, o.end)
+			} else {
+				it = store.Iterator(o.key, o.end)
+			}
+			for i := 0; it.Valid() && i < o.maxItems; it.Next() {
+				h.Write(it.Key())
+				h.Write(it.Value())
+				i++
+			}
+			_ = it.Close()
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func newStorage(t *testing.T, r *rand.Rand, keys []storetypes.StoreKey) storetypes.CacheMultiStore {
+	t.Helper()
+	rs := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	for _, key := range keys {
+		rs.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	}
+	require.NoError(t, rs.LoadLatestVersion())
+
+	for _, key := range keys {
+		store := rs.GetKVStore(key)
+		for i := 0; i < 4; i++ {
+			value := make([]byte, 8)
+			binary.BigEndian.PutUint64(value, r.Uint64())
+			store.Set(randomKey(r), value)
+		}
+	}
+	rs.Commit()
+
+	return rs.CacheMultiStore()
+}
+
+func storeContents(ms storetypes.MultiStore, keys []storetypes.StoreKey) []string {
+	var res []string
+	for _, key := range keys {
+		it := ms.GetKVStore(key).Iterator(nil, nil)
+		for ; it.Valid(); it.Next() {
+			res = append(res, fmt.Sprintf("%s/%s=%x", key.Name(), it.Key(), it.Value()))
+		}
+		_ = it.Close()
+	}
+	return res
+}
+
+func TestExecuteBlock(t *testing.T) {
+	keys := []storetypes.StoreKey{storetypes.NewKVStoreKey("a"), storetypes.NewKVStoreKey("b")}
+
+	for seed := int64(0); seed < 50; seed++ {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			r := rand.New(rand.NewSource(seed))
+			txns := make([][]op, 1+r.Intn(40))
+			for i := range txns {
+				txns[i] = randomTxn(r, len(keys))
+			}
+			workers := 1 + r.Intn(8)
+
+			// the storage is initialized the same way for both executions
+			sequential := newStorage(t, rand.New(rand.NewSource(seed)), keys)
+			parallel := newStorage(t, rand.New(rand.NewSource(seed)), keys)
+
+			expected := make([]string, len(txns))
+			for i, ops := range txns {
+				cache := sequential.CacheMultiStore()
+				expected[i] = runTxn(i, ops, keys, cache)
+				cache.Write()
+			}
+
+			results := make([]string, len(txns))
+			err := ExecuteBlock(context.Background(), len(txns), keys, parallel, workers, func(txn TxnIndex, ms storetypes.MultiStore) {
+				cache := ms.CacheMultiStore()
+				results[txn] = runTxn(int(txn), txns[txn], keys, cache)
+				cache.Write()
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, expected, results)
+			require.Equal(t, storeContents(sequential, keys), storeContents(parallel, keys))
+		})
+	}
+}
+
+func TestExecuteBlockCanceled(t *testing.T) {
+	keys := []storetypes.StoreKey{storetypes.NewKVStoreKey("a")}
+	storage := newStorage(t, rand.New(rand.NewSource(0)), keys)
+	before := storeContents(storage, keys)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := ExecuteBlock(ctx, 10, keys, storage, 2, func(txn TxnIndex, ms storetypes.MultiStore) {
+		ms.GetKVStore(keys[0]).Set([]byte("key"), []byte{byte(txn)})
+		if txn == 5 {
+			cancel()
+		}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, before, storeContents(storage, keys))
+}
@@ -0,0 +1,192 @@
+package blockstm
+
+import (
+	"bytes"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// versionedValue is the value of a key along with the version which wrote it.
+// Deletions have a nil value.
+type versionedValue struct {
+	key     []byte
+	value   []byte
+	version version
+}
+
+// versionedIterator is an iterator which exposes the version of its values.
+type versionedIterator interface {
+	storetypes.Iterator
+
+	version() version
+}
+
+// storageIterator is an iterator over the storage.
+type storageIterator struct {
+	storetypes.Iterator
+}
+
+func (storageIterator) version() version {
+	return storageVersion
+}
+
+// mergedIterator merges a parent iterator with sorted values overriding it,
+// skipping deletions.
+type mergedIterator struct {
+	parent  versionedIterator
+	values  []versionedValue
+	pos     int
+	reverse bool
+	// useValue is true when the current item is values[pos] rather than the
+	// current item of the parent
+	useValue bool
+	valid    bool
+}
+
+var _ versionedIterator = &mergedIterator{}
+
+// newStorageIterator returns an iterator over the [start, end) range of the
+// storage.
+func newStorageIterator(storage storetypes.KVStore, start, end []byte, reverse bool) versionedIterator {
+	if reverse {
+		return storageIterator{storage.ReverseIterator(start, end)}
+	}
+	return storageIterator{storage.Iterator(start, end)}
+}
+
+// newMergedIterator returns an iterator over the parent overridden by the
+// provided values, which must be sorted in iteration order.
+func newMergedIterator(parent versionedIterator, values []versionedValue, reverse bool) *mergedIterator {
+	it := &mergedIterator{parent: parent, values: values, reverse: reverse}
+	it.settle()
+	return it
+}
+
+// compare compares the keys in iteration order.
+func (it *mergedIterator) compare(a, b []byte) int {
+	if it.reverse {
+		return bytes.Compare(b, a)
+	}
+	return bytes.Compare(a, b)
+}
+
+// settle moves the iterator to the next item which isn't a deletion.
+func (it *mergedIterator) settle() {
+	for {
+		if it.pos >= len(it.values) {
+			it.useValue = false
+			it.valid = it.parent.Valid()
+			return
+		}
+
+		value := it.values[it.pos]
+		if it.parent.Valid() {
+			cmp := it.compare(it.parent.Key(), value.key)
+			if cmp < 0 {
+				it.useValue = false
+				it.valid = true
+				return
+			}
+			if cmp == 0 {
+				// values override the parent
+				it.parent.Next()
+			}
+		}
+
+		if value.value == nil {
+			it.pos++
+			continue
+		}
+
+		it.useValue = true
+		it.valid = true
+		return
+	}
+}
+
+func (it *mergedIterator) Domain() (start, end []byte) {
+	return it.parent.Domain()
+}
+
+func (it *mergedIterator) Valid() bool {
+	return it.valid
+}
+
+func (it *mergedIterator) Next() {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+
+	if it.useValue {
+		it.pos++
+	} else {
+		it.parent.Next()
+	}
+	it.settle()
+}
+
+func (it *mergedIterator) Key() []byte {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+
+	if it.useValue {
+		return it.values[it.pos].key
+	}
+	return it.parent.Key()
+}
+
+func (it *mergedIterator) Value() []byte {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+
+	if it.useValue {
+		return it.values[it.pos].value
+	}
+	return it.parent.Value()
+}
+
+func (it *mergedIterator) version() version {
+	if it.useValue {
+		return it.values[it.pos].version
+	}
+	return it.parent.version()
+}
+
+func (it *mergedIterator) Error() error {
+	return it.parent.Error()
+}
+
+func (it *mergedIterator) Close() error {
+	return it.parent.Close()
+}
+
+// recordingIterator records the keys and versions yielded by an iterator.
+type recordingIterator struct {
+	versionedIterator
+	record *iterationRead
+	// recorded is true once the current item is recorded
+	recorded bool
+}
+
+func (it *recordingIterator) Valid() bool {
+	valid := it.versionedIterator.Valid()
+	if !it.recorded {
+		it.recorded = true
+		if valid {
+			it.record.observed = append(it.record.observed, versionedValue{
+				key:     bytes.Clone(it.versionedIterator.Key()),
+				version: it.versionedIterator.version(),
+			})
+		} else {
+			it.record.exhausted = true
+		}
+	}
+	return valid
+}
+
+func (it *recordingIterator) Next() {
+	it.versionedIterator.Next()
+	it.recorded = false
+}
@@ -0,0 +1,99 @@
+package blockstm
+
+import (
+	"errors"
+	"io"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"cosmossdk.io/store/cachemulti"
+	"cosmossdk.io/store/dbadapter"
+	storetypes "cosmossdk.io/store/types"
+)
+
+// multiStore is the MultiStore seen by an incarnation of a transaction, made
+// of the views of every store of the block.
+type multiStore struct {
+	storage storetypes.MultiStore
+	keys    []storetypes.StoreKey
+	views   map[storetypes.StoreKey]*view
+	reads   *readSet
+}
+
+var _ storetypes.MultiStore = &multiStore{}
+
+func newMultiStore(storage storetypes.MultiStore, keys []storetypes.StoreKey, stores []storetypes.KVStore, mv *MVMemory, v version) *multiStore {
+	ms := &multiStore{
+		storage: storage,
+		keys:    keys,
+		views:   make(map[storetypes.StoreKey]*view, len(keys)),
+		reads:   newReadSet(),
+	}
+	for i, key := range keys {
+		ms.views[key] = newView(i, stores[i], mv, v, ms.reads)
+	}
+	return ms
+}
+
+// writeSet returns the values written by the incarnation to every store.
+func (ms *multiStore) writeSet() writeSet {
+	writes := make(writeSet)
+	for _, key := range ms.keys {
+		ms.views[key].collectWrites(writes)
+	}
+	return writes
+}
+
+func (ms *multiStore) GetStoreType() storetypes.StoreType {
+	return storetypes.StoreTypeMulti
+}
+
+func (ms *multiStore) CacheWrap() storetypes.CacheWrap {
+	return ms.CacheMultiStore().(storetypes.CacheWrap)
+}
+
+func (ms *multiStore) CacheWrapWithTrace(_ io.Writer, _ storetypes.TraceContext) storetypes.CacheWrap {
+	return ms.CacheWrap()
+}
+
+func (ms *multiStore) CacheMultiStore() storetypes.CacheMultiStore {
+	stores := make(map[storetypes.StoreKey]storetypes.CacheWrapper, len(ms.views))
+	keysByName := make(map[string]storetypes.StoreKey, len(ms.views))
+	for key, view := range ms.views {
+		stores[key] = view
+		keysByName[key.Name()] = key
+	}
+	return cachemulti.NewFromKVStore(dbadapter.Store{DB: dbm.NewMemDB()}, stores, keysByName, nil, nil)
+}
+
+func (ms *multiStore) CacheMultiStoreWithVersion(_ int64) (storetypes.CacheMultiStore, error) {
+	return nil, errors.New("cannot branch the multi-store of a transaction at a version")
+}
+
+func (ms *multiStore) GetStore(key storetypes.StoreKey) storetypes.Store {
+	return ms.GetKVStore(key)
+}
+
+func (ms *multiStore) GetKVStore(key storetypes.StoreKey) storetypes.KVStore {
+	view, ok := ms.views[key]
+	if !ok {
+		panic("store does not exist for key: " + key.Name())
+	}
+	return view
+}
+
+func (ms *multiStore) TracingEnabled() bool {
+	return false
+}
+
+func (ms *multiStore) SetTracer(_ io.Writer) storetypes.MultiStore {
+	return ms
+}
+
+func (ms *multiStore) SetTracingContext(_ storetypes.TraceContext) storetypes.MultiStore {
+	return ms
+}
+
+func (ms *multiStore) LatestVersion() int64 {
+	return ms.storage.LatestVersion()
+}
@@ -0,0 +1,391 @@
+package blockstm
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tidwall/btree"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// TxnIndex is the index of a transaction in its block.
+type TxnIndex int
+
+// storageTxn is the transaction index of the versions read from the storage,
+// which aren't written by any transaction of the block.
+const storageTxn TxnIndex = -1
+
+// version identifies an incarnation of a transaction, which is its execution
+// number.
+type version struct {
+	txn         TxnIndex
+	incarnation int
+}
+
+// storageVersion is the version of the values read from the storage.
+var storageVersion = version{txn: storageTxn}
+
+// mvEntry is the value written to a key by an incarnation of a transaction.
+type mvEntry struct {
+	txn         TxnIndex
+	incarnation int
+	// value is nil for deletions
+	value []byte
+	// estimate marks the entries of an aborted incarnation, which will likely
+	// be written again by the next incarnation.
+	estimate bool
+}
+
+// mvItem holds the entries written to a key, sorted by transaction index.
+type mvItem struct {
+	key     []byte
+	mtx     sync.RWMutex
+	entries []mvEntry
+}
+
+// latest returns the entry written by the highest transaction lower than txn.
+func (item *mvItem) latest(txn TxnIndex) (mvEntry, bool) {
+	item.mtx.RLock()
+	defer item.mtx.RUnlock()
+
+	i := item.search(txn)
+	if i == 0 {
+		return mvEntry{}, false
+	}
+	return item.entries[i-1], true
+}
+
+func (item *mvItem) search(txn TxnIndex) int {
+	return sort.Search(len(item.entries), func(i int) bool { return item.entries[i].txn >= txn })
+}
+
+func (item *mvItem) write(entry mvEntry) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	i := item.search(entry.txn)
+	if i < len(item.entries) && item.entries[i].txn == entry.txn {
+		item.entries[i] = entry
+		return
+	}
+	item.entries = append(item.entries, mvEntry{})
+	copy(item.entries[i+1:], item.entries[i:])
+	item.entries[i] = entry
+}
+
+func (item *mvItem) remove(txn TxnIndex) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	i := item.search(txn)
+	if i < len(item.entries) && item.entries[i].txn == txn {
+		item.entries = append(item.entries[:i], item.entries[i+1:]...)
+	}
+}
+
+func (item *mvItem) markEstimate(txn TxnIndex) {
+	item.mtx.Lock()
+	defer item.mtx.Unlock()
+
+	i := item.search(txn)
+	if i < len(item.entries) && item.entries[i].txn == txn {
+		item.entries[i].estimate = true
+	}
+}
+
+func itemLess(a, b *mvItem) bool {
+	return bytes.Compare(a.key, b.key) < 0
+}
+
+// mvStore holds the entries written to the keys of a store.
+type mvStore struct {
+	mtx   sync.Mutex
+	items *btree.BTreeG[*mvItem]
+}
+
+func newMVStore() *mvStore {
+	return &mvStore{items: btree.NewBTreeG(itemLess)}
+}
+
+func (s *mvStore) get(key []byte) *mvItem {
+	item, _ := s.items.Get(&mvItem{key: key})
+	return item
+}
+
+func (s *mvStore) getOrCreate(key []byte) *mvItem {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if item, ok := s.items.Get(&mvItem{key: key}); ok {
+		return item
+	}
+	item := &mvItem{key: key}
+	s.items.Set(item)
+	return item
+}
+
+// scan calls fn with the items in the [start, end) range, in ascending or
+// descending order, until it returns false.
+func (s *mvStore) scan(start, end []byte, reverse bool, fn func(*mvItem) bool) {
+	inRange := func(item *mvItem) bool {
+		return (start == nil || bytes.Compare(item.key, start) >= 0) &&
+			(end == nil || bytes.Compare(item.key, end) < 0)
+	}
+
+	if !reverse {
+		iter := func(item *mvItem) bool {
+			if !inRange(item) {
+				return end == nil || bytes.Compare(item.key, end) < 0
+			}
+			return fn(item)
+		}
+		if start == nil {
+			s.items.Scan(iter)
+		} else {
+			s.items.Ascend(&mvItem{key: start}, iter)
+		}
+		return
+	}
+
+	iter := func(item *mvItem) bool {
+		if !inRange(item) {
+			return start == nil || bytes.Compare(item.key, start) >= 0
+		}
+		return fn(item)
+	}
+	if end == nil {
+		s.items.Reverse(iter)
+	} else {
+		s.items.Descend(&mvItem{key: end}, iter)
+	}
+}
+
+// location is a key of a store.
+type location struct {
+	store int
+	key   string
+}
+
+// MVMemory is the multi-version memory of the block, which holds the values
+// written by every transaction, so that transactions read the values written
+// by the transactions preceding them in the block.
+type MVMemory struct {
+	stores []*mvStore
+
+	// lastWrites and lastReads are the write and read sets of the last
+	// incarnation of every transaction, recorded by the executing worker and
+	// loaded by the validating ones
+	lastWrites []atomic.Pointer[writeSet]
+	lastReads  []atomic.Pointer[readSet]
+
+	// cond is broadcast whenever entries are written, to wake up the
+	// transactions waiting on estimates
+	cond    *sync.Cond
+	aborted bool
+}
+
+// NewMVMemory creates the multi-version memory of a block of the provided size
+// with the provided number of stores.
+func NewMVMemory(blockSize, numStores int) *MVMemory {
+	stores := make([]*mvStore, numStores)
+	for i := range stores {
+		stores[i] = newMVStore()
+	}
+	return &MVMemory{
+		stores:     stores,
+		lastWrites: make([]atomic.Pointer[writeSet], blockSize),
+		lastReads:  make([]atomic.Pointer[readSet], blockSize),
+		cond:       sync.NewCond(&sync.Mutex{}),
+	}
+}
+
+// record records the read and write sets of an incarnation, and returns true
+// if it wrote to a location which wasn't written by the previous incarnation.
+func (m *MVMemory) record(v version, reads *readSet, writes writeSet) bool {
+	wroteNewLocation := false
+	prev := m.lastWriteSet(v.txn)
+	for loc, value := range writes {
+		m.stores[loc.store].getOrCreate([]byte(loc.key)).write(mvEntry{
+			txn:         v.txn,
+			incarnation: v.incarnation,
+			value:       value,
+		})
+		if _, ok := prev[loc]; !ok {
+			wroteNewLocation = true
+		}
+	}
+	for loc := range prev {
+		if _, ok := writes[loc]; !ok {
+			m.stores[loc.store].get([]byte(loc.key)).remove(v.txn)
+		}
+	}
+
+	m.lastWrites[v.txn].Store(&writes)
+	m.lastReads[v.txn].Store(reads)
+
+	m.cond.L.Lock()
+	m.cond.Broadcast()
+	m.cond.L.Unlock()
+
+	return wroteNewLocation
+}
+
+// lastWriteSet returns the write set of the last incarnation of the
+// transaction, which is nil if it wasn't executed yet.
+func (m *MVMemory) lastWriteSet(txn TxnIndex) writeSet {
+	if writes := m.lastWrites[txn].Load(); writes != nil {
+		return *writes
+	}
+	return nil
+}
+
+// convertWritesToEstimates marks the entries written by the last incarnation
+// of the transaction as estimates.
+func (m *MVMemory) convertWritesToEstimates(txn TxnIndex) {
+	for loc := range m.lastWriteSet(txn) {
+		m.stores[loc.store].get([]byte(loc.key)).markEstimate(txn)
+	}
+}
+
+// abort wakes up the transactions waiting on estimates so that they return.
+func (m *MVMemory) abort() {
+	m.cond.L.Lock()
+	m.aborted = true
+	m.cond.Broadcast()
+	m.cond.L.Unlock()
+}
+
+// read returns the value of the key written by the highest transaction lower
+// than txn, waiting for estimates to be written again. It returns false if no
+// transaction wrote the key, or if the block was aborted.
+func (m *MVMemory) read(store int, key []byte, txn TxnIndex) (value []byte, v version, ok bool) {
+	item := m.stores[store].get(key)
+	if item == nil {
+		return nil, version{}, false
+	}
+
+	entry, ok := m.waitLatest(item, txn)
+	if !ok {
+		return nil, version{}, false
+	}
+	return entry.value, version{txn: entry.txn, incarnation: entry.incarnation}, true
+}
+
+func (m *MVMemory) waitLatest(item *mvItem, txn TxnIndex) (mvEntry, bool) {
+	entry, ok := item.latest(txn)
+	if !ok || !entry.estimate {
+		return entry, ok
+	}
+
+	m.cond.L.Lock()
+	defer m.cond.L.Unlock()
+	for {
+		entry, ok = item.latest(txn)
+		if !ok || !entry.estimate || m.aborted {
+			return entry, ok && !entry.estimate
+		}
+		m.cond.Wait()
+	}
+}
+
+// snapshot returns the entries of the [start, end) range visible to the
+// transaction, in iteration order, waiting for estimates to be written again.
+func (m *MVMemory) snapshot(store int, start, end []byte, reverse bool, txn TxnIndex) []versionedValue {
+	var items []*mvItem
+	m.stores[store].scan(start, end, reverse, func(item *mvItem) bool {
+		items = append(items, item)
+		return true
+	})
+
+	res := make([]versionedValue, 0, len(items))
+	for _, item := range items {
+		if entry, ok := m.waitLatest(item, txn); ok {
+			res = append(res, versionedValue{
+				key:     item.key,
+				value:   entry.value,
+				version: version{txn: entry.txn, incarnation: entry.incarnation},
+			})
+		}
+	}
+	return res
+}
+
+// validateReadSet returns false if the values read by the last incarnation of
+// the transaction changed, or if some of them are estimates.
+func (m *MVMemory) validateReadSet(txn TxnIndex, storage []storetypes.KVStore) bool {
+	reads := m.lastReads[txn].Load()
+	if reads.inconsistent {
+		return false
+	}
+
+	for loc, v := range reads.reads {
+		item := m.stores[loc.store].get([]byte(loc.key))
+		cur := storageVersion
+		if item != nil {
+			if entry, ok := item.latest(txn); ok {
+				if entry.estimate {
+					return false
+				}
+				cur = version{txn: entry.txn, incarnation: entry.incarnation}
+			}
+		}
+		if cur != v {
+			return false
+		}
+	}
+
+	for _, it := range reads.iterations {
+		if !m.validateIteration(txn, it, storage[it.store]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateIteration replays the iteration over the multi-version memory and
+// the storage, and checks that it yields the keys and versions it yielded
+// during execution.
+func (m *MVMemory) validateIteration(txn TxnIndex, it *iterationRead, storage storetypes.KVStore) bool {
+	var (
+		estimate bool
+		values   []versionedValue
+	)
+	m.stores[it.store].scan(it.start, it.end, it.reverse, func(item *mvItem) bool {
+		entry, ok := item.latest(txn)
+		if !ok {
+			return true
+		}
+		if entry.estimate {
+			estimate = true
+			return false
+		}
+		values = append(values, versionedValue{
+			key:     item.key,
+			value:   entry.value,
+			version: version{txn: entry.txn, incarnation: entry.incarnation},
+		})
+		return true
+	})
+	if estimate {
+		return false
+	}
+
+	replay := newMergedIterator(newStorageIterator(storage, it.start, it.end, it.reverse), values, it.reverse)
+	defer replay.Close()
+
+	for _, observed := range it.observed {
+		if !replay.Valid() {
+			return false
+		}
+		if !bytes.Equal(replay.Key(), observed.key) || replay.version() != observed.version {
+			return false
+		}
+		replay.Next()
+	}
+
+	return !it.exhausted || !replay.Valid()
+}
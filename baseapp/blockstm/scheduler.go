@@ -0,0 +1,208 @@
+package blockstm
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type taskKind int
+
+const (
+	taskNone taskKind = iota
+	taskExecution
+	taskValidation
+)
+
+type txnStatus int
+
+const (
+	statusReadyToExecute txnStatus = iota
+	statusExecuting
+	statusExecuted
+	statusAborting
+)
+
+// txnState is the status of the current incarnation of a transaction.
+type txnState struct {
+	mtx         sync.Mutex
+	incarnation int
+	status      txnStatus
+}
+
+// scheduler dispatches the execution and validation tasks of a block to the
+// workers, following the collaborative scheduler of the Block-STM paper.
+//
+// Instead of suspending the transactions which read estimates until their
+// dependency is executed again, reads wait for the estimates to be written,
+// which is always done by the worker which aborted the dependency.
+type scheduler struct {
+	blockSize int
+
+	// executionIdx and validationIdx are the indexes of the next transactions
+	// to execute and to validate
+	executionIdx  atomic.Int64
+	validationIdx atomic.Int64
+	// decreaseCnt is incremented whenever validationIdx is decreased, to detect
+	// races with checkDone
+	decreaseCnt    atomic.Int64
+	numActiveTasks atomic.Int64
+	done           atomic.Bool
+
+	txns []txnState
+}
+
+func newScheduler(blockSize int) *scheduler {
+	return &scheduler{
+		blockSize: blockSize,
+		txns:      make([]txnState, blockSize),
+	}
+}
+
+// isDone returns true once every transaction is executed and validated.
+func (s *scheduler) isDone() bool {
+	return s.done.Load()
+}
+
+// abort makes the scheduler done without completing the block.
+func (s *scheduler) abort() {
+	s.done.Store(true)
+}
+
+func (s *scheduler) decreaseValidationIdx(target TxnIndex) {
+	for {
+		cur := s.validationIdx.Load()
+		if cur <= int64(target) {
+			return
+		}
+		if s.validationIdx.CompareAndSwap(cur, int64(target)) {
+			s.decreaseCnt.Add(1)
+			return
+		}
+	}
+}
+
+func (s *scheduler) checkDone() {
+	observedCnt := s.decreaseCnt.Load()
+	if min(s.executionIdx.Load(), s.validationIdx.Load()) >= int64(s.blockSize) &&
+		s.numActiveTasks.Load() == 0 && observedCnt == s.decreaseCnt.Load() {
+		s.done.Store(true)
+	}
+}
+
+// tryIncarnate starts executing the next incarnation of the transaction if it
+// is ready to execute.
+func (s *scheduler) tryIncarnate(txn TxnIndex) (version, bool) {
+	if int(txn) < s.blockSize {
+		state := &s.txns[txn]
+		state.mtx.Lock()
+		defer state.mtx.Unlock()
+
+		if state.status == statusReadyToExecute {
+			state.status = statusExecuting
+			return version{txn: txn, incarnation: state.incarnation}, true
+		}
+	}
+	s.numActiveTasks.Add(-1)
+	return version{}, false
+}
+
+func (s *scheduler) nextVersionToExecute() (version, bool) {
+	if s.executionIdx.Load() >= int64(s.blockSize) {
+		s.checkDone()
+		return version{}, false
+	}
+	s.numActiveTasks.Add(1)
+	return s.tryIncarnate(TxnIndex(s.executionIdx.Add(1) - 1))
+}
+
+func (s *scheduler) nextVersionToValidate() (version, bool) {
+	if s.validationIdx.Load() >= int64(s.blockSize) {
+		s.checkDone()
+		return version{}, false
+	}
+	s.numActiveTasks.Add(1)
+	txn := TxnIndex(s.validationIdx.Add(1) - 1)
+	if int(txn) < s.blockSize {
+		state := &s.txns[txn]
+		state.mtx.Lock()
+		defer state.mtx.Unlock()
+
+		if state.status == statusExecuted {
+			return version{txn: txn, incarnation: state.incarnation}, true
+		}
+	}
+	s.numActiveTasks.Add(-1)
+	return version{}, false
+}
+
+// nextTask returns the next task to perform, validations having priority over
+// executions of higher transactions.
+func (s *scheduler) nextTask() (version, taskKind) {
+	if s.validationIdx.Load() < s.executionIdx.Load() {
+		if v, ok := s.nextVersionToValidate(); ok {
+			return v, taskValidation
+		}
+	} else {
+		if v, ok := s.nextVersionToExecute(); ok {
+			return v, taskExecution
+		}
+	}
+	return version{}, taskNone
+}
+
+// finishExecution marks the incarnation as executed, and returns its
+// validation task if it can be performed right away.
+func (s *scheduler) finishExecution(v version, wroteNewLocation bool) (version, taskKind) {
+	state := &s.txns[v.txn]
+	state.mtx.Lock()
+	state.status = statusExecuted
+	state.mtx.Unlock()
+
+	if s.validationIdx.Load() > int64(v.txn) {
+		if !wroteNewLocation {
+			return v, taskValidation
+		}
+		// the higher transactions must be validated again as they could have
+		// missed the new locations
+		s.decreaseValidationIdx(v.txn)
+	}
+	s.numActiveTasks.Add(-1)
+	return version{}, taskNone
+}
+
+// tryValidationAbort marks the incarnation as aborting, unless it was already
+// aborted.
+func (s *scheduler) tryValidationAbort(v version) bool {
+	state := &s.txns[v.txn]
+	state.mtx.Lock()
+	defer state.mtx.Unlock()
+
+	if state.incarnation == v.incarnation && state.status == statusExecuted {
+		state.status = statusAborting
+		return true
+	}
+	return false
+}
+
+// finishValidation returns the execution task of the next incarnation of an
+// aborted transaction, which is performed by the worker which aborted it.
+func (s *scheduler) finishValidation(txn TxnIndex, aborted bool) (version, taskKind) {
+	if aborted {
+		state := &s.txns[txn]
+		state.mtx.Lock()
+		state.incarnation++
+		state.status = statusReadyToExecute
+		state.mtx.Unlock()
+
+		s.decreaseValidationIdx(txn + 1)
+		if s.executionIdx.Load() > int64(txn) {
+			// tryIncarnate decrements the active tasks if it fails
+			if v, ok := s.tryIncarnate(txn); ok {
+				return v, taskExecution
+			}
+			return version{}, taskNone
+		}
+	}
+	s.numActiveTasks.Add(-1)
+	return version{}, taskNone
+}
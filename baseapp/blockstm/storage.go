@@ -0,0 +1,87 @@
+package blockstm
+
+import (
+	"sync"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// lockedStore serializes the reads of a store which isn't safe for concurrent
+// use, such as a cachekv store whose reads fill its cache.
+type lockedStore struct {
+	storetypes.KVStore
+	mtx *sync.Mutex
+}
+
+func (s lockedStore) Get(key []byte) []byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.KVStore.Get(key)
+}
+
+func (s lockedStore) Has(key []byte) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.KVStore.Has(key)
+}
+
+func (s lockedStore) Set(_, _ []byte) {
+	panic("the storage is read-only during the execution of the block")
+}
+
+func (s lockedStore) Delete(_ []byte) {
+	panic("the storage is read-only during the execution of the block")
+}
+
+func (s lockedStore) Iterator(start, end []byte) storetypes.Iterator {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return lockedIterator{Iterator: s.KVStore.Iterator(start, end), mtx: s.mtx}
+}
+
+func (s lockedStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return lockedIterator{Iterator: s.KVStore.ReverseIterator(start, end), mtx: s.mtx}
+}
+
+type lockedIterator struct {
+	storetypes.Iterator
+	mtx *sync.Mutex
+}
+
+func (it lockedIterator) Valid() bool {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Valid()
+}
+
+func (it lockedIterator) Next() {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	it.Iterator.Next()
+}
+
+func (it lockedIterator) Key() []byte {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Key()
+}
+
+func (it lockedIterator) Value() []byte {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Value()
+}
+
+func (it lockedIterator) Error() error {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Error()
+}
+
+func (it lockedIterator) Close() error {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Close()
+}
@@ -0,0 +1,183 @@
+package blockstm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/tidwall/btree"
+
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/tracekv"
+	storetypes "cosmossdk.io/store/types"
+)
+
+// writeSet holds the values written by an incarnation, nil for deletions.
+type writeSet map[location][]byte
+
+// readSet holds the versions read by an incarnation.
+type readSet struct {
+	reads      map[location]version
+	iterations []*iterationRead
+	// inconsistent is true if a location was read with different versions
+	// during the incarnation
+	inconsistent bool
+}
+
+func newReadSet() *readSet {
+	return &readSet{reads: make(map[location]version)}
+}
+
+func (rs *readSet) record(loc location, v version) {
+	if prev, ok := rs.reads[loc]; ok {
+		if prev != v {
+			rs.inconsistent = true
+		}
+		return
+	}
+	rs.reads[loc] = v
+}
+
+// iterationRead records the keys, and their versions, yielded by an iteration.
+type iterationRead struct {
+	store      int
+	start, end []byte
+	reverse    bool
+	observed   []versionedValue
+	// exhausted is true if the iteration reached the end of its range
+	exhausted bool
+}
+
+func valueLess(a, b versionedValue) bool {
+	return bytes.Compare(a.key, b.key) < 0
+}
+
+// view is the KVStore seen by an incarnation of a transaction, which reads the
+// values written by the preceding transactions of the block and records its
+// reads and writes.
+type view struct {
+	store   int
+	storage storetypes.KVStore
+	mv      *MVMemory
+	version version
+	reads   *readSet
+	// writes holds the values written by the incarnation, nil for deletions
+	writes *btree.BTreeG[versionedValue]
+}
+
+var _ storetypes.KVStore = &view{}
+
+func newView(store int, storage storetypes.KVStore, mv *MVMemory, v version, reads *readSet) *view {
+	return &view{
+		store:   store,
+		storage: storage,
+		mv:      mv,
+		version: v,
+		reads:   reads,
+		writes:  btree.NewBTreeGOptions(valueLess, btree.Options{NoLocks: true}),
+	}
+}
+
+func (v *view) GetStoreType() storetypes.StoreType {
+	return v.storage.GetStoreType()
+}
+
+func (v *view) CacheWrap() storetypes.CacheWrap {
+	return cachekv.NewStore(v)
+}
+
+func (v *view) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(v, w, tc))
+}
+
+func (v *view) Get(key []byte) []byte {
+	storetypes.AssertValidKey(key)
+
+	if written, ok := v.writes.Get(versionedValue{key: key}); ok {
+		return written.value
+	}
+
+	loc := location{store: v.store, key: string(key)}
+	value, ver, ok := v.mv.read(v.store, key, v.version.txn)
+	if !ok {
+		value, ver = v.storage.Get(key), storageVersion
+	}
+	v.reads.record(loc, ver)
+	return value
+}
+
+func (v *view) Has(key []byte) bool {
+	return v.Get(key) != nil
+}
+
+func (v *view) Set(key, value []byte) {
+	storetypes.AssertValidKey(key)
+	storetypes.AssertValidValue(value)
+	v.writes.Set(versionedValue{key: bytes.Clone(key), value: value, version: v.version})
+}
+
+func (v *view) Delete(key []byte) {
+	storetypes.AssertValidKey(key)
+	v.writes.Set(versionedValue{key: bytes.Clone(key), version: v.version})
+}
+
+func (v *view) Iterator(start, end []byte) storetypes.Iterator {
+	return v.iterator(start, end, false)
+}
+
+func (v *view) ReverseIterator(start, end []byte) storetypes.Iterator {
+	return v.iterator(start, end, true)
+}
+
+func (v *view) iterator(start, end []byte, reverse bool) storetypes.Iterator {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		panic(errors.New("keys must not be empty"))
+	}
+
+	// the reads of the storage and of the preceding transactions are recorded,
+	// the writes of the incarnation are merged on top of them
+	record := &iterationRead{
+		store:   v.store,
+		start:   bytes.Clone(start),
+		end:     bytes.Clone(end),
+		reverse: reverse,
+	}
+	v.reads.iterations = append(v.reads.iterations, record)
+
+	parent := newMergedIterator(
+		newStorageIterator(v.storage, start, end, reverse),
+		v.mv.snapshot(v.store, start, end, reverse, v.version.txn),
+		reverse,
+	)
+	return newMergedIterator(&recordingIterator{versionedIterator: parent, record: record}, v.ownWrites(start, end, reverse), reverse)
+}
+
+// ownWrites returns the values written by the incarnation in the [start, end)
+// range, in iteration order.
+func (v *view) ownWrites(start, end []byte, reverse bool) []versionedValue {
+	var res []versionedValue
+	iter := func(item versionedValue) bool {
+		if start != nil && bytes.Compare(item.key, start) < 0 {
+			return !reverse
+		}
+		if end != nil && bytes.Compare(item.key, end) >= 0 {
+			return reverse
+		}
+		res = append(res, item)
+		return true
+	}
+	if reverse {
+		v.writes.Reverse(iter)
+	} else {
+		v.writes.Scan(iter)
+	}
+	return res
+}
+
+// collectWrites adds the values written by the incarnation to the write set.
+func (v *view) collectWrites(writes writeSet) {
+	v.writes.Scan(func(item versionedValue) bool {
+		writes[location{store: v.store, key: string(item.key)}] = item.value
+		return true
+	})
+}
@@ -115,7 +115,7 @@ func (msr *MsgServiceRouter) registerHybridHandler(sd *grpc.ServiceDesc, method
 	msr.responseByMsgName[string(inputName)] = string(outputName)
 	// if circuit breaker is not nil, then we decorate the hybrid handler with the circuit breaker
 	if msr.circuitBreaker == nil {
-		msr.hybridHandlers[string(inputName)] = hybridHandler
+		msr.hybridHandlers[string(inputName)] = traceHybridHandler(hybridHandler)
 		return nil
 	}
 	// decorate the hybrid handler with the circuit breaker
@@ -130,7 +130,7 @@ func (msr *MsgServiceRouter) registerHybridHandler(sd *grpc.ServiceDesc, method
 		}
 		return hybridHandler(ctx, req, resp)
 	}
-	msr.hybridHandlers[string(inputName)] = circuitBreakerHybridHandler
+	msr.hybridHandlers[string(inputName)] = traceHybridHandler(circuitBreakerHybridHandler)
 	return nil
 }
 
@@ -185,8 +185,10 @@ func (msr *MsgServiceRouter) registerMsgServiceHandler(sd *grpc.ServiceDesc, met
 		)
 	}
 
-	msr.routes[requestTypeName] = func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		ctx = ctx.WithEventManager(sdk.NewEventManager())
+	msr.routes[requestTypeName] = traceMsgServiceHandler(func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.
+			WithEventManager(sdk.NewEventManager()).
+			WithGasProfileFrame(sdk.MsgTypeURL(msg))
 		interceptor := func(goCtx context.Context, _ interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 			goCtx = context.WithValue(goCtx, sdk.SdkContextKey, ctx)
 			return handler(goCtx, msg)
@@ -236,7 +238,7 @@ func (msr *MsgServiceRouter) registerMsgServiceHandler(sd *grpc.ServiceDesc, met
 			Events:       events,
 			MsgResponses: []*codectypes.Any{anyResp},
 		}, nil
-	}
+	})
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
 	"github.com/cosmos/cosmos-sdk/types/mempool"
 )
 
@@ -49,6 +50,20 @@ func SetQueryGasLimit(queryGasLimit uint64) func(*BaseApp) {
 	return func(bapp *BaseApp) { bapp.queryGasLimit = queryGasLimit }
 }
 
+// SetTraceTx returns an option that enables TraceTx, with at most maxConcurrent
+// concurrent traces, or one if maxConcurrent is 0. TraceTx is disabled if
+// enabled is false.
+func SetTraceTx(enabled bool, maxConcurrent uint) func(*BaseApp) {
+	if !enabled {
+		return func(bapp *BaseApp) { bapp.traceTxSem = nil }
+	}
+	if maxConcurrent == 0 {
+		maxConcurrent = 1
+	}
+
+	return func(bapp *BaseApp) { bapp.traceTxSem = make(chan struct{}, maxConcurrent) }
+}
+
 // SetHaltHeight returns a BaseApp option function that sets the halt block height.
 func SetHaltHeight(blockHeight uint64) func(*BaseApp) {
 	return func(bapp *BaseApp) { bapp.setHaltHeight(blockHeight) }
@@ -119,6 +134,19 @@ func SetOptimisticExecution(opts ...func(*oe.OptimisticExecution)) func(*BaseApp
 	}
 }
 
+// SetParallelExecution enables the parallel execution of the transactions of
+// FinalizeBlock with the provided number of workers, following the Block-STM
+// algorithm. It yields the same results and app hash as sequential execution.
+func SetParallelExecution(workers int) func(*BaseApp) {
+	return func(app *BaseApp) { app.parallelWorkers = workers }
+}
+
+// SetGasProfiler enables the profiling of the gas consumed by the transactions
+// of simulations and FinalizeBlock, and by queries, with the provided profiler.
+func SetGasProfiler(p *gasprofile.Profiler) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetGasProfiler(p) }
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -386,6 +414,13 @@ func (app *BaseApp) SetStreamingManager(manager storetypes.StreamingManager) {
 	app.streamingManager = manager
 }
 
+// SetGasProfiler sets the gas profiler of the transactions of simulations and
+// FinalizeBlock, and of queries. A nil profiler disables profiling. It can be
+// set on a sealed BaseApp, e.g. by the simulation framework.
+func (app *BaseApp) SetGasProfiler(p *gasprofile.Profiler) {
+	app.gasProfiler = p
+}
+
 // SetMsgServiceRouter sets the MsgServiceRouter of a BaseApp.
 func (app *BaseApp) SetMsgServiceRouter(msgServiceRouter *MsgServiceRouter) {
 	app.msgServiceRouter = msgServiceRouter
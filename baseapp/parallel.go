@@ -0,0 +1,151 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"golang.org/x/exp/maps"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/blockstm"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// parallelTxResult is the result of the last execution of a transaction by
+// executeTxsInParallel.
+type parallelTxResult struct {
+	decoded    bool
+	gInfo      sdk.GasInfo
+	result     *sdk.Result
+	anteEvents []abci.Event
+	err        error
+	// blockGas is the gas consumed by the transaction on the block gas meter
+	blockGas uint64
+	// removedTx is the transaction to remove from the mempool, if its
+	// execution reached that point
+	removedTx sdk.Tx
+}
+
+// executeTxsInParallel executes the transactions of FinalizeBlock with the
+// Block-STM parallel executor, which yields the same results and state as
+// executing them sequentially.
+//
+// Since transactions are executed speculatively, the block gas meter and the
+// mempool are only updated once every transaction is executed, in the order of
+// the block. It returns nil results if the block must be executed sequentially
+// instead, which is the case when the block gas limit is reached, when removing
+// a transaction from the mempool fails, or when the multi-store doesn't
+// support parallel execution. The state is left untouched in that case.
+func (app *BaseApp) executeTxsInParallel(ctx context.Context, txs [][]byte) ([]*abci.ExecTxResult, error) {
+	cms, ok := app.cms.(interface {
+		StoreKeysByName() map[string]storetypes.StoreKey
+	})
+	if !ok || len(txs) == 0 || app.finalizeBlockState.ms.TracingEnabled() {
+		return nil, nil
+	}
+
+	keysByName := cms.StoreKeysByName()
+	names := maps.Keys(keysByName)
+	sort.Strings(names)
+	keys := make([]storetypes.StoreKey, len(names))
+	for i, name := range names {
+		keys[i] = keysByName[name]
+	}
+
+	baseCtx := app.getContextForTx(execModeFinalize, nil)
+	results := make([]parallelTxResult, len(txs))
+
+	// the transactions are executed on a branch so that nothing is written if
+	// the block is executed sequentially instead
+	branch := app.finalizeBlockState.ms.CacheMultiStore()
+	err := blockstm.ExecuteBlock(ctx, len(txs), keys, branch, app.parallelWorkers, func(txn blockstm.TxnIndex, ms storetypes.MultiStore) {
+		txBytes := txs[txn]
+
+		// NOTE: Not all raw transactions may adhere to the sdk.Tx interface, e.g.
+		// vote extensions, so skip those.
+		if _, err := app.txDecoder(txBytes); err != nil {
+			results[txn] = parallelTxResult{}
+			return
+		}
+
+		res := parallelTxResult{decoded: true}
+		blockGasMeter := storetypes.NewInfiniteGasMeter()
+		txCtx := baseCtx.
+			WithTxBytes(txBytes).
+			WithGasMeter(storetypes.NewInfiniteGasMeter()).
+			WithBlockGasMeter(blockGasMeter).
+			WithEventManager(sdk.NewEventManager()).
+			WithMultiStore(ms)
+		res.gInfo, res.result, res.anteEvents, res.err = app.runTxWithContext(execModeFinalize, txCtx, txBytes, func(tx sdk.Tx) error {
+			res.removedTx = tx
+			return nil
+		})
+		res.blockGas = blockGasMeter.GasConsumed()
+		results[txn] = res
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !app.consumeParallelBlockGas(results) {
+		return nil, nil
+	}
+
+	for _, res := range results {
+		if res.removedTx == nil {
+			continue
+		}
+		if err := app.mempool.Remove(res.removedTx); err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
+			app.logger.Error("failed to remove tx from mempool, executing the block sequentially", "err", err)
+			return nil, nil
+		}
+	}
+
+	branch.Write()
+
+	txResults := make([]*abci.ExecTxResult, len(txs))
+	for i, res := range results {
+		if !res.decoded {
+			txResults[i] = sdkerrors.ResponseExecTxResultWithEvents(
+				sdkerrors.ErrTxDecode,
+				0,
+				0,
+				nil,
+				false,
+			)
+			continue
+		}
+		txResults[i] = app.execTxResult(res.gInfo, res.result, res.anteEvents, res.err)
+	}
+
+	return txResults, nil
+}
+
+// consumeParallelBlockGas consumes the block gas of the transactions executed
+// in parallel on the block gas meter. It returns false, leaving the meter
+// untouched, if a transaction would have run out of block gas when executed
+// sequentially.
+func (app *BaseApp) consumeParallelBlockGas(results []parallelTxResult) bool {
+	blockGasMeter := app.finalizeBlockState.Context().BlockGasMeter()
+
+	var consumed uint64
+	for _, res := range results {
+		if !res.decoded {
+			continue
+		}
+		if blockGasMeter.IsOutOfGas() || res.blockGas > blockGasMeter.GasRemaining() ||
+			blockGasMeter.GasConsumed()+res.blockGas < res.blockGas {
+			blockGasMeter.RefundGas(consumed, "block gas meter")
+			return false
+		}
+		blockGasMeter.ConsumeGas(res.blockGas, "block gas meter")
+		consumed += res.blockGas
+	}
+
+	return true
+}
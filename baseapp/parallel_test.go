@@ -0,0 +1,217 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var balancePrefix = []byte("balance/")
+
+// transferServer interprets MsgKeyValue as transfers between few accounts, so
+// that the transactions of a block conflict:
+//   - "mint" mints 10 to the account in the value,
+//   - "sweep" iterates over the balances in reverse, deleting the empty ones,
+//   - any other key transfers 1 from the account in the key to the account in
+//     the value, failing if the balance is insufficient.
+type transferServer struct{}
+
+func (transferServer) Set(ctx context.Context, msg *baseapptestutil.MsgKeyValue) (*baseapptestutil.MsgCreateKeyValueResponse, error) {
+	store := sdk.UnwrapSDKContext(ctx).KVStore(capKey2)
+
+	switch string(msg.Key) {
+	case "mint":
+		setBalance(store, msg.Value, getBalance(store, msg.Value)+10)
+	case "sweep":
+		var empty [][]byte
+		it := storetypes.KVStoreReversePrefixIterator(store, balancePrefix)
+		for ; it.Valid(); it.Next() {
+			if binary.BigEndian.Uint64(it.Value()) == 0 {
+				empty = append(empty, it.Key())
+			}
+		}
+		if err := it.Close(); err != nil {
+			return nil, err
+		}
+		for _, key := range empty {
+			store.Delete(key)
+		}
+	default:
+		balance := getBalance(store, msg.Key)
+		if balance == 0 {
+			return nil, errors.New("insufficient funds")
+		}
+		setBalance(store, msg.Key, balance-1)
+		setBalance(store, msg.Value, getBalance(store, msg.Value)+1)
+	}
+
+	return &baseapptestutil.MsgCreateKeyValueResponse{}, nil
+}
+
+func getBalance(store storetypes.KVStore, account []byte) uint64 {
+	bz := store.Get(append(balancePrefix, account...))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func setBalance(store storetypes.KVStore, account []byte, balance uint64) {
+	store.Set(append(balancePrefix, account...), binary.BigEndian.AppendUint64(nil, balance))
+}
+
+// sequenceAnteHandler increments the sequence of the signer of the first
+// message, and consumes gas.
+func sequenceAnteHandler(ctx sdk.Context, tx sdk.Tx, _ bool) (sdk.Context, error) {
+	store := ctx.KVStore(capKey1)
+	key := []byte("sequence/" + tx.GetMsgs()[0].(*baseapptestutil.MsgKeyValue).Signer)
+	var sequence uint64
+	if bz := store.Get(key); bz != nil {
+		sequence = binary.BigEndian.Uint64(bz)
+	}
+	store.Set(key, binary.BigEndian.AppendUint64(nil, sequence+1))
+
+	ctx.GasMeter().ConsumeGas(1000, "ante")
+	ctx.EventManager().EmitEvent(sdk.NewEvent("ante", sdk.NewAttribute("sequence", fmt.Sprint(sequence))))
+	return ctx, nil
+}
+
+func newParallelTestSuite(t *testing.T, maxGas int64, opts ...func(*baseapp.BaseApp)) *BaseAppSuite {
+	t.Helper()
+	opts = append(opts, func(app *baseapp.BaseApp) { app.SetAnteHandler(sequenceAnteHandler) })
+	suite := NewBaseAppSuite(t, opts...)
+	baseapptestutil.RegisterKeyValueServer(suite.baseApp.MsgServiceRouter(), transferServer{})
+
+	_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxGas: maxGas}},
+	})
+	require.NoError(t, err)
+	return suite
+}
+
+func randomTransferBlocks(t *testing.T, suite *BaseAppSuite, r *rand.Rand, numBlocks, numTxs int) [][][]byte {
+	t.Helper()
+	accounts := []string{"a", "b", "c", "d", "e"}
+	signers := make([]string, 3)
+	for i := range signers {
+		_, _, addr := testdata.KeyTestPubAddr()
+		signers[i] = addr.String()
+	}
+
+	blocks := make([][][]byte, numBlocks)
+	for i := range blocks {
+		for j := 0; j < numTxs; j++ {
+			if r.Intn(20) == 0 {
+				blocks[i] = append(blocks[i], []byte("malformed"))
+				continue
+			}
+
+			signer := signers[r.Intn(len(signers))]
+			msgs := make([]sdk.Msg, 1+r.Intn(3))
+			for k := range msgs {
+				key := accounts[r.Intn(len(accounts))]
+				switch r.Intn(6) {
+				case 0:
+					key = "mint"
+				case 1:
+					key = "sweep"
+				}
+				msgs[k] = &baseapptestutil.MsgKeyValue{
+					Key:    []byte(key),
+					Value:  []byte(accounts[r.Intn(len(accounts))]),
+					Signer: signer,
+				}
+			}
+
+			builder := suite.txConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(msgs...))
+			setTxSignature(t, builder, 0)
+			txBytes, err := suite.txConfig.TxEncoder()(builder.GetTx())
+			require.NoError(t, err)
+			blocks[i] = append(blocks[i], txBytes)
+		}
+	}
+	return blocks
+}
+
+// requireSameExecution executes the blocks with both apps and checks that they
+// yield the same results and app hashes.
+func requireSameExecution(t *testing.T, sequential, parallel *BaseAppSuite, blocks [][][]byte) []*abci.ResponseFinalizeBlock {
+	t.Helper()
+	var responses []*abci.ResponseFinalizeBlock
+	for i, txs := range blocks {
+		req := &abci.RequestFinalizeBlock{Height: int64(i + 1), Txs: txs}
+
+		expected, err := sequential.baseApp.FinalizeBlock(req)
+		require.NoError(t, err)
+		_, err = sequential.baseApp.Commit()
+		require.NoError(t, err)
+
+		res, err := parallel.baseApp.FinalizeBlock(req)
+		require.NoError(t, err)
+		_, err = parallel.baseApp.Commit()
+		require.NoError(t, err)
+
+		require.Equal(t, expected.TxResults, res.TxResults, "block %d", i+1)
+		require.Equal(t, expected.AppHash, res.AppHash, "block %d", i+1)
+		responses = append(responses, res)
+	}
+	return responses
+}
+
+func TestParallelExecutionDeterminism(t *testing.T) {
+	for _, workers := range []int{1, 4, 16} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			sequential := newParallelTestSuite(t, 0)
+			parallel := newParallelTestSuite(t, 0, baseapp.SetParallelExecution(workers))
+
+			blocks := randomTransferBlocks(t, sequential, rand.New(rand.NewSource(int64(workers))), 5, 40)
+			responses := requireSameExecution(t, sequential, parallel, blocks)
+
+			var succeeded, failed int
+			for _, res := range responses {
+				for _, txRes := range res.TxResults {
+					if txRes.IsOK() {
+						succeeded++
+					} else {
+						failed++
+					}
+				}
+			}
+			require.NotZero(t, succeeded)
+			require.NotZero(t, failed)
+		})
+	}
+}
+
+func TestParallelExecutionBlockGasLimit(t *testing.T) {
+	// the block gas limit is reached in the middle of the block, which is then
+	// executed sequentially
+	sequential := newParallelTestSuite(t, 40000)
+	parallel := newParallelTestSuite(t, 40000, baseapp.SetParallelExecution(4))
+
+	blocks := randomTransferBlocks(t, sequential, rand.New(rand.NewSource(1)), 2, 40)
+	responses := requireSameExecution(t, sequential, parallel, blocks)
+
+	outOfGas := false
+	for _, txRes := range responses[0].TxResults {
+		if txRes.Codespace == "sdk" && txRes.Code == 11 {
+			outOfGas = true
+		}
+	}
+	require.True(t, outOfGas)
+}
@@ -0,0 +1,143 @@
+package baseapp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/gogoproto/proto"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/cachemulti"
+	"cosmossdk.io/store/dbadapter"
+	"cosmossdk.io/store/listenkv"
+	storetypes "cosmossdk.io/store/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// StateOverrideHandler applies a typed state override to the state of a
+// simulation, e.g. by setting the balance of an account with a keeper.
+type StateOverrideHandler func(ctx sdk.Context, override proto.Message) error
+
+type stateOverrideHandler struct {
+	typ     reflect.Type
+	handler StateOverrideHandler
+}
+
+// SimulationOverrides are the changes applied to the state of a simulation
+// before the simulated transaction is executed.
+type SimulationOverrides struct {
+	// KVPairs are raw key-value pairs set, or deleted when Delete is true, in
+	// the stores named by their StoreKey.
+	KVPairs []*storetypes.StoreKVPair
+
+	// Typed are typed overrides, applied after KVPairs by the handlers
+	// registered with RegisterStateOverrideHandler for their types.
+	Typed []*codectypes.Any
+}
+
+// RegisterStateOverrideHandler registers the handler applying the typed state
+// overrides of the type of override. It panics if a handler is already
+// registered for that type.
+func (app *BaseApp) RegisterStateOverrideHandler(override proto.Message, handler StateOverrideHandler) {
+	typeURL := "/" + proto.MessageName(override)
+	if _, ok := app.stateOverrideHandlers[typeURL]; ok {
+		panic(fmt.Sprintf("state override handler already registered for %s", typeURL))
+	}
+
+	app.stateOverrideHandlers[typeURL] = stateOverrideHandler{
+		typ:     reflect.TypeOf(override).Elem(),
+		handler: handler,
+	}
+}
+
+// SimulateWithOverrides executes a tx in simulate mode like Simulate, after
+// applying the overrides to the branched state of the simulation. It also
+// returns the state diff of the tx, which is the key-value pairs it wrote or
+// deleted, excluding the overrides, sorted by store and key. Nothing is
+// persisted.
+func (app *BaseApp) SimulateWithOverrides(txBytes []byte, overrides SimulationOverrides) (sdk.GasInfo, *sdk.Result, []*storetypes.StoreKVPair, error) {
+	cms, ok := app.cms.(interface {
+		StoreKeysByName() map[string]storetypes.StoreKey
+	})
+	if !ok {
+		return sdk.GasInfo{}, nil, nil, errors.New("multi-store doesn't support state overrides")
+	}
+	keysByName := cms.StoreKeysByName()
+
+	ctx := app.getContextForTx(execModeSimulate, txBytes)
+	if err := app.applyStateOverrides(ctx, keysByName, overrides); err != nil {
+		return sdk.GasInfo{}, nil, nil, err
+	}
+
+	// the writes of the tx are recorded on top of the overridden state, so
+	// that the overrides aren't part of the state diff
+	listener := storetypes.NewMemoryListener()
+	stores := make(map[storetypes.StoreKey]storetypes.CacheWrapper, len(keysByName))
+	for _, key := range keysByName {
+		stores[key] = listenkv.NewStore(ctx.MultiStore().GetKVStore(key), key, listener)
+	}
+	ms := cachemulti.NewFromKVStore(dbadapter.Store{DB: dbm.NewMemDB()}, stores, keysByName, nil, nil)
+
+	gInfo, result, _, err := app.runTxWithContext(execModeSimulate, ctx.WithMultiStore(ms), txBytes, nil)
+	ms.Write()
+
+	// the pairs of each store are written in the order of their keys, but the
+	// stores aren't
+	diff := listener.PopStateCache()
+	sort.SliceStable(diff, func(i, j int) bool {
+		return diff[i].StoreKey < diff[j].StoreKey
+	})
+
+	return gInfo, result, diff, err
+}
+
+// applyStateOverrides applies the overrides of a simulation to the state of
+// ctx, raw key-value pairs first.
+func (app *BaseApp) applyStateOverrides(ctx sdk.Context, keysByName map[string]storetypes.StoreKey, overrides SimulationOverrides) error {
+	for _, pair := range overrides.KVPairs {
+		key, ok := keysByName[pair.StoreKey]
+		if !ok {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown store %s in state override", pair.StoreKey)
+		}
+		if len(pair.Key) == 0 {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "state override key cannot be empty")
+		}
+
+		store := ctx.MultiStore().GetKVStore(key)
+		if pair.Delete {
+			store.Delete(pair.Key)
+			continue
+		}
+		if pair.Value == nil {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "state override value cannot be nil")
+		}
+		store.Set(pair.Key, pair.Value)
+	}
+
+	// typed overrides consume no gas and emit no events in the simulation
+	ctx = ctx.
+		WithGasMeter(storetypes.NewInfiniteGasMeter()).
+		WithEventManager(sdk.NewEventManager())
+	for _, typed := range overrides.Typed {
+		h, ok := app.stateOverrideHandlers[typed.TypeUrl]
+		if !ok {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "no state override handler for %s", typed.TypeUrl)
+		}
+
+		override := reflect.New(h.typ).Interface().(proto.Message)
+		if err := proto.Unmarshal(typed.Value, override); err != nil {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "invalid state override %s: %s", typed.TypeUrl, err)
+		}
+		if err := h.handler(ctx, override); err != nil {
+			return errorsmod.Wrapf(err, "failed to apply state override %s", typed.TypeUrl)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package baseapp_test
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
+)
+
+func TestSimulateWithOverrides(t *testing.T) {
+	suite := newParallelTestSuite(t, 0)
+	// the typed override sets the balance of the account in the key to the
+	// value
+	suite.baseApp.RegisterStateOverrideHandler(&baseapptestutil.MsgKeyValue{}, func(ctx sdk.Context, override proto.Message) error {
+		msg := override.(*baseapptestutil.MsgKeyValue)
+		setBalance(ctx.KVStore(capKey2), msg.Key, binary.BigEndian.Uint64(msg.Value))
+		return nil
+	})
+
+	_, _, addr := testdata.KeyTestPubAddr()
+	signer := addr.String()
+	builder := suite.txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte("a"), Value: []byte("b"), Signer: signer}))
+	setTxSignature(t, builder, 0)
+	txBytes, err := suite.txConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	// the account has no balance without overrides
+	_, _, err = suite.baseApp.Simulate(txBytes)
+	require.ErrorContains(t, err, "insufficient funds")
+
+	balance := binary.BigEndian.AppendUint64(nil, 5)
+	expectedDiff := []*storetypes.StoreKVPair{
+		{StoreKey: capKey1.Name(), Key: []byte("sequence/" + signer), Value: binary.BigEndian.AppendUint64(nil, 1)},
+		{StoreKey: capKey2.Name(), Key: []byte("balance/a"), Value: binary.BigEndian.AppendUint64(nil, 4)},
+		{StoreKey: capKey2.Name(), Key: []byte("balance/b"), Value: binary.BigEndian.AppendUint64(nil, 1)},
+	}
+
+	gInfo, result, diff, err := suite.baseApp.SimulateWithOverrides(txBytes, baseapp.SimulationOverrides{
+		KVPairs: []*storetypes.StoreKVPair{
+			{StoreKey: capKey2.Name(), Key: []byte("balance/a"), Value: balance},
+			{StoreKey: capKey2.Name(), Key: []byte("balance/c"), Value: balance},
+		},
+	})
+	require.NoError(t, err)
+	require.Greater(t, gInfo.GasUsed, uint64(1000))
+	require.Equal(t, "ante", result.Events[0].Type)
+	require.Equal(t, expectedDiff, diff)
+
+	typed, err := codectypes.NewAnyWithValue(&baseapptestutil.MsgKeyValue{Key: []byte("a"), Value: balance})
+	require.NoError(t, err)
+	_, _, diff, err = suite.baseApp.SimulateWithOverrides(txBytes, baseapp.SimulationOverrides{
+		Typed: []*codectypes.Any{typed},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expectedDiff, diff)
+
+	// overrides of the same key are applied in order
+	_, _, _, err = suite.baseApp.SimulateWithOverrides(txBytes, baseapp.SimulationOverrides{
+		KVPairs: []*storetypes.StoreKVPair{
+			{StoreKey: capKey2.Name(), Key: []byte("balance/a"), Value: balance},
+			{StoreKey: capKey2.Name(), Key: []byte("balance/a"), Delete: true},
+		},
+	})
+	require.ErrorContains(t, err, "insufficient funds")
+
+	// nothing is persisted
+	_, _, err = suite.baseApp.Simulate(txBytes)
+	require.ErrorContains(t, err, "insufficient funds")
+}
+
+func TestSimulateWithInvalidOverrides(t *testing.T) {
+	suite := newParallelTestSuite(t, 0)
+	_, _, addr := testdata.KeyTestPubAddr()
+	builder := suite.txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte("mint"), Value: []byte("a"), Signer: addr.String()}))
+	setTxSignature(t, builder, 0)
+	txBytes, err := suite.txConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	typed, err := codectypes.NewAnyWithValue(&baseapptestutil.MsgKeyValue{})
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		overrides baseapp.SimulationOverrides
+		expErr    string
+	}{
+		"unknown store": {
+			overrides: baseapp.SimulationOverrides{KVPairs: []*storetypes.StoreKVPair{{StoreKey: "unknown", Key: []byte("a"), Value: []byte("a")}}},
+			expErr:    "unknown store unknown",
+		},
+		"empty key": {
+			overrides: baseapp.SimulationOverrides{KVPairs: []*storetypes.StoreKVPair{{StoreKey: capKey2.Name(), Value: []byte("a")}}},
+			expErr:    "key cannot be empty",
+		},
+		"nil value": {
+			overrides: baseapp.SimulationOverrides{KVPairs: []*storetypes.StoreKVPair{{StoreKey: capKey2.Name(), Key: []byte("a")}}},
+			expErr:    "value cannot be nil",
+		},
+		"no handler": {
+			overrides: baseapp.SimulationOverrides{Typed: []*codectypes.Any{typed}},
+			expErr:    "no state override handler",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, err := suite.baseApp.SimulateWithOverrides(txBytes, tc.overrides)
+			require.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestSimulateGasProfile(t *testing.T) {
+	profiler := gasprofile.NewProfiler()
+	suite := newParallelTestSuite(t, 0, baseapp.SetGasProfiler(profiler))
+
+	_, _, addr := testdata.KeyTestPubAddr()
+	builder := suite.txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte("mint"), Value: []byte("a"), Signer: addr.String()}))
+	setTxSignature(t, builder, 0)
+	txBytes, err := suite.txConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	gInfo, _, err := suite.baseApp.Simulate(txBytes)
+	require.NoError(t, err)
+
+	// the gas of the ante handler and of the message is attributed to their
+	// stores and key prefixes
+	msgTypeURL := sdk.MsgTypeURL(&baseapptestutil.MsgKeyValue{})
+	gas := make(map[string]uint64)
+	var total uint64
+	for _, sample := range profiler.Samples() {
+		gas[strings.Join(sample.Stack, ";")] = sample.Gas
+		total += sample.Gas
+	}
+	require.Equal(t, gInfo.GasUsed, total)
+	require.Equal(t, uint64(1000), gas["ante;ante"])
+	require.Equal(t, storetypes.KVGasConfig().ReadCostFlat, gas["ante;key1;0x73;"+storetypes.GasReadCostFlatDesc])
+	require.Equal(t, storetypes.KVGasConfig().WriteCostFlat, gas["ante;key1;0x73;"+storetypes.GasWriteCostFlatDesc])
+	require.Equal(t, storetypes.KVGasConfig().ReadCostFlat, gas[msgTypeURL+";key2;0x62;"+storetypes.GasReadCostFlatDesc])
+	require.Equal(t, storetypes.KVGasConfig().WriteCostFlat, gas[msgTypeURL+";key2;0x62;"+storetypes.GasWriteCostFlatDesc])
+}
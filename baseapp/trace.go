@@ -0,0 +1,415 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"google.golang.org/protobuf/runtime/protoiface"
+
+	corecomet "cosmossdk.io/core/comet"
+	coreheader "cosmossdk.io/core/header"
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// TraceTx re-executes the transaction at index txIndex of the block of req on
+// the state of the previous block, and returns a trace of its execution: every
+// key-value operation with its gas, and every message dispatched through the
+// MsgServiceRouter with its gas and events.
+//
+// The pre-blocker, the begin blocker and the transactions preceding the traced
+// one in the block are re-executed first, without tracing. The state of the
+// previous block must not be pruned, so the transactions of the first block
+// can't be traced. Nothing is persisted.
+//
+// TraceTx must be enabled with SetTraceTx, which also limits the number of
+// concurrent traces. The re-execution stops between transactions once goCtx is
+// done, and a panic during it is returned as an error.
+func (app *BaseApp) TraceTx(goCtx context.Context, req *abci.RequestFinalizeBlock, txIndex int) (res *txtypes.TraceTxResponse, err error) {
+	if app.traceTxSem == nil {
+		return nil, errors.New("tx tracing is disabled")
+	}
+	select {
+	case app.traceTxSem <- struct{}{}:
+		defer func() { <-app.traceTxSem }()
+	case <-goCtx.Done():
+		return nil, goCtx.Err()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, errorsmod.Wrapf(sdkerrors.ErrPanic, "failed to trace tx %d of block %d: %v", txIndex, req.Height, r)
+		}
+	}()
+
+	if txIndex < 0 || txIndex >= len(req.Txs) {
+		return nil, fmt.Errorf("tx index %d out of range of the %d txs of block %d", txIndex, len(req.Txs), req.Height)
+	}
+	if req.Height <= 1 {
+		return nil, errors.New("cannot trace the txs of the first block")
+	}
+
+	ms, err := app.cms.CacheMultiStoreWithVersion(req.Height - 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state at height %d: %w", req.Height-1, err)
+	}
+
+	var appHash []byte
+	if cms, ok := app.cms.(interface {
+		GetCommitInfo(int64) (*storetypes.CommitInfo, error)
+	}); ok {
+		if commitInfo, err := cms.GetCommitInfo(req.Height - 1); err == nil {
+			appHash = commitInfo.Hash()
+		}
+	}
+
+	// the context is set up like the one of FinalizeBlock, see internalFinalizeBlock
+	header := cmtproto.Header{
+		ChainID:            app.chainID,
+		Height:             req.Height,
+		Time:               req.Time,
+		ProposerAddress:    req.ProposerAddress,
+		NextValidatorsHash: req.NextValidatorsHash,
+		AppHash:            appHash,
+	}
+	ctx := sdk.NewContext(ms, false, app.logger).
+		WithBlockHeader(header).
+		WithHeaderHash(req.Hash).
+		WithHeaderInfo(coreheader.Info{
+			ChainID: app.chainID,
+			Height:  req.Height,
+			Time:    req.Time,
+			Hash:    req.Hash,
+			AppHash: appHash,
+		}).
+		WithVoteInfos(req.DecidedLastCommit.Votes).
+		WithExecMode(sdk.ExecModeFinalize).
+		WithCometInfo(corecomet.Info{
+			Evidence:        sdk.ToSDKEvidence(req.Misbehavior),
+			ValidatorsHash:  req.NextValidatorsHash,
+			ProposerAddress: req.ProposerAddress,
+			LastCommit:      sdk.ToSDKCommitInfo(req.DecidedLastCommit),
+		}).
+		WithIsSigverifyTx(app.sigverifyTx)
+	ctx = ctx.WithConsensusParams(app.GetConsensusParams(ctx))
+
+	if app.preBlocker != nil {
+		if err := app.preBlocker(ctx, req); err != nil {
+			return nil, err
+		}
+		ctx = ctx.WithConsensusParams(app.GetConsensusParams(ctx))
+	}
+	if app.beginBlocker != nil {
+		if _, err := app.beginBlocker(ctx.WithBlockGasMeter(app.getBlockGasMeter(ctx))); err != nil {
+			return nil, err
+		}
+	}
+	ctx = ctx.WithBlockGasMeter(app.getBlockGasMeter(ctx))
+
+	removeTx := func(sdk.Tx) error { return nil }
+	txContext := func(ctx sdk.Context, txBytes []byte) sdk.Context {
+		return ctx.
+			WithTxBytes(txBytes).
+			WithGasMeter(storetypes.NewInfiniteGasMeter()).
+			WithEventManager(sdk.NewEventManager())
+	}
+
+	for _, txBytes := range req.Txs[:txIndex] {
+		if err := goCtx.Err(); err != nil {
+			return nil, err
+		}
+		// NOTE: Not all raw transactions may adhere to the sdk.Tx interface, e.g.
+		// vote extensions, so skip those.
+		if _, err := app.txDecoder(txBytes); err != nil {
+			continue
+		}
+		_, _, _, _ = app.runTxWithContext(execModeFinalize, txContext(ctx, txBytes), txBytes, removeTx)
+	}
+
+	if err := goCtx.Err(); err != nil {
+		return nil, err
+	}
+
+	tracer := newTxTracer(ctx)
+	txBytes := req.Txs[txIndex]
+	txCtx := txContext(ctx, txBytes).
+		WithMultiStore(&traceMultiStore{parent: ctx.MultiStore(), tracer: tracer}).
+		WithValue(txTracerKey{}, tracer)
+	gInfo, result, _, err := app.runTxWithContext(execModeFinalize, txCtx, txBytes, removeTx)
+
+	res = &txtypes.TraceTxResponse{
+		Height:     req.Height,
+		Index:      uint32(txIndex),
+		GasInfo:    &gInfo,
+		Result:     result,
+		Operations: tracer.ops,
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res, nil
+}
+
+// TraceTxEnabled returns true if TraceTx is enabled with SetTraceTx.
+func (app *BaseApp) TraceTxEnabled() bool {
+	return app.traceTxSem != nil
+}
+
+// txTracerKey is the context key of the txTracer of a traced transaction.
+type txTracerKey struct{}
+
+// txTracer records the operations of a traced transaction.
+type txTracer struct {
+	ops                  []*txtypes.TraceOperation
+	depth                uint32
+	kvGasConfig          storetypes.GasConfig
+	transientKVGasConfig storetypes.GasConfig
+}
+
+func newTxTracer(ctx sdk.Context) *txTracer {
+	return &txTracer{
+		kvGasConfig:          ctx.KVGasConfig(),
+		transientKVGasConfig: ctx.TransientKVGasConfig(),
+	}
+}
+
+// recordKV records a key-value operation, with the gas charged for it by the
+// gas KV store.
+func (t *txTracer) recordKV(kind txtypes.TraceOperation_Kind, storeKey storetypes.StoreKey, key, value []byte) {
+	gasConfig := t.kvGasConfig
+	if _, ok := storeKey.(*storetypes.TransientStoreKey); ok {
+		gasConfig = t.transientKVGasConfig
+	}
+
+	var gas storetypes.Gas
+	size := storetypes.Gas(len(key) + len(value))
+	switch kind {
+	case txtypes.TraceOperation_KIND_READ:
+		gas = gasConfig.ReadCostFlat + gasConfig.ReadCostPerByte*size
+	case txtypes.TraceOperation_KIND_HAS:
+		gas = gasConfig.HasCost
+	case txtypes.TraceOperation_KIND_WRITE:
+		gas = gasConfig.WriteCostFlat + gasConfig.WriteCostPerByte*size
+	case txtypes.TraceOperation_KIND_DELETE:
+		gas = gasConfig.DeleteCost
+	case txtypes.TraceOperation_KIND_ITERATE:
+		gas = gasConfig.IterNextCostFlat + gasConfig.ReadCostPerByte*size
+	}
+
+	t.ops = append(t.ops, &txtypes.TraceOperation{
+		Kind:     kind,
+		Depth:    t.depth,
+		Gas:      gas,
+		StoreKey: storeKey.Name(),
+		Key:      key,
+		Value:    value,
+	})
+}
+
+// beginMsg records the dispatch of a message, and returns a function which
+// records its result.
+func (t *txTracer) beginMsg(ctx sdk.Context, typeURL string) func(res *sdk.Result, err error) {
+	op := &txtypes.TraceOperation{
+		Kind:       txtypes.TraceOperation_KIND_MSG,
+		Depth:      t.depth,
+		MsgTypeUrl: typeURL,
+	}
+	t.ops = append(t.ops, op)
+	t.depth++
+
+	gasBefore := ctx.GasMeter().GasConsumed()
+	return func(res *sdk.Result, err error) {
+		t.depth--
+		op.Gas = ctx.GasMeter().GasConsumed() - gasBefore
+		if err != nil {
+			op.Error = err.Error()
+			return
+		}
+		if res == nil {
+			return
+		}
+		for i := range res.Events {
+			t.ops = append(t.ops, &txtypes.TraceOperation{
+				Kind:  txtypes.TraceOperation_KIND_EVENT,
+				Depth: op.Depth,
+				Event: &res.Events[i],
+			})
+		}
+	}
+}
+
+// traceMsgServiceHandler wraps a message handler so that its dispatches are
+// recorded when tracing a transaction.
+func traceMsgServiceHandler(handler MsgServiceHandler) MsgServiceHandler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		tracer, ok := ctx.Value(txTracerKey{}).(*txTracer)
+		if !ok {
+			return handler(ctx, msg)
+		}
+
+		end := tracer.beginMsg(ctx, sdk.MsgTypeURL(msg))
+		res, err := handler(ctx, msg)
+		end(res, err)
+		return res, err
+	}
+}
+
+// traceHybridHandler wraps a hybrid message handler so that its dispatches are
+// recorded when tracing a transaction. The events of hybrid handlers aren't
+// recorded, since they are emitted on the event manager of the caller.
+func traceHybridHandler(handler func(ctx context.Context, req, resp protoiface.MessageV1) error) func(ctx context.Context, req, resp protoiface.MessageV1) error {
+	return func(ctx context.Context, req, resp protoiface.MessageV1) error {
+		tracer, ok := ctx.Value(txTracerKey{}).(*txTracer)
+		if !ok {
+			return handler(ctx, req, resp)
+		}
+
+		end := tracer.beginMsg(sdk.UnwrapSDKContext(ctx), sdk.MsgTypeURL(req))
+		err := handler(ctx, req, resp)
+		end(nil, err)
+		return err
+	}
+}
+
+// traceMultiStore is a MultiStore whose stores, and the stores of its
+// branches, record their operations with a txTracer. Since it wraps the
+// branches of the transaction, the operations are recorded as they are
+// performed, including the ones served by caches.
+type traceMultiStore struct {
+	parent storetypes.MultiStore
+	tracer *txTracer
+}
+
+var _ storetypes.CacheMultiStore = &traceMultiStore{}
+
+func (ms *traceMultiStore) GetStoreType() storetypes.StoreType {
+	return ms.parent.GetStoreType()
+}
+
+func (ms *traceMultiStore) CacheWrap() storetypes.CacheWrap {
+	return ms.CacheMultiStore()
+}
+
+func (ms *traceMultiStore) CacheWrapWithTrace(_ io.Writer, _ storetypes.TraceContext) storetypes.CacheWrap {
+	return ms.CacheWrap()
+}
+
+func (ms *traceMultiStore) CacheMultiStore() storetypes.CacheMultiStore {
+	return &traceMultiStore{parent: ms.parent.CacheMultiStore(), tracer: ms.tracer}
+}
+
+func (ms *traceMultiStore) CacheMultiStoreWithVersion(_ int64) (storetypes.CacheMultiStore, error) {
+	return nil, errors.New("cannot branch the multi-store of a traced transaction at a version")
+}
+
+func (ms *traceMultiStore) GetStore(key storetypes.StoreKey) storetypes.Store {
+	return ms.GetKVStore(key)
+}
+
+func (ms *traceMultiStore) GetKVStore(key storetypes.StoreKey) storetypes.KVStore {
+	return &traceStore{parent: ms.parent.GetKVStore(key), key: key, tracer: ms.tracer}
+}
+
+func (ms *traceMultiStore) TracingEnabled() bool {
+	return ms.parent.TracingEnabled()
+}
+
+func (ms *traceMultiStore) SetTracer(w io.Writer) storetypes.MultiStore {
+	ms.parent = ms.parent.SetTracer(w)
+	return ms
+}
+
+func (ms *traceMultiStore) SetTracingContext(tc storetypes.TraceContext) storetypes.MultiStore {
+	ms.parent = ms.parent.SetTracingContext(tc)
+	return ms
+}
+
+func (ms *traceMultiStore) LatestVersion() int64 {
+	return ms.parent.LatestVersion()
+}
+
+// Write writes the parent, which must be a CacheMultiStore.
+func (ms *traceMultiStore) Write() {
+	ms.parent.(storetypes.CacheMultiStore).Write()
+}
+
+// traceStore is a KVStore recording its operations with a txTracer.
+type traceStore struct {
+	parent storetypes.KVStore
+	key    storetypes.StoreKey
+	tracer *txTracer
+}
+
+var _ storetypes.KVStore = &traceStore{}
+
+func (s *traceStore) GetStoreType() storetypes.StoreType {
+	return s.parent.GetStoreType()
+}
+
+func (s *traceStore) CacheWrap() storetypes.CacheWrap {
+	return s.parent.CacheWrap()
+}
+
+func (s *traceStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	return s.parent.CacheWrapWithTrace(w, tc)
+}
+
+func (s *traceStore) Get(key []byte) []byte {
+	value := s.parent.Get(key)
+	s.tracer.recordKV(txtypes.TraceOperation_KIND_READ, s.key, key, value)
+	return value
+}
+
+func (s *traceStore) Has(key []byte) bool {
+	s.tracer.recordKV(txtypes.TraceOperation_KIND_HAS, s.key, key, nil)
+	return s.parent.Has(key)
+}
+
+func (s *traceStore) Set(key, value []byte) {
+	s.parent.Set(key, value)
+	s.tracer.recordKV(txtypes.TraceOperation_KIND_WRITE, s.key, key, value)
+}
+
+func (s *traceStore) Delete(key []byte) {
+	s.parent.Delete(key)
+	s.tracer.recordKV(txtypes.TraceOperation_KIND_DELETE, s.key, key, nil)
+}
+
+func (s *traceStore) Iterator(start, end []byte) storetypes.Iterator {
+	return newTraceIterator(s.parent.Iterator(start, end), s)
+}
+
+func (s *traceStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	return newTraceIterator(s.parent.ReverseIterator(start, end), s)
+}
+
+// traceIterator records the key-value pairs iterated over.
+type traceIterator struct {
+	storetypes.Iterator
+	store *traceStore
+}
+
+func newTraceIterator(parent storetypes.Iterator, store *traceStore) storetypes.Iterator {
+	it := &traceIterator{Iterator: parent, store: store}
+	it.record()
+	return it
+}
+
+func (it *traceIterator) Next() {
+	it.Iterator.Next()
+	it.record()
+}
+
+func (it *traceIterator) record() {
+	if it.Iterator.Valid() {
+		it.store.tracer.recordKV(txtypes.TraceOperation_KIND_ITERATE, it.store.key, it.Iterator.Key(), it.Iterator.Value())
+	}
+}
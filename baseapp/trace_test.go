@@ -0,0 +1,166 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// eventTransferServer is a transferServer emitting an event for each message.
+type eventTransferServer struct {
+	transferServer
+}
+
+func (s eventTransferServer) Set(ctx context.Context, msg *baseapptestutil.MsgKeyValue) (*baseapptestutil.MsgCreateKeyValueResponse, error) {
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent("transfer", sdk.NewAttribute("from", string(msg.Key))))
+	return s.transferServer.Set(ctx, msg)
+}
+
+func TestTraceTx(t *testing.T) {
+	suite := NewBaseAppSuite(t, func(app *baseapp.BaseApp) { app.SetAnteHandler(sequenceAnteHandler) }, baseapp.SetTraceTx(true, 1))
+	baseapptestutil.RegisterKeyValueServer(suite.baseApp.MsgServiceRouter(), eventTransferServer{})
+	_, err := suite.baseApp.InitChain(&abci.RequestInitChain{ConsensusParams: &cmtproto.ConsensusParams{}})
+	require.NoError(t, err)
+
+	_, _, addr := testdata.KeyTestPubAddr()
+	signer := addr.String()
+	newTx := func(key, value string) []byte {
+		builder := suite.txConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte(key), Value: []byte(value), Signer: signer}))
+		setTxSignature(t, builder, 0)
+		txBytes, err := suite.txConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return txBytes
+	}
+
+	blocks := []*abci.RequestFinalizeBlock{
+		{Height: 1, Txs: [][]byte{newTx("mint", "a")}},
+		{Height: 2, Txs: [][]byte{newTx("mint", "a"), []byte("malformed"), newTx("a", "b")}},
+	}
+	for _, req := range blocks {
+		_, err := suite.baseApp.FinalizeBlock(req)
+		require.NoError(t, err)
+		_, err = suite.baseApp.Commit()
+		require.NoError(t, err)
+	}
+	commitID := suite.baseApp.LastCommitID()
+
+	res, err := suite.baseApp.TraceTx(context.Background(), blocks[1], 2)
+	require.NoError(t, err)
+	require.Empty(t, res.Error)
+	require.Equal(t, int64(2), res.Height)
+	require.Equal(t, uint32(2), res.Index)
+	require.Greater(t, res.GasInfo.GasUsed, uint64(1000))
+
+	// the state is the one left by the preceding txs of the block
+	sequenceKey := []byte("sequence/" + signer)
+	uint64Bytes := func(i uint64) []byte { return binary.BigEndian.AppendUint64(nil, i) }
+	ops := res.Operations
+	require.Len(t, ops, 8)
+	require.Equal(t, &txtypes.TraceOperation{
+		Kind:     txtypes.TraceOperation_KIND_READ,
+		Gas:      storetypes.KVGasConfig().ReadCostFlat + storetypes.KVGasConfig().ReadCostPerByte*uint64(len(sequenceKey)+8),
+		StoreKey: capKey1.Name(),
+		Key:      sequenceKey,
+		Value:    uint64Bytes(2),
+	}, ops[0])
+	require.Equal(t, txtypes.TraceOperation_KIND_WRITE, ops[1].Kind)
+	require.Equal(t, uint64Bytes(3), ops[1].Value)
+
+	require.Equal(t, txtypes.TraceOperation_KIND_MSG, ops[2].Kind)
+	require.Equal(t, uint32(0), ops[2].Depth)
+	require.Equal(t, sdk.MsgTypeURL(&baseapptestutil.MsgKeyValue{}), ops[2].MsgTypeUrl)
+	require.NotZero(t, ops[2].Gas)
+	require.Empty(t, ops[2].Error)
+
+	expected := []struct {
+		kind  txtypes.TraceOperation_Kind
+		key   string
+		value []byte
+	}{
+		{txtypes.TraceOperation_KIND_READ, "balance/a", uint64Bytes(20)},
+		{txtypes.TraceOperation_KIND_WRITE, "balance/a", uint64Bytes(19)},
+		{txtypes.TraceOperation_KIND_READ, "balance/b", nil},
+		{txtypes.TraceOperation_KIND_WRITE, "balance/b", uint64Bytes(1)},
+	}
+	ops = ops[3:]
+	for i, op := range expected {
+		require.Equal(t, op.kind, ops[i].Kind, "op %d", i)
+		require.Equal(t, uint32(1), ops[i].Depth, "op %d", i)
+		require.Equal(t, capKey2.Name(), ops[i].StoreKey, "op %d", i)
+		require.Equal(t, []byte(op.key), ops[i].Key, "op %d", i)
+		require.Equal(t, op.value, ops[i].Value, "op %d", i)
+	}
+
+	// the events of the message follow its operations
+	require.Equal(t, txtypes.TraceOperation_KIND_EVENT, ops[4].Kind)
+	require.Equal(t, uint32(0), ops[4].Depth)
+	require.Equal(t, "transfer", ops[4].Event.Type)
+
+	// nothing is persisted
+	require.Equal(t, commitID, suite.baseApp.LastCommitID())
+	again, err := suite.baseApp.TraceTx(context.Background(), blocks[1], 2)
+	require.NoError(t, err)
+	require.Equal(t, res, again)
+
+	_, err = suite.baseApp.TraceTx(context.Background(), blocks[1], 3)
+	require.ErrorContains(t, err, "out of range")
+	_, err = suite.baseApp.TraceTx(context.Background(), blocks[0], 0)
+	require.ErrorContains(t, err, "first block")
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = suite.baseApp.TraceTx(canceled, blocks[1], 2)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTraceTx_Disabled(t *testing.T) {
+	suite := NewBaseAppSuite(t)
+	require.False(t, suite.baseApp.TraceTxEnabled())
+
+	_, err := suite.baseApp.TraceTx(context.Background(), &abci.RequestFinalizeBlock{Height: 2, Txs: [][]byte{{}}}, 0)
+	require.ErrorContains(t, err, "disabled")
+}
+
+func TestTraceTx_PreBlockerPanic(t *testing.T) {
+	panicking := false
+	suite := NewBaseAppSuite(t, baseapp.SetTraceTx(true, 1), func(app *baseapp.BaseApp) {
+		app.SetPreBlocker(func(sdk.Context, *abci.RequestFinalizeBlock) error {
+			if panicking {
+				panic("pre-blocker panic")
+			}
+			return nil
+		})
+	})
+	_, err := suite.baseApp.InitChain(&abci.RequestInitChain{ConsensusParams: &cmtproto.ConsensusParams{}})
+	require.NoError(t, err)
+	_, err = suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: 1})
+	require.NoError(t, err)
+	_, err = suite.baseApp.Commit()
+	require.NoError(t, err)
+
+	req := &abci.RequestFinalizeBlock{Height: 2, Txs: [][]byte{[]byte("tx")}}
+	panicking = true
+	_, err = suite.baseApp.TraceTx(context.Background(), req, 0)
+	require.ErrorIs(t, err, sdkerrors.ErrPanic)
+	require.ErrorContains(t, err, "pre-blocker panic")
+
+	// the trace is released, so the next one runs
+	panicking = false
+	res, err := suite.baseApp.TraceTx(context.Background(), req, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Error)
+}
@@ -0,0 +1,416 @@
+package baseapp
+
+import (
+	"fmt"
+	"sort"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	collcodec "cosmossdk.io/collections/codec"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VoteExtensionHandler defines the vote extension handler of a module, whose
+// vote extensions are of type V and are aggregated into a value of type A, such
+// as the prices of an oracle aggregated into a stake-weighted median price.
+type VoteExtensionHandler[V, A any] struct {
+	// Codec encodes the vote extensions of the module.
+	Codec collcodec.ValueCodec[V]
+
+	// Extend returns the vote extension of the module in ExtendVote. If it
+	// returns an error, the vote is extended without an extension of the module.
+	Extend func(ctx sdk.Context, req *abci.RequestExtendVote) (V, error)
+
+	// Verify optionally verifies the vote extension of a validator. It is called
+	// in VerifyVoteExtension, where an error rejects the whole vote extension,
+	// and in PreBlocker, where an error excludes the vote extension from the
+	// aggregate.
+	Verify func(ctx sdk.Context, validator sdk.ConsAddress, extension V) error
+
+	// Aggregate aggregates the vote extensions of the module of the validators
+	// which committed the previous block in PreBlocker. An error makes
+	// FinalizeBlock fail.
+	Aggregate func(ctx sdk.Context, votes []ValidatorVoteExtension[V]) (A, error)
+
+	// Apply applies the aggregate in PreBlocker, typically by writing it to
+	// state. An error makes FinalizeBlock fail.
+	Apply func(ctx sdk.Context, aggregate A) error
+}
+
+// ValidatorVoteExtension is the vote extension of a module of a validator.
+type ValidatorVoteExtension[V any] struct {
+	Validator abci.Validator
+	Extension V
+}
+
+// MissingVoteExtensionHook is called in PreBlocker for each validator which
+// committed the previous block without a valid vote extension of a module, so
+// that the module can slash or jail it. Validators which did not commit the
+// previous block aren't reported, as their liveness is tracked by x/slashing.
+type MissingVoteExtensionHook func(ctx sdk.Context, module string, validator abci.Validator) error
+
+// moduleVoteExtensionHandler is a VoteExtensionHandler with its types erased.
+type moduleVoteExtensionHandler struct {
+	module string
+	extend func(ctx sdk.Context, req *abci.RequestExtendVote) ([]byte, error)
+	verify func(ctx sdk.Context, validator sdk.ConsAddress, bz []byte) error
+	// preBlock aggregates the vote extensions of the module, extensions being
+	// the decoded vote extensions of the validators, and applies the
+	// aggregate. It returns the validators without a valid vote extension.
+	preBlock func(ctx sdk.Context, validators []abci.Validator, extensions []map[string][]byte) ([]abci.Validator, error)
+}
+
+// VoteExtensionManager manages the vote extensions of the modules. A vote
+// extension is an sdk.VoteExtension made of the vote extensions of the modules
+// registering a VoteExtensionHandler. The extended commit of the previous
+// block is injected as the first transaction of the proposal in
+// PrepareProposal, validated in ProcessProposal, and the vote extensions of
+// each module are aggregated and applied in PreBlocker.
+//
+// The handlers of the manager are set on the BaseApp of an application with:
+//
+//	app.SetExtendVoteHandler(manager.ExtendVoteHandler())
+//	app.SetVerifyVoteExtensionHandler(manager.VerifyVoteExtensionHandler())
+//	app.SetPrepareProposal(manager.PrepareProposalHandler(proposalHandler.PrepareProposalHandler()))
+//	app.SetProcessProposal(manager.ProcessProposalHandler(proposalHandler.ProcessProposalHandler()))
+//	app.SetPreBlocker(manager.PreBlocker(app.PreBlocker))
+type VoteExtensionManager struct {
+	valStore ValidatorStore
+	// handlers are sorted by module
+	handlers     []moduleVoteExtensionHandler
+	missingHooks []MissingVoteExtensionHook
+}
+
+// NewVoteExtensionManager returns a new VoteExtensionManager verifying the
+// vote extension signatures of the validators of valStore.
+func NewVoteExtensionManager(valStore ValidatorStore) *VoteExtensionManager {
+	return &VoteExtensionManager{valStore: valStore}
+}
+
+// RegisterVoteExtensionHandler registers the vote extension handler of a
+// module. It panics if the module already has a handler, or if the handler
+// misses a required function.
+func RegisterVoteExtensionHandler[V, A any](m *VoteExtensionManager, module string, h VoteExtensionHandler[V, A]) {
+	if h.Codec == nil || h.Extend == nil || h.Aggregate == nil || h.Apply == nil {
+		panic(fmt.Errorf("vote extension handler of module %s must have a codec, extend, aggregate and apply functions", module))
+	}
+
+	i := sort.Search(len(m.handlers), func(i int) bool { return m.handlers[i].module >= module })
+	if i < len(m.handlers) && m.handlers[i].module == module {
+		panic(fmt.Errorf("vote extension handler of module %s already registered", module))
+	}
+
+	verify := func(ctx sdk.Context, validator sdk.ConsAddress, bz []byte) (V, error) {
+		extension, err := h.Codec.Decode(bz)
+		if err != nil {
+			return extension, fmt.Errorf("failed to decode vote extension: %w", err)
+		}
+		if h.Verify != nil {
+			err = h.Verify(ctx, validator, extension)
+		}
+		return extension, err
+	}
+
+	handler := moduleVoteExtensionHandler{
+		module: module,
+		extend: func(ctx sdk.Context, req *abci.RequestExtendVote) ([]byte, error) {
+			extension, err := h.Extend(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return h.Codec.Encode(extension)
+		},
+		verify: func(ctx sdk.Context, validator sdk.ConsAddress, bz []byte) error {
+			_, err := verify(ctx, validator, bz)
+			return err
+		},
+		preBlock: func(ctx sdk.Context, validators []abci.Validator, extensions []map[string][]byte) ([]abci.Validator, error) {
+			var (
+				votes   []ValidatorVoteExtension[V]
+				missing []abci.Validator
+			)
+			for i, validator := range validators {
+				bz, ok := extensions[i][module]
+				if !ok {
+					missing = append(missing, validator)
+					continue
+				}
+
+				extension, err := verify(ctx, validator.Address, bz)
+				if err != nil {
+					ctx.Logger().Error("invalid vote extension", "module", module, "validator", sdk.ConsAddress(validator.Address), "err", err)
+					missing = append(missing, validator)
+					continue
+				}
+				votes = append(votes, ValidatorVoteExtension[V]{Validator: validator, Extension: extension})
+			}
+
+			aggregate, err := h.Aggregate(ctx, votes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to aggregate vote extensions of module %s: %w", module, err)
+			}
+			if err := h.Apply(ctx, aggregate); err != nil {
+				return nil, fmt.Errorf("failed to apply vote extensions aggregate of module %s: %w", module, err)
+			}
+			return missing, nil
+		},
+	}
+
+	m.handlers = append(m.handlers, moduleVoteExtensionHandler{})
+	copy(m.handlers[i+1:], m.handlers[i:])
+	m.handlers[i] = handler
+}
+
+// AddMissingVoteExtensionHook adds a hook called for missing vote extensions.
+func (m *VoteExtensionManager) AddMissingVoteExtensionHook(hook MissingVoteExtensionHook) {
+	m.missingHooks = append(m.missingHooks, hook)
+}
+
+// ExtendVoteHandler returns the ExtendVote handler extending votes with the
+// vote extensions of the modules.
+func (m *VoteExtensionManager) ExtendVoteHandler() sdk.ExtendVoteHandler {
+	return func(ctx sdk.Context, req *abci.RequestExtendVote) (*abci.ResponseExtendVote, error) {
+		voteExt := sdk.VoteExtension{Extensions: make([]sdk.ModuleVoteExtension, 0, len(m.handlers))}
+		for _, h := range m.handlers {
+			bz, err := h.extend(ctx, req)
+			if err != nil {
+				// the vote is still extended with the extensions of the other modules
+				ctx.Logger().Error("failed to extend vote", "module", h.module, "height", req.Height, "err", err)
+				continue
+			}
+			voteExt.Extensions = append(voteExt.Extensions, sdk.ModuleVoteExtension{Module: h.module, Extension: bz})
+		}
+
+		bz, err := voteExt.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode vote extension: %w", err)
+		}
+		return &abci.ResponseExtendVote{VoteExtension: bz}, nil
+	}
+}
+
+// VerifyVoteExtensionHandler returns the VerifyVoteExtension handler rejecting
+// vote extensions which can't be decoded, have extensions of unknown modules,
+// or have an extension failing the verification of its module.
+func (m *VoteExtensionManager) VerifyVoteExtensionHandler() sdk.VerifyVoteExtensionHandler {
+	return func(ctx sdk.Context, req *abci.RequestVerifyVoteExtension) (*abci.ResponseVerifyVoteExtension, error) {
+		if err := m.verify(ctx, req.ValidatorAddress, req.VoteExtension); err != nil {
+			ctx.Logger().Error("rejecting vote extension", "validator", sdk.ConsAddress(req.ValidatorAddress), "height", req.Height, "err", err)
+			return &abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_REJECT}, nil
+		}
+		return &abci.ResponseVerifyVoteExtension{Status: abci.ResponseVerifyVoteExtension_ACCEPT}, nil
+	}
+}
+
+func (m *VoteExtensionManager) verify(ctx sdk.Context, validator sdk.ConsAddress, bz []byte) error {
+	extensions, err := m.decode(bz)
+	if err != nil {
+		return err
+	}
+	for _, h := range m.handlers {
+		if bz, ok := extensions[h.module]; ok {
+			if err := h.verify(ctx, validator, bz); err != nil {
+				return fmt.Errorf("invalid vote extension of module %s: %w", h.module, err)
+			}
+		}
+	}
+	return nil
+}
+
+// decode decodes a vote extension into the vote extensions of the modules, by
+// module. An empty vote extension has no module vote extensions.
+func (m *VoteExtensionManager) decode(bz []byte) (map[string][]byte, error) {
+	var voteExt sdk.VoteExtension
+	if err := voteExt.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("failed to decode vote extension: %w", err)
+	}
+
+	extensions := make(map[string][]byte, len(voteExt.Extensions))
+	for i, ext := range voteExt.Extensions {
+		if i > 0 && voteExt.Extensions[i-1].Module >= ext.Module {
+			return nil, fmt.Errorf("vote extensions must be sorted by unique module, got %s after %s", ext.Module, voteExt.Extensions[i-1].Module)
+		}
+		if !m.hasHandler(ext.Module) {
+			return nil, fmt.Errorf("vote extension of unknown module %s", ext.Module)
+		}
+		extensions[ext.Module] = ext.Extension
+	}
+	return extensions, nil
+}
+
+func (m *VoteExtensionManager) hasHandler(module string) bool {
+	i := sort.Search(len(m.handlers), func(i int) bool { return m.handlers[i].module >= module })
+	return i < len(m.handlers) && m.handlers[i].module == module
+}
+
+// PrepareProposalHandler returns a PrepareProposal handler injecting the
+// extended commit of the previous block as the first transaction of the
+// proposal prepared by next, once vote extensions are enabled. The bytes of
+// the injected transaction are subtracted from the MaxTxBytes given to next.
+func (m *VoteExtensionManager) PrepareProposalHandler(next sdk.PrepareProposalHandler) sdk.PrepareProposalHandler {
+	return func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+		if !voteExtensionsEnabled(ctx) {
+			return next(ctx, req)
+		}
+
+		bz, err := req.LocalLastCommit.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode extended commit: %w", err)
+		}
+		size := cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{bz})
+		if size > req.MaxTxBytes {
+			return nil, fmt.Errorf("extended commit of %d bytes exceeds max tx bytes %d", size, req.MaxTxBytes)
+		}
+
+		nextReq := *req
+		nextReq.MaxTxBytes -= size
+		res, err := next(ctx, &nextReq)
+		if err != nil {
+			return nil, err
+		}
+		res.Txs = append([][]byte{bz}, res.Txs...)
+		return res, nil
+	}
+}
+
+// ProcessProposalHandler returns a ProcessProposal handler rejecting proposals
+// whose first transaction isn't a valid extended commit of the previous block,
+// as verified by ValidateVoteExtensions, once vote extensions are enabled. The
+// remaining transactions of the proposal are processed by next.
+func (m *VoteExtensionManager) ProcessProposalHandler(next sdk.ProcessProposalHandler) sdk.ProcessProposalHandler {
+	return func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+		if !voteExtensionsEnabled(ctx) {
+			return next(ctx, req)
+		}
+
+		extCommit, err := extractExtendedCommit(req.Txs)
+		if err == nil {
+			err = ValidateVoteExtensions(ctx, m.valStore, extCommit)
+		}
+		if err != nil {
+			ctx.Logger().Error("rejecting proposal with invalid extended commit", "height", req.Height, "err", err)
+			return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
+		}
+
+		nextReq := *req
+		nextReq.Txs = req.Txs[1:]
+		return next(ctx, &nextReq)
+	}
+}
+
+// PreBlocker returns a PreBlocker aggregating and applying the vote extensions
+// of each module, in the order of the modules, from the extended commit
+// injected in the block, once vote extensions are enabled, and calling the
+// missing vote extension hooks. It then calls next, if not nil.
+func (m *VoteExtensionManager) PreBlocker(next sdk.PreBlocker) sdk.PreBlocker {
+	return func(ctx sdk.Context, req *abci.RequestFinalizeBlock) error {
+		if voteExtensionsEnabled(ctx) {
+			extCommit, err := extractExtendedCommit(req.Txs)
+			if err != nil {
+				return err
+			}
+			if err := m.aggregate(ctx, extCommit); err != nil {
+				return err
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+		return next(ctx, req)
+	}
+}
+
+func (m *VoteExtensionManager) aggregate(ctx sdk.Context, extCommit abci.ExtendedCommitInfo) error {
+	var (
+		validators []abci.Validator
+		extensions []map[string][]byte
+	)
+	for _, vote := range extCommit.Votes {
+		if vote.BlockIdFlag != cmtproto.BlockIDFlagCommit {
+			continue
+		}
+
+		exts, err := m.decode(vote.VoteExtension)
+		if err != nil {
+			// all the vote extensions of the validator are missing
+			ctx.Logger().Error("invalid vote extension", "validator", sdk.ConsAddress(vote.Validator.Address), "err", err)
+		}
+		validators = append(validators, vote.Validator)
+		extensions = append(extensions, exts)
+	}
+
+	for _, h := range m.handlers {
+		missing, err := h.preBlock(ctx, validators, extensions)
+		if err != nil {
+			return err
+		}
+		for _, validator := range missing {
+			for _, hook := range m.missingHooks {
+				if err := hook(ctx, h.module, validator); err != nil {
+					return fmt.Errorf("missing vote extension hook of module %s failed: %w", h.module, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// extractExtendedCommit decodes the extended commit injected as the first
+// transaction of a proposal by PrepareProposalHandler.
+func extractExtendedCommit(txs [][]byte) (abci.ExtendedCommitInfo, error) {
+	var extCommit abci.ExtendedCommitInfo
+	if len(txs) == 0 {
+		return extCommit, fmt.Errorf("proposal has no extended commit")
+	}
+	if err := extCommit.Unmarshal(txs[0]); err != nil {
+		return extCommit, fmt.Errorf("failed to decode extended commit: %w", err)
+	}
+	return extCommit, nil
+}
+
+// voteExtensionsEnabled returns whether the proposal or block of the context
+// has the vote extensions of the previous block, which is the case after the
+// vote extensions enable height.
+func voteExtensionsEnabled(ctx sdk.Context) bool {
+	cp := ctx.ConsensusParams()
+	return cp.Abci != nil && cp.Abci.VoteExtensionsEnableHeight != 0 && ctx.HeaderInfo().Height > cp.Abci.VoteExtensionsEnableHeight
+}
+
+// WeightedValue is a value weighted by the voting power of a validator.
+type WeightedValue struct {
+	Value math.LegacyDec
+	Power int64
+}
+
+// StakeWeightedMedian returns the stake-weighted median of values, which is
+// the smallest value such that the values lower than or equal to it have at
+// least half of the total power. Values with a non-positive power are
+// ignored. It returns false if no value has a positive power.
+func StakeWeightedMedian(values []WeightedValue) (math.LegacyDec, bool) {
+	weighted := make([]WeightedValue, 0, len(values))
+	var total int64
+	for _, v := range values {
+		if v.Power > 0 {
+			weighted = append(weighted, v)
+			total += v.Power
+		}
+	}
+	if len(weighted) == 0 {
+		return math.LegacyDec{}, false
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool { return weighted[i].Value.LT(weighted[j].Value) })
+	var cumulative int64
+	for _, v := range weighted {
+		cumulative += v.Power
+		if 2*cumulative >= total {
+			return v.Value, true
+		}
+	}
+	return weighted[len(weighted)-1].Value, true
+}
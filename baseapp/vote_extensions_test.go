@@ -0,0 +1,209 @@
+package baseapp_test
+
+import (
+	"errors"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/core/header"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (s *ABCIUtilsTestSuite) TestVoteExtensionManager() {
+	// the oracle module votes a price aggregated into its stake-weighted median
+	prices := map[string]int64{
+		s.vals[0].consAddr.String(): 100,
+		s.vals[1].consAddr.String(): 120,
+		s.vals[2].consAddr.String(): 90,
+	}
+	var median math.LegacyDec
+	manager := baseapp.NewVoteExtensionManager(s.valStore)
+	baseapp.RegisterVoteExtensionHandler(manager, "oracle", baseapp.VoteExtensionHandler[math.Int, math.LegacyDec]{
+		Codec: sdk.IntValue,
+		Extend: func(ctx sdk.Context, _ *abci.RequestExtendVote) (math.Int, error) {
+			price, ok := prices[sdk.ConsAddress(ctx.BlockHeader().ProposerAddress).String()]
+			if !ok {
+				return math.Int{}, errors.New("no price")
+			}
+			return math.NewInt(price), nil
+		},
+		Verify: func(_ sdk.Context, _ sdk.ConsAddress, price math.Int) error {
+			if !price.IsPositive() {
+				return errors.New("non-positive price")
+			}
+			return nil
+		},
+		Aggregate: func(_ sdk.Context, votes []baseapp.ValidatorVoteExtension[math.Int]) (math.LegacyDec, error) {
+			values := make([]baseapp.WeightedValue, len(votes))
+			for i, vote := range votes {
+				values[i] = baseapp.WeightedValue{Value: math.LegacyNewDecFromInt(vote.Extension), Power: vote.Validator.Power}
+			}
+			median, _ := baseapp.StakeWeightedMedian(values)
+			return median, nil
+		},
+		Apply: func(_ sdk.Context, aggregate math.LegacyDec) error {
+			median = aggregate
+			return nil
+		},
+	})
+
+	// the counter module votes a counter aggregated into the number of votes
+	var count int
+	baseapp.RegisterVoteExtensionHandler(manager, "counter", baseapp.VoteExtensionHandler[uint64, int]{
+		Codec: collections.Uint64Value,
+		Extend: func(sdk.Context, *abci.RequestExtendVote) (uint64, error) {
+			return 1, nil
+		},
+		Aggregate: func(_ sdk.Context, votes []baseapp.ValidatorVoteExtension[uint64]) (int, error) {
+			return len(votes), nil
+		},
+		Apply: func(_ sdk.Context, aggregate int) error {
+			count = aggregate
+			return nil
+		},
+	})
+	s.Require().Panics(func() {
+		baseapp.RegisterVoteExtensionHandler(manager, "counter", baseapp.VoteExtensionHandler[uint64, int]{})
+	})
+
+	type missingExtension struct {
+		module    string
+		validator sdk.ConsAddress
+	}
+	var missing []missingExtension
+	manager.AddMissingVoteExtensionHook(func(_ sdk.Context, module string, validator abci.Validator) error {
+		missing = append(missing, missingExtension{module, validator.Address})
+		return nil
+	})
+
+	// the votes of height 2 are extended, val2 has no price
+	delete(prices, s.vals[2].consAddr.String())
+	extendVote := manager.ExtendVoteHandler()
+	verifyVoteExtension := manager.VerifyVoteExtensionHandler()
+	votes := make([]abci.ExtendedVoteInfo, len(s.vals))
+	for i, val := range s.vals {
+		ctx := s.ctx.WithBlockHeader(cmtproto.Header{ChainID: chainID, ProposerAddress: val.consAddr})
+		res, err := extendVote(ctx, &abci.RequestExtendVote{Height: 2})
+		s.Require().NoError(err)
+
+		verifyRes, err := verifyVoteExtension(s.ctx, &abci.RequestVerifyVoteExtension{ValidatorAddress: val.consAddr, Height: 2, VoteExtension: res.VoteExtension})
+		s.Require().NoError(err)
+		s.Require().Equal(abci.ResponseVerifyVoteExtension_ACCEPT, verifyRes.Status)
+
+		bz, err := marshalDelimitedFn(&cmtproto.CanonicalVoteExtension{Extension: res.VoteExtension, Height: 2, ChainId: chainID})
+		s.Require().NoError(err)
+		sig, err := val.privKey.Sign(bz)
+		s.Require().NoError(err)
+		votes[i] = abci.ExtendedVoteInfo{
+			Validator:          val.toValidator(int64(100 * (i + 1))),
+			VoteExtension:      res.VoteExtension,
+			ExtensionSignature: sig,
+			BlockIdFlag:        cmtproto.BlockIDFlagCommit,
+		}
+	}
+
+	// invalid vote extensions are rejected
+	invalid := []sdk.VoteExtension{
+		{Extensions: []sdk.ModuleVoteExtension{{Module: "unknown"}}},
+		{Extensions: []sdk.ModuleVoteExtension{{Module: "oracle"}, {Module: "counter"}}},
+		{Extensions: []sdk.ModuleVoteExtension{{Module: "oracle", Extension: []byte("-1")}}},
+	}
+	for _, voteExt := range invalid {
+		bz, err := voteExt.Marshal()
+		s.Require().NoError(err)
+		res, err := verifyVoteExtension(s.ctx, &abci.RequestVerifyVoteExtension{VoteExtension: bz})
+		s.Require().NoError(err)
+		s.Require().Equal(abci.ResponseVerifyVoteExtension_REJECT, res.Status, voteExt.String())
+	}
+
+	// the extended commit is injected in the proposal of height 3
+	extCommit, info := extendedCommitToLastCommit(abci.ExtendedCommitInfo{Votes: votes})
+	s.ctx = s.ctx.WithBlockHeight(3).WithHeaderInfo(header.Info{Height: 3, ChainID: chainID}).WithCometInfo(info)
+	extCommitBz, err := extCommit.Marshal()
+	s.Require().NoError(err)
+
+	var maxTxBytes int64
+	prepareProposal := manager.PrepareProposalHandler(func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+		maxTxBytes = req.MaxTxBytes
+		return baseapp.NoOpPrepareProposal()(ctx, req)
+	})
+	prepareRes, err := prepareProposal(s.ctx, &abci.RequestPrepareProposal{Height: 3, MaxTxBytes: 1000, Txs: [][]byte{[]byte("tx")}, LocalLastCommit: extCommit})
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{extCommitBz, []byte("tx")}, prepareRes.Txs)
+	s.Require().Less(maxTxBytes, int64(1000-len(extCommitBz)))
+
+	var processedTxs [][]byte
+	processProposal := manager.ProcessProposalHandler(func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+		processedTxs = req.Txs
+		return baseapp.NoOpProcessProposal()(ctx, req)
+	})
+	processRes, err := processProposal(s.ctx, &abci.RequestProcessProposal{Height: 3, Txs: prepareRes.Txs})
+	s.Require().NoError(err)
+	s.Require().Equal(abci.ResponseProcessProposal_ACCEPT, processRes.Status)
+	s.Require().Equal([][]byte{[]byte("tx")}, processedTxs)
+
+	// proposals without a valid extended commit are rejected
+	tampered := extCommit
+	tampered.Votes = append([]abci.ExtendedVoteInfo(nil), extCommit.Votes...)
+	tampered.Votes[0].VoteExtension = votes[0].VoteExtension[1:]
+	tamperedBz, err := tampered.Marshal()
+	s.Require().NoError(err)
+	for _, txs := range [][][]byte{nil, {[]byte("tx")}, {tamperedBz}} {
+		processRes, err := processProposal(s.ctx, &abci.RequestProcessProposal{Height: 3, Txs: txs})
+		s.Require().NoError(err)
+		s.Require().Equal(abci.ResponseProcessProposal_REJECT, processRes.Status)
+	}
+
+	// the vote extensions are aggregated and applied before the block
+	preBlocked := false
+	preBlocker := manager.PreBlocker(func(sdk.Context, *abci.RequestFinalizeBlock) error {
+		preBlocked = true
+		return nil
+	})
+	s.Require().NoError(preBlocker(s.ctx, &abci.RequestFinalizeBlock{Height: 3, Txs: prepareRes.Txs}))
+	s.Require().True(preBlocked)
+	s.Require().True(math.LegacyNewDec(120).Equal(median), median.String())
+	s.Require().Equal(3, count)
+	s.Require().Equal([]missingExtension{{"oracle", s.vals[2].consAddr}}, missing)
+	s.Require().Error(preBlocker(s.ctx, &abci.RequestFinalizeBlock{Height: 3, Txs: [][]byte{[]byte("tx")}}))
+
+	// before vote extensions are enabled, the handlers are passed through
+	s.ctx = s.ctx.WithHeaderInfo(header.Info{Height: 2, ChainID: chainID})
+	prepareRes, err = prepareProposal(s.ctx, &abci.RequestPrepareProposal{Height: 2, MaxTxBytes: 1000, Txs: [][]byte{[]byte("tx")}})
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{[]byte("tx")}, prepareRes.Txs)
+	s.Require().Equal(int64(1000), maxTxBytes)
+	processRes, err = processProposal(s.ctx, &abci.RequestProcessProposal{Height: 2, Txs: prepareRes.Txs})
+	s.Require().NoError(err)
+	s.Require().Equal(abci.ResponseProcessProposal_ACCEPT, processRes.Status)
+	s.Require().NoError(preBlocker(s.ctx, &abci.RequestFinalizeBlock{Height: 2, Txs: prepareRes.Txs}))
+}
+
+func (s *ABCIUtilsTestSuite) TestStakeWeightedMedian() {
+	dec := math.LegacyNewDec
+	_, ok := baseapp.StakeWeightedMedian(nil)
+	s.Require().False(ok)
+	_, ok = baseapp.StakeWeightedMedian([]baseapp.WeightedValue{{Value: dec(1), Power: 0}})
+	s.Require().False(ok)
+
+	testCases := []struct {
+		values   []baseapp.WeightedValue
+		expected math.LegacyDec
+	}{
+		{[]baseapp.WeightedValue{{dec(5), 1}}, dec(5)},
+		{[]baseapp.WeightedValue{{dec(3), 1}, {dec(1), 1}, {dec(2), 1}}, dec(2)},
+		{[]baseapp.WeightedValue{{dec(3), 1}, {dec(1), 1}}, dec(1)},
+		{[]baseapp.WeightedValue{{dec(3), 10}, {dec(1), 1}, {dec(2), 1}}, dec(3)},
+		{[]baseapp.WeightedValue{{dec(3), 1}, {dec(1), 5}, {dec(2), -10}}, dec(1)},
+	}
+	for _, tc := range testCases {
+		median, ok := baseapp.StakeWeightedMedian(tc.values)
+		s.Require().True(ok)
+		s.Require().True(tc.expected.Equal(median), median.String())
+	}
+}
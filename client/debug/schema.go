@@ -0,0 +1,94 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"cosmossdk.io/collections"
+
+	"github.com/cosmos/cosmos-sdk/runtime/services"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+const (
+	flagOutput = "output"
+
+	outputJSON  = "json"
+	outputProto = "proto"
+)
+
+// SchemaCmd returns the command to print the descriptor of the collections
+// schema of a store, or of all the stores which have one. The schemas are given
+// by store key.
+func SchemaCmd(schemas map[string]collections.Schema) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [store-key]",
+		Short: "Print the descriptor of the collections schema of a store",
+		Long: `Print the descriptor of the collections schema of a store: the name and prefix of every
+collection, the structure of its keys and the type of its values. Without a store key, the
+descriptors of all the stores are printed as a JSON object by store key.
+
+With --output proto, the descriptor of the store is written as a binary
+cosmos.collections.reflection.v1.SchemaDescriptor message.`,
+		Example: fmt.Sprintf("%s debug schema bank", version.AppName),
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString(flagOutput)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				if output != outputJSON {
+					return fmt.Errorf("output %s requires a store key", output)
+				}
+				descriptors := make(map[string]collections.SchemaDescriptor, len(schemas))
+				for storeKey, schema := range schemas {
+					descriptors[storeKey] = schema.Descriptor()
+				}
+				bz, err := json.MarshalIndent(descriptors, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+				return nil
+			}
+
+			schema, ok := schemas[args[0]]
+			if !ok {
+				return fmt.Errorf("no schema for store key %s", args[0])
+			}
+			descriptor := schema.Descriptor()
+
+			switch output {
+			case outputJSON:
+				bz, err := json.MarshalIndent(descriptor, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+			case outputProto:
+				d, err := services.SchemaDescriptorProto(descriptor)
+				if err != nil {
+					return err
+				}
+				bz, err := proto.MarshalOptions{Deterministic: true}.Marshal(d)
+				if err != nil {
+					return err
+				}
+				_, err = cmd.OutOrStdout().Write(bz)
+				return err
+			default:
+				return fmt.Errorf("unknown output %s, expected %s or %s", output, outputJSON, outputProto)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagOutput, outputJSON, "the output format (json|proto)")
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+package debug_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	collreflectionv1 "cosmossdk.io/api/cosmos/collections/reflection/v1"
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/colltest"
+
+	"github.com/cosmos/cosmos-sdk/client/debug"
+)
+
+func TestSchemaCmd(t *testing.T) {
+	sk, _ := colltest.MockStore()
+	sb := collections.NewSchemaBuilder(sk)
+	collections.NewMap(sb, collections.NewPrefix(1), "balances", collections.PairKeyCodec(collections.StringKey, collections.StringKey), collections.Uint64Value)
+	collections.NewItem(sb, collections.NewPrefix(2), "supply", collections.Uint64Value)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+	schemas := map[string]collections.Schema{"bank": schema}
+
+	run := func(args ...string) ([]byte, error) {
+		var out bytes.Buffer
+		cmd := debug.SchemaCmd(schemas)
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.Bytes(), err
+	}
+
+	// the proto output decodes as a SchemaDescriptor
+	bz, err := run("bank", "--output", "proto")
+	require.NoError(t, err)
+	var d collreflectionv1.SchemaDescriptor
+	require.NoError(t, proto.Unmarshal(bz, &d))
+	require.True(t, proto.Equal(&collreflectionv1.SchemaDescriptor{
+		Version: collections.SchemaDescriptorVersion,
+		Collections: []*collreflectionv1.CollectionDescriptor{
+			{
+				Name:   "balances",
+				Prefix: []byte{1},
+				Key: &collreflectionv1.KeyDescriptor{
+					KeyType: "Pair[string, string]",
+					Parts:   []*collreflectionv1.KeyDescriptor{{KeyType: "string"}, {KeyType: "string"}},
+				},
+				ValueType: "uint64",
+			},
+			{
+				Name:      "supply",
+				Prefix:    []byte{2},
+				Key:       &collreflectionv1.KeyDescriptor{KeyType: "no_key"},
+				ValueType: "uint64",
+			},
+		},
+	}, &d), d.String())
+
+	// the json output is the descriptor of every store by store key
+	bz, err = run()
+	require.NoError(t, err)
+	var descriptors map[string]collections.SchemaDescriptor
+	require.NoError(t, json.Unmarshal(bz, &descriptors))
+	require.Equal(t, map[string]collections.SchemaDescriptor{"bank": schema.Descriptor()}, descriptors)
+
+	_, err = run("staking")
+	require.ErrorContains(t, err, "no schema for store key staking")
+	_, err = run("--output", "proto")
+	require.ErrorContains(t, err, "requires a store key")
+}
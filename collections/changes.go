@@ -0,0 +1,242 @@
+package collections
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"cosmossdk.io/collections/codec"
+)
+
+// ErrUnknownCollection is returned when a raw key does not belong to any
+// collection of a schema.
+var ErrUnknownCollection = errors.New("collections: unknown collection")
+
+// Change is a change of a collection, decoded from a raw change of its store.
+type Change struct {
+	// StoreKey is the store key of the schema of the collection.
+	StoreKey string
+	// Collection is the name of the collection.
+	Collection string
+	// Key is the decoded key, whose type is the key type of the collection.
+	Key any
+	// Value is the decoded value, whose type is the value type of the
+	// collection. It is nil if the key was deleted.
+	Value any
+	// Delete is true if the key was deleted.
+	Delete bool
+}
+
+// FindCollection returns the collection the given raw key, including its prefix,
+// belongs to. Fails with ErrUnknownCollection if there is none.
+func (s Schema) FindCollection(key []byte) (Collection, error) {
+	// prefixes of a schema do not overlap, so at most one of them matches
+	for i := 1; i <= len(key); i++ {
+		if c, ok := s.collectionsByPrefix[string(key[:i])]; ok {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: key %X", ErrUnknownCollection, key)
+}
+
+// DecodeChange decodes a raw change of the store of the schema, i.e. a key,
+// including its prefix, which is either set to the given value or deleted, with
+// the codecs of the collection it belongs to. Fails with ErrUnknownCollection if
+// the key does not belong to any collection of the schema.
+func (s Schema) DecodeChange(key, value []byte, delete bool) (Change, error) {
+	c, err := s.FindCollection(key)
+	if err != nil {
+		return Change{}, err
+	}
+	return decodeChange(c, key, value, delete)
+}
+
+func decodeChange(c Collection, key, value []byte, delete bool) (change Change, err error) {
+	change = Change{Collection: c.GetName(), Delete: delete}
+	change.Key, err = c.KeyCodec().Decode(key[len(c.GetPrefix()):])
+	if err != nil {
+		return Change{}, fmt.Errorf("%w: key decode of collection %s: %w", ErrEncoding, c.GetName(), err)
+	}
+	if !delete {
+		change.Value, err = c.ValueCodec().Decode(value)
+		if err != nil {
+			return Change{}, fmt.Errorf("%w: value decode of collection %s: %w", ErrEncoding, c.GetName(), err)
+		}
+	}
+	return change, nil
+}
+
+// ChangeDecoder decodes the raw changes of multiple stores, e.g. the changesets
+// committed by an application, with the schemas of their modules.
+type ChangeDecoder struct {
+	schemas map[string]Schema
+}
+
+// NewChangeDecoder returns a ChangeDecoder of the given schemas, by store key.
+func NewChangeDecoder(schemas map[string]Schema) ChangeDecoder {
+	return ChangeDecoder{schemas: schemas}
+}
+
+// DecodeChange decodes a raw change of the given store, see Schema.DecodeChange.
+// Fails with ErrUnknownCollection if the store has no schema, or if the key does
+// not belong to any collection of the schema.
+func (d ChangeDecoder) DecodeChange(storeKey string, key, value []byte, delete bool) (Change, error) {
+	schema, ok := d.schemas[storeKey]
+	if !ok {
+		return Change{}, fmt.Errorf("%w: no schema for store %s", ErrUnknownCollection, storeKey)
+	}
+
+	change, err := schema.DecodeChange(key, value, delete)
+	if err != nil {
+		return Change{}, err
+	}
+	change.StoreKey = storeKey
+	return change, nil
+}
+
+// FormatDiff formats a raw key of the given store which changed from oldValue
+// to newValue, either of which is nil if the key did not exist, with the codecs
+// of the collection it belongs to, e.g. to print the state diff of a store
+// between two heights. Returns false if the store has no schema, or if the key
+// does not belong to any collection of the schema. The key and values which the
+// codecs cannot decode are formatted as hex.
+func (d ChangeDecoder) FormatDiff(storeKey string, key, oldValue, newValue []byte) (string, bool) {
+	schema, ok := d.schemas[storeKey]
+	if !ok {
+		return "", false
+	}
+	c, err := schema.FindCollection(key)
+	if err != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("collection: %s key: %s old: %s new: %s", c.GetName(),
+		formatKey(c.KeyCodec(), key[len(c.GetPrefix()):]), formatValue(c.ValueCodec(), oldValue), formatValue(c.ValueCodec(), newValue)), true
+}
+
+func formatKey(kc codec.UntypedKeyCodec, bz []byte) string {
+	k, err := kc.Decode(bz)
+	if err != nil {
+		return hex.EncodeToString(bz)
+	}
+	s, err := kc.Stringify(k)
+	if err != nil {
+		return fmt.Sprintf("%v", k)
+	}
+	return s
+}
+
+func formatValue(vc codec.UntypedValueCodec, bz []byte) string {
+	if bz == nil {
+		return ""
+	}
+	v, err := vc.Decode(bz)
+	if err != nil {
+		return hex.EncodeToString(bz)
+	}
+	s, err := vc.Stringify(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return s
+}
+
+// ChangeRecord is a change of a collection whose key and value are encoded with
+// the JSON encoding of the codecs of the collection, meant for off-chain
+// indexers.
+type ChangeRecord struct {
+	StoreKey   string          `json:"store_key"`
+	Collection string          `json:"collection"`
+	Key        json.RawMessage `json:"key"`
+	Value      json.RawMessage `json:"value,omitempty"`
+	Delete     bool            `json:"delete,omitempty"`
+}
+
+// RawChange is a raw change of a store, e.g. one of the key value pairs of the
+// changeset of a block, as received by streaming listeners.
+type RawChange struct {
+	StoreKey string
+	Key      []byte
+	Value    []byte
+	Delete   bool
+}
+
+// ChangeRecords returns the records of the given changes, e.g. the changeset of
+// a block, in order. The changes of stores without a schema, and of keys which
+// do not belong to any collection, are skipped.
+func (d ChangeDecoder) ChangeRecords(changes []RawChange) (ChangeRecords, error) {
+	var records ChangeRecords
+	for _, change := range changes {
+		schema, ok := d.schemas[change.StoreKey]
+		if !ok {
+			continue
+		}
+		c, err := schema.FindCollection(change.Key)
+		if err != nil {
+			continue
+		}
+
+		decoded, err := decodeChange(c, change.Key, change.Value, change.Delete)
+		if err != nil {
+			return nil, fmt.Errorf("store %s: %w", change.StoreKey, err)
+		}
+		record, err := changeRecord(c, decoded)
+		if err != nil {
+			return nil, fmt.Errorf("store %s: %w", change.StoreKey, err)
+		}
+		record.StoreKey = change.StoreKey
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func changeRecord(c Collection, change Change) (record ChangeRecord, err error) {
+	record = ChangeRecord{Collection: change.Collection, Delete: change.Delete}
+	record.Key, err = c.KeyCodec().EncodeJSON(change.Key)
+	if err != nil {
+		return ChangeRecord{}, fmt.Errorf("%w: key json encode of collection %s: %w", ErrEncoding, c.GetName(), err)
+	}
+	if change.Delete {
+		return record, nil
+	}
+	record.Value, err = c.ValueCodec().EncodeJSON(change.Value)
+	if err != nil {
+		return ChangeRecord{}, fmt.Errorf("%w: value json encode of collection %s: %w", ErrEncoding, c.GetName(), err)
+	}
+	return record, nil
+}
+
+// Proto returns the record as a google.protobuf.Struct, with the same fields as
+// its JSON encoding.
+func (r ChangeRecord) Proto() (*structpb.Struct, error) {
+	bz, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	s := new(structpb.Struct)
+	if err := s.UnmarshalJSON(bz); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ChangeRecords are the records of the changes of a changeset.
+type ChangeRecords []ChangeRecord
+
+// MarshalProto encodes the records as a google.protobuf.ListValue of the
+// google.protobuf.Struct of every record.
+func (r ChangeRecords) MarshalProto() ([]byte, error) {
+	list := &structpb.ListValue{Values: make([]*structpb.Value, len(r))}
+	for i, record := range r {
+		s, err := record.Proto()
+		if err != nil {
+			return nil, err
+		}
+		list.Values[i] = structpb.NewStructValue(s)
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(list)
+}
@@ -0,0 +1,128 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSchema_DecodeChange(t *testing.T) {
+	sk, _ := deps()
+	sb := NewSchemaBuilder(sk)
+	m := NewMap(sb, NewPrefix(1), "balances", PairKeyCodec(StringKey, StringKey), Uint64Value)
+	item := NewItem(sb, NewPrefix(2), "params", StringValue)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	key, err := EncodeKeyWithPrefix(m.prefix, m.kc, Join("alice", "atom"))
+	require.NoError(t, err)
+	value, err := m.vc.Encode(100)
+	require.NoError(t, err)
+
+	change, err := schema.DecodeChange(key, value, false)
+	require.NoError(t, err)
+	require.Equal(t, Change{Collection: "balances", Key: Join("alice", "atom"), Value: uint64(100)}, change)
+
+	change, err = schema.DecodeChange(key, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, Change{Collection: "balances", Key: Join("alice", "atom"), Delete: true}, change)
+
+	change, err = schema.DecodeChange(item.prefix, []byte("p"), false)
+	require.NoError(t, err)
+	require.Equal(t, Change{Collection: "params", Key: noKey{}, Value: "p"}, change)
+
+	_, err = schema.DecodeChange([]byte{0x3, 0x1}, nil, true)
+	require.ErrorIs(t, err, ErrUnknownCollection)
+
+	_, err = schema.DecodeChange(key, []byte{0x1}, false)
+	require.ErrorIs(t, err, ErrEncoding)
+
+	// decoding through a ChangeDecoder sets the store key
+	decoder := NewChangeDecoder(map[string]Schema{"bank": schema})
+	change, err = decoder.DecodeChange("bank", key, value, false)
+	require.NoError(t, err)
+	require.Equal(t, "bank", change.StoreKey)
+
+	_, err = decoder.DecodeChange("staking", key, value, false)
+	require.ErrorIs(t, err, ErrUnknownCollection)
+}
+
+func TestChangeDecoder_FormatDiff(t *testing.T) {
+	sk, _ := deps()
+	sb := NewSchemaBuilder(sk)
+	m := NewMap(sb, NewPrefix(1), "balances", PairKeyCodec(StringKey, StringKey), Uint64Value)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	key, err := EncodeKeyWithPrefix(m.prefix, m.kc, Join("alice", "atom"))
+	require.NoError(t, err)
+	value, err := m.vc.Encode(100)
+	require.NoError(t, err)
+
+	decoder := NewChangeDecoder(map[string]Schema{"bank": schema})
+	s, ok := decoder.FormatDiff("bank", key, nil, value)
+	require.True(t, ok)
+	require.Equal(t, `collection: balances key: ("alice", "atom") old:  new: 100`, s)
+
+	// undecodable values are formatted as hex
+	s, ok = decoder.FormatDiff("bank", key, value, []byte{0x1})
+	require.True(t, ok)
+	require.Equal(t, `collection: balances key: ("alice", "atom") old: 100 new: 01`, s)
+
+	// keys of stores without a schema, or of no collection, are not formatted
+	_, ok = decoder.FormatDiff("staking", key, nil, value)
+	require.False(t, ok)
+	_, ok = decoder.FormatDiff("bank", []byte{0x9}, nil, value)
+	require.False(t, ok)
+}
+
+func TestChangeDecoder_ChangeRecords(t *testing.T) {
+	sk, _ := deps()
+	sb := NewSchemaBuilder(sk)
+	m := NewMap(sb, NewPrefix(1), "balances", PairKeyCodec(StringKey, StringKey), Uint64Value)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	key, err := EncodeKeyWithPrefix(m.prefix, m.kc, Join("alice", "atom"))
+	require.NoError(t, err)
+	value, err := m.vc.Encode(100)
+	require.NoError(t, err)
+
+	decoder := NewChangeDecoder(map[string]Schema{"bank": schema})
+	records, err := decoder.ChangeRecords([]RawChange{
+		{StoreKey: "bank", Key: key, Value: value},
+		// not a collection
+		{StoreKey: "bank", Key: []byte{0x9}, Value: value},
+		// no schema
+		{StoreKey: "staking", Key: key, Value: value},
+		{StoreKey: "bank", Key: key, Delete: true},
+	})
+	require.NoError(t, err)
+
+	bz, err := json.Marshal(records)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"store_key":"bank","collection":"balances","key":["alice","atom"],"value":"100"},
+		{"store_key":"bank","collection":"balances","key":["alice","atom"],"delete":true}
+	]`, string(bz))
+
+	bz, err = records.MarshalProto()
+	require.NoError(t, err)
+	list := new(structpb.ListValue)
+	require.NoError(t, proto.Unmarshal(bz, list))
+	require.Len(t, list.Values, 2)
+	record := list.Values[0].GetStructValue().AsMap()
+	require.Equal(t, map[string]any{
+		"store_key":  "bank",
+		"collection": "balances",
+		"key":        []any{"alice", "atom"},
+		"value":      "100",
+	}, record)
+
+	// undecodable changes of a collection fail
+	_, err = decoder.ChangeRecords([]RawChange{{StoreKey: "bank", Key: key, Value: []byte{0x1}}})
+	require.ErrorIs(t, err, ErrEncoding)
+}
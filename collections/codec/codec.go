@@ -124,6 +124,68 @@ type UntypedValueCodec struct {
 	ValueType  func() string
 }
 
+// NewUntypedKeyCodec returns an UntypedKeyCodec for the provided KeyCodec.
+func NewUntypedKeyCodec[K any](k KeyCodec[K]) UntypedKeyCodec {
+	typeName := fmt.Sprintf("%T", *new(K))
+	checkType := func(key interface{}) (concrete K, err error) {
+		concrete, ok := key.(K)
+		if !ok {
+			return concrete, fmt.Errorf("%w: expected key of type %s, got %T", ErrEncoding, typeName, key)
+		}
+		return concrete, nil
+	}
+	return UntypedKeyCodec{
+		Decode: func(b []byte) (interface{}, error) {
+			r, key, err := k.Decode(b)
+			if err != nil {
+				return nil, err
+			}
+			if r != len(b) {
+				return nil, fmt.Errorf("%w: was supposed to fully consume the key '%x', consumed %d out of %d", ErrEncoding, b, r, len(b))
+			}
+			return key, nil
+		},
+		Encode: func(key interface{}) ([]byte, error) {
+			concrete, err := checkType(key)
+			if err != nil {
+				return nil, err
+			}
+			buf := make([]byte, k.Size(concrete))
+			_, err = k.Encode(buf, concrete)
+			return buf, err
+		},
+		DecodeJSON: func(b []byte) (interface{}, error) {
+			return k.DecodeJSON(b)
+		},
+		EncodeJSON: func(key interface{}) ([]byte, error) {
+			concrete, err := checkType(key)
+			if err != nil {
+				return nil, err
+			}
+			return k.EncodeJSON(concrete)
+		},
+		Stringify: func(key interface{}) (string, error) {
+			concrete, err := checkType(key)
+			if err != nil {
+				return "", err
+			}
+			return k.Stringify(concrete), nil
+		},
+		KeyType: func() string { return k.KeyType() },
+	}
+}
+
+// UntypedKeyCodec wraps a KeyCodec to expose an untyped API for encoding and decoding keys.
+// Keys are decoded from, and encoded to, their terminal binary representation.
+type UntypedKeyCodec struct {
+	Decode     func(b []byte) (interface{}, error)
+	Encode     func(key interface{}) ([]byte, error)
+	DecodeJSON func(b []byte) (interface{}, error)
+	EncodeJSON func(key interface{}) ([]byte, error)
+	Stringify  func(key interface{}) (string, error)
+	KeyType    func() string
+}
+
 // KeyToValueCodec converts a KeyCodec into a ValueCodec.
 func KeyToValueCodec[K any](keyCodec KeyCodec[K]) ValueCodec[K] { return keyToValueCodec[K]{keyCodec} }
 
@@ -37,3 +37,40 @@ func TestUntypedValueCodec(t *testing.T) {
 		require.Equal(t, "hello", s)
 	})
 }
+
+func TestUntypedKeyCodec(t *testing.T) {
+	kc := NewUntypedKeyCodec(NewUint64Key[uint64]())
+
+	t.Run("encode/decode", func(t *testing.T) {
+		_, err := kc.Encode("hello")
+		require.ErrorIs(t, err, ErrEncoding)
+		b, err := kc.Encode(uint64(5))
+		require.NoError(t, err)
+		key, err := kc.Decode(b)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), key)
+
+		// the key must be fully consumed
+		_, err = kc.Decode(append(b, 0x0))
+		require.ErrorIs(t, err, ErrEncoding)
+	})
+
+	t.Run("json encode/decode", func(t *testing.T) {
+		_, err := kc.EncodeJSON("hello")
+		require.ErrorIs(t, err, ErrEncoding)
+		b, err := kc.EncodeJSON(uint64(5))
+		require.NoError(t, err)
+		key, err := kc.DecodeJSON(b)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), key)
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		_, err := kc.Stringify("hello")
+		require.ErrorIs(t, err, ErrEncoding)
+		s, err := kc.Stringify(uint64(5))
+		require.NoError(t, err)
+		require.Equal(t, "5", s)
+		require.Equal(t, "uint64", kc.KeyType())
+	})
+}
@@ -2,6 +2,7 @@ package codec_test
 
 import (
 	"testing"
+	"time"
 
 	"cosmossdk.io/collections"
 	"cosmossdk.io/collections/colltest"
@@ -41,6 +42,11 @@ func TestKeyCorrectness(t *testing.T) {
 		colltest.TestKeyCodec(t, collections.Int64Key, -100)
 	})
 
+	t.Run("time", func(t *testing.T) {
+		colltest.TestKeyCodec(t, collections.TimeKey, time.Date(2023, 10, 4, 12, 30, 15, 123456789, time.UTC))
+		colltest.TestKeyCodec(t, collections.TimeKey, time.Date(1900, 1, 1, 0, 0, 0, 1, time.UTC))
+	})
+
 	t.Run("Pair", func(t *testing.T) {
 		colltest.TestKeyCodec(
 			t,
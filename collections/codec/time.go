@@ -0,0 +1,79 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timeSize is the size of an encoded time: 8 bytes for the seconds since the
+// Unix epoch, and 4 bytes for the nanoseconds within the second.
+const timeSize = 12
+
+// NewTimeKey returns a KeyCodec for time.Time. Times are encoded as their
+// seconds since the Unix epoch, whose MSB is toggled, followed by their
+// nanoseconds within the second, both big endian, so the encoding retains
+// chronological ordering. The location and the monotonic clock reading of a
+// time are not encoded: times are decoded in UTC.
+func NewTimeKey() KeyCodec[time.Time] {
+	return timeKey{}
+}
+
+type timeKey struct{}
+
+func (t timeKey) Encode(buffer []byte, key time.Time) (int, error) {
+	binary.BigEndian.PutUint64(buffer, uint64(key.Unix()))
+	buffer[0] ^= 0x80
+	binary.BigEndian.PutUint32(buffer[8:], uint32(key.Nanosecond()))
+	return timeSize, nil
+}
+
+func (t timeKey) Decode(buffer []byte) (int, time.Time, error) {
+	if len(buffer) < timeSize {
+		return 0, time.Time{}, fmt.Errorf("%w: invalid buffer size, wanted: %d", ErrEncoding, timeSize)
+	}
+	sec := binary.BigEndian.Uint64(buffer) ^ (1 << 63)
+	nsec := binary.BigEndian.Uint32(buffer[8:])
+	if nsec >= uint32(time.Second) {
+		return 0, time.Time{}, fmt.Errorf("%w: invalid nanoseconds %d", ErrEncoding, nsec)
+	}
+
+	return timeSize, time.Unix(int64(sec), int64(nsec)).UTC(), nil
+}
+
+func (t timeKey) Size(_ time.Time) int { return timeSize }
+
+func (t timeKey) EncodeJSON(value time.Time) ([]byte, error) {
+	return json.Marshal(value.UTC().Format(time.RFC3339Nano))
+}
+
+func (t timeKey) DecodeJSON(b []byte) (time.Time, error) {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return time.Time{}, err
+	}
+	k, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return k.UTC(), nil
+}
+
+func (t timeKey) Stringify(key time.Time) string { return key.UTC().Format(time.RFC3339Nano) }
+
+func (t timeKey) KeyType() string {
+	return "time"
+}
+
+func (t timeKey) EncodeNonTerminal(buffer []byte, key time.Time) (int, error) {
+	return t.Encode(buffer, key)
+}
+
+func (t timeKey) DecodeNonTerminal(buffer []byte) (int, time.Time, error) {
+	return t.Decode(buffer)
+}
+
+func (t timeKey) SizeNonTerminal(_ time.Time) int {
+	return timeSize
+}
@@ -0,0 +1,49 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeKey_Ordering(t *testing.T) {
+	kc := NewTimeKey()
+	times := []time.Time{
+		time.Date(1200, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(-1, 999999999),
+		time.Unix(0, 0),
+		time.Unix(0, 1),
+		time.Unix(1, 0),
+		time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var prev []byte
+	for _, ti := range times {
+		buffer := make([]byte, kc.Size(ti))
+		_, err := kc.Encode(buffer, ti)
+		require.NoError(t, err)
+		require.Equal(t, -1, bytes.Compare(prev, buffer), "encoding of %s does not retain ordering", ti)
+		prev = buffer
+	}
+}
+
+func TestTimeKey_Decode(t *testing.T) {
+	kc := NewTimeKey()
+	_, _, err := kc.Decode(make([]byte, 11))
+	require.ErrorIs(t, err, ErrEncoding)
+
+	// nanoseconds out of range
+	_, _, err = kc.Decode([]byte{0x80, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0xFF})
+	require.ErrorIs(t, err, ErrEncoding)
+
+	// decoded in UTC
+	buffer := make([]byte, timeSize)
+	ti := time.Date(2023, 10, 4, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	_, err = kc.Encode(buffer, ti)
+	require.NoError(t, err)
+	_, decoded, err := kc.Decode(buffer)
+	require.NoError(t, err)
+	require.Equal(t, ti.UTC(), decoded)
+}
@@ -0,0 +1,184 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// versionedValueMagic prefixes the values encoded by a VersionedValueCodec. A
+// protobuf message never starts with 0xFF, whose wire type is invalid.
+var versionedValueMagic = []byte{0xFF, 0x56}
+
+// rawValueTypes are the value types of the codecs of this package whose
+// encoding can start with any byte, and thus with versionedValueMagic.
+var rawValueTypes = map[string]bool{
+	"bytes":  true,
+	"string": true,
+	"int64":  true,
+	"int32":  true,
+	"uint64": true,
+	"uint32": true,
+	"uint16": true,
+	"time":   true,
+}
+
+// ValueUpgrader decodes a value encoded with a previous version of the values of
+// a VersionedValueCodec, and upgrades it to the next version. It is created by
+// NewValueUpgrader.
+type ValueUpgrader struct {
+	valueType string
+	decode    func([]byte) (any, error)
+	upgrade   func(any) (any, error)
+}
+
+// NewValueUpgrader returns a ValueUpgrader which decodes a value with the codec
+// of its version, and upgrades it to the type of the next version with the
+// given function.
+func NewValueUpgrader[From, To any](from ValueCodec[From], upgrade func(From) (To, error)) ValueUpgrader {
+	return ValueUpgrader{
+		valueType: from.ValueType(),
+		decode: func(b []byte) (any, error) {
+			return from.Decode(b)
+		},
+		upgrade: func(value any) (any, error) {
+			v, ok := value.(From)
+			if !ok {
+				return nil, fmt.Errorf("%w: cannot upgrade %T from %s", ErrEncoding, value, from.ValueType())
+			}
+			return upgrade(v)
+		},
+	}
+}
+
+// NewVersionedValueCodec returns a VersionedValueCodec whose latest version is
+// encoded with the given codec. upgraders[i] upgrades the values of version i
+// to version i+1, so the latest version is len(upgraders).
+func NewVersionedValueCodec[V any](latest ValueCodec[V], upgraders ...ValueUpgrader) VersionedValueCodec[V] {
+	return VersionedValueCodec[V]{
+		latest:    latest,
+		upgraders: upgraders,
+	}
+}
+
+// VersionedValueCodec encodes values in a versioned envelope, so the encoding of
+// the values of a collection can change across upgrades without migrating all
+// of them at once. Values are always encoded with the latest version, while
+// values of a previous version are decoded with the codec of their version and
+// upgraded through the chain of upgraders up to the latest version.
+//
+// Values which are not enveloped fail to decode, unless the codec is created
+// WithLegacyValues.
+type VersionedValueCodec[V any] struct {
+	latest    ValueCodec[V]
+	upgraders []ValueUpgrader
+	legacy    bool
+}
+
+// WithLegacyValues returns a copy of the codec which decodes the values which
+// are not enveloped, i.e. written before the VersionedValueCodec was adopted,
+// as version 0. Values starting with the envelope prefix 0xFF 0x56 are always
+// decoded as enveloped, so the encoding of version 0 must never start with it,
+// which holds for protobuf messages. WithLegacyValues panics if the codec of
+// version 0 is one of the codecs of this package whose encoding can start with
+// any byte, such as Uint64Value, StringValue or BytesValue: their legacy values
+// must be migrated to the envelope before the VersionedValueCodec is adopted.
+func (c VersionedValueCodec[V]) WithLegacyValues() VersionedValueCodec[V] {
+	valueType := c.latest.ValueType()
+	if len(c.upgraders) > 0 {
+		valueType = c.upgraders[0].valueType
+	}
+	if rawValueTypes[valueType] {
+		panic(fmt.Errorf("legacy %s values can start with the versioned value envelope prefix", valueType))
+	}
+
+	c.legacy = true
+	return c
+}
+
+// LatestVersion returns the version values are encoded with.
+func (c VersionedValueCodec[V]) LatestVersion() uint32 {
+	return uint32(len(c.upgraders))
+}
+
+// Version returns the version of the given encoded value.
+func (c VersionedValueCodec[V]) Version(b []byte) (uint32, error) {
+	version, _, err := c.unwrap(b)
+	return version, err
+}
+
+// unwrap returns the version and the payload of the given encoded value.
+func (c VersionedValueCodec[V]) unwrap(b []byte) (uint32, []byte, error) {
+	if !bytes.HasPrefix(b, versionedValueMagic) {
+		if !c.legacy {
+			return 0, nil, fmt.Errorf("%w: value is not versioned", ErrEncoding)
+		}
+		return 0, b, nil
+	}
+
+	version, n := binary.Uvarint(b[len(versionedValueMagic):])
+	if n <= 0 {
+		return 0, nil, fmt.Errorf("%w: invalid value version", ErrEncoding)
+	}
+	if version > uint64(c.LatestVersion()) {
+		return 0, nil, fmt.Errorf("%w: unknown value version %d, latest is %d", ErrEncoding, version, c.LatestVersion())
+	}
+
+	return uint32(version), b[len(versionedValueMagic)+n:], nil
+}
+
+func (c VersionedValueCodec[V]) Encode(value V) ([]byte, error) {
+	payload, err := c.latest.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 0, len(versionedValueMagic)+binary.MaxVarintLen32+len(payload))
+	b = append(b, versionedValueMagic...)
+	b = binary.AppendUvarint(b, uint64(c.LatestVersion()))
+	return append(b, payload...), nil
+}
+
+// Decode decodes the given value with the codec of its version, and upgrades it
+// to the latest version.
+func (c VersionedValueCodec[V]) Decode(b []byte) (V, error) {
+	version, payload, err := c.unwrap(b)
+	if err != nil {
+		var v V
+		return v, err
+	}
+	if version == c.LatestVersion() {
+		return c.latest.Decode(payload)
+	}
+
+	value, err := c.upgraders[version].decode(payload)
+	if err != nil {
+		var v V
+		return v, fmt.Errorf("%w: decoding version %d as %s: %w", ErrEncoding, version, c.upgraders[version].valueType, err)
+	}
+	for i := version; i < c.LatestVersion(); i++ {
+		value, err = c.upgraders[i].upgrade(value)
+		if err != nil {
+			var v V
+			return v, fmt.Errorf("upgrading value from version %d: %w", i, err)
+		}
+	}
+
+	v, ok := value.(V)
+	if !ok {
+		return v, fmt.Errorf("%w: upgraded value is %T, expected %s", ErrEncoding, value, c.latest.ValueType())
+	}
+	return v, nil
+}
+
+// Below there is the implementation of ValueCodec relying on the latest value codec.
+
+func (c VersionedValueCodec[V]) EncodeJSON(value V) ([]byte, error) {
+	return c.latest.EncodeJSON(value)
+}
+
+func (c VersionedValueCodec[V]) DecodeJSON(b []byte) (V, error) { return c.latest.DecodeJSON(b) }
+
+func (c VersionedValueCodec[V]) Stringify(value V) string { return c.latest.Stringify(value) }
+
+func (c VersionedValueCodec[V]) ValueType() string { return c.latest.ValueType() }
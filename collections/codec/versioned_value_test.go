@@ -0,0 +1,137 @@
+package codec_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections/codec"
+	"cosmossdk.io/collections/colltest"
+)
+
+// altValueCodec encodes altValue as JSON.
+type altValueCodec struct{}
+
+func (altValueCodec) Encode(v altValue) ([]byte, error) { return json.Marshal(v) }
+
+func (altValueCodec) Decode(b []byte) (altValue, error) {
+	var v altValue
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+func (c altValueCodec) EncodeJSON(v altValue) ([]byte, error) { return c.Encode(v) }
+
+func (c altValueCodec) DecodeJSON(b []byte) (altValue, error) { return c.Decode(b) }
+
+func (altValueCodec) Stringify(v altValue) string { return strconv.FormatUint(v.Value, 10) }
+
+func (altValueCodec) ValueType() string { return "altValue" }
+
+func TestVersionedValueCodec(t *testing.T) {
+	// version 0 is a json(altValue), version 1 a decimal string, and version 2
+	// the raw uint64
+	v0 := altValueCodec{}
+	v1 := codec.KeyToValueCodec(codec.NewStringKeyCodec[string]())
+	latest := codec.KeyToValueCodec(codec.NewUint64Key[uint64]())
+
+	cdc := codec.NewVersionedValueCodec(latest,
+		codec.NewValueUpgrader(v0, func(v altValue) (string, error) {
+			return strconv.FormatUint(v.Value, 10), nil
+		}),
+		codec.NewValueUpgrader(v1, func(v string) (uint64, error) {
+			return strconv.ParseUint(v, 10, 64)
+		}),
+	)
+	require.Equal(t, uint32(2), cdc.LatestVersion())
+
+	t.Run("decodes a value which is not enveloped as version 0 with legacy values", func(t *testing.T) {
+		bz, err := json.Marshal(altValue{Value: 100})
+		require.NoError(t, err)
+
+		_, err = cdc.Decode(bz)
+		require.ErrorIs(t, err, codec.ErrEncoding)
+
+		legacy := cdc.WithLegacyValues()
+		version, err := legacy.Version(bz)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), version)
+
+		got, err := legacy.Decode(bz)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), got)
+	})
+
+	t.Run("rejects legacy values which can start with the envelope prefix", func(t *testing.T) {
+		// a legacy uint64 of 0xFF56... is parsed as an envelope, so it cannot be
+		// told apart from a versioned value
+		collision := uint64(0xFF56) << 48
+		bz, err := latest.Encode(collision)
+		require.NoError(t, err)
+		_, err = codec.NewVersionedValueCodec(latest).Decode(bz)
+		require.ErrorIs(t, err, codec.ErrEncoding)
+
+		require.Panics(t, func() { codec.NewVersionedValueCodec(latest).WithLegacyValues() })
+		for _, legacy := range []codec.ValueUpgrader{
+			codec.NewValueUpgrader(codec.KeyToValueCodec(codec.NewStringKeyCodec[string]()), func(s string) (int64, error) {
+				return strconv.ParseInt(s, 10, 64)
+			}),
+			codec.NewValueUpgrader(codec.KeyToValueCodec(codec.NewBytesKey[[]byte]()), func(b []byte) (int64, error) {
+				return int64(len(b)), nil
+			}),
+		} {
+			require.Panics(t, func() {
+				codec.NewVersionedValueCodec(codec.KeyToValueCodec(codec.NewInt64Key[int64]()), legacy).WithLegacyValues()
+			})
+		}
+	})
+
+	t.Run("decodes a previous version", func(t *testing.T) {
+		previous := codec.NewVersionedValueCodec(v1, codec.NewValueUpgrader(v0, func(v altValue) (string, error) {
+			return strconv.FormatUint(v.Value, 10), nil
+		}))
+		bz, err := previous.Encode("200")
+		require.NoError(t, err)
+
+		version, err := cdc.Version(bz)
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), version)
+
+		got, err := cdc.Decode(bz)
+		require.NoError(t, err)
+		require.Equal(t, uint64(200), got)
+	})
+
+	t.Run("encodes the latest version", func(t *testing.T) {
+		bz, err := cdc.Encode(300)
+		require.NoError(t, err)
+
+		version, err := cdc.Version(bz)
+		require.NoError(t, err)
+		require.Equal(t, uint32(2), version)
+	})
+
+	t.Run("fails on an unknown version", func(t *testing.T) {
+		next := codec.NewVersionedValueCodec(latest, codec.ValueUpgrader{}, codec.ValueUpgrader{}, codec.ValueUpgrader{})
+		bz, err := next.Encode(300)
+		require.NoError(t, err)
+
+		_, err = cdc.Decode(bz)
+		require.ErrorIs(t, err, codec.ErrEncoding)
+	})
+
+	t.Run("fails on an upgrade error", func(t *testing.T) {
+		previous := codec.NewVersionedValueCodec(v1, codec.ValueUpgrader{})
+		bz, err := previous.Encode("not a number")
+		require.NoError(t, err)
+
+		_, err = cdc.Decode(bz)
+		require.Error(t, err)
+	})
+
+	t.Run("conformance", func(t *testing.T) {
+		colltest.TestValueCodec(t, cdc, uint64(100))
+	})
+}
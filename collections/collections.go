@@ -51,6 +51,10 @@ var (
 	// BoolKey can be used to encode booleans. It uses a single byte to represent the boolean.
 	// 0x0 is used to represent false, and 0x1 is used to represent true.
 	BoolKey = codec.NewBoolKey[bool]()
+	// TimeKey can be used to encode time.Time keys. Encoding retains chronological
+	// ordering. Times are decoded in UTC, without monotonic clock reading.
+	// JSON encoding represents a time key as an RFC 3339 string.
+	TimeKey = codec.NewTimeKey()
 )
 
 // VALUES
@@ -72,6 +76,8 @@ var (
 	StringValue = codec.KeyToValueCodec(StringKey)
 	// BytesValue implements a ValueCodec for bytes.
 	BytesValue = codec.KeyToValueCodec(BytesKey)
+	// TimeValue implements a ValueCodec for time.Time.
+	TimeValue = codec.KeyToValueCodec(TimeKey)
 )
 
 // Collection is the interface that all collections implement. It will eventually
@@ -86,9 +92,16 @@ type Collection interface {
 	// GetPrefix is the unique prefix of the collection within a schema.
 	GetPrefix() []byte
 
+	// KeyCodec returns the codec used to encode/decode keys of the collection,
+	// without their prefix.
+	KeyCodec() codec.UntypedKeyCodec
+
 	// ValueCodec returns the codec used to encode/decode values of the collection.
 	ValueCodec() codec.UntypedValueCodec
 
+	// describe returns the CollectionDescriptor of the collection.
+	describe() CollectionDescriptor
+
 	genesisHandler
 }
 
@@ -138,6 +151,10 @@ type collectionImpl[K, V any] struct {
 	m Map[K, V]
 }
 
+func (c collectionImpl[K, V]) KeyCodec() codec.UntypedKeyCodec {
+	return codec.NewUntypedKeyCodec(c.m.kc)
+}
+
 func (c collectionImpl[K, V]) ValueCodec() codec.UntypedValueCodec {
 	return codec.NewUntypedValueCodec(c.m.vc)
 }
@@ -157,3 +174,15 @@ func (c collectionImpl[K, V]) exportGenesis(ctx context.Context, w io.Writer) er
 }
 
 func (c collectionImpl[K, V]) defaultGenesis(w io.Writer) error { return c.m.defaultGenesis(w) }
+
+func (c collectionImpl[K, V]) validateGenesisNDJSON(r io.Reader) error {
+	return c.m.validateGenesisNDJSON(r)
+}
+
+func (c collectionImpl[K, V]) importGenesisNDJSON(ctx context.Context, r io.Reader) error {
+	return c.m.importGenesisNDJSON(ctx, r)
+}
+
+func (c collectionImpl[K, V]) exportGenesisNDJSON(ctx context.Context, w io.Writer) error {
+	return c.m.exportGenesisNDJSON(ctx, w)
+}
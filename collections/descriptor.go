@@ -0,0 +1,74 @@
+package collections
+
+import "encoding/hex"
+
+// SchemaDescriptorVersion is the version of the format of the SchemaDescriptor,
+// which is increased on every breaking change of the format.
+const SchemaDescriptorVersion = 1
+
+// SchemaDescriptor is a machine-readable description of the storage layout of a
+// Schema, meant for off-chain clients decoding the state of a module.
+type SchemaDescriptor struct {
+	// Version is the version of the format of the descriptor.
+	Version uint32 `json:"version"`
+	// Collections are the collections of the schema, in ascending name order.
+	Collections []CollectionDescriptor `json:"collections"`
+}
+
+// CollectionDescriptor describes a collection of a schema.
+type CollectionDescriptor struct {
+	Name string `json:"name"`
+	// Prefix is the prefix of the keys of the collection, hex encoded.
+	Prefix string `json:"prefix"`
+	// Key describes the encoding of the keys of the collection, following
+	// their prefix.
+	Key KeyDescriptor `json:"key"`
+	// ValueType is the type of the values of the collection, as reported by
+	// their codec.
+	ValueType string `json:"value_type"`
+}
+
+// KeyDescriptor describes the encoding of a key.
+type KeyDescriptor struct {
+	// Type is the type of the key, as reported by its codec.
+	Type string `json:"type"`
+	// Parts describe the parts of a multipart key, e.g. a Pair or a Triple, in
+	// order, which are encoded with their non terminal encoding except for the
+	// last one.
+	Parts []KeyDescriptor `json:"parts,omitempty"`
+}
+
+// keyDescriber is implemented by the key codecs of multipart keys, in order to
+// describe their parts.
+type keyDescriber interface {
+	describeKey() KeyDescriptor
+}
+
+// describeKeyCodec returns the KeyDescriptor of the given key codec.
+func describeKeyCodec(kc interface{ KeyType() string }) KeyDescriptor {
+	if d, ok := kc.(keyDescriber); ok {
+		return d.describeKey()
+	}
+	return KeyDescriptor{Type: kc.KeyType()}
+}
+
+// Descriptor returns the SchemaDescriptor of the schema.
+func (s Schema) Descriptor() SchemaDescriptor {
+	d := SchemaDescriptor{
+		Version:     SchemaDescriptorVersion,
+		Collections: make([]CollectionDescriptor, 0, len(s.collectionsOrdered)),
+	}
+	for _, name := range s.collectionsOrdered {
+		d.Collections = append(d.Collections, s.collectionsByName[name].describe())
+	}
+	return d
+}
+
+func (c collectionImpl[K, V]) describe() CollectionDescriptor {
+	return CollectionDescriptor{
+		Name:      c.m.name,
+		Prefix:    hex.EncodeToString(c.m.prefix),
+		Key:       describeKeyCodec(c.m.kc),
+		ValueType: c.m.vc.ValueType(),
+	}
+}
@@ -0,0 +1,54 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Descriptor(t *testing.T) {
+	sk, _ := deps()
+	sb := NewSchemaBuilder(sk)
+	NewMap(sb, NewPrefix(1), "balances", PairKeyCodec(StringKey, PairKeyCodec(StringKey, Uint64Key)), Uint64Value)
+	NewKeySet(sb, NewPrefix(2), "redelegations", TripleKeyCodec(StringKey, StringKey, BytesKey))
+	NewItem(sb, NewPrefix("params"), "params", StringValue)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	d := schema.Descriptor()
+	bz, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"version": 1,
+		"collections": [
+			{
+				"name": "balances",
+				"prefix": "01",
+				"key": {
+					"type": "Pair[string, Pair[string, uint64]]",
+					"parts": [
+						{"type": "string"},
+						{"type": "Pair[string, uint64]", "parts": [{"type": "string"}, {"type": "uint64"}]}
+					]
+				},
+				"value_type": "uint64"
+			},
+			{
+				"name": "params",
+				"prefix": "706172616d73",
+				"key": {"type": "no_key"},
+				"value_type": "string"
+			},
+			{
+				"name": "redelegations",
+				"prefix": "02",
+				"key": {
+					"type": "Triple[string,string,bytes]",
+					"parts": [{"type": "string"}, {"type": "string"}, {"type": "bytes"}]
+				},
+				"value_type": "no_value"
+			}
+		]
+	}`, string(bz))
+}
@@ -1,10 +1,17 @@
 package collections
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+
+	"cosmossdk.io/core/appmodule"
 )
 
 type genesisHandler interface {
@@ -12,6 +19,10 @@ type genesisHandler interface {
 	importGenesis(ctx context.Context, r io.Reader) error
 	exportGenesis(ctx context.Context, w io.Writer) error
 	defaultGenesis(w io.Writer) error
+
+	validateGenesisNDJSON(r io.Reader) error
+	importGenesisNDJSON(ctx context.Context, r io.Reader) error
+	exportGenesisNDJSON(ctx context.Context, w io.Writer) error
 }
 
 type jsonMapEntry struct {
@@ -151,3 +162,128 @@ func (m Map[K, V]) defaultGenesis(writer io.Writer) error {
 	_, err := io.WriteString(writer, `[]`)
 	return err
 }
+
+// NDJSON genesis
+
+func (m Map[K, V]) validateGenesisNDJSON(reader io.Reader) error {
+	return m.doDecodeNDJSON(reader, func(key K, value V) error {
+		return nil
+	})
+}
+
+func (m Map[K, V]) importGenesisNDJSON(ctx context.Context, reader io.Reader) error {
+	return m.doDecodeNDJSON(reader, func(key K, value V) error {
+		return m.Set(ctx, key, value)
+	})
+}
+
+func (m Map[K, V]) exportGenesisNDJSON(ctx context.Context, writer io.Writer) error {
+	w := bufio.NewWriter(writer)
+
+	it, err := m.Iterate(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		kv, err := it.KeyValue()
+		if err != nil {
+			return err
+		}
+
+		keyBz, err := m.kc.EncodeJSON(kv.Key)
+		if err != nil {
+			return err
+		}
+
+		valueBz, err := m.vc.EncodeJSON(kv.Value)
+		if err != nil {
+			return err
+		}
+
+		bz, err := json.Marshal(jsonMapEntry{Key: keyBz, Value: valueBz})
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(append(bz, '\n'))
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// doDecodeNDJSON decodes the entries of the reader, one JSON object per line,
+// holding at most one line in memory. Empty lines are skipped. Errors carry the
+// line and the index of the entry they occurred at, both starting from 1.
+func (m Map[K, V]) doDecodeNDJSON(reader io.Reader, onEntry func(key K, value V) error) error {
+	r := bufio.NewReader(reader)
+	line, entry := 0, 0
+	for {
+		bz, readErr := r.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return readErr
+		}
+		line++
+
+		bz = bytes.TrimSpace(bz)
+		if len(bz) > 0 {
+			entry++
+			if err := m.decodeNDJSONEntry(bz, onEntry); err != nil {
+				return fmt.Errorf("line %d, entry %d: %w", line, entry, err)
+			}
+		}
+
+		if readErr != nil {
+			return nil
+		}
+	}
+}
+
+func (m Map[K, V]) decodeNDJSONEntry(bz []byte, onEntry func(key K, value V) error) error {
+	var mapEntry jsonMapEntry
+	err := json.Unmarshal(bz, &mapEntry)
+	if err != nil {
+		return err
+	}
+
+	key, err := m.kc.DecodeJSON(mapEntry.Key)
+	if err != nil {
+		return err
+	}
+
+	value, err := m.vc.DecodeJSON(mapEntry.Value)
+	if err != nil {
+		return err
+	}
+
+	return onEntry(key, value)
+}
+
+// NDJSONFileExtension is the extension of the files of NDJSONDirSource and
+// NDJSONDirTarget.
+const NDJSONFileExtension = ".ndjson"
+
+// NDJSONDirSource returns a GenesisSource which reads every collection from its
+// own file in the given directory, named after the collection with the
+// NDJSONFileExtension. It is safe for concurrent use.
+func NDJSONDirSource(dir string) appmodule.GenesisSource {
+	return func(field string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, field+NDJSONFileExtension))
+	}
+}
+
+// NDJSONDirTarget returns a GenesisTarget which writes every collection to its
+// own file in the given directory, named after the collection with the
+// NDJSONFileExtension. The directory is created if it does not exist.
+func NDJSONDirTarget(dir string) appmodule.GenesisTarget {
+	return func(field string) (io.WriteCloser, error) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		return os.Create(filepath.Join(dir, field+NDJSONFileExtension))
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -162,3 +164,56 @@ func newBufCloser(t *testing.T, str string) *bufCloser {
 	})
 	return b
 }
+
+func TestGenesisNDJSON(t *testing.T) {
+	f := initFixture(t)
+	require.NoError(t, f.schema.InitGenesis(f.ctx, createTestGenesisSource(t)))
+
+	dir := t.TempDir()
+	require.NoError(t, f.schema.ExportGenesisNDJSON(f.ctx, NDJSONDirTarget(dir)))
+
+	bz, err := os.ReadFile(filepath.Join(dir, "map"+NDJSONFileExtension))
+	require.NoError(t, err)
+	require.Equal(t, "{\"key\":\"abc\",\"value\":\"1\"}\n{\"key\":\"def\",\"value\":\"2\"}\n", string(bz))
+	bz, err = os.ReadFile(filepath.Join(dir, "key_set"+NDJSONFileExtension))
+	require.NoError(t, err)
+	require.Equal(t, "{\"key\":\"0\"}\n{\"key\":\"1\"}\n{\"key\":\"2\"}\n", string(bz))
+
+	// import into a new store
+	f2 := initFixture(t)
+	require.NoError(t, f2.schema.ValidateGenesisNDJSON(NDJSONDirSource(dir)))
+	require.NoError(t, f2.schema.InitGenesisNDJSON(f2.ctx, NDJSONDirSource(dir)))
+
+	for _, name := range []string{"item", "key_set", "map", "sequence"} {
+		var exported, reexported bytes.Buffer
+		require.NoError(t, f.schema.exportGenesisNDJSON(f.ctx, func(string) (io.WriteCloser, error) { return nopWriteCloser{&exported}, nil }, name))
+		require.NoError(t, f2.schema.exportGenesisNDJSON(f2.ctx, func(string) (io.WriteCloser, error) { return nopWriteCloser{&reexported}, nil }, name))
+		require.Equal(t, exported.String(), reexported.String(), name)
+	}
+}
+
+func TestGenesisNDJSON_Errors(t *testing.T) {
+	f := initFixture(t)
+	source := func(field string) (io.ReadCloser, error) {
+		switch field {
+		case "map":
+			// the blank line is not an entry
+			return io.NopCloser(bytes.NewBufferString("{\"key\":\"abc\",\"value\":\"1\"}\n\n{\"key\":\"def\",\"value\":\"x\"}\n")), nil
+		case "item":
+			return io.NopCloser(bytes.NewBufferString("{\"key\":\"item\"")), nil
+		default:
+			return io.NopCloser(bytes.NewBufferString("")), nil
+		}
+	}
+
+	err := f.schema.ValidateGenesisNDJSON(source)
+	require.ErrorContains(t, err, "failed genesis validation of item: line 1, entry 1:")
+	require.ErrorContains(t, err, "failed genesis validation of map: line 3, entry 2:")
+
+	err = f.schema.InitGenesisNDJSON(f.ctx, source)
+	require.ErrorContains(t, err, "failed genesis initialisation of item: line 1, entry 1:")
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
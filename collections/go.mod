@@ -6,6 +6,7 @@ require (
 	cosmossdk.io/core v0.11.0
 	github.com/cosmos/cosmos-db v1.0.2
 	github.com/stretchr/testify v1.9.0
+	google.golang.org/protobuf v1.33.0
 	pgregory.net/rapid v1.1.0
 )
 
@@ -47,6 +48,5 @@ require (
 	golang.org/x/text v0.14.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240401170217-c3f982113cda // indirect
 	google.golang.org/grpc v1.63.2 // indirect
-	google.golang.org/protobuf v1.33.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
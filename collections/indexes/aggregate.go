@@ -0,0 +1,307 @@
+package indexes
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/codec"
+)
+
+// Count is an index which maintains the number of values referencing every
+// reference key, e.g. the number of NFTs of every owner, so it is obtained
+// without iterating over them.
+type Count[ReferenceKey, PrimaryKey, Value any] struct {
+	getRefKey func(pk PrimaryKey, value Value) (ReferenceKey, error)
+	counts    collections.Map[ReferenceKey, uint64]
+}
+
+// NewCount instantiates a new Count index given a schema, a Prefix, the
+// humanized name for the index and the reference key key codec. The
+// getRefKeyFunc is a function that given the primary key and value returns the
+// referencing key.
+func NewCount[ReferenceKey, PrimaryKey, Value any](
+	schema *collections.SchemaBuilder,
+	prefix collections.Prefix,
+	name string,
+	refCodec codec.KeyCodec[ReferenceKey],
+	getRefKeyFunc func(pk PrimaryKey, value Value) (ReferenceKey, error),
+) *Count[ReferenceKey, PrimaryKey, Value] {
+	return &Count[ReferenceKey, PrimaryKey, Value]{
+		getRefKey: getRefKeyFunc,
+		counts:    collections.NewMap(schema, prefix, name, refCodec, collections.Uint64Value),
+	}
+}
+
+func (c *Count[ReferenceKey, PrimaryKey, Value]) Reference(ctx context.Context, pk PrimaryKey, newValue Value, lazyOldValue func() (Value, error)) error {
+	oldValue, err := lazyOldValue()
+	switch {
+	// if no error it means the value existed, and we need to remove the old count
+	case err == nil:
+		err = c.unreference(ctx, pk, oldValue)
+		if err != nil {
+			return err
+		}
+	// if error is ErrNotFound, it means that the object does not exist, so we're counting it for the first time.
+	// we do nothing.
+	case errors.Is(err, collections.ErrNotFound):
+	// default case means that there was some other error
+	default:
+		return err
+	}
+	refKey, err := c.getRefKey(pk, newValue)
+	if err != nil {
+		return err
+	}
+	count, err := c.Count(ctx, refKey)
+	if err != nil {
+		return err
+	}
+	return c.counts.Set(ctx, refKey, count+1)
+}
+
+func (c *Count[ReferenceKey, PrimaryKey, Value]) Unreference(ctx context.Context, pk PrimaryKey, getValue func() (Value, error)) error {
+	value, err := getValue()
+	if err != nil {
+		return err
+	}
+	return c.unreference(ctx, pk, value)
+}
+
+func (c *Count[ReferenceKey, PrimaryKey, Value]) unreference(ctx context.Context, pk PrimaryKey, value Value) error {
+	refKey, err := c.getRefKey(pk, value)
+	if err != nil {
+		return err
+	}
+	count, err := c.counts.Get(ctx, refKey)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return c.counts.Remove(ctx, refKey)
+	}
+	return c.counts.Set(ctx, refKey, count-1)
+}
+
+// Count returns the number of values referencing the provided reference key.
+func (c *Count[ReferenceKey, PrimaryKey, Value]) Count(ctx context.Context, refKey ReferenceKey) (uint64, error) {
+	count, err := c.counts.Get(ctx, refKey)
+	if errors.Is(err, collections.ErrNotFound) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// Walk walks over the reference keys in the provided range, and their count.
+func (c *Count[ReferenceKey, PrimaryKey, Value]) Walk(
+	ctx context.Context,
+	ranger collections.Ranger[ReferenceKey],
+	walkFunc func(refKey ReferenceKey, count uint64) (stop bool, err error),
+) error {
+	return c.counts.Walk(ctx, ranger, walkFunc)
+}
+
+// AggregateValue is the aggregation of the values referencing a reference key
+// of an Aggregate index.
+type AggregateValue[Sum any] struct {
+	// Count is the number of values referencing the reference key.
+	Count uint64
+	// Sum is the sum of the amounts of the values referencing the reference key.
+	Sum Sum
+}
+
+// Aggregate is an index which maintains the number of values referencing every
+// reference key, and the sum of an amount of those values, e.g. the number of
+// delegations to every validator and their total amount, so they are obtained
+// without iterating over them.
+type Aggregate[ReferenceKey, PrimaryKey, Value, Sum any] struct {
+	getRefKey  func(pk PrimaryKey, value Value) (ReferenceKey, Sum, error)
+	add        func(a, b Sum) (Sum, error)
+	sub        func(a, b Sum) (Sum, error)
+	aggregates collections.Map[ReferenceKey, AggregateValue[Sum]]
+}
+
+// NewAggregate instantiates a new Aggregate index given a schema, a Prefix, the
+// humanized name for the index, the reference key key codec and the sum value
+// codec. The getRefKeyAndAmountFunc is a function that given the primary key
+// and value returns the referencing key and the amount of the value, which are
+// added to and subtracted from the sums with the add and sub functions.
+func NewAggregate[ReferenceKey, PrimaryKey, Value, Sum any](
+	schema *collections.SchemaBuilder,
+	prefix collections.Prefix,
+	name string,
+	refCodec codec.KeyCodec[ReferenceKey],
+	sumCodec codec.ValueCodec[Sum],
+	getRefKeyAndAmountFunc func(pk PrimaryKey, value Value) (ReferenceKey, Sum, error),
+	add, sub func(a, b Sum) (Sum, error),
+) *Aggregate[ReferenceKey, PrimaryKey, Value, Sum] {
+	return &Aggregate[ReferenceKey, PrimaryKey, Value, Sum]{
+		getRefKey:  getRefKeyAndAmountFunc,
+		add:        add,
+		sub:        sub,
+		aggregates: collections.NewMap(schema, prefix, name, refCodec, aggregateValueCodec[Sum]{sumCodec: sumCodec}),
+	}
+}
+
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) Reference(ctx context.Context, pk PrimaryKey, newValue Value, lazyOldValue func() (Value, error)) error {
+	oldValue, err := lazyOldValue()
+	switch {
+	// if no error it means the value existed, and we need to remove it from the aggregate
+	case err == nil:
+		err = a.unreference(ctx, pk, oldValue)
+		if err != nil {
+			return err
+		}
+	// if error is ErrNotFound, it means that the object does not exist, so we're aggregating it for the first time.
+	// we do nothing.
+	case errors.Is(err, collections.ErrNotFound):
+	// default case means that there was some other error
+	default:
+		return err
+	}
+	refKey, amount, err := a.getRefKey(pk, newValue)
+	if err != nil {
+		return err
+	}
+	aggregate, err := a.aggregates.Get(ctx, refKey)
+	switch {
+	case err == nil:
+		aggregate.Sum, err = a.add(aggregate.Sum, amount)
+		if err != nil {
+			return err
+		}
+	case errors.Is(err, collections.ErrNotFound):
+		aggregate.Sum = amount
+	default:
+		return err
+	}
+	aggregate.Count++
+	return a.aggregates.Set(ctx, refKey, aggregate)
+}
+
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) Unreference(ctx context.Context, pk PrimaryKey, getValue func() (Value, error)) error {
+	value, err := getValue()
+	if err != nil {
+		return err
+	}
+	return a.unreference(ctx, pk, value)
+}
+
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) unreference(ctx context.Context, pk PrimaryKey, value Value) error {
+	refKey, amount, err := a.getRefKey(pk, value)
+	if err != nil {
+		return err
+	}
+	aggregate, err := a.aggregates.Get(ctx, refKey)
+	if err != nil {
+		return err
+	}
+	// the last value is removed with its sum, so it does not accumulate rounding
+	// or conversion errors of the amounts
+	if aggregate.Count <= 1 {
+		return a.aggregates.Remove(ctx, refKey)
+	}
+	aggregate.Sum, err = a.sub(aggregate.Sum, amount)
+	if err != nil {
+		return err
+	}
+	aggregate.Count--
+	return a.aggregates.Set(ctx, refKey, aggregate)
+}
+
+// Get returns the aggregation of the values referencing the provided reference
+// key. Fails with collections.ErrNotFound if no value references it.
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) Get(ctx context.Context, refKey ReferenceKey) (AggregateValue[Sum], error) {
+	return a.aggregates.Get(ctx, refKey)
+}
+
+// Count returns the number of values referencing the provided reference key.
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) Count(ctx context.Context, refKey ReferenceKey) (uint64, error) {
+	aggregate, err := a.aggregates.Get(ctx, refKey)
+	if errors.Is(err, collections.ErrNotFound) {
+		return 0, nil
+	}
+	return aggregate.Count, err
+}
+
+// Sum returns the sum of the amounts of the values referencing the provided
+// reference key. Fails with collections.ErrNotFound if no value references it.
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) Sum(ctx context.Context, refKey ReferenceKey) (Sum, error) {
+	aggregate, err := a.aggregates.Get(ctx, refKey)
+	return aggregate.Sum, err
+}
+
+// Walk walks over the reference keys in the provided range, and their aggregation.
+func (a *Aggregate[ReferenceKey, PrimaryKey, Value, Sum]) Walk(
+	ctx context.Context,
+	ranger collections.Ranger[ReferenceKey],
+	walkFunc func(refKey ReferenceKey, aggregate AggregateValue[Sum]) (stop bool, err error),
+) error {
+	return a.aggregates.Walk(ctx, ranger, walkFunc)
+}
+
+// aggregateValueCodec encodes an AggregateValue as its count, big endian,
+// followed by its sum.
+type aggregateValueCodec[Sum any] struct {
+	sumCodec codec.ValueCodec[Sum]
+}
+
+type jsonAggregateValue struct {
+	Count string          `json:"count"`
+	Sum   json.RawMessage `json:"sum"`
+}
+
+func (c aggregateValueCodec[Sum]) Encode(value AggregateValue[Sum]) ([]byte, error) {
+	sum, err := c.sumCodec.Encode(value.Sum)
+	if err != nil {
+		return nil, err
+	}
+	return append(binary.BigEndian.AppendUint64(make([]byte, 0, 8+len(sum)), value.Count), sum...), nil
+}
+
+func (c aggregateValueCodec[Sum]) Decode(b []byte) (AggregateValue[Sum], error) {
+	if len(b) < 8 {
+		return AggregateValue[Sum]{}, fmt.Errorf("%w: invalid aggregate size, wanted at least: 8", collections.ErrEncoding)
+	}
+	sum, err := c.sumCodec.Decode(b[8:])
+	if err != nil {
+		return AggregateValue[Sum]{}, err
+	}
+	return AggregateValue[Sum]{Count: binary.BigEndian.Uint64(b), Sum: sum}, nil
+}
+
+func (c aggregateValueCodec[Sum]) EncodeJSON(value AggregateValue[Sum]) ([]byte, error) {
+	sum, err := c.sumCodec.EncodeJSON(value.Sum)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonAggregateValue{Count: strconv.FormatUint(value.Count, 10), Sum: sum})
+}
+
+func (c aggregateValueCodec[Sum]) DecodeJSON(b []byte) (AggregateValue[Sum], error) {
+	var v jsonAggregateValue
+	if err := json.Unmarshal(b, &v); err != nil {
+		return AggregateValue[Sum]{}, err
+	}
+	count, err := strconv.ParseUint(v.Count, 10, 64)
+	if err != nil {
+		return AggregateValue[Sum]{}, err
+	}
+	sum, err := c.sumCodec.DecodeJSON(v.Sum)
+	if err != nil {
+		return AggregateValue[Sum]{}, err
+	}
+	return AggregateValue[Sum]{Count: count, Sum: sum}, nil
+}
+
+func (c aggregateValueCodec[Sum]) Stringify(value AggregateValue[Sum]) string {
+	return fmt.Sprintf("AggregateValue{Count: %d, Sum: %s}", value.Count, c.sumCodec.Stringify(value.Sum))
+}
+
+func (c aggregateValueCodec[Sum]) ValueType() string {
+	return fmt.Sprintf("AggregateValue[%s]", c.sumCodec.ValueType())
+}
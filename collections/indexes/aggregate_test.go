@@ -0,0 +1,142 @@
+package indexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/colltest"
+)
+
+type nft struct {
+	Owner string
+}
+
+type nftIndexes struct {
+	Owner *Count[string, uint64, nft]
+}
+
+func TestCountIndex(t *testing.T) {
+	sk, ctx := deps()
+	schema := collections.NewSchemaBuilder(sk)
+
+	im := collections.NewIndexedMap(schema, collections.NewPrefix(0), "nfts", collections.Uint64Key, colltest.MockValueCodec[nft](), nftIndexes{
+		Owner: NewCount(schema, collections.NewPrefix(1), "nfts_by_owner", collections.StringKey, func(_ uint64, v nft) (string, error) {
+			return v.Owner, nil
+		}),
+	})
+
+	require.NoError(t, im.Set(ctx, 1, nft{Owner: "alice"}))
+	require.NoError(t, im.Set(ctx, 2, nft{Owner: "alice"}))
+	require.NoError(t, im.Set(ctx, 3, nft{Owner: "bob"}))
+
+	count, err := im.Indexes.Owner.Count(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	// overwriting a value with the same reference key does not change the count
+	require.NoError(t, im.Set(ctx, 1, nft{Owner: "alice"}))
+	count, err = im.Indexes.Owner.Count(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	// transfer
+	require.NoError(t, im.Set(ctx, 2, nft{Owner: "bob"}))
+	count, err = im.Indexes.Owner.Count(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	count, err = im.Indexes.Owner.Count(ctx, "bob")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	// removing the last value removes the count
+	require.NoError(t, im.Remove(ctx, 1))
+	count, err = im.Indexes.Owner.Count(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+
+	counts := map[string]uint64{}
+	require.NoError(t, im.Indexes.Owner.Walk(ctx, nil, func(owner string, count uint64) (bool, error) {
+		counts[owner] = count
+		return false, nil
+	}))
+	require.Equal(t, map[string]uint64{"bob": 2}, counts)
+}
+
+type delegation struct {
+	Validator string
+	Amount    uint64
+}
+
+type delegationIndexes struct {
+	Validator *Aggregate[string, collections.Pair[string, string], delegation, uint64]
+}
+
+func addUint64(a, b uint64) (uint64, error) { return a + b, nil }
+
+func subUint64(a, b uint64) (uint64, error) {
+	if b > a {
+		return 0, collections.ErrConflict
+	}
+	return a - b, nil
+}
+
+func TestAggregateIndex(t *testing.T) {
+	sk, ctx := deps()
+	schema := collections.NewSchemaBuilder(sk)
+
+	im := collections.NewIndexedMap(schema, collections.NewPrefix(0), "delegations",
+		collections.PairKeyCodec(collections.StringKey, collections.StringKey), colltest.MockValueCodec[delegation](),
+		delegationIndexes{
+			Validator: NewAggregate(schema, collections.NewPrefix(1), "delegations_by_validator", collections.StringKey, collections.Uint64Value,
+				func(_ collections.Pair[string, string], v delegation) (string, uint64, error) {
+					return v.Validator, v.Amount, nil
+				}, addUint64, subUint64),
+		},
+	)
+	_, err := schema.Build()
+	require.NoError(t, err)
+
+	require.NoError(t, im.Set(ctx, collections.Join("alice", "val1"), delegation{Validator: "val1", Amount: 100}))
+	require.NoError(t, im.Set(ctx, collections.Join("bob", "val1"), delegation{Validator: "val1", Amount: 50}))
+	require.NoError(t, im.Set(ctx, collections.Join("bob", "val2"), delegation{Validator: "val2", Amount: 10}))
+
+	agg, err := im.Indexes.Validator.Get(ctx, "val1")
+	require.NoError(t, err)
+	require.Equal(t, AggregateValue[uint64]{Count: 2, Sum: 150}, agg)
+
+	// update an amount
+	require.NoError(t, im.Set(ctx, collections.Join("alice", "val1"), delegation{Validator: "val1", Amount: 30}))
+	sum, err := im.Indexes.Validator.Sum(ctx, "val1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(80), sum)
+	count, err := im.Indexes.Validator.Count(ctx, "val1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	// remove
+	require.NoError(t, im.Remove(ctx, collections.Join("bob", "val2")))
+	_, err = im.Indexes.Validator.Sum(ctx, "val2")
+	require.ErrorIs(t, err, collections.ErrNotFound)
+	count, err = im.Indexes.Validator.Count(ctx, "val2")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+
+	// errors of the sum functions are returned
+	require.ErrorIs(t, im.Indexes.Validator.Unreference(ctx, collections.Join("alice", "val1"), func() (delegation, error) {
+		return delegation{Validator: "val1", Amount: 1000}, nil
+	}), collections.ErrConflict)
+}
+
+func TestAggregateValueCodec(t *testing.T) {
+	vc := aggregateValueCodec[uint64]{sumCodec: collections.Uint64Value}
+	colltest.TestValueCodec(t, vc, AggregateValue[uint64]{Count: 3, Sum: 1000})
+
+	bz, err := vc.EncodeJSON(AggregateValue[uint64]{Count: 3, Sum: 1000})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"count":"3","sum":"1000"}`, string(bz))
+
+	_, err = vc.Decode([]byte{0x1})
+	require.ErrorIs(t, err, collections.ErrEncoding)
+}
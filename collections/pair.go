@@ -131,6 +131,13 @@ func (p pairKeyCodec[K1, K2]) Stringify(key Pair[K1, K2]) string {
 	return b.String()
 }
 
+func (p pairKeyCodec[K1, K2]) describeKey() KeyDescriptor {
+	return KeyDescriptor{
+		Type:  p.KeyType(),
+		Parts: []KeyDescriptor{describeKeyCodec(p.keyCodec1), describeKeyCodec(p.keyCodec2)},
+	}
+}
+
 func (p pairKeyCodec[K1, K2]) KeyType() string {
 	return fmt.Sprintf("Pair[%s, %s]", p.keyCodec1.KeyType(), p.keyCodec2.KeyType())
 }
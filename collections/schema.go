@@ -2,10 +2,13 @@ package collections
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/store"
@@ -284,6 +287,111 @@ func (s Schema) exportGenesis(ctx context.Context, target appmodule.GenesisTarge
 	return coll.exportGenesis(ctx, wc)
 }
 
+// ValidateGenesisNDJSON validates the genesis read from the source in the NDJSON
+// format, where every collection is a stream of entries, one JSON object per
+// line, see ExportGenesisNDJSON. The collections are validated in parallel, so
+// the source must be safe for concurrent use, e.g. a file per collection. The
+// errors of all the collections are returned, and carry the line and the entry
+// they occurred at.
+func (s Schema) ValidateGenesisNDJSON(source appmodule.GenesisSource) error {
+	errs := make([]error, len(s.collectionsOrdered))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, name := range s.collectionsOrdered {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer func() { <-sem; wg.Done() }()
+			err := s.validateGenesisNDJSON(source, name)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed genesis validation of %s: %w", name, err)
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (s Schema) validateGenesisNDJSON(source appmodule.GenesisSource, name string) error {
+	rc, err := source(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	coll, err := s.getCollection(name)
+	if err != nil {
+		return err
+	}
+
+	return coll.validateGenesisNDJSON(rc)
+}
+
+// InitGenesisNDJSON initializes the collections from the genesis read from the
+// source in the NDJSON format, see ExportGenesisNDJSON. The entries are imported
+// as they are read, so the memory used does not grow with the size of the
+// genesis.
+func (s Schema) InitGenesisNDJSON(ctx context.Context, source appmodule.GenesisSource) error {
+	for _, name := range s.collectionsOrdered {
+		err := s.initGenesisNDJSON(ctx, source, name)
+		if err != nil {
+			return fmt.Errorf("failed genesis initialisation of %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Schema) initGenesisNDJSON(ctx context.Context, source appmodule.GenesisSource, name string) error {
+	rc, err := source(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	coll, err := s.getCollection(name)
+	if err != nil {
+		return err
+	}
+
+	return coll.importGenesisNDJSON(ctx, rc)
+}
+
+// ExportGenesisNDJSON exports the collections to the target in the NDJSON
+// format: every collection is written as a stream of entries, one JSON object
+// per line, with the same key and value fields as the entries of the JSON
+// format. An empty collection is an empty stream.
+func (s Schema) ExportGenesisNDJSON(ctx context.Context, target appmodule.GenesisTarget) error {
+	for _, name := range s.collectionsOrdered {
+		err := s.exportGenesisNDJSON(ctx, target, name)
+		if err != nil {
+			return fmt.Errorf("failed to export genesis for %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Schema) exportGenesisNDJSON(ctx context.Context, target appmodule.GenesisTarget, name string) error {
+	coll, err := s.getCollection(name)
+	if err != nil {
+		return err
+	}
+
+	wc, err := target(name)
+	if err != nil {
+		return err
+	}
+
+	// the error of Close is returned, as it may be the one of a file
+	err = coll.exportGenesisNDJSON(ctx, wc)
+	if closeErr := wc.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 func (s Schema) getCollection(name string) (Collection, error) {
 	coll, ok := s.collectionsByName[name]
 	if !ok {
@@ -0,0 +1,145 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cosmossdk.io/collections/codec"
+)
+
+const (
+	TimeQueueEntriesNameSuffix   = "_entries"
+	TimeQueueKeysNameSuffix      = "_keys"
+	TimeQueueEntriesPrefixSuffix = 0x0
+	TimeQueueKeysPrefixSuffix    = 0x1
+)
+
+// NewTimeQueue creates a new TimeQueue instance. Since TimeQueue relies on two collections,
+// one for the entries and the other for the times of the keys, it will register two state
+// objects on the schema builder.
+// The first is the entries which is a map, whose prefix is the provided prefix with a suffix
+// which equals to TimeQueueEntriesPrefixSuffix, the name is also suffixed with
+// TimeQueueEntriesNameSuffix.
+// The second is the keys which is a map, whose prefix is the provided prefix with a suffix
+// which equals to TimeQueueKeysPrefixSuffix, the name is also suffixed with
+// TimeQueueKeysNameSuffix.
+func NewTimeQueue[K, V any](
+	sb *SchemaBuilder,
+	prefix Prefix,
+	name string,
+	keyCodec codec.KeyCodec[K],
+	valueCodec codec.ValueCodec[V],
+) TimeQueue[K, V] {
+	return TimeQueue[K, V]{
+		entries: NewMap(sb, append(prefix, TimeQueueEntriesPrefixSuffix), name+TimeQueueEntriesNameSuffix, PairKeyCodec(TimeKey, keyCodec), valueCodec),
+		keys:    NewMap(sb, append(prefix, TimeQueueKeysPrefixSuffix), name+TimeQueueKeysNameSuffix, keyCodec, TimeValue),
+	}
+}
+
+// TimeQueue is a queue of values ordered by time, in which every key is enqueued
+// at most once, e.g. the expirations of grants or proposals. It relies on two
+// collections, one for the entries which is a Map[Pair[time.Time, K], V], the
+// other for the time every key is enqueued at which is a Map[K, time.Time].
+// Entries enqueued at the same time are dequeued in ascending key order.
+type TimeQueue[K, V any] struct {
+	entries Map[Pair[time.Time, K], V]
+	keys    Map[K, time.Time]
+}
+
+// TimeQueueEntry is an entry of a TimeQueue.
+type TimeQueueEntry[K, V any] struct {
+	Time  time.Time
+	Key   K
+	Value V
+}
+
+// Enqueue enqueues the value of the given key at the given time. If the key is
+// already enqueued, its previous entry is replaced.
+func (q TimeQueue[K, V]) Enqueue(ctx context.Context, t time.Time, key K, value V) error {
+	prev, err := q.keys.Get(ctx, key)
+	switch {
+	case err == nil:
+		err = q.entries.Remove(ctx, Join(prev, key))
+		if err != nil {
+			return err
+		}
+	case !errors.Is(err, ErrNotFound):
+		return err
+	}
+
+	err = q.entries.Set(ctx, Join(t, key), value)
+	if err != nil {
+		return err
+	}
+	return q.keys.Set(ctx, key, t)
+}
+
+// Get returns the time the given key is enqueued at and its value. Fails with
+// ErrNotFound if the key is not enqueued.
+func (q TimeQueue[K, V]) Get(ctx context.Context, key K) (t time.Time, value V, err error) {
+	t, err = q.keys.Get(ctx, key)
+	if err != nil {
+		return t, value, err
+	}
+	value, err = q.entries.Get(ctx, Join(t, key))
+	return t, value, err
+}
+
+// Has reports whether the given key is enqueued.
+func (q TimeQueue[K, V]) Has(ctx context.Context, key K) (bool, error) {
+	return q.keys.Has(ctx, key)
+}
+
+// Remove removes the entry of the given key from the queue. It is a no-op if the
+// key is not enqueued.
+func (q TimeQueue[K, V]) Remove(ctx context.Context, key K) error {
+	t, err := q.keys.Get(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = q.entries.Remove(ctx, Join(t, key))
+	if err != nil {
+		return err
+	}
+	return q.keys.Remove(ctx, key)
+}
+
+// DequeueUntil removes from the queue and returns the entries enqueued at or
+// before the given time, in ascending time order.
+func (q TimeQueue[K, V]) DequeueUntil(ctx context.Context, t time.Time) ([]TimeQueueEntry[K, V], error) {
+	var entries []TimeQueueEntry[K, V]
+	err := q.Walk(ctx, t, func(t time.Time, key K, value V) (stop bool, err error) {
+		entries = append(entries, TimeQueueEntry[K, V]{Time: t, Key: key, Value: value})
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the entries are removed once the iterator is closed
+	for _, e := range entries {
+		err = q.entries.Remove(ctx, Join(e.Time, e.Key))
+		if err != nil {
+			return nil, err
+		}
+		err = q.keys.Remove(ctx, e.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// Walk walks over the entries enqueued at or before the given time, in ascending
+// time order, without removing them. The walk stops when walkFunc returns true
+// or an error.
+func (q TimeQueue[K, V]) Walk(ctx context.Context, until time.Time, walkFunc func(t time.Time, key K, value V) (stop bool, err error)) error {
+	return q.entries.Walk(ctx, NewPrefixUntilPairRange[time.Time, K](until), func(key Pair[time.Time, K], value V) (bool, error) {
+		return walkFunc(key.K1(), key.K2(), value)
+	})
+}
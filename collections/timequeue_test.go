@@ -0,0 +1,125 @@
+package collections_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/colltest"
+)
+
+type nopCloser struct{ *bytes.Buffer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestTimeQueue(t *testing.T) {
+	sk, ctx := colltest.MockStore()
+	schemaBuilder := collections.NewSchemaBuilder(sk)
+	q := collections.NewTimeQueue(schemaBuilder, collections.NewPrefix(0), "queue", collections.StringKey, collections.Uint64Value)
+	_, err := schemaBuilder.Build()
+	require.NoError(t, err)
+
+	t0 := time.Unix(1000, 0).UTC()
+	t1 := t0.Add(time.Second)
+	t2 := t0.Add(2 * time.Second)
+
+	require.NoError(t, q.Enqueue(ctx, t1, "b", 2))
+	require.NoError(t, q.Enqueue(ctx, t0, "a", 1))
+	require.NoError(t, q.Enqueue(ctx, t1, "c", 3))
+	require.NoError(t, q.Enqueue(ctx, t2, "d", 4))
+
+	// get
+	ti, v, err := q.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, t1, ti)
+	require.Equal(t, uint64(2), v)
+
+	_, _, err = q.Get(ctx, "z")
+	require.ErrorIs(t, err, collections.ErrNotFound)
+
+	// enqueuing an enqueued key replaces its entry
+	require.NoError(t, q.Enqueue(ctx, t2, "a", 10))
+	ti, v, err = q.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, t2, ti)
+	require.Equal(t, uint64(10), v)
+
+	// remove
+	require.NoError(t, q.Remove(ctx, "c"))
+	has, err := q.Has(ctx, "c")
+	require.NoError(t, err)
+	require.False(t, has)
+	require.NoError(t, q.Remove(ctx, "c"))
+
+	// nothing is due before t1
+	entries, err := q.DequeueUntil(ctx, t0)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// dequeue is inclusive of the given time
+	entries, err = q.DequeueUntil(ctx, t1)
+	require.NoError(t, err)
+	require.Equal(t, []collections.TimeQueueEntry[string, uint64]{{Time: t1, Key: "b", Value: 2}}, entries)
+	has, err = q.Has(ctx, "b")
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// entries due at the same time are dequeued in key order
+	entries, err = q.DequeueUntil(ctx, t2.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []collections.TimeQueueEntry[string, uint64]{
+		{Time: t2, Key: "a", Value: 10},
+		{Time: t2, Key: "d", Value: 4},
+	}, entries)
+
+	entries, err = q.DequeueUntil(ctx, t2.Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestTimeQueue_Genesis(t *testing.T) {
+	sk, ctx := colltest.MockStore()
+	schemaBuilder := collections.NewSchemaBuilder(sk)
+	q := collections.NewTimeQueue(schemaBuilder, collections.NewPrefix(0), "queue", collections.StringKey, collections.Uint64Value)
+	schema, err := schemaBuilder.Build()
+	require.NoError(t, err)
+
+	t0 := time.Unix(1000, 0).UTC()
+	require.NoError(t, q.Enqueue(ctx, t0, "a", 1))
+	require.NoError(t, q.Enqueue(ctx, t0.Add(time.Second), "b", 2))
+
+	genesis := map[string]*bytes.Buffer{}
+	require.NoError(t, schema.ExportGenesis(ctx, func(field string) (io.WriteCloser, error) {
+		genesis[field] = new(bytes.Buffer)
+		return nopCloser{genesis[field]}, nil
+	}))
+	require.Equal(t,
+		`[{"key":["1970-01-01T00:16:40Z","a"],"value":"1"},{"key":["1970-01-01T00:16:41Z","b"],"value":"2"}]`,
+		genesis["queue_entries"].String(),
+	)
+	require.Equal(t,
+		`[{"key":"a","value":"1970-01-01T00:16:40Z"},{"key":"b","value":"1970-01-01T00:16:41Z"}]`,
+		genesis["queue_keys"].String(),
+	)
+
+	// import into a new store
+	ctx = sk.NewStoreContext()
+	source := func(field string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(genesis[field].Bytes())), nil
+	}
+	require.NoError(t, schema.ValidateGenesis(source))
+	require.NoError(t, schema.InitGenesis(ctx, source))
+
+	ti, v, err := q.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, t0.Add(time.Second), ti)
+	require.Equal(t, uint64(2), v)
+
+	entries, err := q.DequeueUntil(ctx, t0)
+	require.NoError(t, err)
+	require.Equal(t, []collections.TimeQueueEntry[string, uint64]{{Time: t0, Key: "a", Value: 1}}, entries)
+}
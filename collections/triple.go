@@ -149,6 +149,13 @@ func (t tripleKeyCodec[K1, K2, K3]) Stringify(key Triple[K1, K2, K3]) string {
 	return b.String()
 }
 
+func (t tripleKeyCodec[K1, K2, K3]) describeKey() KeyDescriptor {
+	return KeyDescriptor{
+		Type:  t.KeyType(),
+		Parts: []KeyDescriptor{describeKeyCodec(t.keyCodec1), describeKeyCodec(t.keyCodec2), describeKeyCodec(t.keyCodec3)},
+	}
+}
+
 func (t tripleKeyCodec[K1, K2, K3]) KeyType() string {
 	return fmt.Sprintf("Triple[%s,%s,%s]", t.keyCodec1.KeyType(), t.keyCodec2.KeyType(), t.keyCodec3.KeyType())
 }
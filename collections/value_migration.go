@@ -0,0 +1,136 @@
+package collections
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/collections/codec"
+)
+
+// the position of a ValueMigration is either done, or in progress followed by
+// the last key visited
+const (
+	valueMigrationInProgress byte = iota
+	valueMigrationDone
+)
+
+// ValueMigration re-encodes, in the background, the values of a Map encoded
+// with a codec.VersionedValueCodec which are not of the latest version, e.g. a
+// bounded number of entries every block, until all of them are. The position of
+// the migration is kept in its own Item, so it progresses deterministically
+// across nodes and restarts.
+type ValueMigration[K, V any] struct {
+	m   Map[K, V]
+	vc  codec.VersionedValueCodec[V]
+	pos Item[[]byte]
+}
+
+// NewValueMigration returns a ValueMigration of the given Map, whose position is
+// kept under the given prefix and name. It panics if the values of the Map are
+// not encoded with a codec.VersionedValueCodec.
+func NewValueMigration[K, V any](schemaBuilder *SchemaBuilder, prefix Prefix, name string, m Map[K, V]) ValueMigration[K, V] {
+	vc, ok := m.vc.(codec.VersionedValueCodec[V])
+	if !ok {
+		panic(fmt.Errorf("values of map %s are not encoded with a VersionedValueCodec", m.name))
+	}
+
+	return ValueMigration[K, V]{
+		m:   m,
+		vc:  vc,
+		pos: NewItem(schemaBuilder, prefix, name, BytesValue),
+	}
+}
+
+// Done reports whether all the values of the Map were migrated.
+func (vm ValueMigration[K, V]) Done(ctx context.Context) (bool, error) {
+	pos, err := vm.pos.Get(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return len(pos) > 0 && pos[0] == valueMigrationDone, nil
+}
+
+// Step visits at most limit entries of the Map from where the previous step
+// stopped, and re-encodes the ones which are not of the latest version. It
+// returns the number of entries re-encoded, and whether all the values of the
+// Map are migrated. Entries written after the migration passed them are
+// already encoded with the latest version.
+func (vm ValueMigration[K, V]) Step(ctx context.Context, limit int) (migrated int, done bool, err error) {
+	start := vm.m.prefix
+	pos, err := vm.pos.Get(ctx)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return 0, false, err
+	case len(pos) == 0:
+		return 0, false, fmt.Errorf("%w: invalid position of value migration", ErrEncoding)
+	case pos[0] == valueMigrationDone:
+		return 0, true, nil
+	default:
+		start = nextBytesKey(pos[1:])
+	}
+
+	type entry struct{ key, value []byte }
+	var (
+		outdated []entry
+		visited  int
+		last     []byte
+	)
+
+	kvStore := vm.m.sa(ctx)
+	iter, err := kvStore.Iterator(start, nextBytesPrefixKey(vm.m.prefix))
+	if err != nil {
+		return 0, false, err
+	}
+	for ; iter.Valid() && visited < limit; iter.Next() {
+		visited++
+		last = bytes.Clone(iter.Key())
+
+		version, err := vm.vc.Version(iter.Value())
+		if err != nil {
+			_ = iter.Close()
+			return 0, false, err
+		}
+		if version != vm.vc.LatestVersion() {
+			outdated = append(outdated, entry{key: last, value: bytes.Clone(iter.Value())})
+		}
+	}
+	done = !iter.Valid()
+	if err := iter.Close(); err != nil {
+		return 0, false, err
+	}
+
+	// the entries are written once the iterator is closed
+	for _, e := range outdated {
+		value, err := vm.vc.Decode(e.value)
+		if err != nil {
+			return 0, false, fmt.Errorf("%w: value decode of key %X: %w", ErrEncoding, e.key, err)
+		}
+		bz, err := vm.vc.Encode(value)
+		if err != nil {
+			return 0, false, fmt.Errorf("%w: value encode: %w", ErrEncoding, err)
+		}
+		if err := kvStore.Set(e.key, bz); err != nil {
+			return 0, false, err
+		}
+	}
+
+	var posErr error
+	switch {
+	case done:
+		posErr = vm.pos.Set(ctx, []byte{valueMigrationDone})
+	case last != nil:
+		posErr = vm.pos.Set(ctx, append([]byte{valueMigrationInProgress}, last...))
+	}
+	if posErr != nil {
+		return 0, false, posErr
+	}
+
+	return len(outdated), done, nil
+}
@@ -0,0 +1,116 @@
+package collections
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections/codec"
+)
+
+func TestValueMigration(t *testing.T) {
+	sk, ctx := deps()
+
+	// the entries are first written as decimal strings
+	legacySchema := NewSchemaBuilder(sk)
+	legacy := NewMap(legacySchema, NewPrefix(0), "m", Uint64Key, codec.ValueCodec[uint64](decimalValue{}))
+	_, err := legacySchema.Build()
+	require.NoError(t, err)
+	for i := uint64(0); i < 10; i++ {
+		require.NoError(t, legacy.Set(ctx, i, i*10))
+	}
+
+	vc := codec.NewVersionedValueCodec(Uint64Value, codec.NewValueUpgrader(decimalValue{}, func(v uint64) (uint64, error) {
+		return v, nil
+	})).WithLegacyValues()
+	schemaBuilder := NewSchemaBuilder(sk)
+	m := NewMap(schemaBuilder, NewPrefix(0), "m", Uint64Key, codec.ValueCodec[uint64](vc))
+	migration := NewValueMigration(schemaBuilder, NewPrefix(1), "m_migration", m)
+	_, err = schemaBuilder.Build()
+	require.NoError(t, err)
+
+	// entries are upgraded when read, and re-encoded when written
+	v, err := m.Get(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), v)
+	require.NoError(t, m.Set(ctx, 4, 41))
+
+	version := func(key uint64) uint32 {
+		bz, err := sk.OpenKVStore(ctx).Get(append([]byte{0}, encodeKey(t, key)...))
+		require.NoError(t, err)
+		v, err := vc.Version(bz)
+		require.NoError(t, err)
+		return v
+	}
+	require.Equal(t, uint32(0), version(3))
+	require.Equal(t, uint32(1), version(4))
+
+	// the remaining entries are migrated in steps
+	done, err := migration.Done(ctx)
+	require.NoError(t, err)
+	require.False(t, done)
+
+	migrated, done, err := migration.Step(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 4, migrated)
+	require.False(t, done)
+
+	migrated, done, err = migration.Step(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, migrated) // key 4 was already written
+	require.False(t, done)
+
+	migrated, done, err = migration.Step(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, migrated)
+	require.True(t, done)
+
+	done, err = migration.Done(ctx)
+	require.NoError(t, err)
+	require.True(t, done)
+	migrated, done, err = migration.Step(ctx, 4)
+	require.NoError(t, err)
+	require.Zero(t, migrated)
+	require.True(t, done)
+
+	for i := uint64(0); i < 10; i++ {
+		require.Equal(t, uint32(1), version(i))
+
+		v, err := m.Get(ctx, i)
+		require.NoError(t, err)
+		if i == 4 {
+			require.Equal(t, uint64(41), v)
+		} else {
+			require.Equal(t, i*10, v)
+		}
+	}
+
+	require.Panics(t, func() {
+		NewValueMigration(NewSchemaBuilder(sk), NewPrefix(2), "legacy_migration", legacy)
+	})
+}
+
+// decimalValue encodes uint64 values as decimal strings, which never start with
+// the prefix of versioned values.
+type decimalValue struct{}
+
+func (decimalValue) Encode(v uint64) ([]byte, error) { return strconv.AppendUint(nil, v, 10), nil }
+
+func (decimalValue) Decode(b []byte) (uint64, error) { return strconv.ParseUint(string(b), 10, 64) }
+
+func (d decimalValue) EncodeJSON(v uint64) ([]byte, error) { return d.Encode(v) }
+
+func (d decimalValue) DecodeJSON(b []byte) (uint64, error) { return d.Decode(b) }
+
+func (decimalValue) Stringify(v uint64) string { return strconv.FormatUint(v, 10) }
+
+func (decimalValue) ValueType() string { return "decimal" }
+
+func encodeKey(t *testing.T, key uint64) []byte {
+	t.Helper()
+	bz := make([]byte, Uint64Key.Size(key))
+	_, err := Uint64Key.Encode(bz, key)
+	require.NoError(t, err)
+	return bz
+}
@@ -0,0 +1,261 @@
+package ormkv
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"cosmossdk.io/orm/encoding/encodeutil"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+// AggregateCodec is the codec for the counts, and the sums of integer fields,
+// of the entries of an index matching its prefix keys.
+//
+// Keys are the prefix followed by the varint encoded number of prefix fields
+// and the prefix field segments. Values are the varint encoded count followed
+// by the varint encoded sums, zig-zag encoded for signed fields.
+type AggregateCodec struct {
+	prefix      []byte
+	messageType protoreflect.MessageType
+	fieldNames  []protoreflect.Name
+	keyCodecs   []*KeyCodec
+	sumFields   []protoreflect.FieldDescriptor
+	sumNames    []protoreflect.Name
+}
+
+var _ EntryCodec = &AggregateCodec{}
+
+// NewAggregateCodec creates a new AggregateCodec with an optional prefix for
+// the provided message descriptor, index and sum fields.
+func NewAggregateCodec(prefix []byte, messageType protoreflect.MessageType, indexFields, sumFields []protoreflect.Name) (*AggregateCodec, error) {
+	if len(indexFields) == 0 {
+		return nil, ormerrors.InvalidTableDefinition.Wrapf("index fields are empty")
+	}
+
+	// prefix keys of every length have their own codec, so that the last
+	// field of a prefix key is encoded as a terminal segment and keys of
+	// different lengths never collide
+	keyCodecs := make([]*KeyCodec, len(indexFields)+1)
+	for i := range keyCodecs {
+		cdc, err := NewKeyCodec(encodeutil.AppendVarUInt32(prefix, uint32(i)), messageType, indexFields[:i])
+		if err != nil {
+			return nil, err
+		}
+		keyCodecs[i] = cdc
+	}
+
+	messageFields := messageType.Descriptor().Fields()
+	sumFieldDescriptors := make([]protoreflect.FieldDescriptor, len(sumFields))
+	for i, name := range sumFields {
+		field := messageFields.ByName(name)
+		if field == nil {
+			return nil, ormerrors.FieldNotFound.Wrapf("field %s on %s", name, messageType.Descriptor().FullName())
+		}
+		if field.IsList() || field.IsMap() || !(isSignedKind(field.Kind()) || isUnsignedKind(field.Kind())) {
+			return nil, ormerrors.InvalidSumField.Wrapf("field %s of type %s on %s", name, field.Kind(), messageType.Descriptor().FullName())
+		}
+		sumFieldDescriptors[i] = field
+	}
+
+	return &AggregateCodec{
+		prefix:      prefix,
+		messageType: messageType,
+		fieldNames:  indexFields,
+		keyCodecs:   keyCodecs,
+		sumFields:   sumFieldDescriptors,
+		sumNames:    sumFields,
+	}, nil
+}
+
+func isSignedKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnsignedKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// EncodeKey encodes the key of the aggregate of the provided prefix key, which
+// can be shorter than the number of index fields.
+func (cdc *AggregateCodec) EncodeKey(prefixKey []protoreflect.Value) ([]byte, error) {
+	n := len(prefixKey)
+	if n >= len(cdc.keyCodecs) {
+		return nil, ormerrors.IndexOutOfBounds.Wrapf("cannot encode %d values into %d fields", n, len(cdc.fieldNames))
+	}
+
+	return cdc.keyCodecs[n].EncodeKey(prefixKey)
+}
+
+// DecodeKey decodes the prefix key of the aggregate stored under the provided key.
+func (cdc *AggregateCodec) DecodeKey(k []byte) ([]protoreflect.Value, error) {
+	r := bytes.NewReader(k)
+	if err := encodeutil.SkipPrefix(r, cdc.prefix); err != nil {
+		return nil, err
+	}
+
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if n >= uint64(len(cdc.keyCodecs)) {
+		return nil, ormerrors.UnexpectedDecodePrefix.Wrapf("%d prefix fields for an index with %d fields", n, len(cdc.fieldNames))
+	}
+
+	return cdc.keyCodecs[n].DecodeKey(bytes.NewReader(k))
+}
+
+// GetKeyValues extracts the values of the index fields from the message.
+func (cdc *AggregateCodec) GetKeyValues(message protoreflect.Message) []protoreflect.Value {
+	return cdc.keyCodecs[len(cdc.fieldNames)].GetKeyValues(message)
+}
+
+// GetSumValues extracts the values of the sum fields from the message, as
+// int64 values for signed fields and uint64 values for unsigned fields.
+func (cdc *AggregateCodec) GetSumValues(message protoreflect.Message) []protoreflect.Value {
+	res := make([]protoreflect.Value, len(cdc.sumFields))
+	for i, f := range cdc.sumFields {
+		if isSignedKind(f.Kind()) {
+			res[i] = protoreflect.ValueOfInt64(message.Get(f).Int())
+		} else {
+			res[i] = protoreflect.ValueOfUint64(message.Get(f).Uint())
+		}
+	}
+	return res
+}
+
+// ZeroSums returns the sums of an aggregate without entries.
+func (cdc *AggregateCodec) ZeroSums() []protoreflect.Value {
+	res := make([]protoreflect.Value, len(cdc.sumFields))
+	for i, f := range cdc.sumFields {
+		if isSignedKind(f.Kind()) {
+			res[i] = protoreflect.ValueOfInt64(0)
+		} else {
+			res[i] = protoreflect.ValueOfUint64(0)
+		}
+	}
+	return res
+}
+
+// EncodeValue encodes the count and sums of an aggregate.
+func (cdc *AggregateCodec) EncodeValue(count uint64, sums []protoreflect.Value) ([]byte, error) {
+	if len(sums) != len(cdc.sumFields) {
+		return nil, ormerrors.IndexOutOfBounds.Wrapf("cannot encode %d sums into %d fields", len(sums), len(cdc.sumFields))
+	}
+
+	bz := binary.AppendUvarint(nil, count)
+	for i, f := range cdc.sumFields {
+		if isSignedKind(f.Kind()) {
+			bz = binary.AppendVarint(bz, sums[i].Int())
+		} else {
+			bz = binary.AppendUvarint(bz, sums[i].Uint())
+		}
+	}
+	return bz, nil
+}
+
+// DecodeValue decodes the count and sums of an aggregate. An empty value
+// decodes as an aggregate without entries.
+func (cdc *AggregateCodec) DecodeValue(v []byte) (count uint64, sums []protoreflect.Value, err error) {
+	if len(v) == 0 {
+		return 0, cdc.ZeroSums(), nil
+	}
+
+	r := bytes.NewReader(v)
+	count, err = binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sums = make([]protoreflect.Value, len(cdc.sumFields))
+	for i, f := range cdc.sumFields {
+		if isSignedKind(f.Kind()) {
+			x, err := binary.ReadVarint(r)
+			if err != nil {
+				return 0, nil, err
+			}
+			sums[i] = protoreflect.ValueOfInt64(x)
+		} else {
+			x, err := binary.ReadUvarint(r)
+			if err != nil {
+				return 0, nil, err
+			}
+			sums[i] = protoreflect.ValueOfUint64(x)
+		}
+	}
+	return count, sums, nil
+}
+
+func (cdc *AggregateCodec) DecodeEntry(k, v []byte) (Entry, error) {
+	prefixKey, err := cdc.DecodeKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	count, sums, err := cdc.DecodeValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AggregateEntry{
+		TableName: cdc.messageType.Descriptor().FullName(),
+		Fields:    cdc.fieldNames,
+		PrefixKey: prefixKey,
+		Count:     count,
+		SumFields: cdc.sumNames,
+		Sums:      sums,
+	}, nil
+}
+
+func (cdc *AggregateCodec) EncodeEntry(entry Entry) (k, v []byte, err error) {
+	aggregateEntry, ok := entry.(*AggregateEntry)
+	if !ok {
+		return nil, nil, ormerrors.BadDecodeEntry
+	}
+
+	if aggregateEntry.TableName != cdc.messageType.Descriptor().FullName() {
+		return nil, nil, ormerrors.BadDecodeEntry
+	}
+
+	k, err = cdc.EncodeKey(aggregateEntry.PrefixKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err = cdc.EncodeValue(aggregateEntry.Count, aggregateEntry.Sums)
+	return k, v, err
+}
+
+// GetFieldNames returns the index fields of the prefix keys.
+func (cdc *AggregateCodec) GetFieldNames() []protoreflect.Name {
+	return cdc.fieldNames
+}
+
+// GetSumFieldNames returns the fields whose sums are maintained.
+func (cdc *AggregateCodec) GetSumFieldNames() []protoreflect.Name {
+	return cdc.sumNames
+}
+
+// Prefix returns the prefix of the aggregate keys.
+func (cdc *AggregateCodec) Prefix() []byte {
+	return cdc.prefix
+}
+
+// MessageType returns the message type of the aggregated index.
+func (cdc *AggregateCodec) MessageType() protoreflect.MessageType {
+	return cdc.messageType
+}
@@ -0,0 +1,83 @@
+package ormkv_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gotest.tools/v3/assert"
+	"pgregory.net/rapid"
+
+	"cosmossdk.io/orm/encoding/ormkv"
+	"cosmossdk.io/orm/internal/testpb"
+	"cosmossdk.io/orm/internal/testutil"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+func TestAggregateCodec(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		idxCdc := testutil.TestKeyCodecGen(1, 5).Draw(t, "idxCdc")
+		prefix := rapid.SliceOfN(rapid.Byte(), 0, 5).Draw(t, "prefix")
+		messageType := (&testpb.ExampleTable{}).ProtoReflect().Type()
+		sumFields := []protoreflect.Name{"i32", "u64", "sf64"}
+		cdc, err := ormkv.NewAggregateCodec(prefix, messageType, idxCdc.Codec.GetFieldNames(), sumFields)
+		assert.NilError(t, err)
+
+		count, sums, err := cdc.DecodeValue(nil)
+		assert.NilError(t, err)
+		assert.Equal(t, uint64(0), count)
+		assert.DeepEqual(t, interfaces(cdc.ZeroSums()), interfaces(sums))
+
+		for i := 0; i < 100; i++ {
+			a := testutil.GenA.Draw(t, fmt.Sprintf("a%d", i))
+			keyValues := cdc.GetKeyValues(a.ProtoReflect())
+			n := rapid.IntRange(0, len(keyValues)).Draw(t, fmt.Sprintf("n%d", i))
+			entry := &ormkv.AggregateEntry{
+				TableName: messageType.Descriptor().FullName(),
+				Fields:    cdc.GetFieldNames(),
+				PrefixKey: keyValues[:n],
+				Count:     rapid.Uint64().Draw(t, fmt.Sprintf("count%d", i)),
+				SumFields: sumFields,
+				Sums:      cdc.GetSumValues(a.ProtoReflect()),
+			}
+			k, v, err := cdc.EncodeEntry(entry)
+			assert.NilError(t, err)
+			assert.Assert(t, bytes.HasPrefix(k, cdc.Prefix()))
+
+			entry2, err := cdc.DecodeEntry(k, v)
+			assert.NilError(t, err)
+			agg2 := entry2.(*ormkv.AggregateEntry)
+			assert.Equal(t, n, len(agg2.PrefixKey))
+			assert.Equal(t, 0, idxCdc.Codec.CompareKeys(entry.PrefixKey, agg2.PrefixKey))
+			assert.Equal(t, entry.Count, agg2.Count)
+			assert.DeepEqual(t, interfaces(entry.Sums), interfaces(agg2.Sums))
+
+			// prefix keys of different lengths never share a key
+			for j := 0; j < n; j++ {
+				k2, err := cdc.EncodeKey(keyValues[:j])
+				assert.NilError(t, err)
+				assert.Assert(t, !bytes.Equal(k, k2))
+			}
+		}
+	})
+}
+
+func TestAggregateCodecInvalidSumField(t *testing.T) {
+	messageType := (&testpb.ExampleTable{}).ProtoReflect().Type()
+	for _, field := range []protoreflect.Name{"str", "bz", "repeated", "map", "b", "e"} {
+		_, err := ormkv.NewAggregateCodec(nil, messageType, []protoreflect.Name{"u32"}, []protoreflect.Name{field})
+		assert.ErrorIs(t, err, ormerrors.InvalidSumField, "field %s", field)
+	}
+
+	_, err := ormkv.NewAggregateCodec(nil, messageType, []protoreflect.Name{"u32"}, []protoreflect.Name{"foo"})
+	assert.ErrorIs(t, err, ormerrors.FieldNotFound)
+}
+
+func interfaces(values []protoreflect.Value) []interface{} {
+	res := make([]interface{}, len(values))
+	for i, v := range values {
+		res[i] = v.Interface()
+	}
+	return res
+}
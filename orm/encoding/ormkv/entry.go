@@ -7,6 +7,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
 	"cosmossdk.io/orm/internal/stablejson"
 )
 
@@ -132,4 +133,70 @@ func (s *SeqEntry) String() string {
 	return fmt.Sprintf("SEQ %s %d", s.TableName, s.Value)
 }
 
-var _, _, _ Entry = &PrimaryKeyEntry{}, &IndexKeyEntry{}, &SeqEntry{}
+// AggregateEntry represents the count, and the sums of integer fields, of the
+// entries of an index matching a prefix key.
+type AggregateEntry struct {
+	// TableName is the table this entry represents.
+	TableName protoreflect.FullName
+
+	// Fields are the fields of the aggregated index.
+	Fields []protoreflect.Name
+
+	// PrefixKey represents the values of the prefix key, which can be shorter
+	// than the index fields.
+	PrefixKey []protoreflect.Value
+
+	// Count is the number of entries matching the prefix key.
+	Count uint64
+
+	// SumFields are the fields whose sums are maintained.
+	SumFields []protoreflect.Name
+
+	// Sums are the sums of the sum fields of the entries matching the prefix key.
+	Sums []protoreflect.Value
+}
+
+func (a *AggregateEntry) GetTableName() protoreflect.FullName {
+	return a.TableName
+}
+
+func (a *AggregateEntry) doNotImplement() {}
+
+func (a *AggregateEntry) String() string {
+	str := fmt.Sprintf("AGG %s %s : %s -> %d", a.TableName, fmtFields(a.Fields), fmtValues(a.PrefixKey), a.Count)
+	for i, field := range a.SumFields {
+		if i < len(a.Sums) {
+			str += fmt.Sprintf(" %s=%v", field, a.Sums[i].Interface())
+		}
+	}
+	return str
+}
+
+// SchemaEntry represents the schema of a table stored by its last migration.
+type SchemaEntry struct {
+	// TableName is the table this entry represents.
+	TableName protoreflect.FullName
+
+	// Version is incremented by every migration which changes the schema.
+	Version uint64
+
+	// TableDescriptor is the descriptor of the table at this version.
+	TableDescriptor *ormv1.TableDescriptor
+}
+
+func (s *SchemaEntry) GetTableName() protoreflect.FullName {
+	return s.TableName
+}
+
+func (s *SchemaEntry) doNotImplement() {}
+
+func (s *SchemaEntry) String() string {
+	descBz, err := stablejson.Marshal(s.TableDescriptor)
+	descStr := string(descBz)
+	if err != nil {
+		descStr = fmt.Sprintf("ERR %v", err)
+	}
+	return fmt.Sprintf("SCHEMA %s %d %s", s.TableName, s.Version, descStr)
+}
+
+var _, _, _, _, _ Entry = &PrimaryKeyEntry{}, &IndexKeyEntry{}, &SeqEntry{}, &AggregateEntry{}, &SchemaEntry{}
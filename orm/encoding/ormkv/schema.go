@@ -0,0 +1,92 @@
+package ormkv
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+// SchemaCodec is the codec for the table schemas stored by migrations.
+//
+// Values are the varint encoded schema version followed by the protobuf
+// encoded table descriptor.
+type SchemaCodec struct {
+	messageType protoreflect.FullName
+	prefix      []byte
+}
+
+// NewSchemaCodec creates a new SchemaCodec.
+func NewSchemaCodec(messageType protoreflect.MessageType, prefix []byte) *SchemaCodec {
+	return &SchemaCodec{messageType: messageType.Descriptor().FullName(), prefix: prefix}
+}
+
+var _ EntryCodec = &SchemaCodec{}
+
+func (s SchemaCodec) DecodeEntry(k, v []byte) (Entry, error) {
+	if !bytes.Equal(k, s.prefix) {
+		return nil, ormerrors.UnexpectedDecodePrefix
+	}
+
+	version, desc, err := s.DecodeValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SchemaEntry{
+		TableName:       s.messageType,
+		Version:         version,
+		TableDescriptor: desc,
+	}, nil
+}
+
+func (s SchemaCodec) EncodeEntry(entry Entry) (k, v []byte, err error) {
+	schemaEntry, ok := entry.(*SchemaEntry)
+	if !ok {
+		return nil, nil, ormerrors.BadDecodeEntry
+	}
+
+	if schemaEntry.TableName != s.messageType {
+		return nil, nil, ormerrors.BadDecodeEntry
+	}
+
+	v, err = s.EncodeValue(schemaEntry.Version, schemaEntry.TableDescriptor)
+	return s.prefix, v, err
+}
+
+func (s SchemaCodec) Prefix() []byte {
+	return s.prefix
+}
+
+func (s SchemaCodec) EncodeValue(version uint64, desc *ormv1.TableDescriptor) ([]byte, error) {
+	bz, err := proto.MarshalOptions{Deterministic: true}.Marshal(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(binary.AppendUvarint(nil, version), bz...), nil
+}
+
+// DecodeValue decodes the version and the table descriptor of a schema. An
+// empty value decodes as version 0 and a nil descriptor.
+func (s SchemaCodec) DecodeValue(v []byte) (uint64, *ormv1.TableDescriptor, error) {
+	if len(v) == 0 {
+		return 0, nil, nil
+	}
+
+	version, n := binary.Uvarint(v)
+	if n <= 0 {
+		return 0, nil, ormerrors.UnexpectedDecodePrefix.Wrapf("invalid schema version")
+	}
+
+	desc := &ormv1.TableDescriptor{}
+	if err := proto.Unmarshal(v[n:], desc); err != nil {
+		return 0, nil, err
+	}
+
+	return version, desc, nil
+}
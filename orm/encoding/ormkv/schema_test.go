@@ -0,0 +1,50 @@
+package ormkv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+	"pgregory.net/rapid"
+
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
+	"cosmossdk.io/orm/encoding/ormkv"
+	"cosmossdk.io/orm/internal/testpb"
+)
+
+func TestSchemaCodec(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		prefix := rapid.SliceOfN(rapid.Byte(), 0, 5).Draw(t, "prefix")
+		typ := (&testpb.ExampleTable{}).ProtoReflect().Type()
+		tableName := typ.Descriptor().FullName()
+		cdc := ormkv.NewSchemaCodec(typ, prefix)
+
+		version, desc, err := cdc.DecodeValue(nil)
+		assert.NilError(t, err)
+		assert.Equal(t, uint64(0), version)
+		assert.Assert(t, desc == nil)
+
+		entry := &ormkv.SchemaEntry{
+			TableName: tableName,
+			Version:   rapid.Uint64().Draw(t, "version"),
+			TableDescriptor: &ormv1.TableDescriptor{
+				Id:         rapid.Uint32().Draw(t, "id"),
+				PrimaryKey: &ormv1.PrimaryKeyDescriptor{Fields: "u32,i64"},
+				Index: []*ormv1.SecondaryIndexDescriptor{
+					{Id: 1, Fields: "str", Unique: rapid.Bool().Draw(t, "unique"), Count: rapid.Bool().Draw(t, "count")},
+				},
+			},
+		}
+		k, v, err := cdc.EncodeEntry(entry)
+		assert.NilError(t, err)
+		assert.Assert(t, bytes.Equal(cdc.Prefix(), k))
+
+		entry2, err := cdc.DecodeEntry(k, v)
+		assert.NilError(t, err)
+		schemaEntry := entry2.(*ormkv.SchemaEntry)
+		assert.Equal(t, entry.Version, schemaEntry.Version)
+		assert.Assert(t, proto.Equal(entry.TableDescriptor, schemaEntry.TableDescriptor))
+		assert.Equal(t, entry.String(), schemaEntry.String())
+	})
+}
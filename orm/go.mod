@@ -22,6 +22,8 @@ require (
 )
 
 require (
+	buf.build/gen/go/cosmos/gogo-proto/protocolbuffers/go v1.32.0-20230509103710-5e5b9fdd0180.1 // indirect
+	buf.build/gen/go/tendermint/tendermint/protocolbuffers/go v1.32.0-20231117195010-33ed361a9051.1 // indirect
 	github.com/DataDog/zstd v1.5.5 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/bmatcuk/doublestar/v4 v4.6.1 // indirect
@@ -67,4 +69,7 @@ require (
 	sigs.k8s.io/yaml v1.4.0 // indirect
 )
 
-replace cosmossdk.io/depinject => ../depinject
+replace (
+	cosmossdk.io/api => ../api
+	cosmossdk.io/depinject => ../depinject
+)
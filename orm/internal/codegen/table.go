@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/iancoleman/strcase"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/dynamicpb"
@@ -21,6 +22,7 @@ type tableGen struct {
 	primaryKeyFields fieldnames.FieldNames
 	fields           map[protoreflect.Name]*protogen.Field
 	uniqueIndexes    []*ormv1.SecondaryIndexDescriptor
+	aggregateIndexes []*ormv1.SecondaryIndexDescriptor
 	ormTable         ormtable.Table
 }
 
@@ -33,12 +35,17 @@ func newTableGen(fileGen fileGen, msg *protogen.Message, table *ormv1.TableDescr
 		t.fields[field.Desc.Name()] = field
 	}
 	uniqIndexes := make([]*ormv1.SecondaryIndexDescriptor, 0)
+	aggIndexes := make([]*ormv1.SecondaryIndexDescriptor, 0)
 	for _, idx := range t.table.Index {
 		if idx.Unique {
 			uniqIndexes = append(uniqIndexes, idx)
 		}
+		if idx.Count || idx.Sum != "" {
+			aggIndexes = append(aggIndexes, idx)
+		}
 	}
 	t.uniqueIndexes = uniqIndexes
+	t.aggregateIndexes = aggIndexes
 	var err error
 	t.ormTable, err = ormtable.Build(ormtable.Options{
 		MessageType:     dynamicpb.NewMessageType(msg.Desc),
@@ -74,6 +81,9 @@ func (t tableGen) getTableInterface() {
 	for _, idx := range t.uniqueIndexes {
 		t.genUniqueIndexSig(idx)
 	}
+	for _, idx := range t.aggregateIndexes {
+		t.genAggregateIndexSig(idx)
+	}
 	t.P("List(ctx ", contextPkg.Ident("Context"), ", prefixKey ", t.indexKeyInterfaceName(), ", opts ...", ormListPkg.Ident("Option"), ") ", "(", t.iteratorName(), ", error)")
 	t.P("ListRange(ctx ", contextPkg.Ident("Context"), ", from, to ", t.indexKeyInterfaceName(), ", opts ...", ormListPkg.Ident("Option"), ") ", "(", t.iteratorName(), ", error)")
 	t.P("DeleteBy(ctx ", contextPkg.Ident("Context"), ", prefixKey ", t.indexKeyInterfaceName(), ") error")
@@ -105,6 +115,44 @@ func (t tableGen) genUniqueIndexSig(idx *ormv1.SecondaryIndexDescriptor) {
 	t.P(getSig)
 }
 
+// returns the count function signature, and the sum function signatures and
+// field names (in that order) of aggregate indexes.
+func (t tableGen) aggregateIndexSig(idx *ormv1.SecondaryIndexDescriptor) (countSig string, sumSigs []string, sumFields []string) {
+	camelFields := fieldsToCamelCase(idx.Fields)
+	keyName := t.indexStructName(strings.Split(idx.Fields, ","))
+
+	countSig = fmt.Sprintf("CountBy%s(ctx context.Context, prefixKey %s) (uint64, error)", camelFields, keyName)
+	if idx.Sum == "" {
+		return countSig, nil, nil
+	}
+
+	sumFields = strings.Split(idx.Sum, ",")
+	for _, field := range sumFields {
+		sumSigs = append(sumSigs, fmt.Sprintf("Sum%sBy%s(ctx context.Context, prefixKey %s) (%s, error)",
+			strcase.ToCamel(field), camelFields, keyName, t.sumType(field)))
+	}
+	return countSig, sumSigs, sumFields
+}
+
+// sumType returns the Go type of the sums of a field, which are int64 for signed
+// integers and uint64 for unsigned integers.
+func (t tableGen) sumType(field string) string {
+	switch t.fields[protoreflect.Name(field)].Desc.Kind() {
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	default:
+		return "int64"
+	}
+}
+
+func (t tableGen) genAggregateIndexSig(idx *ormv1.SecondaryIndexDescriptor) {
+	countSig, sumSigs, _ := t.aggregateIndexSig(idx)
+	t.P(countSig)
+	for _, sumSig := range sumSigs {
+		t.P(sumSig)
+	}
+}
+
 func (t tableGen) iteratorName() string {
 	return t.msg.GoIdent.GoName + "Iterator"
 }
@@ -249,6 +297,34 @@ func (t tableGen) genTableImpl() {
 		t.P()
 	}
 
+	for _, idx := range t.aggregateIndexes {
+		countSig, sumSigs, sumFields := t.aggregateIndexSig(idx)
+
+		// count
+		t.P(receiver, countSig, " {")
+		t.P("return ", receiverVar, ".table.GetIndexByID(", idx.Id, ").(",
+			ormTablePkg.Ident("AggregateIndex"), ").Count(ctx, prefixKey.values()...)")
+		t.P("}")
+		t.P()
+
+		// sums
+		for i, sumSig := range sumSigs {
+			t.P(receiver, sumSig, " {")
+			t.P("sum, err := ", receiverVar, ".table.GetIndexByID(", idx.Id, ").(",
+				ormTablePkg.Ident("AggregateIndex"), ").Sum(ctx, ", fmt.Sprintf("%q", sumFields[i]), ", prefixKey.values()...)")
+			t.P("if err != nil {")
+			t.P("return 0, err")
+			t.P("}")
+			if t.sumType(sumFields[i]) == "uint64" {
+				t.P("return sum.Uint(), nil")
+			} else {
+				t.P("return sum.Int(), nil")
+			}
+			t.P("}")
+			t.P()
+		}
+	}
+
 	// List
 	t.P(receiver, "List(ctx ", contextPkg.Ident("Context"), ", prefixKey ", t.indexKeyInterfaceName(), ", opts ...", ormListPkg.Ident("Option"), ") (", t.iteratorName(), ", error) {")
 	t.P("it, err := ", receiverVar, ".table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)")
@@ -17,6 +17,8 @@ type BalanceTable interface {
 	Has(ctx context.Context, address string, denom string) (found bool, err error)
 	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
 	Get(ctx context.Context, address string, denom string) (*Balance, error)
+	CountByDenom(ctx context.Context, prefixKey BalanceDenomIndexKey) (uint64, error)
+	SumAmountByDenom(ctx context.Context, prefixKey BalanceDenomIndexKey) (uint64, error)
 	List(ctx context.Context, prefixKey BalanceIndexKey, opts ...ormlist.Option) (BalanceIterator, error)
 	ListRange(ctx context.Context, from, to BalanceIndexKey, opts ...ormlist.Option) (BalanceIterator, error)
 	DeleteBy(ctx context.Context, prefixKey BalanceIndexKey) error
@@ -111,6 +113,18 @@ func (this balanceTable) Get(ctx context.Context, address string, denom string)
 	return &balance, nil
 }
 
+func (this balanceTable) CountByDenom(ctx context.Context, prefixKey BalanceDenomIndexKey) (uint64, error) {
+	return this.table.GetIndexByID(1).(ormtable.AggregateIndex).Count(ctx, prefixKey.values()...)
+}
+
+func (this balanceTable) SumAmountByDenom(ctx context.Context, prefixKey BalanceDenomIndexKey) (uint64, error) {
+	sum, err := this.table.GetIndexByID(1).(ormtable.AggregateIndex).Sum(ctx, "amount", prefixKey.values()...)
+	if err != nil {
+		return 0, err
+	}
+	return sum.Uint(), nil
+}
+
 func (this balanceTable) List(ctx context.Context, prefixKey BalanceIndexKey, opts ...ormlist.Option) (BalanceIterator, error) {
 	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
 	return BalanceIterator{it}, err
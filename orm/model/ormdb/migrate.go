@@ -0,0 +1,52 @@
+package ormdb
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/exp/maps"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"cosmossdk.io/core/gas"
+	"cosmossdk.io/errors"
+	"cosmossdk.io/orm/model/ormtable"
+)
+
+// MigrationGasPerEntry is the gas consumed by MigrateSchema for every entry
+// backfilled into, or deleted from, an index.
+const MigrationGasPerEntry gas.Gas = 100
+
+func (m moduleDB) MigrateSchema(ctx context.Context) (map[protoreflect.FullName]ormtable.MigrationResult, error) {
+	var options ormtable.MigrateOptions
+	if m.gasService != nil {
+		meter := m.gasService.GetGasMeter(ctx)
+		options.OnEntry = func() error {
+			meter.ConsumeGas(MigrationGasPerEntry, "orm schema migration")
+			return nil
+		}
+	}
+
+	// tables are migrated in the order of their ids so that gas consumption
+	// is deterministic
+	fileIDs := maps.Keys(m.filesByID)
+	sort.Slice(fileIDs, func(i, j int) bool { return fileIDs[i] < fileIDs[j] })
+
+	results := map[protoreflect.FullName]ormtable.MigrationResult{}
+	for _, fileID := range fileIDs {
+		file := m.filesByID[fileID]
+		tableIDs := maps.Keys(file.tablesByID)
+		sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+
+		for _, tableID := range tableIDs {
+			table := file.tablesByID[tableID]
+			name := table.MessageType().Descriptor().FullName()
+			res, err := ormtable.Migrate(ctx, table, options)
+			if err != nil {
+				return nil, errors.Wrapf(err, "migrating table %s", name)
+			}
+			results[name] = res
+		}
+	}
+
+	return results, nil
+}
@@ -14,6 +14,7 @@ import (
 
 	ormv1alpha1 "cosmossdk.io/api/cosmos/orm/v1alpha1"
 	"cosmossdk.io/core/appmodule"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/orm/encoding/encodeutil"
 	"cosmossdk.io/orm/encoding/ormkv"
@@ -41,6 +42,23 @@ type ModuleDB interface {
 	//  }
 	GenesisHandler() appmodule.HasGenesis // TODO should be appmodule.HasGenesisAuto with core v1
 
+	// MigrateSchema migrates every table from the schema stored by its
+	// previous migration to its current table descriptor, deleting the
+	// entries of the secondary indexes which were removed, backfilling those
+	// of the secondary indexes which were added, and storing the schema with
+	// an incremented version. See ormtable.Migrate for details. It returns the
+	// result of the migration of every table.
+	//
+	// MigrateSchema should be called from the upgrade handler which changes
+	// the indexes of the module's tables. If a GasService was provided,
+	// MigrationGasPerEntry is consumed for every index entry written or deleted.
+	// Ex:
+	//   app.UpgradeKeeper.SetUpgradeHandler("v2", func(ctx context.Context, _ upgradetypes.Plan, vm module.VersionMap) (module.VersionMap, error) {
+	//     _, err := keeper.db.MigrateSchema(ctx)
+	//     ...
+	//   })
+	MigrateSchema(ctx context.Context) (map[protoreflect.FullName]ormtable.MigrationResult, error)
+
 	private()
 }
 
@@ -48,6 +66,7 @@ type moduleDB struct {
 	prefix       []byte
 	filesByID    map[uint32]*fileDescriptorDB
 	tablesByName map[protoreflect.FullName]ormtable.Table
+	gasService   gas.Service
 }
 
 // ModuleDBOptions are options for constructing a ModuleDB.
@@ -75,6 +94,10 @@ type ModuleDBOptions struct {
 
 	// KVStoreService is the storage service to use for the DB if transient storage is used.
 	TransientStoreService store.TransientStoreService
+
+	// GasService is an optional gas service used to consume gas during
+	// schema migrations.
+	GasService gas.Service
 }
 
 // NewModuleDB constructs a ModuleDB instance from the provided schema and options.
@@ -84,6 +107,7 @@ func NewModuleDB(schema *ormv1alpha1.ModuleSchemaDescriptor, options ModuleDBOpt
 		prefix:       prefix,
 		filesByID:    map[uint32]*fileDescriptorDB{},
 		tablesByName: map[protoreflect.FullName]ormtable.Table{},
+		gasService:   options.GasService,
 	}
 
 	fileResolver := options.FileResolver
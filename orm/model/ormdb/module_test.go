@@ -10,12 +10,14 @@ import (
 
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/golden"
 
 	appv1alpha1 "cosmossdk.io/api/cosmos/app/v1alpha1"
 	ormmodulev1alpha1 "cosmossdk.io/api/cosmos/orm/module/v1alpha1"
 	ormv1alpha1 "cosmossdk.io/api/cosmos/orm/v1alpha1"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/core/genesis"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/depinject"
@@ -291,6 +293,15 @@ func runSimpleBankTests(t *testing.T, k Keeper, ctx context.Context) {
 	supply, err = k.Supply(ctx, denom)
 	assert.NilError(t, err)
 	assert.Equal(t, uint64(97), supply)
+
+	// balances are counted and summed by the denom index
+	balances := k.(keeper).store.BalanceTable()
+	count, err := balances.CountByDenom(ctx, testpb.BalanceDenomIndexKey{}.WithDenom(denom))
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), count)
+	total, err := balances.SumAmountByDenom(ctx, testpb.BalanceDenomIndexKey{}.WithDenom(denom))
+	assert.NilError(t, err)
+	assert.Equal(t, supply, total)
 }
 
 func TestHooks(t *testing.T) {
@@ -413,3 +424,68 @@ func TestAppConfigModule(t *testing.T) {
 
 	runSimpleBankTests(t, k, context.Background())
 }
+
+type testGasService struct {
+	gas.Service
+	meter *testGasMeter
+}
+
+func (s testGasService) GetGasMeter(context.Context) gas.Meter {
+	return s.meter
+}
+
+type testGasMeter struct {
+	gas.Meter
+	consumed gas.Gas
+}
+
+func (m *testGasMeter) ConsumeGas(amount gas.Gas, _ string) {
+	m.consumed += amount
+}
+
+func TestMigrateSchema(t *testing.T) {
+	meter := &testGasMeter{}
+	db, err := ormdb.NewModuleDB(TestBankSchema, ormdb.ModuleDBOptions{GasService: testGasService{meter: meter}})
+	assert.NilError(t, err)
+	backend := ormtest.NewMemoryBackend()
+	ctx := ormtable.WrapContextDefault(backend)
+
+	k, err := NewKeeper(db)
+	assert.NilError(t, err)
+	runSimpleBankTests(t, k, ctx)
+
+	// the first migration rebuilds the balance index and its aggregates
+	results, err := db.MigrateSchema(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[protoreflect.FullName]ormtable.MigrationResult{
+		"testpb.Balance": {Version: 1, PurgedIndexes: []uint32{1}, BackfilledIndexes: []uint32{1}, Entries: 6},
+		"testpb.Supply":  {Version: 1},
+	}, results)
+	assert.Equal(t, 6*ormdb.MigrationGasPerEntry, meter.consumed)
+
+	count, err := k.(keeper).store.BalanceTable().CountByDenom(ctx, testpb.BalanceDenomIndexKey{}.WithDenom("foo"))
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	// stored schemas are regular entries
+	it, err := backend.CommitmentStore().Iterator(nil, nil)
+	assert.NilError(t, err)
+	for ; it.Valid(); it.Next() {
+		entry, err := db.DecodeEntry(it.Key(), it.Value())
+		assert.NilError(t, err)
+		k, v, err := db.EncodeEntry(entry)
+		assert.NilError(t, err)
+		assert.Assert(t, bytes.Equal(k, it.Key()))
+		assert.Assert(t, bytes.Equal(v, it.Value()))
+	}
+	assert.NilError(t, it.Close())
+
+	// migrating an unchanged schema does nothing
+	results, err = db.MigrateSchema(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[protoreflect.FullName]ormtable.MigrationResult{
+		"testpb.Balance": {Version: 1},
+		"testpb.Supply":  {Version: 1},
+	}, results)
+	assert.Equal(t, 6*ormdb.MigrationGasPerEntry, meter.consumed)
+}
@@ -0,0 +1,248 @@
+package ormtable
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"cosmossdk.io/orm/encoding/encodeutil"
+	"cosmossdk.io/orm/encoding/ormkv"
+	"cosmossdk.io/orm/types/kv"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+// AggregateIndex defines an index which maintains the number of its entries,
+// and optionally the sums of integer fields, matching every prefix key. Indexes
+// are aggregated with the count and sum options of their descriptor.
+type AggregateIndex interface {
+	Index
+
+	// Count returns the number of entries matching the provided prefix key
+	// without iterating over them. Prefix key values must correspond in type to
+	// the index's fields and the number of values provided cannot exceed the
+	// number of fields in the index, although fewer values can be provided.
+	Count(ctx context.Context, prefixKey ...interface{}) (uint64, error)
+
+	// Sum returns the sum of the provided field over the entries matching the
+	// provided prefix key without iterating over them, as an int64 value for
+	// signed fields and an uint64 value for unsigned fields.
+	Sum(ctx context.Context, field string, prefixKey ...interface{}) (protoreflect.Value, error)
+}
+
+// aggregator is implemented by aggregated indexes.
+type aggregator interface {
+	aggregateCodec() *ormkv.AggregateCodec
+}
+
+// aggregateIndex wraps a concrete index to maintain its aggregates.
+type aggregateIndex struct {
+	concreteIndex
+	codec          *ormkv.AggregateCodec
+	getReadBackend func(context.Context) (ReadBackend, error)
+}
+
+// uniqueAggregateIndex wraps a unique index to maintain its aggregates.
+type uniqueAggregateIndex struct {
+	aggregateIndex
+	unique *uniqueKeyIndex
+}
+
+var (
+	_ indexer        = &aggregateIndex{}
+	_ AggregateIndex = &aggregateIndex{}
+	_ indexer        = &uniqueAggregateIndex{}
+	_ AggregateIndex = &uniqueAggregateIndex{}
+	_ UniqueIndex    = &uniqueAggregateIndex{}
+)
+
+func (u uniqueAggregateIndex) Has(ctx context.Context, keyValues ...interface{}) (found bool, err error) {
+	return u.unique.Has(ctx, keyValues...)
+}
+
+func (u uniqueAggregateIndex) Get(ctx context.Context, message proto.Message, keyValues ...interface{}) (found bool, err error) {
+	return u.unique.Get(ctx, message, keyValues...)
+}
+
+func (a aggregateIndex) aggregateCodec() *ormkv.AggregateCodec {
+	return a.codec
+}
+
+func (a aggregateIndex) Count(ctx context.Context, prefixKey ...interface{}) (uint64, error) {
+	count, _, err := a.get(ctx, prefixKey)
+	return count, err
+}
+
+func (a aggregateIndex) Sum(ctx context.Context, field string, prefixKey ...interface{}) (protoreflect.Value, error) {
+	for i, name := range a.codec.GetSumFieldNames() {
+		if string(name) != field {
+			continue
+		}
+
+		_, sums, err := a.get(ctx, prefixKey)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return sums[i], nil
+	}
+
+	return protoreflect.Value{}, ormerrors.FieldNotFound.Wrapf("%s is not a sum field of the index on %s", field, a.Fields())
+}
+
+func (a aggregateIndex) get(ctx context.Context, prefixKey []interface{}) (uint64, []protoreflect.Value, error) {
+	backend, err := a.getReadBackend(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	key, err := a.codec.EncodeKey(encodeutil.ValuesOf(prefixKey...))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	bz, err := backend.IndexStoreReader().Get(key)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return a.codec.DecodeValue(bz)
+}
+
+func (a aggregateIndex) onInsert(store kv.Store, message protoreflect.Message) error {
+	err := a.concreteIndex.(indexer).onInsert(store, message)
+	if err != nil {
+		return err
+	}
+
+	return a.aggregate(store, nil, message)
+}
+
+func (a aggregateIndex) onUpdate(store kv.Store, new, existing protoreflect.Message) error {
+	err := a.concreteIndex.(indexer).onUpdate(store, new, existing)
+	if err != nil {
+		return err
+	}
+
+	return a.aggregate(store, existing, new)
+}
+
+func (a aggregateIndex) onDelete(store kv.Store, message protoreflect.Message) error {
+	err := a.concreteIndex.(indexer).onDelete(store, message)
+	if err != nil {
+		return err
+	}
+
+	return a.aggregate(store, message, nil)
+}
+
+type aggregateValue struct {
+	bz    []byte
+	count uint64
+	sums  []protoreflect.Value
+}
+
+// aggregate removes the removed message, and adds the added message, to the
+// aggregates of every prefix key. Either message can be nil.
+func (a aggregateIndex) aggregate(store kv.Store, removed, added protoreflect.Message) error {
+	// an update usually changes the aggregates of some prefix keys only, so
+	// they are read once and only written if they changed
+	var keys []string
+	values := map[string]*aggregateValue{}
+	apply := func(message protoreflect.Message, add bool) error {
+		keyValues := a.codec.GetKeyValues(message)
+		amounts := a.codec.GetSumValues(message)
+		for i := 0; i <= len(keyValues); i++ {
+			key, err := a.codec.EncodeKey(keyValues[:i])
+			if err != nil {
+				return err
+			}
+
+			value, ok := values[string(key)]
+			if !ok {
+				bz, err := store.Get(key)
+				if err != nil {
+					return err
+				}
+				count, sums, err := a.codec.DecodeValue(bz)
+				if err != nil {
+					return err
+				}
+				value = &aggregateValue{bz: bz, count: count, sums: sums}
+				values[string(key)] = value
+				keys = append(keys, string(key))
+			}
+
+			if add {
+				value.count++
+			} else {
+				if value.count == 0 {
+					return ormerrors.UnexpectedError.Wrapf("negative count for index %s", a.Fields())
+				}
+				value.count--
+			}
+
+			for j, amount := range amounts {
+				if value.sums[j], ok = addSum(value.sums[j], amount, add); !ok {
+					return ormerrors.SumOverflow.Wrapf("field %s of the index on %s", a.codec.GetSumFieldNames()[j], a.Fields())
+				}
+			}
+		}
+		return nil
+	}
+
+	if removed != nil {
+		if err := apply(removed, false); err != nil {
+			return err
+		}
+	}
+	if added != nil {
+		if err := apply(added, true); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		value := values[key]
+		if value.count == 0 {
+			if value.bz != nil {
+				if err := store.Delete([]byte(key)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		bz, err := a.codec.EncodeValue(value.count, value.sums)
+		if err != nil {
+			return err
+		}
+		if string(bz) == string(value.bz) {
+			continue
+		}
+		if err := store.Set([]byte(key), bz); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addSum adds the amount to the sum, or subtracts it if add is false, which
+// are either both int64 or both uint64 values. It returns false on overflow.
+func addSum(sum, amount protoreflect.Value, add bool) (protoreflect.Value, bool) {
+	if x, ok := sum.Interface().(int64); ok {
+		y := amount.Int()
+		if add {
+			res := x + y
+			return protoreflect.ValueOfInt64(res), (y >= 0) == (res >= x)
+		}
+		res := x - y
+		return protoreflect.ValueOfInt64(res), (y >= 0) == (res <= x)
+	}
+
+	x, y := sum.Uint(), amount.Uint()
+	if add {
+		return protoreflect.ValueOfUint64(x + y), x+y >= x
+	}
+	return protoreflect.ValueOfUint64(x - y), y <= x
+}
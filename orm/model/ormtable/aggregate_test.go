@@ -0,0 +1,182 @@
+package ormtable_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
+	"cosmossdk.io/orm/internal/testkv"
+	"cosmossdk.io/orm/internal/testpb"
+	"cosmossdk.io/orm/model/ormtable"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+func buildAggregateTable(t *testing.T) ormtable.Table {
+	t.Helper()
+	table, err := ormtable.Build(ormtable.Options{
+		MessageType: (&testpb.ExampleTable{}).ProtoReflect().Type(),
+		TableDescriptor: &ormv1.TableDescriptor{
+			Id:         1,
+			PrimaryKey: &ormv1.PrimaryKeyDescriptor{Fields: "u32,i64"},
+			Index: []*ormv1.SecondaryIndexDescriptor{
+				{Id: 1, Fields: "u64,str", Unique: true, Count: true},
+				{Id: 2, Fields: "str,b", Sum: "u64,i32"},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	return table
+}
+
+func TestAggregateIndex(t *testing.T) {
+	table := buildAggregateTable(t)
+	runAggregateScenario(t, table, testkv.NewSplitMemBackend())
+
+	runAggregateScenario(t, table, testkv.NewSharedMemBackend())
+}
+
+func runAggregateScenario(t *testing.T, table ormtable.Table, backend ormtable.Backend) {
+	t.Helper()
+	ctx := ormtable.WrapContextDefault(backend)
+	byStr, ok := table.GetIndex("str,b").(ormtable.AggregateIndex)
+	assert.Assert(t, ok)
+
+	assertAggregates := func(prefixKey []interface{}, count, sumU64 uint64, sumI32 int64) {
+		t.Helper()
+		n, err := byStr.Count(ctx, prefixKey...)
+		assert.NilError(t, err)
+		assert.Equal(t, count, n)
+		assert.Equal(t, count, countEntries(t, ctx, byStr, prefixKey))
+
+		sum, err := byStr.Sum(ctx, "u64", prefixKey...)
+		assert.NilError(t, err)
+		assert.Equal(t, sumU64, sum.Uint())
+
+		sum, err = byStr.Sum(ctx, "i32", prefixKey...)
+		assert.NilError(t, err)
+		assert.Equal(t, sumI32, sum.Int())
+	}
+
+	data := []*testpb.ExampleTable{
+		{U32: 1, I64: 1, Str: "a", B: true, U64: 10, I32: -5},
+		{U32: 1, I64: 2, Str: "a", B: true, U64: 20, I32: 3},
+		{U32: 2, I64: 1, Str: "a", U64: 30, I32: 7},
+		{U32: 2, I64: 2, Str: "b", U64: 40, I32: -1},
+	}
+	for _, d := range data {
+		assert.NilError(t, table.Insert(ctx, d))
+	}
+
+	assertAggregates(nil, 4, 100, 4)
+	assertAggregates([]interface{}{"a"}, 3, 60, 5)
+	assertAggregates([]interface{}{"a", true}, 2, 30, -2)
+	assertAggregates([]interface{}{"a", false}, 1, 30, 7)
+	assertAggregates([]interface{}{"b"}, 1, 40, -1)
+	assertAggregates([]interface{}{"c"}, 0, 0, 0)
+
+	// updating the sum fields only updates the sums
+	assert.NilError(t, table.Update(ctx, &testpb.ExampleTable{U32: 1, I64: 2, Str: "a", B: true, U64: 25, I32: -3}))
+	assertAggregates(nil, 4, 105, -2)
+	assertAggregates([]interface{}{"a", true}, 2, 35, -8)
+
+	// moving an entry to another prefix key updates both
+	assert.NilError(t, table.Save(ctx, &testpb.ExampleTable{U32: 2, I64: 1, Str: "b", B: true, U64: 50, I32: 1}))
+	assertAggregates(nil, 4, 125, -8)
+	assertAggregates([]interface{}{"a"}, 2, 35, -8)
+	assertAggregates([]interface{}{"a", false}, 0, 0, 0)
+	assertAggregates([]interface{}{"b"}, 2, 90, 0)
+	assertAggregates([]interface{}{"b", true}, 1, 50, 1)
+	assert.NilError(t, table.Delete(ctx, data[3]))
+	assertAggregates(nil, 3, 85, -7)
+
+	// the unique index counts its entries too, and is still unique
+	byU64, ok := table.GetUniqueIndex("u64,str").(ormtable.AggregateIndex)
+	assert.Assert(t, ok)
+	n, err := byU64.Count(ctx, uint64(25))
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), n)
+	found, err := table.GetUniqueIndex("u64,str").Has(ctx, uint64(25), "a")
+	assert.NilError(t, err)
+	assert.Assert(t, found)
+	err = table.Insert(ctx, &testpb.ExampleTable{U32: 3, Str: "a", U64: 25})
+	assert.ErrorIs(t, err, ormerrors.UniqueKeyViolation)
+	n, err = byU64.Count(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(3), n)
+
+	// overflowing a sum fails without writing anything
+	err = table.Insert(ctx, &testpb.ExampleTable{U32: 3, Str: "b", U64: math.MaxUint64})
+	assert.ErrorIs(t, err, ormerrors.SumOverflow)
+	assertAggregates(nil, 3, 85, -7)
+
+	// deleting several entries in a batch
+	assert.NilError(t, table.Insert(ctx, &testpb.ExampleTable{U32: 3, Str: "b", U64: 60, I32: 2}))
+	assertAggregates([]interface{}{"b"}, 2, 110, 3)
+	assert.NilError(t, byStr.DeleteBy(ctx, "b"))
+	assertAggregates(nil, 2, 35, -8)
+	assertAggregates([]interface{}{"b"}, 0, 0, 0)
+
+	checkEncodeDecodeEntries(t, table, backend.IndexStoreReader())
+
+	// empty aggregates are deleted
+	assert.NilError(t, table.GetIndex("u32,i64").DeleteBy(ctx))
+	assertAggregates(nil, 0, 0, 0)
+	n, err = byU64.Count(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(0), n)
+	it, err := backend.IndexStoreReader().Iterator(nil, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, !it.Valid())
+	assert.NilError(t, it.Close())
+
+	_, err = byStr.Sum(ctx, "i64")
+	assert.ErrorIs(t, err, ormerrors.FieldNotFound)
+	_, err = byStr.Count(ctx, "a", true, uint32(1))
+	assert.ErrorIs(t, err, ormerrors.IndexOutOfBounds)
+}
+
+func countEntries(t *testing.T, ctx context.Context, index ormtable.Index, prefixKey []interface{}) uint64 {
+	t.Helper()
+	it, err := index.List(ctx, prefixKey)
+	assert.NilError(t, err)
+	defer it.Close()
+	var n uint64
+	for it.Next() {
+		n++
+	}
+	return n
+}
+
+func TestAggregateIndexImportJSON(t *testing.T) {
+	table := buildAggregateTable(t)
+	ctx := ormtable.WrapContextDefault(testkv.NewSplitMemBackend())
+	assert.NilError(t, table.ImportJSON(ctx, strings.NewReader(`[
+{"u32":1,"i64":"1","str":"a","u64":"10","i32":-5},
+{"u32":1,"i64":"2","str":"a","u64":"20","i32":3},
+{"u32":2,"i64":"1","str":"b","u64":"30","i32":7}
+]`)))
+
+	byStr := table.GetIndex("str,b").(ormtable.AggregateIndex)
+	n, err := byStr.Count(ctx, "a")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), n)
+	sum, err := byStr.Sum(ctx, "i32")
+	assert.NilError(t, err)
+	assert.Equal(t, int64(5), sum.Int())
+}
+
+func TestAggregateIndexInvalid(t *testing.T) {
+	_, err := ormtable.Build(ormtable.Options{
+		MessageType: (&testpb.ExampleTable{}).ProtoReflect().Type(),
+		TableDescriptor: &ormv1.TableDescriptor{
+			Id:         1,
+			PrimaryKey: &ormv1.PrimaryKeyDescriptor{Fields: "u32"},
+			Index:      []*ormv1.SecondaryIndexDescriptor{{Id: 1, Fields: "str", Sum: "bz"}},
+		},
+	})
+	assert.ErrorIs(t, err, ormerrors.InvalidSumField)
+}
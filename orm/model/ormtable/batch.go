@@ -88,6 +88,8 @@ func (w *batchIndexCommitmentWriter) Close() {
 	w.commitmentWriter.curBuf = nil
 	w.indexWriter.prevBufs = nil
 	w.indexWriter.curBuf = nil
+	w.commitmentWriter.pending = nil
+	w.indexWriter.pending = nil
 }
 
 type batchWriterEntry struct {
@@ -100,6 +102,10 @@ type batchStoreWriter struct {
 	kv.ReadonlyStore
 	prevBufs [][]*batchWriterEntry
 	curBuf   []*batchWriterEntry
+	// pending holds the last pending write of every key so that reads through
+	// the writer observe them, which aggregates rely on when a batch writes
+	// several entries
+	pending map[string]*batchWriterEntry
 }
 
 const capacity = 16
@@ -114,6 +120,23 @@ func (b *batchStoreWriter) Delete(key []byte) error {
 	return nil
 }
 
+func (b *batchStoreWriter) Get(key []byte) ([]byte, error) {
+	if entry, ok := b.pending[string(key)]; ok {
+		if entry.delete {
+			return nil, nil
+		}
+		return entry.value, nil
+	}
+	return b.ReadonlyStore.Get(key)
+}
+
+func (b *batchStoreWriter) Has(key []byte) (bool, error) {
+	if entry, ok := b.pending[string(key)]; ok {
+		return !entry.delete, nil
+	}
+	return b.ReadonlyStore.Has(key)
+}
+
 func (w *batchIndexCommitmentWriter) enqueueHook(f func()) {
 	w.indexWriter.append(&batchWriterEntry{hookCall: f})
 }
@@ -125,6 +148,13 @@ func (b *batchStoreWriter) append(entry *batchWriterEntry) {
 	}
 
 	b.curBuf = append(b.curBuf, entry)
+
+	if entry.hookCall == nil {
+		if b.pending == nil {
+			b.pending = map[string]*batchWriterEntry{}
+		}
+		b.pending[string(entry.key)] = entry
+	}
 }
 
 var _ Backend = &batchIndexCommitmentWriter{}
@@ -18,6 +18,12 @@ const (
 	primaryKeyID uint32 = 0
 	indexIDLimit uint32 = 32768
 	seqID               = indexIDLimit
+	// schemaID is the id of the schema stored by the last migration of the
+	// table.
+	schemaID = seqID + 1
+	// aggregateIDOffset is added to the id of an aggregated index to get the
+	// id prefixing its aggregates.
+	aggregateIDOffset = 2 * indexIDLimit
 )
 
 // Options are options for building a Table.
@@ -140,6 +146,10 @@ func Build(options Options) (Table, error) {
 	prefix = encodeutil.AppendVarUInt32(prefix, tableID)
 	table.tablePrefix = prefix
 	table.tableID = tableID
+	table.tableDescriptor = tableDesc
+
+	table.schemaCodec = ormkv.NewSchemaCodec(options.MessageType, encodeutil.AppendVarUInt32(prefix, schemaID))
+	table.entryCodecsByID[schemaID] = table.schemaCodec
 
 	if tableDesc.PrimaryKey == nil {
 		return nil, ormerrors.MissingPrimaryKey.Wrap(string(messageDescriptor.FullName()))
@@ -255,6 +265,35 @@ func Build(options Options) (Table, error) {
 			}
 		}
 
+		if idxDesc.Count || idxDesc.Sum != "" {
+			var sumFields []protoreflect.Name
+			if idxDesc.Sum != "" {
+				sumFields = fieldnames.CommaSeparatedFieldNames(idxDesc.Sum).Names()
+			}
+			aggCdc, err := ormkv.NewAggregateCodec(
+				encodeutil.AppendVarUInt32(prefix, aggregateIDOffset+id),
+				options.MessageType,
+				idxFields.Names(),
+				sumFields,
+			)
+			if err != nil {
+				return nil, err
+			}
+			table.entryCodecsByID[aggregateIDOffset+id] = aggCdc
+			aggIdx := aggregateIndex{
+				concreteIndex:  index,
+				codec:          aggCdc,
+				getReadBackend: backendResolver,
+			}
+			if uniqIdx, ok := index.(*uniqueKeyIndex); ok {
+				uniqAggIdx := &uniqueAggregateIndex{aggregateIndex: aggIdx, unique: uniqIdx}
+				table.uniqueIndexesByFields[idxFields] = uniqAggIdx
+				index = uniqAggIdx
+			} else {
+				index = &aggIdx
+			}
+		}
+
 		for name := range altNames {
 			if _, ok := table.indexesByFields[name]; ok {
 				return nil, fmt.Errorf("duplicate index for fields %s", name)
@@ -0,0 +1,213 @@
+package ormtable
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
+	"cosmossdk.io/orm/encoding/encodeutil"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+// MigrateOptions are options for migrating a table.
+type MigrateOptions struct {
+	// OnEntry is an optional function called for every entry backfilled into
+	// an index and every key deleted from an index, which can be used to
+	// consume gas. Migration stops at the first error it returns.
+	OnEntry func() error
+}
+
+// MigrationResult describes the changes made by the migration of a table.
+type MigrationResult struct {
+	// Version is the version of the schema stored for the table after the
+	// migration. It is incremented by every migration which changes the
+	// schema, starting from 1 for the first migration.
+	Version uint64
+
+	// PurgedIndexes are the ids of the secondary indexes which were removed
+	// or changed and whose entries were deleted.
+	PurgedIndexes []uint32
+
+	// BackfilledIndexes are the ids of the secondary indexes which were
+	// added or changed and whose entries were written for every entry of
+	// the table.
+	BackfilledIndexes []uint32
+
+	// Entries is the number of entries backfilled into, and keys deleted
+	// from, the indexes.
+	Entries uint64
+}
+
+// Migrate compares the schema stored for the table by its previous migration
+// with its current table descriptor. It then deletes the entries, and
+// aggregates, of the secondary indexes which were removed or changed,
+// backfills those of the secondary indexes which were added or changed from
+// the entries of the table, and stores the current schema with an incremented
+// version. It does nothing if the schema didn't change.
+//
+// If no schema was stored yet, every secondary index is rebuilt. Changes to
+// the primary key are not supported and fail with
+// ormerrors.UnsupportedSchemaChange. Singletons don't have indexes and are
+// never migrated.
+//
+// Migrate is meant to be called from an upgrade handler, before the table is
+// otherwise used with its new schema. Validate and write hooks are not called
+// and writes are batched until the end of the migration, which either writes
+// them all or nothing, unless there is an error with the underlying store.
+func Migrate(ctx context.Context, table Table, options MigrateOptions) (MigrationResult, error) {
+	var t *tableImpl
+	switch table := table.(type) {
+	case *tableImpl:
+		t = table
+	case *autoIncrementTable:
+		t = table.tableImpl
+	default:
+		return MigrationResult{}, nil
+	}
+
+	backend, err := t.getWriteBackend(ctx)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+
+	bz, err := backend.CommitmentStoreReader().Get(t.schemaCodec.Prefix())
+	if err != nil {
+		return MigrationResult{}, err
+	}
+
+	version, stored, err := t.schemaCodec.DecodeValue(bz)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+
+	if stored != nil && proto.Equal(stored, t.tableDescriptor) {
+		return MigrationResult{Version: version}, nil
+	}
+
+	tableName := t.MessageType().Descriptor().FullName()
+	if stored != nil && !proto.Equal(stored.PrimaryKey, t.tableDescriptor.PrimaryKey) {
+		return MigrationResult{}, ormerrors.UnsupportedSchemaChange.Wrapf("primary key of %s changed from %s to %s",
+			tableName, stored.PrimaryKey.Fields, t.tableDescriptor.PrimaryKey.Fields)
+	}
+
+	res := MigrationResult{Version: version + 1}
+	storedIndexes := map[uint32]*ormv1.SecondaryIndexDescriptor{}
+	if stored != nil {
+		for _, idxDesc := range stored.Index {
+			storedIndexes[idxDesc.Id] = idxDesc
+		}
+	}
+	for _, idxDesc := range t.tableDescriptor.Index {
+		storedIdx, ok := storedIndexes[idxDesc.Id]
+		delete(storedIndexes, idxDesc.Id)
+		if ok && proto.Equal(storedIdx, idxDesc) {
+			continue
+		}
+
+		// without a stored schema, entries might exist for any index
+		if ok || stored == nil {
+			res.PurgedIndexes = append(res.PurgedIndexes, idxDesc.Id)
+		}
+		res.BackfilledIndexes = append(res.BackfilledIndexes, idxDesc.Id)
+	}
+	for id := range storedIndexes {
+		res.PurgedIndexes = append(res.PurgedIndexes, id)
+	}
+	sort.Slice(res.PurgedIndexes, func(i, j int) bool { return res.PurgedIndexes[i] < res.PurgedIndexes[j] })
+
+	onEntry := func() error {
+		res.Entries++
+		if options.OnEntry != nil {
+			return options.OnEntry()
+		}
+		return nil
+	}
+
+	// we batch writes so that the index store isn't written to while it is
+	// iterated over, and so that backfilling reads the purged indexes as empty
+	writer := newBatchIndexCommitmentWriter(backend)
+	defer writer.Close()
+
+	for _, id := range res.PurgedIndexes {
+		for _, prefix := range [][]byte{
+			encodeutil.AppendVarUInt32(t.tablePrefix, id),
+			encodeutil.AppendVarUInt32(t.tablePrefix, aggregateIDOffset+id),
+		} {
+			err = purgePrefix(backend, writer, prefix, onEntry)
+			if err != nil {
+				return MigrationResult{}, err
+			}
+		}
+	}
+
+	for _, id := range res.BackfilledIndexes {
+		err = t.backfillIndex(ctx, writer, t.indexesByID[id].(indexer), onEntry)
+		if err != nil {
+			return MigrationResult{}, err
+		}
+	}
+
+	v, err := t.schemaCodec.EncodeValue(res.Version, t.tableDescriptor)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+
+	err = writer.CommitmentStore().Set(t.schemaCodec.Prefix(), v)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+
+	return res, writer.Write()
+}
+
+func purgePrefix(backend Backend, writer *batchIndexCommitmentWriter, prefix []byte, onEntry func() error) error {
+	it, err := backend.IndexStoreReader().Iterator(prefix, prefixEndBytes(prefix))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		err = onEntry()
+		if err != nil {
+			return err
+		}
+
+		// keys are copied as iterators can reuse them
+		err = writer.IndexStore().Delete(append([]byte{}, it.Key()...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return it.Error()
+}
+
+func (t tableImpl) backfillIndex(ctx context.Context, writer *batchIndexCommitmentWriter, index indexer, onEntry func() error) error {
+	it, err := t.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		err = onEntry()
+		if err != nil {
+			return err
+		}
+
+		msg, err := it.GetMessage()
+		if err != nil {
+			return err
+		}
+
+		err = index.onInsert(writer.IndexStore(), msg.ProtoReflect())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
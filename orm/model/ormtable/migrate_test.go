@@ -0,0 +1,119 @@
+package ormtable_test
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
+	"cosmossdk.io/orm/internal/testkv"
+	"cosmossdk.io/orm/internal/testpb"
+	"cosmossdk.io/orm/model/ormtable"
+	"cosmossdk.io/orm/types/ormerrors"
+)
+
+func buildMigrateTable(t *testing.T, indexes ...*ormv1.SecondaryIndexDescriptor) ormtable.Table {
+	t.Helper()
+	table, err := ormtable.Build(ormtable.Options{
+		MessageType: (&testpb.ExampleTable{}).ProtoReflect().Type(),
+		TableDescriptor: &ormv1.TableDescriptor{
+			Id:         1,
+			PrimaryKey: &ormv1.PrimaryKeyDescriptor{Fields: "u32,i64"},
+			Index:      indexes,
+		},
+	})
+	assert.NilError(t, err)
+	return table
+}
+
+func TestMigrate(t *testing.T) {
+	backend := testkv.NewSplitMemBackend()
+	ctx := ormtable.WrapContextDefault(backend)
+
+	v1 := buildMigrateTable(t,
+		&ormv1.SecondaryIndexDescriptor{Id: 1, Fields: "str"},
+		&ormv1.SecondaryIndexDescriptor{Id: 2, Fields: "u64,str", Unique: true},
+	)
+	data := []*testpb.ExampleTable{
+		{U32: 1, I64: 1, Str: "a", U64: 10, I32: 1},
+		{U32: 1, I64: 2, Str: "a", U64: 20, I32: 1},
+		{U32: 2, I64: 1, Str: "b", U64: 10, I32: 2},
+	}
+	for _, d := range data {
+		assert.NilError(t, v1.Insert(ctx, d))
+	}
+
+	// without a stored schema every index is rebuilt
+	res, err := ormtable.Migrate(ctx, v1, ormtable.MigrateOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ormtable.MigrationResult{
+		Version:           1,
+		PurgedIndexes:     []uint32{1, 2},
+		BackfilledIndexes: []uint32{1, 2},
+		Entries:           12,
+	}, res)
+	assert.Equal(t, uint64(2), countEntries(t, ctx, v1.GetIndex("str"), []interface{}{"a"}))
+	checkEncodeDecodeEntries(t, v1, backend.CommitmentStoreReader())
+	checkEncodeDecodeEntries(t, v1, backend.IndexStoreReader())
+
+	res, err = ormtable.Migrate(ctx, v1, ormtable.MigrateOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ormtable.MigrationResult{Version: 1}, res)
+
+	// index 1 is removed, index 2 is changed and index 3 is added
+	v2 := buildMigrateTable(t,
+		&ormv1.SecondaryIndexDescriptor{Id: 2, Fields: "u64,str", Unique: true, Count: true},
+		&ormv1.SecondaryIndexDescriptor{Id: 3, Fields: "i32", Sum: "u64"},
+	)
+	var entries int
+	res, err = ormtable.Migrate(ctx, v2, ormtable.MigrateOptions{OnEntry: func() error {
+		entries++
+		return nil
+	}})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ormtable.MigrationResult{
+		Version:           2,
+		PurgedIndexes:     []uint32{1, 2},
+		BackfilledIndexes: []uint32{2, 3},
+		Entries:           12,
+	}, res)
+	assert.Equal(t, 12, entries)
+
+	byI32 := v2.GetIndex("i32").(ormtable.AggregateIndex)
+	assert.Equal(t, uint64(2), countEntries(t, ctx, byI32, []interface{}{int32(1)}))
+	sum, err := byI32.Sum(ctx, "u64", int32(1))
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(30), sum.Uint())
+	n, err := v2.GetUniqueIndex("u64,str").(ormtable.AggregateIndex).Count(ctx, uint64(10))
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(2), n)
+	found, err := v2.GetUniqueIndex("u64,str").Has(ctx, uint64(20), "a")
+	assert.NilError(t, err)
+	assert.Assert(t, found)
+	checkEncodeDecodeEntries(t, v2, backend.CommitmentStoreReader())
+	checkEncodeDecodeEntries(t, v2, backend.IndexStoreReader())
+
+	// a unique index which can't be backfilled leaves the state unchanged
+	v3 := buildMigrateTable(t,
+		&ormv1.SecondaryIndexDescriptor{Id: 2, Fields: "u64,str", Unique: true, Count: true},
+		&ormv1.SecondaryIndexDescriptor{Id: 3, Fields: "i32", Sum: "u64"},
+		&ormv1.SecondaryIndexDescriptor{Id: 4, Fields: "u64", Unique: true},
+	)
+	_, err = ormtable.Migrate(ctx, v3, ormtable.MigrateOptions{})
+	assert.ErrorIs(t, err, ormerrors.UniqueKeyViolation)
+	res, err = ormtable.Migrate(ctx, v2, ormtable.MigrateOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ormtable.MigrationResult{Version: 2}, res)
+
+	// primary keys can't be migrated
+	v4, err := ormtable.Build(ormtable.Options{
+		MessageType: (&testpb.ExampleTable{}).ProtoReflect().Type(),
+		TableDescriptor: &ormv1.TableDescriptor{
+			Id:         1,
+			PrimaryKey: &ormv1.PrimaryKeyDescriptor{Fields: "u32,i64,str"},
+		},
+	})
+	assert.NilError(t, err)
+	_, err = ormtable.Migrate(ctx, v4, ormtable.MigrateOptions{})
+	assert.ErrorIs(t, err, ormerrors.UnsupportedSchemaChange)
+}
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	ormv1 "cosmossdk.io/api/cosmos/orm/v1"
 	"cosmossdk.io/orm/encoding/encodeutil"
 	"cosmossdk.io/orm/encoding/ormkv"
 	"cosmossdk.io/orm/internal/fieldnames"
@@ -27,6 +28,8 @@ type tableImpl struct {
 	entryCodecsByID       map[uint32]ormkv.EntryCodec
 	tablePrefix           []byte
 	tableID               uint32
+	tableDescriptor       *ormv1.TableDescriptor
+	schemaCodec           *ormkv.SchemaCodec
 	typeResolver          TypeResolver
 	customJSONValidator   func(message proto.Message) error
 }
@@ -384,6 +387,19 @@ func (t tableImpl) EncodeEntry(entry ormkv.Entry) (k, v []byte, err error) {
 		}
 
 		return idx.EncodeEntry(entry)
+	case *ormkv.AggregateEntry:
+		idx, ok := t.indexesByFields[fieldnames.FieldsFromNames(entry.Fields)].(aggregator)
+		if !ok {
+			return nil, nil, ormerrors.BadDecodeEntry.Wrapf("can't find aggregated index with fields %s", entry.Fields)
+		}
+
+		return idx.aggregateCodec().EncodeEntry(entry)
+	case *ormkv.SchemaEntry:
+		if t.schemaCodec == nil {
+			return nil, nil, ormerrors.BadDecodeEntry.Wrapf("%s has no schema", t.MessageType().Descriptor().FullName())
+		}
+
+		return t.schemaCodec.EncodeEntry(entry)
 	default:
 		return nil, nil, ormerrors.BadDecodeEntry.Wrapf("%s", entry)
 	}
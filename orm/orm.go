@@ -10,6 +10,7 @@ import (
 	appv1alpha1 "cosmossdk.io/api/cosmos/app/v1alpha1"
 	modulev1alpha1 "cosmossdk.io/api/cosmos/orm/module/v1alpha1"
 	ormv1alpha1 "cosmossdk.io/api/cosmos/orm/v1alpha1"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/depinject/appconfig"
@@ -31,6 +32,7 @@ type ModuleDBInputs struct {
 	KVStoreService        store.KVStoreService
 	MemoryStoreService    store.MemoryStoreService    `optional:"true"`
 	TransientStoreService store.TransientStoreService `optional:"true"`
+	GasService            gas.Service                 `optional:"true"`
 	TypeResolver          ormtable.TypeResolver       `optional:"true"`
 	FileResolver          protodesc.Resolver          `optional:"true"`
 }
@@ -60,6 +62,7 @@ func ProvideModuleDB(moduleKey depinject.ModuleKey, inputs ModuleDBInputs) (ormd
 				KVStoreService:        inputs.KVStoreService,
 				MemoryStoreService:    inputs.MemoryStoreService,
 				TransientStoreService: inputs.TransientStoreService,
+				GasService:            inputs.GasService,
 			})
 		}
 	}
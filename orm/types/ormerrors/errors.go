@@ -45,4 +45,7 @@ var (
 	AlreadyExists                 = errors.RegisterWithGRPCCode(codespace, 31, codes.AlreadyExists, "already exists")
 	ConstraintViolation           = errors.RegisterWithGRPCCode(codespace, 32, codes.FailedPrecondition, "failed precondition")
 	NoTableDescriptor             = errors.New(codespace, 33, "no table descriptor found")
+	InvalidSumField               = errors.New(codespace, 34, "invalid sum field, need a non-repeated integer field")
+	SumOverflow                   = errors.RegisterWithGRPCCode(codespace, 35, codes.OutOfRange, "sum overflow")
+	UnsupportedSchemaChange       = errors.New(codespace, 36, "unsupported schema change")
 )
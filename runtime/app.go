@@ -9,6 +9,7 @@ import (
 
 	runtimev1alpha1 "cosmossdk.io/api/cosmos/app/runtime/v1alpha1"
 	appv1alpha1 "cosmossdk.io/api/cosmos/app/v1alpha1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
@@ -52,6 +53,9 @@ type App struct {
 	grpcQueryRouter   *baseapp.GRPCQueryRouter
 	appConfig         *appv1alpha1.Config
 	logger            log.Logger
+	// collectionsSchemas are the collections schemas served by the
+	// SchemaReflectionService, by store key.
+	collectionsSchemas map[string]collections.Schema
 	// initChainer is the init chainer function defined by the app config.
 	// this is only required if the chain wants to add special InitChainer logic.
 	initChainer sdk.InitChainer
@@ -99,6 +103,16 @@ func (a *App) RegisterStores(keys ...storetypes.StoreKey) error {
 	return nil
 }
 
+// RegisterCollectionsSchema registers the collections schema of the given store
+// key, to be served by the SchemaReflectionService.
+func (a *App) RegisterCollectionsSchema(storeKey string, schema collections.Schema) error {
+	if _, ok := a.collectionsSchemas[storeKey]; ok {
+		return fmt.Errorf("collections schema of store key %q already registered", storeKey)
+	}
+	a.collectionsSchemas[storeKey] = schema
+	return nil
+}
+
 // Load finishes all initialization operations and loads the app.
 func (a *App) Load(loadLatest bool) error {
 	if len(a.config.InitGenesis) != 0 {
@@ -213,7 +227,11 @@ func (a *App) RegisterAPIRoutes(apiSvr *api.Server, _ config.APIConfig) {
 
 // RegisterTxService implements the Application.RegisterTxService method.
 func (a *App) RegisterTxService(clientCtx client.Context) {
-	authtx.RegisterTxService(a.GRPCQueryRouter(), clientCtx, a.Simulate, a.interfaceRegistry)
+	opts := authtx.TxServerOptions{SimulateWithOverrides: a.SimulateWithOverrides}
+	if a.TraceTxEnabled() {
+		opts.TraceTx = a.TraceTx
+	}
+	authtx.RegisterTxServiceWithOptions(a.GRPCQueryRouter(), clientCtx, a.Simulate, a.interfaceRegistry, opts)
 }
 
 // RegisterTendermintService implements the Application.RegisterTendermintService method.
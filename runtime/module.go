@@ -14,6 +14,7 @@ import (
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
 	reflectionv1 "cosmossdk.io/api/cosmos/reflection/v1"
 	stakingmodulev1 "cosmossdk.io/api/cosmos/staking/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/genesis"
@@ -136,12 +137,13 @@ func ProvideApp(interfaceRegistry codectypes.InterfaceRegistry) (
 	msgServiceRouter := baseapp.NewMsgServiceRouter()
 	grpcQueryRouter := baseapp.NewGRPCQueryRouter()
 	app := &App{
-		storeKeys:         nil,
-		interfaceRegistry: interfaceRegistry,
-		cdc:               cdc,
-		amino:             amino,
-		msgServiceRouter:  msgServiceRouter,
-		grpcQueryRouter:   grpcQueryRouter,
+		storeKeys:          nil,
+		interfaceRegistry:  interfaceRegistry,
+		cdc:                cdc,
+		amino:              amino,
+		msgServiceRouter:   msgServiceRouter,
+		grpcQueryRouter:    grpcQueryRouter,
+		collectionsSchemas: map[string]collections.Schema{},
 	}
 	appBuilder := &AppBuilder{app}
 
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	collreflectionv1 "cosmossdk.io/api/cosmos/collections/reflection/v1"
 	reflectionv1 "cosmossdk.io/api/cosmos/reflection/v1"
 
 	"github.com/cosmos/cosmos-sdk/runtime/services"
@@ -17,5 +18,7 @@ func (a *App) registerRuntimeServices(cfg module.Configurator) error { // nolint
 	}
 	reflectionv1.RegisterReflectionServiceServer(cfg.QueryServer(), reflectionSvc)
 
+	collreflectionv1.RegisterSchemaReflectionServiceServer(cfg.QueryServer(), services.NewSchemaReflectionService(a.collectionsSchemas))
+
 	return nil
 }
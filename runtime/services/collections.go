@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	collreflectionv1 "cosmossdk.io/api/cosmos/collections/reflection/v1"
+	"cosmossdk.io/collections"
+)
+
+// SchemaReflectionService implements the cosmos.collections.reflection.v1
+// service over the collections schemas of an app, by store key.
+type SchemaReflectionService struct {
+	collreflectionv1.UnimplementedSchemaReflectionServiceServer
+
+	schemas map[string]collections.Schema
+}
+
+// NewSchemaReflectionService returns a SchemaReflectionService of the given
+// schemas, by store key. The map is not copied, so schemas added to it later on
+// are served as well.
+func NewSchemaReflectionService(schemas map[string]collections.Schema) *SchemaReflectionService {
+	return &SchemaReflectionService{schemas: schemas}
+}
+
+// StoreKeys returns the store keys which have a schema, in ascending order.
+func (s SchemaReflectionService) StoreKeys(_ context.Context, _ *collreflectionv1.StoreKeysRequest) (*collreflectionv1.StoreKeysResponse, error) {
+	storeKeys := make([]string, 0, len(s.schemas))
+	for storeKey := range s.schemas {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	sort.Strings(storeKeys)
+
+	return &collreflectionv1.StoreKeysResponse{StoreKeys: storeKeys}, nil
+}
+
+// SchemaDescriptor returns the descriptor of the schema of the given store key.
+func (s SchemaReflectionService) SchemaDescriptor(_ context.Context, req *collreflectionv1.SchemaDescriptorRequest) (*collreflectionv1.SchemaDescriptorResponse, error) {
+	schema, ok := s.schemas[req.StoreKey]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no schema for store key %q", req.StoreKey)
+	}
+
+	d, err := SchemaDescriptorProto(schema.Descriptor())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &collreflectionv1.SchemaDescriptorResponse{Schema: d}, nil
+}
+
+var _ collreflectionv1.SchemaReflectionServiceServer = &SchemaReflectionService{}
+
+// SchemaDescriptorProto returns the cosmos.collections.reflection.v1 message of
+// the given collections schema descriptor.
+func SchemaDescriptorProto(d collections.SchemaDescriptor) (*collreflectionv1.SchemaDescriptor, error) {
+	collectionDescriptors := make([]*collreflectionv1.CollectionDescriptor, len(d.Collections))
+	for i, c := range d.Collections {
+		prefix, err := hex.DecodeString(c.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		collectionDescriptors[i] = &collreflectionv1.CollectionDescriptor{
+			Name:      c.Name,
+			Prefix:    prefix,
+			Key:       keyDescriptorProto(c.Key),
+			ValueType: c.ValueType,
+		}
+	}
+
+	return &collreflectionv1.SchemaDescriptor{
+		Version:     d.Version,
+		Collections: collectionDescriptors,
+	}, nil
+}
+
+func keyDescriptorProto(d collections.KeyDescriptor) *collreflectionv1.KeyDescriptor {
+	kd := &collreflectionv1.KeyDescriptor{KeyType: d.Type}
+	for _, part := range d.Parts {
+		kd.Parts = append(kd.Parts, keyDescriptorProto(part))
+	}
+	return kd
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	collreflectionv1 "cosmossdk.io/api/cosmos/collections/reflection/v1"
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/colltest"
+
+	"github.com/cosmos/cosmos-sdk/runtime/services"
+)
+
+func TestSchemaDescriptorProto(t *testing.T) {
+	sk, _ := colltest.MockStore()
+	sb := collections.NewSchemaBuilder(sk)
+	collections.NewMap(sb, collections.NewPrefix(1), "balances", collections.PairKeyCodec(collections.StringKey, collections.StringKey), collections.Uint64Value)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	d, err := services.SchemaDescriptorProto(schema.Descriptor())
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&collreflectionv1.SchemaDescriptor{
+		Version: collections.SchemaDescriptorVersion,
+		Collections: []*collreflectionv1.CollectionDescriptor{{
+			Name:   "balances",
+			Prefix: []byte{1},
+			Key: &collreflectionv1.KeyDescriptor{
+				KeyType: "Pair[string, string]",
+				Parts:   []*collreflectionv1.KeyDescriptor{{KeyType: "string"}, {KeyType: "string"}},
+			},
+			ValueType: "uint64",
+		}},
+	}, d), d.String())
+}
+
+func TestSchemaReflectionService(t *testing.T) {
+	sk, _ := colltest.MockStore()
+	sb := collections.NewSchemaBuilder(sk)
+	collections.NewMap(sb, collections.NewPrefix(1), "balances", collections.PairKeyCodec(collections.StringKey, collections.StringKey), collections.Uint64Value)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcSrv := grpc.NewServer()
+	collreflectionv1.RegisterSchemaReflectionServiceServer(grpcSrv, services.NewSchemaReflectionService(map[string]collections.Schema{"bank": schema, "auth": schema}))
+	go func() { _ = grpcSrv.Serve(listener) }()
+	defer grpcSrv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	client := collreflectionv1.NewSchemaReflectionServiceClient(conn)
+
+	storeKeys, err := client.StoreKeys(ctx, &collreflectionv1.StoreKeysRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"auth", "bank"}, storeKeys.StoreKeys)
+
+	res, err := client.SchemaDescriptor(ctx, &collreflectionv1.SchemaDescriptorRequest{StoreKey: "bank"})
+	require.NoError(t, err)
+	expected, err := services.SchemaDescriptorProto(schema.Descriptor())
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, res.Schema))
+
+	_, err = client.SchemaDescriptor(ctx, &collreflectionv1.SchemaDescriptorRequest{StoreKey: "staking"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
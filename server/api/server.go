@@ -185,6 +185,14 @@ func (s *Server) SetTelemetry(m *telemetry.Metrics) {
 	s.mtx.Unlock()
 }
 
+// SetStoreStats serves the statistics of the store with the given handler at
+// /store/stats.
+func (s *Server) SetStoreStats(h http.Handler) {
+	s.mtx.Lock()
+	s.Router.Handle("/store/stats", h).Methods("GET")
+	s.mtx.Unlock()
+}
+
 func (s *Server) registerMetrics() {
 	metricsHandler := func(w http.ResponseWriter, r *http.Request) {
 		format := strings.TrimSpace(r.FormValue("format"))
@@ -42,6 +42,14 @@ type BaseConfig struct {
 	// If set to 0, it is unbounded.
 	QueryGasLimit uint64 `mapstructure:"query-gas-limit"`
 
+	// TraceTx enables the TraceTx gRPC endpoint, which re-executes the
+	// transactions of a committed block.
+	TraceTx bool `mapstructure:"trace-tx"`
+
+	// TraceTxMaxConcurrent defines the maximum number of concurrent TraceTx
+	// calls. If set to 0, it is 1.
+	TraceTxMaxConcurrent uint `mapstructure:"trace-tx-max-concurrent"`
+
 	Pruning           string `mapstructure:"pruning"`
 	PruningKeepRecent string `mapstructure:"pruning-keep-recent"`
 	PruningInterval   string `mapstructure:"pruning-interval"`
@@ -168,6 +176,29 @@ type MempoolConfig struct {
 	MaxTxs int `mapstructure:"max-txs"`
 }
 
+// StoreStatsConfig defines the configuration of the statistics of the store,
+// see the StatsConfig of store/v2/metrics.
+type StoreStatsConfig struct {
+	// Enable defines if the statistics of the store are tracked. They are then
+	// emitted as telemetry, and served by the API server at /store/stats.
+	Enable bool `mapstructure:"enable"`
+
+	// PrefixLength is the number of leading bytes of a key which group the
+	// writes of a store.
+	PrefixLength int `mapstructure:"prefix-length"`
+
+	// MaxPrefixes is the number of prefixes tracked for every store, beyond
+	// which the writes of the prefixes are counted together.
+	MaxPrefixes int `mapstructure:"max-prefixes"`
+
+	// RateWindow is the number of blocks over which the write rate of every
+	// prefix is averaged.
+	RateWindow int `mapstructure:"rate-window"`
+
+	// HotKeys is the number of most written keys reported for every store.
+	HotKeys int `mapstructure:"hot-keys"`
+}
+
 // State Streaming configuration
 type (
 	// StreamingConfig defines application configuration for external streaming services
@@ -194,6 +225,8 @@ type Config struct {
 	StateSync StateSyncConfig  `mapstructure:"state-sync"`
 	Streaming StreamingConfig  `mapstructure:"streaming"`
 	Mempool   MempoolConfig    `mapstructure:"mempool"`
+
+	StoreStats StoreStatsConfig `mapstructure:"store-stats"`
 }
 
 // SetMinGasPrices sets the validator's minimum gas prices.
@@ -219,17 +252,19 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig: BaseConfig{
-			MinGasPrices:        defaultMinGasPrices,
-			QueryGasLimit:       0,
-			InterBlockCache:     true,
-			Pruning:             pruningtypes.PruningOptionDefault,
-			PruningKeepRecent:   "0",
-			PruningInterval:     "0",
-			MinRetainBlocks:     0,
-			IndexEvents:         make([]string, 0),
-			IAVLCacheSize:       781250,
-			IAVLDisableFastNode: false,
-			AppDBBackend:        "",
+			MinGasPrices:         defaultMinGasPrices,
+			QueryGasLimit:        0,
+			TraceTx:              false,
+			TraceTxMaxConcurrent: 1,
+			InterBlockCache:      true,
+			Pruning:              pruningtypes.PruningOptionDefault,
+			PruningKeepRecent:    "0",
+			PruningInterval:      "0",
+			MinRetainBlocks:      0,
+			IndexEvents:          make([]string, 0),
+			IAVLCacheSize:        781250,
+			IAVLDisableFastNode:  false,
+			AppDBBackend:         "",
 		},
 		Telemetry: telemetry.Config{
 			Enabled:      false,
@@ -265,6 +300,13 @@ func DefaultConfig() *Config {
 		Mempool: MempoolConfig{
 			MaxTxs: -1,
 		},
+		StoreStats: StoreStatsConfig{
+			Enable:       false,
+			PrefixLength: 1,
+			MaxPrefixes:  256,
+			RateWindow:   100,
+			HotKeys:      10,
+		},
 	}
 }
 
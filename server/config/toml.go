@@ -25,6 +25,14 @@ minimum-gas-prices = "{{ .BaseConfig.MinGasPrices }}"
 # If this is set to zero, the query can consume an unbounded amount of gas.
 query-gas-limit = "{{ .BaseConfig.QueryGasLimit }}"
 
+# TraceTx enables the TraceTx gRPC endpoint, which re-executes the transactions
+# of a committed block to trace one of them. It is expensive, so it is disabled
+# by default.
+trace-tx = {{ .BaseConfig.TraceTx }}
+
+# The maximum number of concurrent TraceTx calls. If set to zero, it is one.
+trace-tx-max-concurrent = {{ .BaseConfig.TraceTxMaxConcurrent }}
+
 # default: the last 362880 states are kept, pruning at 10 block intervals
 # nothing: all historic states will be saved, nothing will be deleted (i.e. archiving node)
 # everything: 2 latest states will be kept; pruning at 10 block intervals.
@@ -246,6 +254,32 @@ stop-node-on-err = {{ .Streaming.ABCI.StopNodeOnErr }}
 # Note, this configuration only applies to SDK built-in app-side mempool
 # implementations.
 max-txs = {{ .Mempool.MaxTxs }}
+
+###############################################################################
+###                               Store Stats                               ###
+###############################################################################
+
+# Store stats track, per store, the number of keys and bytes, the write rate of
+# the key prefixes and the most written keys. They are emitted as telemetry and
+# served by the API server at /store/stats. The store of the app must support
+# them, such as the store/v2 root store.
+[store-stats]
+
+# enable defines if the statistics of the store are tracked.
+enable = {{ .StoreStats.Enable }}
+
+# prefix-length is the number of leading bytes of a key which group the writes of a store.
+prefix-length = {{ .StoreStats.PrefixLength }}
+
+# max-prefixes is the number of prefixes tracked for every store, beyond which the
+# writes of the prefixes are counted together.
+max-prefixes = {{ .StoreStats.MaxPrefixes }}
+
+# rate-window is the number of blocks over which the write rate of every prefix is averaged.
+rate-window = {{ .StoreStats.RateWindow }}
+
+# hot-keys is the number of most written keys reported for every store.
+hot-keys = {{ .StoreStats.HotKeys }}
 `
 
 var configTemplate *template.Template
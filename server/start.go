@@ -3,9 +3,11 @@ package server
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"os"
 	"runtime/pprof"
 	"strings"
@@ -52,19 +54,21 @@ import (
 
 const (
 	// CometBFT full-node start flags
-	flagWithComet          = "with-comet"
-	flagAddress            = "address"
-	flagTransport          = "transport"
-	flagTraceStore         = "trace-store"
-	flagCPUProfile         = "cpu-profile"
-	FlagMinGasPrices       = "minimum-gas-prices"
-	FlagQueryGasLimit      = "query-gas-limit"
-	FlagHaltHeight         = "halt-height"
-	FlagHaltTime           = "halt-time"
-	FlagInterBlockCache    = "inter-block-cache"
-	FlagUnsafeSkipUpgrades = "unsafe-skip-upgrades"
-	FlagTrace              = "trace"
-	FlagInvCheckPeriod     = "inv-check-period"
+	flagWithComet            = "with-comet"
+	flagAddress              = "address"
+	flagTransport            = "transport"
+	flagTraceStore           = "trace-store"
+	flagCPUProfile           = "cpu-profile"
+	FlagMinGasPrices         = "minimum-gas-prices"
+	FlagQueryGasLimit        = "query-gas-limit"
+	FlagTraceTx              = "trace-tx"
+	FlagTraceTxMaxConcurrent = "trace-tx-max-concurrent"
+	FlagHaltHeight           = "halt-height"
+	FlagHaltTime             = "halt-time"
+	FlagInterBlockCache      = "inter-block-cache"
+	FlagUnsafeSkipUpgrades   = "unsafe-skip-upgrades"
+	FlagTrace                = "trace"
+	FlagInvCheckPeriod       = "inv-check-period"
 
 	FlagPruning             = "pruning"
 	FlagPruningKeepRecent   = "pruning-keep-recent"
@@ -231,13 +235,18 @@ func start[T types.Application](svrCtx *Context, clientCtx client.Context, appCr
 
 	emitServerInfoMetrics()
 
+	storeStats, err := startStoreStats(svrCfg, app)
+	if err != nil {
+		return err
+	}
+
 	if !withCmt {
-		return startStandAlone[T](svrCtx, svrCfg, clientCtx, app, metrics, opts)
+		return startStandAlone[T](svrCtx, svrCfg, clientCtx, app, metrics, storeStats, opts)
 	}
-	return startInProcess[T](svrCtx, svrCfg, clientCtx, app, metrics, opts)
+	return startInProcess[T](svrCtx, svrCfg, clientCtx, app, metrics, storeStats, opts)
 }
 
-func startStandAlone[T types.Application](svrCtx *Context, svrCfg serverconfig.Config, clientCtx client.Context, app T, metrics *telemetry.Metrics, opts StartCmdOptions[T]) error {
+func startStandAlone[T types.Application](svrCtx *Context, svrCfg serverconfig.Config, clientCtx client.Context, app T, metrics *telemetry.Metrics, storeStats http.Handler, opts StartCmdOptions[T]) error {
 	addr := svrCtx.Viper.GetString(flagAddress)
 	transport := svrCtx.Viper.GetString(flagTransport)
 
@@ -276,7 +285,7 @@ func startStandAlone[T types.Application](svrCtx *Context, svrCfg serverconfig.C
 		return err
 	}
 
-	err = startAPIServer(ctx, g, svrCfg, clientCtx, svrCtx, app, svrCtx.Config.RootDir, grpcSrv, metrics)
+	err = startAPIServer(ctx, g, svrCfg, clientCtx, svrCtx, app, svrCtx.Config.RootDir, grpcSrv, metrics, storeStats)
 	if err != nil {
 		return err
 	}
@@ -304,7 +313,7 @@ func startStandAlone[T types.Application](svrCtx *Context, svrCfg serverconfig.C
 }
 
 func startInProcess[T types.Application](svrCtx *Context, svrCfg serverconfig.Config, clientCtx client.Context, app T,
-	metrics *telemetry.Metrics, opts StartCmdOptions[T],
+	metrics *telemetry.Metrics, storeStats http.Handler, opts StartCmdOptions[T],
 ) error {
 	cmtCfg := svrCtx.Config
 	gRPCOnly := svrCtx.Viper.GetBool(flagGRPCOnly)
@@ -342,7 +351,7 @@ func startInProcess[T types.Application](svrCtx *Context, svrCfg serverconfig.Co
 		return err
 	}
 
-	err = startAPIServer(ctx, g, svrCfg, clientCtx, svrCtx, app, cmtCfg.RootDir, grpcSrv, metrics)
+	err = startAPIServer(ctx, g, svrCfg, clientCtx, svrCtx, app, cmtCfg.RootDir, grpcSrv, metrics, storeStats)
 	if err != nil {
 		return err
 	}
@@ -512,6 +521,7 @@ func startAPIServer(
 	home string,
 	grpcSrv *grpc.Server,
 	metrics *telemetry.Metrics,
+	storeStats http.Handler,
 ) error {
 	if !svrCfg.API.Enable {
 		return nil
@@ -526,6 +536,10 @@ func startAPIServer(
 		apiSrv.SetTelemetry(metrics)
 	}
 
+	if storeStats != nil {
+		apiSrv.SetStoreStats(storeStats)
+	}
+
 	g.Go(func() error {
 		return apiSrv.Start(ctx, svrCfg)
 	})
@@ -536,6 +550,25 @@ func startTelemetry(cfg serverconfig.Config) (*telemetry.Metrics, error) {
 	return telemetry.New(cfg.Telemetry)
 }
 
+// startStoreStats enables the statistics of the store of the app if they are
+// enabled in the config, and returns the handler serving them, or nil.
+func startStoreStats(cfg serverconfig.Config, app types.Application) (http.Handler, error) {
+	if !cfg.StoreStats.Enable {
+		return nil, nil
+	}
+
+	statsApp, ok := app.(types.StoreStatsApplication)
+	if !ok {
+		return nil, errors.New("store-stats are enabled, but the store of the app does not track statistics")
+	}
+
+	h, err := statsApp.EnableStoreStats(cfg.StoreStats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable store stats: %w", err)
+	}
+	return h, nil
+}
+
 // wrapCPUProfile starts CPU profiling, if enabled, and executes the provided
 // callbackFn in a separate goroutine, then will wait for that callback to
 // return.
@@ -956,6 +989,8 @@ func addStartNodeFlags[T types.Application](cmd *cobra.Command, opts StartCmdOpt
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
 	cmd.Flags().String(FlagMinGasPrices, "", "Minimum gas prices to accept for transactions; Any fee in a tx must meet this minimum (e.g. 0.01photino;0.0001stake)")
 	cmd.Flags().Uint64(FlagQueryGasLimit, 0, "Maximum gas a Rest/Grpc query can consume. Blank and 0 imply unbounded.")
+	cmd.Flags().Bool(FlagTraceTx, false, "Enable the TraceTx gRPC endpoint")
+	cmd.Flags().Uint(FlagTraceTxMaxConcurrent, 1, "Maximum number of concurrent TraceTx calls")
 	cmd.Flags().IntSlice(FlagUnsafeSkipUpgrades, []int{}, "Skip a set of upgrade heights to continue the old binary")
 	cmd.Flags().Uint64(FlagHaltHeight, 0, "Block height at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Uint64(FlagHaltTime, 0, "Minimum block time (in Unix seconds) at which to gracefully halt the chain and shutdown the node")
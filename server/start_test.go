@@ -0,0 +1,50 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/server/types"
+)
+
+type storeStatsApp struct {
+	types.Application
+	enabled *serverconfig.StoreStatsConfig
+	err     error
+}
+
+func (a *storeStatsApp) EnableStoreStats(cfg serverconfig.StoreStatsConfig) (http.Handler, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	a.enabled = &cfg
+	return http.NotFoundHandler(), nil
+}
+
+func TestStartStoreStats(t *testing.T) {
+	cfg := *serverconfig.DefaultConfig()
+
+	// disabled by default
+	app := &storeStatsApp{}
+	h, err := startStoreStats(cfg, app)
+	require.NoError(t, err)
+	require.Nil(t, h)
+	require.Nil(t, app.enabled)
+
+	cfg.StoreStats.Enable = true
+	h, err = startStoreStats(cfg, app)
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	require.Equal(t, &cfg.StoreStats, app.enabled)
+
+	_, err = startStoreStats(cfg, &storeStatsApp{err: errors.New("no state")})
+	require.ErrorContains(t, err, "no state")
+
+	// the store of the app must track statistics
+	_, err = startStoreStats(cfg, struct{ types.Application }{})
+	require.ErrorContains(t, err, "does not track statistics")
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"io"
+	"net/http"
 
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmttypes "github.com/cometbft/cometbft/types"
@@ -63,6 +64,16 @@ type (
 		Close() error
 	}
 
+	// StoreStatsApplication is an Application whose store tracks the statistics
+	// of its state, such as a store/v2 root.Store. It is required to enable the
+	// store-stats of app.toml.
+	StoreStatsApplication interface {
+		// EnableStoreStats enables the statistics of the store with the given
+		// configuration, e.g. with root.Store.EnableStats, and returns the
+		// handler serving them, e.g. metrics.NewStatsHandler of store/v2.
+		EnableStoreStats(config.StoreStatsConfig) (http.Handler, error)
+	}
+
 	// AppCreator is a function that allows us to lazily initialize an
 	// application using various configurations.
 	AppCreator[T Application] func(log.Logger, dbm.DB, io.Writer, AppOptions) T
@@ -544,6 +544,7 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		defaultMempool,
 		baseapp.SetChainID(chainID),
 		baseapp.SetQueryGasLimit(cast.ToUint64(appOpts.Get(FlagQueryGasLimit))),
+		baseapp.SetTraceTx(cast.ToBool(appOpts.Get(FlagTraceTx)), cast.ToUint(appOpts.Get(FlagTraceTxMaxConcurrent))),
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
 	reflectionv1 "cosmossdk.io/api/cosmos/reflection/v1"
 	"cosmossdk.io/client/v2/autocli"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
 	"cosmossdk.io/x/accounts"
@@ -694,6 +695,18 @@ func (app *SimApp) InterfaceRegistry() types.InterfaceRegistry {
 	return app.interfaceRegistry
 }
 
+// CollectionsSchemas returns the collections schemas of the modules, by store
+// key, which decode the state in the debug commands.
+func (app *SimApp) CollectionsSchemas() map[string]collections.Schema {
+	return map[string]collections.Schema{
+		authtypes.StoreKey:     app.AuthKeeper.Schema,
+		stakingtypes.StoreKey:  app.StakingKeeper.Schema,
+		slashingtypes.StoreKey: app.SlashingKeeper.Schema,
+		govtypes.StoreKey:      app.GovKeeper.Schema,
+		minttypes.StoreKey:     app.MintKeeper.Schema,
+	}
+}
+
 // TxConfig returns SimApp's TxConfig
 func (app *SimApp) TxConfig() client.TxConfig {
 	return app.txConfig
@@ -758,7 +771,11 @@ func (app *SimApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APICon
 
 // RegisterTxService implements the Application.RegisterTxService method.
 func (app *SimApp) RegisterTxService(clientCtx client.Context) {
-	authtx.RegisterTxService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.BaseApp.Simulate, app.interfaceRegistry)
+	opts := authtx.TxServerOptions{SimulateWithOverrides: app.BaseApp.SimulateWithOverrides}
+	if app.BaseApp.TraceTxEnabled() {
+		opts.TraceTx = app.BaseApp.TraceTx
+	}
+	authtx.RegisterTxServiceWithOptions(app.BaseApp.GRPCQueryRouter(), clientCtx, app.BaseApp.Simulate, app.interfaceRegistry, opts)
 }
 
 // RegisterTendermintService implements the Application.RegisterTendermintService method.
@@ -12,6 +12,7 @@ import (
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/spf13/cast"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
@@ -30,13 +31,17 @@ import (
 	evidencekeeper "cosmossdk.io/x/evidence/keeper"
 	feegrantkeeper "cosmossdk.io/x/feegrant/keeper"
 	govkeeper "cosmossdk.io/x/gov/keeper"
+	govtypes "cosmossdk.io/x/gov/types"
 	groupkeeper "cosmossdk.io/x/group/keeper"
 	mintkeeper "cosmossdk.io/x/mint/keeper"
+	minttypes "cosmossdk.io/x/mint/types"
 	nftkeeper "cosmossdk.io/x/nft/keeper"
 	_ "cosmossdk.io/x/protocolpool"
 	poolkeeper "cosmossdk.io/x/protocolpool/keeper"
 	slashingkeeper "cosmossdk.io/x/slashing/keeper"
+	slashingtypes "cosmossdk.io/x/slashing/types"
 	stakingkeeper "cosmossdk.io/x/staking/keeper"
+	stakingtypes "cosmossdk.io/x/staking/types"
 	upgradekeeper "cosmossdk.io/x/upgrade/keeper"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -247,6 +252,13 @@ func NewSimApp(
 		panic(err)
 	}
 
+	// register the collections schemas served by the SchemaReflectionService
+	for storeKey, schema := range app.CollectionsSchemas() {
+		if err := app.RegisterCollectionsSchema(storeKey, schema); err != nil {
+			panic(err)
+		}
+	}
+
 	/****  Module Options ****/
 
 	// RegisterUpgradeHandlers is used for registering any on-chain upgrades.
@@ -357,6 +369,19 @@ func (app *SimApp) InterfaceRegistry() codectypes.InterfaceRegistry {
 	return app.interfaceRegistry
 }
 
+// CollectionsSchemas returns the collections schemas of the modules, by store
+// key, which are served by the SchemaReflectionService and decode the state in
+// the debug commands.
+func (app *SimApp) CollectionsSchemas() map[string]collections.Schema {
+	return map[string]collections.Schema{
+		authtypes.StoreKey:     app.AuthKeeper.Schema,
+		stakingtypes.StoreKey:  app.StakingKeeper.Schema,
+		slashingtypes.StoreKey: app.SlashingKeeper.Schema,
+		govtypes.StoreKey:      app.GovKeeper.Schema,
+		minttypes.StoreKey:     app.MintKeeper.Schema,
+	}
+}
+
 // TxConfig returns SimApp's TxConfig
 func (app *SimApp) TxConfig() client.TxConfig {
 	return app.txConfig
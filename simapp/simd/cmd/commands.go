@@ -10,8 +10,10 @@ import (
 	"github.com/spf13/viper"
 
 	"cosmossdk.io/client/v2/offchain"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/log"
 	"cosmossdk.io/simapp"
+	storecli "cosmossdk.io/store/v2/cli"
 	confixcmd "cosmossdk.io/tools/confix/cmd"
 	authcmd "cosmossdk.io/x/auth/client/cli"
 	banktypes "cosmossdk.io/x/bank/types"
@@ -34,6 +36,7 @@ func initRootCmd(
 	rootCmd *cobra.Command,
 	txConfig client.TxConfig,
 	moduleManager *module.Manager,
+	schemas map[string]collections.Schema,
 ) {
 	cfg := sdk.GetConfig()
 	cfg.Seal()
@@ -41,10 +44,11 @@ func initRootCmd(
 	rootCmd.AddCommand(
 		genutilcli.InitCmd(moduleManager),
 		NewTestnetCmd(moduleManager, banktypes.GenesisBalancesIterator{}),
-		debug.Cmd(),
+		debugCommand(schemas),
 		confixcmd.ConfigCommand(),
 		pruning.Cmd(newApp),
 		snapshot.Cmd(newApp),
+		storecli.MigrationCmd(),
 	)
 
 	server.AddCommands(rootCmd, newApp, server.StartCmdOptions[servertypes.Application]{})
@@ -60,6 +64,17 @@ func initRootCmd(
 	)
 }
 
+// debugCommand builds the `simd debug` command, with the commands decoding the
+// state by the collections schemas of the modules, given by store key.
+func debugCommand(schemas map[string]collections.Schema) *cobra.Command {
+	cmd := debug.Cmd()
+	cmd.AddCommand(
+		debug.SchemaCmd(schemas),
+		storecli.StateDiffCmd(collections.NewChangeDecoder(schemas).FormatDiff),
+	)
+	return cmd
+}
+
 // genesisCommand builds genesis-related `simd genesis` command. Users may provide application specific commands as a parameter
 func genesisCommand(txConfig client.TxConfig, moduleManager *module.Manager, appExport servertypes.AppExporter, cmds ...*cobra.Command) *cobra.Command {
 	cmd := genutilcli.Commands(txConfig, moduleManager, appExport)
@@ -86,6 +101,7 @@ func queryCommand() *cobra.Command {
 		authcmd.QueryTxsByEventsCmd(),
 		server.QueryBlocksCmd(),
 		authcmd.QueryTxCmd(),
+		authcmd.TraceTxCmd(),
 		server.QueryBlockResultsCmd(),
 	)
 
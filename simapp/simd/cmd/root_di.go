@@ -11,13 +11,23 @@ import (
 	stakingv1 "cosmossdk.io/api/cosmos/staking/module/v1"
 	"cosmossdk.io/client/v2/autocli"
 	clientv2keyring "cosmossdk.io/client/v2/autocli/keyring"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/log"
 	"cosmossdk.io/simapp"
+	authkeeper "cosmossdk.io/x/auth/keeper"
 	"cosmossdk.io/x/auth/tx"
 	authtxconfig "cosmossdk.io/x/auth/tx/config"
 	"cosmossdk.io/x/auth/types"
+	govkeeper "cosmossdk.io/x/gov/keeper"
+	govtypes "cosmossdk.io/x/gov/types"
+	mintkeeper "cosmossdk.io/x/mint/keeper"
+	minttypes "cosmossdk.io/x/mint/types"
+	slashingkeeper "cosmossdk.io/x/slashing/keeper"
+	slashingtypes "cosmossdk.io/x/slashing/types"
+	stakingkeeper "cosmossdk.io/x/staking/keeper"
+	stakingtypes "cosmossdk.io/x/staking/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/config"
@@ -32,9 +42,14 @@ import (
 // NewRootCmd creates a new root command for simd. It is called once in the main function.
 func NewRootCmd() *cobra.Command {
 	var (
-		autoCliOpts   autocli.AppOptions
-		moduleManager *module.Manager
-		clientCtx     client.Context
+		autoCliOpts    autocli.AppOptions
+		moduleManager  *module.Manager
+		clientCtx      client.Context
+		authKeeper     authkeeper.AccountKeeper
+		stakingKeeper  *stakingkeeper.Keeper
+		slashingKeeper slashingkeeper.Keeper
+		govKeeper      *govkeeper.Keeper
+		mintKeeper     mintkeeper.Keeper
 	)
 
 	if err := depinject.Inject(
@@ -51,6 +66,11 @@ func NewRootCmd() *cobra.Command {
 		&autoCliOpts,
 		&moduleManager,
 		&clientCtx,
+		&authKeeper,
+		&stakingKeeper,
+		&slashingKeeper,
+		&govKeeper,
+		&mintKeeper,
 	); err != nil {
 		panic(err)
 	}
@@ -87,7 +107,16 @@ func NewRootCmd() *cobra.Command {
 		},
 	}
 
-	initRootCmd(rootCmd, clientCtx.TxConfig, moduleManager)
+	// the collections schemas registered by the app, see simapp.NewSimApp
+	schemas := map[string]collections.Schema{
+		types.StoreKey:         authKeeper.Schema,
+		stakingtypes.StoreKey:  stakingKeeper.Schema,
+		slashingtypes.StoreKey: slashingKeeper.Schema,
+		govtypes.StoreKey:      govKeeper.Schema,
+		minttypes.StoreKey:     mintKeeper.Schema,
+	}
+
+	initRootCmd(rootCmd, clientCtx.TxConfig, moduleManager, schemas)
 
 	if err := autoCliOpts.EnhanceRootCommand(rootCmd); err != nil {
 		panic(err)
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/store/v2/storage"
+)
+
+// DiffFormatter formats a key of a store which changed, with its old and new
+// values, and returns false to print them as hex instead. Applications pass
+// one to decode the keys and values of their modules, e.g. by their collections
+// schemas.
+type DiffFormatter func(storeKey string, key, oldValue, newValue []byte) (string, bool)
+
+// DebugCmd returns the store/v2 debug group command. The optional formatter is
+// used to print the keys and values of the stores.
+func DebugCmd(format DiffFormatter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Tools for debugging the store/v2 state",
+	}
+	cmd.AddCommand(
+		StateDiffCmd(format),
+	)
+	return cmd
+}
+
+const (
+	flagStart = "start"
+	flagEnd   = "end"
+)
+
+// StateDiffCmd returns the command to list the keys of a store which were added,
+// modified or deleted between two heights. The keys and values are printed by
+// the optional formatter, and as hex if it is nil or declines to format them.
+func StateDiffCmd(format DiffFormatter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff <ss-dir> <store-key> <from-height> <to-height>",
+		Short: "List the keys of a store which changed between two heights",
+		Long: `List the keys of a store which were added, modified or deleted between two heights,
+as reflected by the state storage (SS). Neither height may be pruned. The diff can be
+limited to the keys within [--start, --end), given as hex.
+
+The SS database must not be opened by a running node.`,
+		Example: "state-diff ~/.simapp/data/ss bank 100 110 --start 02",
+		Args:    cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend, err := cmd.Flags().GetString(flagSSBackend)
+			if err != nil {
+				return err
+			}
+			start, err := getHexFlag(cmd, flagStart)
+			if err != nil {
+				return err
+			}
+			end, err := getHexFlag(cmd, flagEnd)
+			if err != nil {
+				return err
+			}
+			storeKey := args[1]
+			from, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+			to, err := strconv.ParseUint(args[3], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			ss, err := openStorage(backend, args[0])
+			if err != nil {
+				return err
+			}
+			defer ss.Close()
+
+			return ss.Diff([]byte(storeKey), from, to, start, end, func(diff storage.KeyDiff) error {
+				if format != nil {
+					if s, ok := format(storeKey, diff.Key, diff.OldValue, diff.NewValue); ok {
+						cmd.Println(diff.Type, s)
+						return nil
+					}
+				}
+				cmd.Println(diff.Type, "key:", hex.EncodeToString(diff.Key),
+					"old:", hex.EncodeToString(diff.OldValue), "new:", hex.EncodeToString(diff.NewValue))
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagSSBackend, ssBackendPebbleDB, "the SS database backend (pebbledb|sqlite|rocksdb)")
+	cmd.Flags().String(flagStart, "", "the first key of the diff, as hex")
+	cmd.Flags().String(flagEnd, "", "the key the diff ends before, as hex")
+
+	return cmd
+}
+
+// getHexFlag returns the hex decoded value of the given flag, or nil if unset.
+func getHexFlag(cmd *cobra.Command, flag string) ([]byte, error) {
+	s, err := cmd.Flags().GetString(flag)
+	if err != nil || s == "" {
+		return nil, err
+	}
+	return hex.DecodeString(s)
+}
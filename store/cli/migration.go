@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/migration"
+)
+
+const (
+	flagDBBackend = "db-backend"
+	flagDBName    = "db-name"
+)
+
+// MigrationCmd returns the store/v1 to store/v2 migration group command.
+func MigrationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migration",
+		Short: "Inspect the store/v1 to store/v2 migration",
+	}
+	cmd.AddCommand(
+		MigrationStatusCmd(),
+	)
+	return cmd
+}
+
+// MigrationStatusCmd returns the command to report the status of a migration
+// from the checkpoints recorded in its migration db.
+func MigrationStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status <db-dir>",
+		Short: "Report the status of the migration recorded in the migration db",
+		Long: `Report the status of the migration recorded in the migration db: the height being
+migrated, whether it completed and was verified, and per store whether its SC tree was
+restored and the last key restored to SS.
+
+The migration db must not be opened by a running node.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend, err := cmd.Flags().GetString(flagDBBackend)
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString(flagDBName)
+			if err != nil {
+				return err
+			}
+
+			db, err := dbm.NewRawDB(dbm.RawDBType(backend), name, args[0], nil)
+			if err != nil {
+				return fmt.Errorf("failed to open migration db: %w", err)
+			}
+			defer db.Close()
+
+			status, err := migration.LoadStatus(db)
+			if err != nil {
+				return err
+			}
+			printMigrationStatus(cmd, status)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagDBBackend, string(dbm.DBTypeGoLevelDB), "the database backend of the migration db")
+	cmd.Flags().String(flagDBName, "migration", "the name of the migration db")
+
+	return cmd
+}
+
+func printMigrationStatus(cmd *cobra.Command, status migration.Status) {
+	if status.Height == 0 {
+		cmd.Println("no migration was started")
+		return
+	}
+
+	cmd.Println("height:", status.Height, "completed:", status.Completed, "verification:", status.Verification)
+	for _, s := range status.Stores {
+		cmd.Println("store:", s.StoreKey, "tree_restored:", s.TreeRestored, "restored_key:", hex.EncodeToString(s.RestoredKey))
+	}
+}
@@ -0,0 +1,48 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/v2/cli"
+	dbm "cosmossdk.io/store/v2/db"
+)
+
+func TestMigrationStatusCmd(t *testing.T) {
+	dir := t.TempDir()
+
+	// the checkpoints of a migration interrupted at height 7, once the tree of
+	// store1 was restored and halfway through restoring store2 to SS
+	db, err := dbm.NewRawDB(dbm.DBTypeGoLevelDB, "migration", dir, nil)
+	require.NoError(t, err)
+	height := make([]byte, 8)
+	binary.BigEndian.PutUint64(height, 7)
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("m/ck/height"), height))
+	require.NoError(t, batch.Set([]byte("m/ck/sc/store1"), []byte{1}))
+	require.NoError(t, batch.Set([]byte("m/ck/ss/store1"), []byte("key-9")))
+	require.NoError(t, batch.Set([]byte("m/ck/ss/store2"), []byte("key-4")))
+	require.NoError(t, batch.WriteSync())
+	require.NoError(t, batch.Close())
+	require.NoError(t, db.Close())
+
+	var out bytes.Buffer
+	cmd := cli.MigrationCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"status", dir})
+	require.NoError(t, cmd.Execute())
+
+	require.Equal(t, `height: 7 completed: false verification: pending
+store: store1 tree_restored: true restored_key: 6b65792d39
+store: store2 tree_restored: false restored_key: 6b65792d34
+`, out.String())
+
+	// a db without checkpoints reports no migration
+	out.Reset()
+	cmd.SetArgs([]string{"status", dir, "--db-name", "empty"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "no migration was started\n", out.String())
+}
@@ -0,0 +1,13 @@
+//go:build rocksdb
+// +build rocksdb
+
+package cli
+
+import (
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/rocksdb"
+)
+
+func newRocksDB(dataDir string) (storage.Database, error) {
+	return rocksdb.New(dataDir)
+}
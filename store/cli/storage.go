@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/pebbledb"
+	"cosmossdk.io/store/v2/storage/sqlite"
+)
+
+const (
+	flagSSBackend = "ss-backend"
+
+	ssBackendPebbleDB = "pebbledb"
+	ssBackendSQLite   = "sqlite"
+	ssBackendRocksDB  = "rocksdb"
+)
+
+// openStorage opens the SS database of the given backend in dataDir.
+func openStorage(backend, dataDir string) (*storage.StorageStore, error) {
+	var (
+		db  storage.Database
+		err error
+	)
+	switch backend {
+	case ssBackendPebbleDB:
+		db, err = pebbledb.New(dataDir)
+	case ssBackendSQLite:
+		db, err = sqlite.New(dataDir)
+	case ssBackendRocksDB:
+		db, err = newRocksDB(dataDir)
+	default:
+		return nil, fmt.Errorf("unsupported SS backend: %s", backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SS database: %w", err)
+	}
+
+	return storage.NewStorageStore(db, nil, log.NewNopLogger()), nil
+}
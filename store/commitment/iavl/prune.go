@@ -0,0 +1,67 @@
+package iavl
+
+import (
+	"sync/atomic"
+
+	idb "github.com/cosmos/iavl/db"
+
+	"cosmossdk.io/store/v2"
+	dbm "cosmossdk.io/store/v2/db"
+)
+
+// pruneCountingDB wraps the database of a tree to count the keys and bytes
+// deleted by its batches while counting is enabled, which is only the case
+// while the tree is pruned by PruneCounted.
+type pruneCountingDB struct {
+	*dbm.Wrapper
+
+	counting atomic.Bool
+	keys     atomic.Int64
+	bytes    atomic.Int64
+}
+
+func newPruneCountingDB(db store.RawDB) *pruneCountingDB {
+	return &pruneCountingDB{Wrapper: dbm.NewWrapper(db)}
+}
+
+// NewBatch implements iavl.DB.
+func (db *pruneCountingDB) NewBatch() idb.Batch {
+	return &pruneCountingBatch{Batch: db.Wrapper.NewBatch(), db: db}
+}
+
+// NewBatchWithSize implements iavl.DB.
+func (db *pruneCountingDB) NewBatchWithSize(size int) idb.Batch {
+	return &pruneCountingBatch{Batch: db.Wrapper.NewBatchWithSize(size), db: db}
+}
+
+// pruneCountingBatch is a batch counting its deletes on its pruneCountingDB.
+type pruneCountingBatch struct {
+	idb.Batch
+	db *pruneCountingDB
+}
+
+// Delete implements iavl.Batch. The value of a key is read before it is
+// deleted while counting, so that the bytes of the node are charged as well.
+func (b *pruneCountingBatch) Delete(key []byte) error {
+	if b.db.counting.Load() {
+		value, err := b.db.Get(key)
+		if err != nil {
+			return err
+		}
+		b.db.keys.Add(1)
+		b.db.bytes.Add(int64(len(key) + len(value)))
+	}
+
+	return b.Batch.Delete(key)
+}
+
+// PruneCounted implements commitment.PruneCounter.
+func (t *IavlTree) PruneCounted(version uint64) (keys, bytes int, err error) {
+	t.pruneDB.keys.Store(0)
+	t.pruneDB.bytes.Store(0)
+	t.pruneDB.counting.Store(true)
+	defer t.pruneDB.counting.Store(false)
+
+	err = t.Prune(version)
+	return int(t.pruneDB.keys.Load()), int(t.pruneDB.bytes.Load()), err
+}
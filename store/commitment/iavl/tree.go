@@ -6,27 +6,46 @@ import (
 	"github.com/cosmos/iavl"
 	ics23 "github.com/cosmos/ics23/go"
 
+	corestore "cosmossdk.io/core/store"
 	log "cosmossdk.io/log"
 	"cosmossdk.io/store/v2"
 	"cosmossdk.io/store/v2/commitment"
-	dbm "cosmossdk.io/store/v2/db"
 )
 
-var _ commitment.Tree = (*IavlTree)(nil)
+var (
+	_ commitment.Tree         = (*IavlTree)(nil)
+	_ commitment.Resetter     = (*IavlTree)(nil)
+	_ store.Secondary         = (*IavlTree)(nil)
+	_ commitment.PruneCounter = (*IavlTree)(nil)
+)
 
 // IavlTree is a wrapper around iavl.MutableTree.
 type IavlTree struct {
 	tree *iavl.MutableTree
+
+	db store.RawDB
+	// pruneDB is the database of the tree, counting the deletes of pruning
+	pruneDB *pruneCountingDB
+	logger  log.Logger
+	cfg     *Config
 }
 
 // NewIavlTree creates a new IavlTree instance.
 func NewIavlTree(db store.RawDB, logger log.Logger, cfg *Config) *IavlTree {
-	tree := iavl.NewMutableTree(dbm.NewWrapper(db), cfg.CacheSize, cfg.SkipFastStorageUpgrade, logger)
+	pruneDB := newPruneCountingDB(db)
 	return &IavlTree{
-		tree: tree,
+		tree:    newMutableTree(pruneDB, logger, cfg),
+		db:      db,
+		pruneDB: pruneDB,
+		logger:  logger,
+		cfg:     cfg,
 	}
 }
 
+func newMutableTree(db *pruneCountingDB, logger log.Logger, cfg *Config) *iavl.MutableTree {
+	return iavl.NewMutableTree(db, cfg.CacheSize, cfg.SkipFastStorageUpgrade, logger)
+}
+
 // Remove removes the given key from the tree.
 func (t *IavlTree) Remove(key []byte) error {
 	_, res, err := t.tree.Remove(key)
@@ -76,6 +95,17 @@ func (t *IavlTree) GetProof(version uint64, key []byte) (*ics23.CommitmentProof,
 	return immutableTree.GetProof(key)
 }
 
+// Iterator returns an iterator over the given domain of the tree at the given
+// version.
+func (t *IavlTree) Iterator(version uint64, start, end []byte, ascending bool) (corestore.Iterator, error) {
+	immutableTree, err := t.tree.GetImmutable(int64(version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get immutable tree at version %d: %w", version, err)
+	}
+
+	return immutableTree.Iterator(start, end, ascending)
+}
+
 func (t *IavlTree) Get(version uint64, key []byte) ([]byte, error) {
 	immutableTree, err := t.tree.GetImmutable(int64(version))
 	if err != nil {
@@ -85,6 +115,36 @@ func (t *IavlTree) Get(version uint64, key []byte) ([]byte, error) {
 	return immutableTree.Get(key)
 }
 
+// GetChangeset returns the key/value changes the given version made to the tree.
+func (t *IavlTree) GetChangeset(version uint64) (corestore.KVPairs, error) {
+	immutableTree, err := t.tree.GetImmutable(int64(version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get immutable tree at version %d: %w", version, err)
+	}
+	// the changes are extracted by diffing against the previous version, which
+	// would silently yield the whole tree if that version was pruned
+	if version > 1 && !t.tree.VersionExists(int64(version-1)) {
+		return nil, fmt.Errorf("previous version %d does not exist", version-1)
+	}
+
+	var pairs corestore.KVPairs
+	err = immutableTree.TraverseStateChanges(int64(version), int64(version), func(_ int64, cs *iavl.ChangeSet) error {
+		for _, pair := range cs.Pairs {
+			pairs = append(pairs, corestore.KVPair{
+				Key:    pair.Key,
+				Value:  pair.Value,
+				Remove: pair.Delete,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
 // GetLatestVersion returns the latest version of the tree.
 func (t *IavlTree) GetLatestVersion() uint64 {
 	return uint64(t.tree.Version())
@@ -129,6 +189,52 @@ func (t *IavlTree) Import(version uint64) (commitment.Importer, error) {
 	}, nil
 }
 
+// Reset implements commitment.Resetter. It deletes all keys of the tree's
+// database, and reopens the tree on the now empty database.
+func (t *IavlTree) Reset() error {
+	itr, err := t.db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		if err := batch.Delete(itr.Key()); err != nil {
+			_ = itr.Close()
+			return err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		_ = itr.Close()
+		return err
+	}
+	if err := itr.Close(); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	t.tree = newMutableTree(t.pruneDB, t.logger, t.cfg)
+	return nil
+}
+
+// TryCatchUpWithPrimary implements store.Secondary. It catches up the tree's
+// database if it is a secondary instance, and reopens the tree on it, dropping
+// the nodes and versions cached from before.
+func (t *IavlTree) TryCatchUpWithPrimary() error {
+	if secondary, ok := t.db.(store.Secondary); ok {
+		if err := secondary.TryCatchUpWithPrimary(); err != nil {
+			return err
+		}
+	}
+
+	t.tree = newMutableTree(t.pruneDB, t.logger, t.cfg)
+	return nil
+}
+
 // Close closes the iavl tree.
 func (t *IavlTree) Close() error {
 	return t.tree.Close()
@@ -1,6 +1,8 @@
 package iavl
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -110,3 +112,50 @@ func TestIavlTree(t *testing.T) {
 	// close the db
 	require.NoError(t, tree.Close())
 }
+
+func TestIavlTree_Reset(t *testing.T) {
+	// the importer flushes its nodes every 10000 nodes
+	source := generateTree()
+	for i := 0; i < 10_000; i++ {
+		require.NoError(t, source.Set([]byte(fmt.Sprintf("key%05d", i)), []byte("value")))
+	}
+	hash, version, err := source.Commit()
+	require.NoError(t, err)
+
+	importAll := func(tree *IavlTree, limit int) error {
+		exporter, err := source.Export(version)
+		require.NoError(t, err)
+		defer exporter.Close()
+
+		importer, err := tree.Import(version)
+		if err != nil {
+			return err
+		}
+		defer importer.Close()
+
+		for i := 0; i != limit; i++ {
+			item, err := exporter.Next()
+			if errors.Is(err, commitment.ErrorExportDone) {
+				return importer.Commit()
+			}
+			require.NoError(t, err)
+			require.NoError(t, importer.Add(item))
+		}
+		return nil
+	}
+
+	// interrupt an import after its nodes were partially flushed
+	db := dbm.NewMemDB()
+	tree := NewIavlTree(db, log.NewNopLogger(), DefaultConfig())
+	require.NoError(t, importAll(tree, 15_000))
+
+	// the flushed nodes keep a reopened tree from being loaded
+	tree = NewIavlTree(db, log.NewNopLogger(), DefaultConfig())
+	require.Error(t, tree.LoadVersion(0))
+
+	require.NoError(t, tree.Reset())
+	require.NoError(t, tree.LoadVersion(0))
+	require.NoError(t, importAll(tree, -1))
+	require.NoError(t, tree.LoadVersion(version))
+	require.Equal(t, hash, tree.Hash())
+}
@@ -2,19 +2,28 @@ package commitment
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
 
 	protoio "github.com/cosmos/gogoproto/io"
+	ics23 "github.com/cosmos/ics23/go"
+	"golang.org/x/sync/errgroup"
 
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/v2"
+	storeerrors "cosmossdk.io/store/v2/errors"
 	internal "cosmossdk.io/store/v2/internal/conv"
 	"cosmossdk.io/store/v2/internal/encoding"
 	"cosmossdk.io/store/v2/proof"
+	"cosmossdk.io/store/v2/pruning"
 	"cosmossdk.io/store/v2/snapshots"
 	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
 )
@@ -22,11 +31,18 @@ import (
 const (
 	commitInfoKeyFmt = "c/%d" // c/<version>
 	latestVersionKey = "c/latest"
+
+	// restoreNodeBufferSize is the number of snapshot nodes buffered for each
+	// tree being imported by Restore.
+	restoreNodeBufferSize = 1024
 )
 
 var (
-	_ store.Committer             = (*CommitStore)(nil)
-	_ snapshots.CommitSnapshotter = (*CommitStore)(nil)
+	_ store.Committer                  = (*CommitStore)(nil)
+	_ snapshots.CommitSnapshotter      = (*CommitStore)(nil)
+	_ snapshots.CommitDeltaSnapshotter = (*CommitStore)(nil)
+	_ pruning.Pruner                   = (*CommitStore)(nil)
+	_ store.Secondary                  = (*CommitStore)(nil)
 )
 
 // CommitStore is a wrapper around multiple Tree objects mapped by a unique store
@@ -39,6 +55,11 @@ type CommitStore struct {
 	db         store.RawDB
 	multiTrees map[string]Tree
 
+	// treeMtx serializes the writes and commits of the trees with their
+	// background pruning by PruneWithLimiter, as an IAVL tree shares a single
+	// write batch between saving and deleting versions.
+	treeMtx sync.Mutex
+
 	// pruneOptions is the pruning configuration.
 	pruneOptions *store.PruneOptions
 }
@@ -58,6 +79,9 @@ func NewCommitStore(trees map[string]Tree, db store.RawDB, pruneOpts *store.Prun
 }
 
 func (c *CommitStore) WriteBatch(cs *corestore.Changeset) error {
+	c.treeMtx.Lock()
+	defer c.treeMtx.Unlock()
+
 	for _, pairs := range cs.Changes {
 
 		key := internal.UnsafeBytesToStr(pairs.Actor)
@@ -81,6 +105,9 @@ func (c *CommitStore) WriteBatch(cs *corestore.Changeset) error {
 }
 
 func (c *CommitStore) WorkingCommitInfo(version uint64) *proof.CommitInfo {
+	c.treeMtx.Lock()
+	defer c.treeMtx.Unlock()
+
 	storeInfos := make([]proof.StoreInfo, 0, len(c.multiTrees))
 	for storeKey, tree := range c.multiTrees {
 		bz := []byte(storeKey)
@@ -195,6 +222,9 @@ func (c *CommitStore) flushCommitInfo(version uint64, cInfo *proof.CommitInfo) e
 }
 
 func (c *CommitStore) Commit(version uint64) (*proof.CommitInfo, error) {
+	c.treeMtx.Lock()
+	defer c.treeMtx.Unlock()
+
 	storeInfos := make([]proof.StoreInfo, 0, len(c.multiTrees))
 
 	for storeKey, tree := range c.multiTrees {
@@ -232,7 +262,7 @@ func (c *CommitStore) Commit(version uint64) (*proof.CommitInfo, error) {
 
 	// Prune the old versions.
 	if prune, pruneVersion := c.pruneOptions.ShouldPrune(version); prune {
-		if err := c.Prune(pruneVersion); err != nil {
+		if err := c.prune(pruneVersion); err != nil {
 			c.logger.Info("failed to prune SC", "prune_version", pruneVersion, "err", err)
 		}
 	}
@@ -276,6 +306,112 @@ func (c *CommitStore) GetProof(storeKey []byte, version uint64, key []byte) ([]p
 	return []proof.CommitmentOp{commitOp, *storeCommitmentOp}, nil
 }
 
+// GetRangeProof returns a proof of the complete set of key/value pairs within
+// the domain [start, end) of the given store at the given version. If the domain
+// holds more than limit pairs, the proof covers the first limit pairs and its
+// domain ends before the next key, which is proven as the right neighbor.
+func (c *CommitStore) GetRangeProof(storeKey []byte, version uint64, start, end []byte, limit int) (*proof.RangeProof, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid range proof limit %d", limit)
+	}
+
+	tree, ok := c.multiTrees[internal.UnsafeBytesToStr(storeKey)]
+	if !ok {
+		return nil, fmt.Errorf("store %s not found", storeKey)
+	}
+
+	cInfo, err := c.GetCommitInfo(version)
+	if err != nil {
+		return nil, err
+	}
+	if cInfo == nil {
+		return nil, fmt.Errorf("commit info not found for version %d", version)
+	}
+
+	keys, err := rangeKeys(tree, version, start, end, true, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > limit {
+		end = keys[limit]
+		keys = keys[:limit]
+	}
+
+	rp := proof.NewIAVLRangeProof(start, end)
+	_, rp.StoreProof, err = cInfo.GetStoreProof(storeKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		exist, err := existenceProof(tree, version, key)
+		if err != nil {
+			return nil, err
+		}
+		rp.Entries = append(rp.Entries, exist)
+	}
+
+	// the neighbors just outside of the domain prove that no key was omitted
+	if start != nil {
+		left, err := rangeKeys(tree, version, nil, start, false, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(left) > 0 {
+			if rp.Left, err = existenceProof(tree, version, left[0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if end != nil {
+		right, err := rangeKeys(tree, version, end, nil, true, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(right) > 0 {
+			if rp.Right, err = existenceProof(tree, version, right[0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return rp, nil
+}
+
+// rangeKeys returns the keys of the tree within the given domain, up to limit
+// keys if limit is non-zero.
+func rangeKeys(tree Tree, version uint64, start, end []byte, ascending bool, limit int) (keys [][]byte, err error) {
+	itr, err := tree.Iterator(version, start, end, ascending)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, itr.Close())
+	}()
+
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, bytes.Clone(itr.Key()))
+		if limit > 0 && len(keys) == limit {
+			break
+		}
+	}
+
+	return keys, itr.Error()
+}
+
+func existenceProof(tree Tree, version uint64, key []byte) (*ics23.ExistenceProof, error) {
+	cp, err := tree.GetProof(version, key)
+	if err != nil {
+		return nil, err
+	}
+
+	exist := cp.GetExist()
+	if exist == nil {
+		return nil, fmt.Errorf("expected existence proof for key %X", key)
+	}
+
+	return exist, nil
+}
+
 func (c *CommitStore) Get(storeKey []byte, version uint64, key []byte) ([]byte, error) {
 	tree, ok := c.multiTrees[internal.UnsafeBytesToStr(storeKey)]
 	if !ok {
@@ -290,7 +426,42 @@ func (c *CommitStore) Get(storeKey []byte, version uint64, key []byte) ([]byte,
 	return bz, nil
 }
 
-func (c *CommitStore) Prune(version uint64) (ferr error) {
+// ChangedKeys returns the keys of the given store which were set or removed by
+// the versions in (from, to], or in (to, from] if to is the lower version, in
+// ascending order and without duplicates. It errors if the changeset of one of
+// these versions cannot be extracted, e.g. because it was pruned.
+func (c *CommitStore) ChangedKeys(storeKey []byte, from, to uint64) ([][]byte, error) {
+	tree, ok := c.multiTrees[internal.UnsafeBytesToStr(storeKey)]
+	if !ok {
+		return nil, fmt.Errorf("store %s not found", storeKey)
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	var keys [][]byte
+	for v := from + 1; v <= to; v++ {
+		pairs, err := tree.GetChangeset(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get changeset of store %s for version %d: %w", storeKey, v, err)
+		}
+		for _, pair := range pairs {
+			keys = append(keys, pair.Key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	return slices.CompactFunc(keys, bytes.Equal), nil
+}
+
+func (c *CommitStore) Prune(version uint64) error {
+	c.treeMtx.Lock()
+	defer c.treeMtx.Unlock()
+
+	return c.prune(version)
+}
+
+func (c *CommitStore) prune(version uint64) (ferr error) {
 	// prune the metadata
 	batch := c.db.NewBatch()
 	for v := version; v > 0; v-- {
@@ -315,6 +486,81 @@ func (c *CommitStore) Prune(version uint64) (ferr error) {
 	return ferr
 }
 
+// PruneWithLimiter implements pruning.Pruner. Unlike Prune, it prunes one
+// version at a time, starting from the earliest version with commit info, so
+// it can be interrupted in between versions. Each version is pruned while
+// holding the trees from being written or committed, and the keys and bytes
+// the trees report to have deleted are charged to the Limiter.
+func (c *CommitStore) PruneWithLimiter(ctx context.Context, version uint64, limiter *pruning.Limiter) error {
+	earliest := version + 1
+	for v := version; v > 0; v-- {
+		exist, err := c.db.Has([]byte(fmt.Sprintf(commitInfoKeyFmt, v)))
+		if err != nil {
+			return err
+		}
+		if !exist {
+			break
+		}
+		earliest = v
+	}
+
+	for v := earliest; v <= version; v++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the trees are pruned before the commit info, so an interrupted version
+		// is pruned again once resumed
+		keys, bytes, err := c.pruneTrees(v)
+		if err != nil {
+			return fmt.Errorf("failed to prune version %d: %w", v, err)
+		}
+
+		cInfoKey := []byte(fmt.Sprintf(commitInfoKeyFmt, v))
+		batch := c.db.NewBatch()
+		if err := batch.Delete(cInfoKey); err != nil {
+			return errors.Join(err, batch.Close())
+		}
+		if err := batch.WriteSync(); err != nil {
+			return errors.Join(err, batch.Close())
+		}
+		if err := batch.Close(); err != nil {
+			return err
+		}
+
+		if err := limiter.Wait(ctx, keys+1, bytes+len(cInfoKey)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneTrees prunes the given version of the trees, and returns the number of
+// keys and bytes deleted by the trees implementing PruneCounter.
+func (c *CommitStore) pruneTrees(version uint64) (keys, bytes int, err error) {
+	c.treeMtx.Lock()
+	defer c.treeMtx.Unlock()
+
+	for _, tree := range c.multiTrees {
+		counter, ok := tree.(PruneCounter)
+		if !ok {
+			if err := tree.Prune(version); err != nil {
+				return keys, bytes, err
+			}
+			continue
+		}
+
+		k, b, err := counter.PruneCounted(version)
+		keys, bytes = keys+k, bytes+b
+		if err != nil {
+			return keys, bytes, err
+		}
+	}
+
+	return keys, bytes, nil
+}
+
 // Snapshot implements snapshotstypes.CommitSnapshotter.
 func (c *CommitStore) Snapshot(version uint64, protoWriter protoio.Writer) error {
 	if version == 0 {
@@ -375,14 +621,62 @@ func (c *CommitStore) Snapshot(version uint64, protoWriter protoio.Writer) error
 	return nil
 }
 
-// Restore implements snapshotstypes.CommitSnapshotter.
+// RestoreCheckpointer records the trees restored by
+// CommitStore.RestoreWithCheckpoints, so an interrupted restore can be resumed.
+type RestoreCheckpointer interface {
+	// IsTreeRestored returns true if the tree of the given store was restored
+	// completely.
+	IsTreeRestored(storeKey string) (bool, error)
+	// SetTreeRestored records that the tree of the given store was restored
+	// completely.
+	SetTreeRestored(storeKey string) error
+}
+
+// Restore implements snapshotstypes.CommitSnapshotter. The trees of the
+// stores are imported concurrently: once the snapshot stream moves on to the
+// next store, the remaining nodes of the previous store are still being added
+// by an importer goroutine of its own. At most GOMAXPROCS trees are imported
+// at the same time.
 func (c *CommitStore) Restore(version uint64, format uint32, protoReader protoio.Reader, chStorage chan<- *corestore.StateChanges) (snapshotstypes.SnapshotItem, error) {
+	return c.RestoreWithCheckpoints(version, format, protoReader, chStorage, nil)
+}
+
+// RestoreWithCheckpoints is like Restore, but records every tree it restored
+// with the given RestoreCheckpointer. The trees restored by an earlier run are
+// not imported again, although their leaves are still passed to chStorage, and
+// the trees it left incomplete are reset before being imported again.
+func (c *CommitStore) RestoreWithCheckpoints(
+	version uint64,
+	format uint32,
+	protoReader protoio.Reader,
+	chStorage chan<- *corestore.StateChanges,
+	ckpt RestoreCheckpointer,
+) (snapshotstypes.SnapshotItem, error) {
 	var (
-		importer     Importer
 		snapshotItem snapshotstypes.SnapshotItem
-		storeKey     string
+		nodes        chan *snapshotstypes.SnapshotIAVLItem
 	)
 
+	eg, ctx := errgroup.WithContext(context.Background())
+	eg.SetLimit(runtime.GOMAXPROCS(0))
+
+	// abort keeps the importer of the current store from committing its
+	// incomplete tree
+	abort := func() {}
+
+	// fail stops all importers and returns the given error, unless an importer
+	// failed first.
+	fail := func(err error) (snapshotstypes.SnapshotItem, error) {
+		abort()
+		if nodes != nil {
+			close(nodes)
+		}
+		if egErr := eg.Wait(); egErr != nil && !errors.Is(egErr, context.Canceled) {
+			return snapshotstypes.SnapshotItem{}, egErr
+		}
+		return snapshotstypes.SnapshotItem{}, err
+	}
+
 loop:
 	for {
 		snapshotItem = snapshotstypes.SnapshotItem{}
@@ -390,77 +684,349 @@ loop:
 		if errors.Is(err, io.EOF) {
 			break
 		} else if err != nil {
-			return snapshotstypes.SnapshotItem{}, fmt.Errorf("invalid protobuf message: %w", err)
+			return fail(fmt.Errorf("invalid protobuf message: %w", err))
 		}
 
 		switch item := snapshotItem.Item.(type) {
 		case *snapshotstypes.SnapshotItem_Store:
-			if importer != nil {
-				if err := importer.Commit(); err != nil {
-					return snapshotstypes.SnapshotItem{}, fmt.Errorf("failed to commit importer: %w", err)
-				}
-				importer.Close()
+			if nodes != nil {
+				close(nodes)
+				nodes = nil
 			}
 
-			storeKey = item.Store.Name
-			tree := c.multiTrees[item.Store.Name]
+			storeKey := item.Store.Name
+			tree := c.multiTrees[storeKey]
 			if tree == nil {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("store %s not found", storeKey)
+				return fail(fmt.Errorf("store %s not found", storeKey))
 			}
-			importer, err = tree.Import(version)
+			importer, restored, err := treeImporter(tree, storeKey, version, ckpt)
 			if err != nil {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("failed to import tree for version %d: %w", version, err)
+				return fail(fmt.Errorf("failed to import tree for version %d: %w", version, err))
 			}
-			defer importer.Close()
+
+			storeNodes := make(chan *snapshotstypes.SnapshotIAVLItem, restoreNodeBufferSize)
+			nodes = storeNodes
+			storeCtx, cancel := context.WithCancel(ctx)
+			abort = cancel
+			eg.Go(func() error {
+				if err := importTree(storeCtx, importer, []byte(storeKey), storeNodes, chStorage); err != nil {
+					return err
+				}
+				if ckpt == nil || restored {
+					return nil
+				}
+				return ckpt.SetTreeRestored(storeKey)
+			})
 
 		case *snapshotstypes.SnapshotItem_IAVL:
-			if importer == nil {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received IAVL node item before store item")
+			if nodes == nil {
+				return fail(fmt.Errorf("received IAVL node item before store item"))
 			}
 			node := item.IAVL
 			if node.Height > int32(math.MaxInt8) {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("node height %v cannot exceed %v",
-					item.IAVL.Height, math.MaxInt8)
+				return fail(fmt.Errorf("node height %v cannot exceed %v", item.IAVL.Height, math.MaxInt8))
 			}
-			// Protobuf does not differentiate between []byte{} and nil, but fortunately IAVL does
-			// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
-			if node.Key == nil {
-				node.Key = []byte{}
+
+			select {
+			case nodes <- node:
+			case <-ctx.Done():
+				// an importer failed, and fail returns its error
+				return fail(ctx.Err())
 			}
-			if node.Height == 0 {
-				if node.Value == nil {
-					node.Value = []byte{}
-				}
 
-				key := []byte(storeKey)
-				// If the node is a leaf node, it will be written to the storage.
-				chStorage <- &corestore.StateChanges{
-					Actor: key,
-					StateChanges: []corestore.KVPair{
-						{
-							Key:    node.Key,
-							Value:  node.Value,
-							Remove: false,
+		default:
+			break loop
+		}
+	}
+
+	if nodes != nil {
+		close(nodes)
+	}
+	if err := eg.Wait(); err != nil {
+		return snapshotstypes.SnapshotItem{}, err
+	}
+
+	return snapshotItem, c.LoadVersion(version)
+}
+
+// treeImporter returns the importer of the given tree. With a checkpointer, a
+// tree restored by an earlier run is skipped, which is reported by restored,
+// while any other tree is reset first.
+func treeImporter(tree Tree, storeKey string, version uint64, ckpt RestoreCheckpointer) (importer Importer, restored bool, err error) {
+	if ckpt != nil {
+		restored, err := ckpt.IsTreeRestored(storeKey)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get restore checkpoint: %w", err)
+		}
+		if restored {
+			return nopImporter{}, true, nil
+		}
+		if r, ok := tree.(Resetter); ok {
+			if err := r.Reset(); err != nil {
+				return nil, false, fmt.Errorf("failed to reset tree: %w", err)
+			}
+		}
+	}
+
+	importer, err = tree.Import(version)
+	return importer, false, err
+}
+
+// nopImporter discards the nodes of a tree which is already restored.
+type nopImporter struct{}
+
+func (nopImporter) Add(*snapshotstypes.SnapshotIAVLItem) error { return nil }
+func (nopImporter) Commit() error                              { return nil }
+func (nopImporter) Close() error                               { return nil }
+
+// importTree adds the given nodes to the importer of a single store, and
+// commits the imported tree once the channel is closed, unless ctx is done. The
+// leaf nodes are also passed to the storage snapshotter.
+func importTree(
+	ctx context.Context,
+	importer Importer,
+	storeKey []byte,
+	nodes <-chan *snapshotstypes.SnapshotIAVLItem,
+	chStorage chan<- *corestore.StateChanges,
+) error {
+	defer importer.Close()
+
+	for node := range nodes {
+		// Protobuf does not differentiate between []byte{} and nil, but fortunately IAVL does
+		// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
+		if node.Key == nil {
+			node.Key = []byte{}
+		}
+		if node.Height == 0 {
+			if node.Value == nil {
+				node.Value = []byte{}
+			}
+
+			// If the node is a leaf node, it will be written to the storage.
+			chStorage <- &corestore.StateChanges{
+				Actor: storeKey,
+				StateChanges: []corestore.KVPair{
+					{
+						Key:    node.Key,
+						Value:  node.Value,
+						Remove: false,
+					},
+				},
+			}
+		}
+		if err := importer.Add(node); err != nil {
+			return fmt.Errorf("failed to add node to importer: %w", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := importer.Commit(); err != nil {
+		return fmt.Errorf("failed to commit importer: %w", err)
+	}
+
+	return nil
+}
+
+// SnapshotDelta implements snapshots.CommitDeltaSnapshotter. For every
+// version in (base, version], it writes a version marker followed by the changes
+// of every store changed in that version, with stores in ascending key order.
+func (c *CommitStore) SnapshotDelta(base, version uint64, protoWriter protoio.Writer) error {
+	if base == 0 || base >= version {
+		return fmt.Errorf("the delta base version %d must be in [1, %d)", base, version)
+	}
+
+	latestVersion, err := c.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version > latestVersion {
+		return fmt.Errorf("the snapshot version %d is greater than the latest version %d", version, latestVersion)
+	}
+
+	storeKeys := make([]string, 0, len(c.multiTrees))
+	for storeKey := range c.multiTrees {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	sort.Strings(storeKeys)
+
+	for v := base + 1; v <= version; v++ {
+		err := protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+			Item: &snapshotstypes.SnapshotItem_DeltaVersion{
+				DeltaVersion: &snapshotstypes.SnapshotDeltaVersion{
+					Version: int64(v),
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write delta version: %w", err)
+		}
+
+		for _, storeKey := range storeKeys {
+			pairs, err := c.multiTrees[storeKey].GetChangeset(v)
+			if err != nil {
+				return fmt.Errorf("failed to get changeset of store %s for version %d: %w", storeKey, v, err)
+			}
+			if len(pairs) == 0 {
+				continue
+			}
+
+			err = protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+				Item: &snapshotstypes.SnapshotItem_Store{
+					Store: &snapshotstypes.SnapshotStoreItem{
+						Name: storeKey,
+					},
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to write store name: %w", err)
+			}
+
+			for _, pair := range pairs {
+				err = protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+					Item: &snapshotstypes.SnapshotItem_Change{
+						Change: &snapshotstypes.SnapshotChangeItem{
+							Key:    pair.Key,
+							Value:  pair.Value,
+							Delete: pair.Remove,
 						},
 					},
+				})
+				if err != nil {
+					return fmt.Errorf("failed to write change item: %w", err)
 				}
 			}
-			err := importer.Add(node)
-			if err != nil {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("failed to add node to importer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RestoreDelta implements snapshots.CommitDeltaSnapshotter. The changes of
+// each version are written and committed in the order they were snapshotted,
+// which reproduces the trees of the snapshotted versions.
+func (c *CommitStore) RestoreDelta(
+	base, version uint64,
+	protoReader protoio.Reader,
+	apply func(version uint64, cs *corestore.Changeset) error,
+) (snapshotstypes.SnapshotItem, error) {
+	latestVersion, err := c.GetLatestVersion()
+	if err != nil {
+		return snapshotstypes.SnapshotItem{}, err
+	}
+	if latestVersion != base {
+		return snapshotstypes.SnapshotItem{}, fmt.Errorf("the delta base version %d does not match the latest version %d", base, latestVersion)
+	}
+
+	var (
+		snapshotItem snapshotstypes.SnapshotItem
+		cs           *corestore.Changeset
+		storeKey     []byte
+		current      = base
+	)
+
+	commit := func() error {
+		if cs == nil {
+			return nil
+		}
+		if err := c.WriteBatch(cs); err != nil {
+			return fmt.Errorf("failed to write changeset for version %d: %w", current, err)
+		}
+		if _, err := c.Commit(current); err != nil {
+			return fmt.Errorf("failed to commit version %d: %w", current, err)
+		}
+
+		return apply(current, cs)
+	}
+
+loop:
+	for {
+		snapshotItem = snapshotstypes.SnapshotItem{}
+		err := protoReader.ReadMsg(&snapshotItem)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return snapshotstypes.SnapshotItem{}, fmt.Errorf("invalid protobuf message: %w", err)
+		}
+
+		switch item := snapshotItem.Item.(type) {
+		case *snapshotstypes.SnapshotItem_DeltaVersion:
+			if err := commit(); err != nil {
+				return snapshotstypes.SnapshotItem{}, err
+			}
+
+			if item.DeltaVersion.Version < 0 || uint64(item.DeltaVersion.Version) != current+1 {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("expected delta version %d, got %d", current+1, item.DeltaVersion.Version)
+			}
+			if uint64(item.DeltaVersion.Version) > version {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("delta version %d exceeds the snapshot version %d", item.DeltaVersion.Version, version)
+			}
+
+			current = uint64(item.DeltaVersion.Version)
+			cs = corestore.NewChangeset()
+			storeKey = nil
+
+		case *snapshotstypes.SnapshotItem_Store:
+			if cs == nil {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received store item before delta version item")
+			}
+			if _, ok := c.multiTrees[item.Store.Name]; !ok {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("store %s not found", item.Store.Name)
+			}
+			storeKey = []byte(item.Store.Name)
+
+		case *snapshotstypes.SnapshotItem_Change:
+			if storeKey == nil {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received change item before store item")
+			}
+			// as with IAVL nodes, protobuf does not differentiate between []byte{}
+			// and nil, while nil values are not allowed
+			value := item.Change.Value
+			if value == nil && !item.Change.Delete {
+				value = []byte{}
 			}
+			cs.Add(storeKey, item.Change.Key, value, item.Change.Delete)
+
 		default:
 			break loop
 		}
 	}
 
-	if importer != nil {
-		if err := importer.Commit(); err != nil {
-			return snapshotstypes.SnapshotItem{}, fmt.Errorf("failed to commit importer: %w", err)
+	if err := commit(); err != nil {
+		return snapshotstypes.SnapshotItem{}, err
+	}
+	if current != version {
+		return snapshotstypes.SnapshotItem{}, fmt.Errorf("delta snapshot ended at version %d, expected %d", current, version)
+	}
+
+	return snapshotItem, nil
+}
+
+// TryCatchUpWithPrimary implements store.Secondary, for a CommitStore whose
+// metadata database is a secondary instance. The metadata is caught up before
+// the trees, as the primary commits the trees before their commit info, so the
+// trees always reflect the latest version recorded by the metadata.
+func (c *CommitStore) TryCatchUpWithPrimary() error {
+	secondary, ok := c.db.(store.Secondary)
+	if !ok {
+		return fmt.Errorf("SC metadata database: %w", storeerrors.ErrSecondaryUnsupported)
+	}
+	if err := secondary.TryCatchUpWithPrimary(); err != nil {
+		return fmt.Errorf("failed to catch up SC metadata: %w", err)
+	}
+
+	for storeKey, tree := range c.multiTrees {
+		treeSecondary, ok := tree.(store.Secondary)
+		if !ok {
+			return fmt.Errorf("tree of store %s: %w", storeKey, storeerrors.ErrSecondaryUnsupported)
+		}
+		if err := treeSecondary.TryCatchUpWithPrimary(); err != nil {
+			return fmt.Errorf("failed to catch up tree of store %s: %w", storeKey, err)
 		}
 	}
 
-	return snapshotItem, c.LoadVersion(version)
+	return nil
 }
 
 func (c *CommitStore) Close() (ferr error) {
@@ -2,6 +2,7 @@ package commitment
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"sync"
@@ -12,6 +13,7 @@ import (
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/v2"
 	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/pruning"
 	"cosmossdk.io/store/v2/snapshots"
 	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
 )
@@ -123,6 +125,117 @@ func (s *CommitStoreTestSuite) TestStore_Snapshotter() {
 	}
 }
 
+func (s *CommitStoreTestSuite) TestStore_DeltaSnapshotter() {
+	storeKeys := []string{storeKey1, storeKey2}
+	commitStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, nil, log.NewNopLogger())
+	s.Require().NoError(err)
+
+	commit := func(version uint64) {
+		kvPairs := make(map[string]corestore.KVPairs)
+		for _, storeKey := range storeKeys {
+			// store2 is left untouched in odd versions after the first one
+			if storeKey == storeKey2 && version > 1 && version%2 == 1 {
+				continue
+			}
+			for j := uint64(0); j < 5; j++ {
+				key := []byte(fmt.Sprintf("key-%02d", (version+j)%20))
+				if version > 3 && j == 0 {
+					kvPairs[storeKey] = append(kvPairs[storeKey], corestore.KVPair{Key: key, Remove: true})
+					continue
+				}
+				value := []byte(fmt.Sprintf("value-%d-%d", version, j))
+				kvPairs[storeKey] = append(kvPairs[storeKey], corestore.KVPair{Key: key, Value: value})
+			}
+		}
+		s.Require().NoError(commitStore.WriteBatch(corestore.NewChangesetWithPairs(kvPairs)))
+		_, err := commitStore.Commit(version)
+		s.Require().NoError(err)
+	}
+
+	baseVersion, latestVersion := uint64(5), uint64(10)
+	for v := uint64(1); v <= baseVersion; v++ {
+		commit(v)
+	}
+
+	// restore the base version from a full snapshot
+	targetStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, nil, log.NewNopLogger())
+	s.Require().NoError(err)
+
+	chunks := make(chan io.ReadCloser, 100)
+	go func() {
+		streamWriter := snapshots.NewStreamWriter(chunks)
+		s.Require().NotNil(streamWriter)
+		defer streamWriter.Close()
+		s.Require().NoError(commitStore.Snapshot(baseVersion, streamWriter))
+	}()
+
+	streamReader, err := snapshots.NewStreamReader(chunks)
+	s.Require().NoError(err)
+	chStorage := make(chan *corestore.StateChanges, 100)
+	go func() {
+		for range chStorage {
+		}
+	}()
+	_, err = targetStore.Restore(baseVersion, snapshotstypes.CurrentFormat, streamReader, chStorage)
+	s.Require().NoError(err)
+	close(chStorage)
+
+	for v := baseVersion + 1; v <= latestVersion; v++ {
+		commit(v)
+	}
+
+	// the delta can only be applied on top of its base version
+	s.Require().Error(commitStore.SnapshotDelta(latestVersion, latestVersion, nil))
+
+	dummyExtensionItem := snapshotstypes.SnapshotItem{
+		Item: &snapshotstypes.SnapshotItem_Extension{
+			Extension: &snapshotstypes.SnapshotExtensionMeta{
+				Name:   "test",
+				Format: 1,
+			},
+		},
+	}
+
+	chunks = make(chan io.ReadCloser, 100)
+	go func() {
+		streamWriter := snapshots.NewStreamWriter(chunks)
+		s.Require().NotNil(streamWriter)
+		defer streamWriter.Close()
+		s.Require().NoError(commitStore.SnapshotDelta(baseVersion, latestVersion, streamWriter))
+		s.Require().NoError(streamWriter.WriteMsg(&dummyExtensionItem))
+	}()
+
+	streamReader, err = snapshots.NewStreamReader(chunks)
+	s.Require().NoError(err)
+
+	var applied []uint64
+	nextItem, err := targetStore.RestoreDelta(baseVersion, latestVersion, streamReader, func(version uint64, cs *corestore.Changeset) error {
+		applied = append(applied, version)
+		for _, pairs := range cs.Changes {
+			expected, err := commitStore.multiTrees[string(pairs.Actor)].GetChangeset(version)
+			s.Require().NoError(err)
+			s.Require().Equal(expected, pairs.StateChanges)
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(*dummyExtensionItem.GetExtension(), *nextItem.GetExtension())
+	s.Require().Equal([]uint64{6, 7, 8, 9, 10}, applied)
+
+	// every restored version must match the original one
+	for v := baseVersion + 1; v <= latestVersion; v++ {
+		expected, err := commitStore.GetCommitInfo(v)
+		s.Require().NoError(err)
+		actual, err := targetStore.GetCommitInfo(v)
+		s.Require().NoError(err)
+		s.Require().Equal(expected.Hash(), actual.Hash(), "version %d", v)
+	}
+
+	// a delta cannot be applied twice
+	_, err = targetStore.RestoreDelta(baseVersion, latestVersion, nil, nil)
+	s.Require().Error(err)
+}
+
 func (s *CommitStoreTestSuite) TestStore_Pruning() {
 	storeKeys := []string{storeKey1, storeKey2}
 	pruneOpts := &store.PruneOptions{
@@ -161,3 +274,68 @@ func (s *CommitStoreTestSuite) TestStore_Pruning() {
 		}
 	}
 }
+
+// TestStore_PruneWithLimiter prunes in the background while versions are
+// committed, as the pruning manager does, and checks that the work of the trees
+// is charged to the limiter.
+func (s *CommitStoreTestSuite) TestStore_PruneWithLimiter() {
+	storeKeys := []string{storeKey1, storeKey2}
+	// pruning is only done in the background
+	pruneOpts := &store.PruneOptions{}
+	commitStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, pruneOpts, log.NewNopLogger())
+	s.Require().NoError(err)
+
+	commit := func(version uint64) {
+		kvPairs := make(map[string]corestore.KVPairs)
+		for _, storeKey := range storeKeys {
+			for j := 0; j < 10; j++ {
+				// overwrite the same keys, orphaning the nodes of previous versions
+				kvPairs[storeKey] = append(kvPairs[storeKey], corestore.KVPair{
+					Key:   []byte(fmt.Sprintf("key-%d", j)),
+					Value: []byte(fmt.Sprintf("value-%d-%d", version, j)),
+				})
+			}
+		}
+		s.Require().NoError(commitStore.WriteBatch(corestore.NewChangesetWithPairs(kvPairs)))
+		_ = commitStore.WorkingCommitInfo(version)
+		_, err := commitStore.Commit(version)
+		s.Require().NoError(err)
+	}
+
+	const (
+		committed    = 100
+		pruneVersion = 90
+		latest       = 200
+	)
+	for v := uint64(1); v <= committed; v++ {
+		commit(v)
+	}
+
+	limiter := pruning.NewLimiter(0, 0)
+	pruned := make(chan error, 1)
+	go func() {
+		pruned <- commitStore.PruneWithLimiter(context.Background(), pruneVersion, limiter)
+	}()
+	for v := uint64(committed + 1); v <= latest; v++ {
+		commit(v)
+	}
+	s.Require().NoError(<-pruned)
+
+	keys, bytes := limiter.Totals()
+	// at least the commit info of each version, and the orphaned nodes
+	s.Require().Greater(keys, uint64(pruneVersion))
+	s.Require().Greater(bytes, keys)
+
+	for v := uint64(1); v <= latest; v++ {
+		commitInfo, err := commitStore.GetCommitInfo(v)
+		s.Require().NoError(err)
+		if v <= pruneVersion {
+			s.Require().Nil(commitInfo)
+		} else {
+			s.Require().NotNil(commitInfo)
+		}
+	}
+	bz, err := commitStore.Get([]byte(storeKey1), pruneVersion+1, []byte("key-0"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte(fmt.Sprintf("value-%d-0", pruneVersion+1)), bz)
+}
@@ -6,6 +6,7 @@ import (
 
 	ics23 "github.com/cosmos/ics23/go"
 
+	corestore "cosmossdk.io/core/store"
 	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
 )
 
@@ -29,12 +30,21 @@ type Tree interface {
 	SetInitialVersion(version uint64) error
 	GetProof(version uint64, key []byte) (*ics23.CommitmentProof, error)
 
+	// Iterator returns an iterator over the domain [start, end) of the tree at
+	// the given version, in ascending or descending key order.
+	Iterator(version uint64, start, end []byte, ascending bool) (corestore.Iterator, error)
+
 	// Get attempts to retrieve a value from the tree for a given version.
 	//
 	// NOTE: This method only exists to support migration from IAVL v0/v1 to v2.
 	// Once migration is complete, this method should be removed and/or not used.
 	Get(version uint64, key []byte) ([]byte, error)
 
+	// GetChangeset returns the key/value changes the given version made to the
+	// tree, in ascending key order. It requires the previous version to still
+	// exist in the tree.
+	GetChangeset(version uint64) (corestore.KVPairs, error)
+
 	Prune(version uint64) error
 	Export(version uint64) (Exporter, error)
 	Import(version uint64) (Importer, error)
@@ -42,6 +52,20 @@ type Tree interface {
 	io.Closer
 }
 
+// Resetter is an optional interface of a Tree which can discard all of its
+// state, such as the nodes left behind by an interrupted import.
+type Resetter interface {
+	Reset() error
+}
+
+// PruneCounter is an optional interface of a Tree which reports the work done
+// by pruning, so that it can be charged to a pruning.Limiter.
+type PruneCounter interface {
+	// PruneCounted prunes like Prune, and returns the number of keys and bytes
+	// deleted from the tree's database.
+	PruneCounted(version uint64) (keys, bytes int, err error)
+}
+
 // Exporter is the interface that wraps the basic Export methods.
 type Exporter interface {
 	Next() (*snapshotstypes.SnapshotIAVLItem, error)
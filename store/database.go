@@ -86,6 +86,12 @@ type Committer interface {
 	// GetProof returns the proof of existence or non-existence for the given key.
 	GetProof(storeKey []byte, version uint64, key []byte) ([]proof.CommitmentOp, error)
 
+	// GetRangeProof returns a proof of the complete set of key/value pairs within
+	// the domain [start, end) of the given store at the given version. If the
+	// domain holds more than limit pairs, the proof covers the first limit pairs
+	// and its domain ends before the next key.
+	GetRangeProof(storeKey []byte, version uint64, start, end []byte, limit int) (*proof.RangeProof, error)
+
 	// Get returns the value for the given key at the given version.
 	//
 	// NOTE: This method only exists to support migration from IAVL v0/v1 to v2.
@@ -148,3 +154,11 @@ type RawDB interface {
 	// This will does the same thing as NewBatch if the batch implementation doesn't support pre-allocation.
 	NewBatchWithSize(int) RawBatch
 }
+
+// Secondary is implemented by databases opened as a read-only secondary instance
+// of a database written by a primary instance, possibly in another process.
+type Secondary interface {
+	// TryCatchUpWithPrimary makes the writes of the primary instance visible to
+	// the secondary instance.
+	TryCatchUpWithPrimary() error
+}
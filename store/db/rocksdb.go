@@ -67,6 +67,31 @@ func NewRocksDBWithOpts(name, dataDir string, opts *grocksdb.Options) (*RocksDB,
 	}, nil
 }
 
+// NewRocksDBSecondary opens a RocksDB as the secondary instance of a primary
+// one, which may be written by another process. The secondary keeps its own
+// logs in secondaryDir, and follows the writes of the primary on every
+// TryCatchUpWithPrimary.
+func NewRocksDBSecondary(name, dataDir, secondaryDir string) (*RocksDB, error) {
+	opts := defaultRocksdbOptions()
+	// a secondary instance requires all files to be kept open
+	opts.SetMaxOpenFiles(-1)
+
+	dbPath := filepath.Join(dataDir, name+DBFileSuffix)
+	storage, err := grocksdb.OpenDbAsSecondary(opts, dbPath, secondaryDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open RocksDB as secondary: %w", err)
+	}
+
+	return &RocksDB{
+		storage: storage,
+	}, nil
+}
+
+// TryCatchUpWithPrimary implements store.Secondary.
+func (db *RocksDB) TryCatchUpWithPrimary() error {
+	return db.storage.TryCatchUpWithPrimary()
+}
+
 func (db *RocksDB) Close() error {
 	db.storage.Close()
 	db.storage = nil
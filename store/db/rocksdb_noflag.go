@@ -23,6 +23,14 @@ func NewRocksDBWithOpts(dataDir string, opts store.DBOptions) (*RocksDB, error)
 	panic("rocksdb must be built with -tags rocksdb")
 }
 
+func NewRocksDBSecondary(name, dataDir, secondaryDir string) (*RocksDB, error) {
+	panic("rocksdb must be built with -tags rocksdb")
+}
+
+func (db *RocksDB) TryCatchUpWithPrimary() error {
+	panic("rocksdb must be built with -tags rocksdb")
+}
+
 func (db *RocksDB) Close() error {
 	panic("rocksdb must be built with -tags rocksdb")
 }
@@ -38,6 +38,13 @@ var (
 
 	// ErrValueNil is returned when attempting to set a nil value.
 	ErrValueNil = errors.New("value nil")
+
+	// ErrReadOnly is returned when attempting to write to a read-only store.
+	ErrReadOnly = errors.New("read-only")
+
+	// ErrSecondaryUnsupported is returned when a read-only store is created over
+	// a backend which cannot be opened as a secondary instance, such as PebbleDB.
+	ErrSecondaryUnsupported = errors.New("secondary instance unsupported")
 )
 
 // ErrVersionPruned defines an error returned when a version queried is pruned
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StatsSource returns the statistics of the state, such as a root.Store with
+// stats enabled.
+type StatsSource interface {
+	Stats() (Stats, error)
+}
+
+// NewStatsHandler returns an http.Handler serving the statistics of the given
+// source as JSON. The optional "store" query parameter restricts the response
+// to a single store.
+func NewStatsHandler(source StatsSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		stats, err := source.Stats()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		if storeKey := r.URL.Query().Get("store"); storeKey != "" {
+			var stores []StoreStats
+			for _, s := range stats.Stores {
+				if s.StoreKey == storeKey {
+					stores = append(stores, s)
+				}
+			}
+			if len(stores) == 0 {
+				http.Error(w, "unknown store "+storeKey, http.StatusNotFound)
+				return
+			}
+			stats.Stores = stores
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(stats)
+	})
+}
@@ -0,0 +1,374 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	corestore "cosmossdk.io/core/store"
+)
+
+// StatsConfig defines the configuration of a StatsTracker.
+type StatsConfig struct {
+	// PrefixLength is the number of leading bytes of a key which group the
+	// writes of a store, e.g. 1 for the prefixes of collections.
+	PrefixLength int
+	// MaxPrefixes is the number of prefixes tracked for every store, in the
+	// order they are first written. The writes of any further prefix are
+	// counted together, which bounds the memory and the telemetry series.
+	MaxPrefixes int
+	// RateWindow is the number of blocks over which the write rate of every
+	// prefix is averaged.
+	RateWindow int
+	// HotKeys is the number of hottest keys reported for every store.
+	HotKeys int
+}
+
+// DefaultStatsConfig returns the default StatsConfig.
+func DefaultStatsConfig() StatsConfig {
+	return StatsConfig{
+		PrefixLength: 1,
+		MaxPrefixes:  256,
+		RateWindow:   100,
+		HotKeys:      10,
+	}
+}
+
+// hotKeysCapacityFactor is the number of keys tracked per reported hot key,
+// which bounds the error of the reported write counts.
+const hotKeysCapacityFactor = 10
+
+// HexBytes is a byte slice which is encoded to JSON as a hex string.
+type HexBytes []byte
+
+func (b HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+func (b *HexBytes) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+
+	return nil
+}
+
+// Stats is a snapshot of the statistics of a StatsTracker.
+type Stats struct {
+	// Version is the last version recorded.
+	Version uint64 `json:"version"`
+	// Stores are the statistics of every store, in ascending store key order.
+	Stores []StoreStats `json:"stores"`
+}
+
+// StoreStats are the statistics of a single store.
+type StoreStats struct {
+	StoreKey string `json:"store_key"`
+	// Keys is the number of keys in the store.
+	Keys int64 `json:"keys"`
+	// Bytes is the total size of the keys and values in the store.
+	Bytes int64 `json:"bytes"`
+	// Prefixes are the write statistics of every tracked prefix of the store,
+	// in ascending prefix order.
+	Prefixes []PrefixStats `json:"prefixes"`
+	// OtherPrefixes are the write statistics of the prefixes written once
+	// MaxPrefixes were tracked, if any, without a prefix.
+	OtherPrefixes *PrefixStats `json:"other_prefixes,omitempty"`
+	// HotKeys are the most written keys of the store, in descending order of
+	// writes.
+	HotKeys []HotKey `json:"hot_keys"`
+}
+
+// PrefixStats are the write statistics of the keys of a store sharing a prefix.
+type PrefixStats struct {
+	Prefix HexBytes `json:"prefix"`
+	// Writes is the number of writes recorded.
+	Writes uint64 `json:"writes"`
+	// WritesPerBlock is the average number of writes per block, over the rate
+	// window.
+	WritesPerBlock float64 `json:"writes_per_block"`
+}
+
+// HotKey is one of the most written keys of a store.
+type HotKey struct {
+	Key HexBytes `json:"key"`
+	// Writes is the number of blocks which wrote the key, which may overestimate
+	// the actual number by at most Error.
+	Writes uint64 `json:"writes"`
+	Error  uint64 `json:"error"`
+}
+
+// StatsTracker keeps the number of keys and bytes of every store, the write rate
+// of their prefixes and their most written keys, from the changesets committed
+// to the state. The write rates are exponentially weighted moving averages,
+// while the hot keys are approximated with the Space-Saving algorithm over a
+// bounded number of keys. It is safe for concurrent use.
+type StatsTracker struct {
+	mtx     sync.Mutex
+	cfg     StatsConfig
+	version uint64
+	stores  map[string]*storeStats
+}
+
+type storeStats struct {
+	keys          int64
+	bytes         int64
+	prefixes      map[string]*prefixStats
+	otherPrefixes *prefixStats // the prefixes beyond MaxPrefixes
+	hotKeys       map[string]*hotKey
+}
+
+type prefixStats struct {
+	writes         uint64
+	pending        uint64 // writes of the block being recorded
+	writesPerBlock float64
+}
+
+type hotKey struct {
+	writes uint64
+	err    uint64
+}
+
+// NewStatsTracker returns a new StatsTracker with the given configuration.
+func NewStatsTracker(cfg StatsConfig) *StatsTracker {
+	if cfg.RateWindow <= 0 {
+		cfg.RateWindow = 1
+	}
+
+	return &StatsTracker{
+		cfg:    cfg,
+		stores: make(map[string]*storeStats),
+	}
+}
+
+func (t *StatsTracker) store(storeKey string) *storeStats {
+	s, ok := t.stores[storeKey]
+	if !ok {
+		s = &storeStats{
+			prefixes: make(map[string]*prefixStats),
+			hotKeys:  make(map[string]*hotKey),
+		}
+		t.stores[storeKey] = s
+	}
+
+	return s
+}
+
+// SetStoreSize sets the number of keys and bytes of the given store, e.g. as
+// counted when the tracker is created on an existing state.
+func (t *StatsTracker) SetStoreSize(storeKey string, keys, bytes int64) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	s := t.store(storeKey)
+	s.keys, s.bytes = keys, bytes
+}
+
+// RecordChangeset records the changeset committed at the given version. The
+// prev function returns the value of a key of a store prior to the changeset,
+// or nil if it did not exist, from which the sizes of the stores are updated.
+// The previous values are all read first, so nothing is recorded if reading
+// any of them fails.
+func (t *StatsTracker) RecordChangeset(version uint64, cs *corestore.Changeset, prev func(storeKey, key []byte) ([]byte, error)) error {
+	olds := make([][][]byte, len(cs.Changes))
+	for i, pairs := range cs.Changes {
+		olds[i] = make([][]byte, len(pairs.StateChanges))
+		for j, kv := range pairs.StateChanges {
+			old, err := prev(pairs.Actor, kv.Key)
+			if err != nil {
+				return fmt.Errorf("failed to get previous value of key %X of store %s: %w", kv.Key, pairs.Actor, err)
+			}
+			olds[i][j] = old
+		}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	for i, pairs := range cs.Changes {
+		s := t.store(string(pairs.Actor))
+
+		for j, kv := range pairs.StateChanges {
+			if old := olds[i][j]; old != nil {
+				s.keys--
+				s.bytes -= int64(len(kv.Key) + len(old))
+			}
+			if !kv.Remove {
+				s.keys++
+				s.bytes += int64(len(kv.Key) + len(kv.Value))
+			}
+
+			prefix := kv.Key
+			if len(prefix) > t.cfg.PrefixLength {
+				prefix = prefix[:t.cfg.PrefixLength]
+			}
+			p := t.prefix(s, prefix)
+			p.writes++
+			p.pending++
+
+			t.recordHotKey(s, kv.Key)
+		}
+	}
+
+	// every block updates the average of all prefixes, including the ones it
+	// did not write
+	alpha := 2 / (float64(t.cfg.RateWindow) + 1)
+	for _, s := range t.stores {
+		for _, p := range s.prefixes {
+			p.updateRate(alpha)
+		}
+		if s.otherPrefixes != nil {
+			s.otherPrefixes.updateRate(alpha)
+		}
+	}
+	t.version = version
+
+	return nil
+}
+
+// prefix returns the statistics of the given prefix of a store, or the ones of
+// the other prefixes once MaxPrefixes are tracked.
+func (t *StatsTracker) prefix(s *storeStats, prefix []byte) *prefixStats {
+	if p, ok := s.prefixes[string(prefix)]; ok {
+		return p
+	}
+	if len(s.prefixes) < t.cfg.MaxPrefixes {
+		p := &prefixStats{}
+		s.prefixes[string(prefix)] = p
+		return p
+	}
+
+	if s.otherPrefixes == nil {
+		s.otherPrefixes = &prefixStats{}
+	}
+	return s.otherPrefixes
+}
+
+// updateRate folds the writes of the block being recorded into the average
+// writes per block, with the given smoothing factor.
+func (p *prefixStats) updateRate(alpha float64) {
+	p.writesPerBlock += alpha * (float64(p.pending) - p.writesPerBlock)
+	p.pending = 0
+}
+
+// recordHotKey counts a write of the given key, replacing the least written
+// key once the tracked keys reach their capacity.
+func (t *StatsTracker) recordHotKey(s *storeStats, key []byte) {
+	if t.cfg.HotKeys <= 0 {
+		return
+	}
+
+	if k, ok := s.hotKeys[string(key)]; ok {
+		k.writes++
+		return
+	}
+
+	if len(s.hotKeys) < t.cfg.HotKeys*hotKeysCapacityFactor {
+		s.hotKeys[string(key)] = &hotKey{writes: 1}
+		return
+	}
+
+	var (
+		coldestKey string
+		coldest    *hotKey
+	)
+	for k, c := range s.hotKeys {
+		if coldest == nil || c.writes < coldest.writes {
+			coldestKey, coldest = k, c
+		}
+	}
+	delete(s.hotKeys, coldestKey)
+	s.hotKeys[string(key)] = &hotKey{writes: coldest.writes + 1, err: coldest.writes}
+}
+
+// Stats returns a snapshot of the statistics.
+func (t *StatsTracker) Stats() Stats {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	stats := Stats{Version: t.version}
+	for storeKey, s := range t.stores {
+		ss := StoreStats{
+			StoreKey: storeKey,
+			Keys:     s.keys,
+			Bytes:    s.bytes,
+		}
+		for prefix, p := range s.prefixes {
+			ss.Prefixes = append(ss.Prefixes, PrefixStats{
+				Prefix:         HexBytes(prefix),
+				Writes:         p.writes,
+				WritesPerBlock: p.writesPerBlock,
+			})
+		}
+		slices.SortFunc(ss.Prefixes, func(a, b PrefixStats) int {
+			return bytes.Compare(a.Prefix, b.Prefix)
+		})
+		if p := s.otherPrefixes; p != nil {
+			ss.OtherPrefixes = &PrefixStats{Writes: p.writes, WritesPerBlock: p.writesPerBlock}
+		}
+		ss.HotKeys = t.hotKeys(s)
+
+		stats.Stores = append(stats.Stores, ss)
+	}
+	slices.SortFunc(stats.Stores, func(a, b StoreStats) int {
+		return strings.Compare(a.StoreKey, b.StoreKey)
+	})
+
+	return stats
+}
+
+// hotKeys returns the hottest keys of the given store, in descending order of
+// writes.
+func (t *StatsTracker) hotKeys(s *storeStats) []HotKey {
+	keys := make([]HotKey, 0, len(s.hotKeys))
+	for key, k := range s.hotKeys {
+		keys = append(keys, HotKey{Key: HexBytes(key), Writes: k.writes, Error: k.err})
+	}
+	slices.SortFunc(keys, func(a, b HotKey) int {
+		if a.Writes != b.Writes {
+			if a.Writes > b.Writes {
+				return -1
+			}
+			return 1
+		}
+		return bytes.Compare(a.Key, b.Key)
+	})
+	if len(keys) > t.cfg.HotKeys {
+		keys = keys[:t.cfg.HotKeys]
+	}
+
+	return keys
+}
+
+// Emit reports the statistics through the given StoreMetrics as gauges, under
+// the "store_stats", <storeKey> keys. The prefixes are named by their hex
+// encoding, of which there are at most MaxPrefixes per store, and "other".
+// The hot keys are named by their rank, starting at 0, since the keys
+// themselves are unbounded and only returned by Stats.
+func (t *StatsTracker) Emit(m StoreMetrics) {
+	for _, s := range t.Stats().Stores {
+		m.SetGauge(float32(s.Keys), "store_stats", s.StoreKey, "keys")
+		m.SetGauge(float32(s.Bytes), "store_stats", s.StoreKey, "bytes")
+		for _, p := range s.Prefixes {
+			m.SetGauge(float32(p.WritesPerBlock), "store_stats", s.StoreKey, "prefix", hex.EncodeToString(p.Prefix), "writes_per_block")
+		}
+		if p := s.OtherPrefixes; p != nil {
+			m.SetGauge(float32(p.WritesPerBlock), "store_stats", s.StoreKey, "prefix", "other", "writes_per_block")
+		}
+		for rank, k := range s.HotKeys {
+			m.SetGauge(float32(k.Writes), "store_stats", s.StoreKey, "hot_key", strconv.Itoa(rank), "writes")
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corestore "cosmossdk.io/core/store"
+)
+
+type statsSource struct {
+	tracker *StatsTracker
+}
+
+func (s statsSource) Stats() (Stats, error) {
+	return s.tracker.Stats(), nil
+}
+
+func TestStatsTracker_HotKeys(t *testing.T) {
+	tracker := NewStatsTracker(StatsConfig{PrefixLength: 1, RateWindow: 10, HotKeys: 2})
+	noPrev := func(_, _ []byte) ([]byte, error) { return nil, nil }
+
+	// "hot" is written every block, while a new cold key is written every block
+	// to exceed the capacity of the tracked keys
+	for v := uint64(1); v <= 100; v++ {
+		cs := corestore.NewChangeset()
+		cs.Add([]byte("store"), []byte("hot"), []byte{1}, false)
+		cs.Add([]byte("store"), []byte(fmt.Sprintf("cold%03d", v)), []byte{1}, false)
+		if v%2 == 0 {
+			cs.Add([]byte("store"), []byte("warm"), []byte{1}, false)
+		}
+		require.NoError(t, tracker.RecordChangeset(v, cs, noPrev))
+	}
+
+	stats := tracker.Stats()
+	require.Len(t, stats.Stores, 1)
+	hotKeys := stats.Stores[0].HotKeys
+	require.Len(t, hotKeys, 2)
+	require.Equal(t, HotKey{Key: HexBytes("hot"), Writes: 100}, hotKeys[0])
+	require.Equal(t, HexBytes("warm"), hotKeys[1].Key)
+	require.Equal(t, uint64(50), hotKeys[1].Writes-hotKeys[1].Error)
+}
+
+func TestStatsHandler(t *testing.T) {
+	tracker := NewStatsTracker(DefaultStatsConfig())
+	cs := corestore.NewChangeset()
+	cs.Add([]byte("bank"), []byte{0x02, 0x01}, []byte("balance"), false)
+	cs.Add([]byte("staking"), []byte{0x21}, []byte("validator"), false)
+	require.NoError(t, tracker.RecordChangeset(1, cs, func(_, _ []byte) ([]byte, error) { return nil, nil }))
+
+	server := httptest.NewServer(NewStatsHandler(statsSource{tracker}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?store=bank")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats Stats
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	require.Equal(t, uint64(1), stats.Version)
+	require.Len(t, stats.Stores, 1)
+	require.Equal(t, "bank", stats.Stores[0].StoreKey)
+	require.Equal(t, int64(1), stats.Stores[0].Keys)
+	require.Equal(t, HexBytes{0x02}, stats.Stores[0].Prefixes[0].Prefix)
+	require.Equal(t, HexBytes{0x02, 0x01}, stats.Stores[0].HotKeys[0].Key)
+
+	resp, err = http.Get(server.URL + "?store=gov")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// gaugeMetrics records the gauges set through it.
+type gaugeMetrics struct {
+	StoreMetrics
+	gauges map[string]float32
+}
+
+func (m gaugeMetrics) SetGauge(val float32, keys ...string) {
+	m.gauges[strings.Join(keys, "/")] = val
+}
+
+func TestStatsTracker_Emit(t *testing.T) {
+	tracker := NewStatsTracker(StatsConfig{PrefixLength: 1, MaxPrefixes: 2, RateWindow: 1, HotKeys: 2})
+	cs := corestore.NewChangeset()
+	for i := 0; i < 10; i++ {
+		cs.Add([]byte("bank"), []byte{byte(i), 0xff}, []byte{1}, false)
+	}
+	require.NoError(t, tracker.RecordChangeset(1, cs, func(_, _ []byte) ([]byte, error) { return nil, nil }))
+	cs = corestore.NewChangeset()
+	cs.Add([]byte("bank"), []byte{0x00, 0xff}, []byte{2}, false)
+	require.NoError(t, tracker.RecordChangeset(2, cs, func(_, _ []byte) ([]byte, error) { return []byte{1}, nil }))
+
+	m := gaugeMetrics{gauges: make(map[string]float32)}
+	tracker.Emit(m)
+
+	// neither the prefixes beyond MaxPrefixes nor the hot keys name a series
+	require.Equal(t, map[string]float32{
+		"store_stats/bank/keys":                          10,
+		"store_stats/bank/bytes":                         30,
+		"store_stats/bank/prefix/00/writes_per_block":    1,
+		"store_stats/bank/prefix/01/writes_per_block":    0,
+		"store_stats/bank/prefix/other/writes_per_block": 0,
+		"store_stats/bank/hot_key/0/writes":              2,
+		"store_stats/bank/hot_key/1/writes":              1,
+	}, m.gauges)
+}
+
+func TestStatsTracker_RecordChangesetError(t *testing.T) {
+	tracker := NewStatsTracker(DefaultStatsConfig())
+	cs := corestore.NewChangeset()
+	cs.Add([]byte("bank"), []byte("a"), []byte("value"), false)
+	cs.Add([]byte("bank"), []byte("b"), []byte("value"), false)
+
+	// nothing is recorded if a previous value can't be read
+	err := tracker.RecordChangeset(1, cs, func(_, key []byte) ([]byte, error) {
+		if string(key) == "b" {
+			return nil, errors.New("not found")
+		}
+		return nil, nil
+	})
+	require.ErrorContains(t, err, "not found")
+	require.Equal(t, Stats{}, tracker.Stats())
+
+	require.NoError(t, tracker.RecordChangeset(1, cs, func(_, _ []byte) ([]byte, error) { return nil, nil }))
+	stats := tracker.Stats()
+	require.Len(t, stats.Stores, 1)
+	require.Equal(t, int64(2), stats.Stores[0].Keys)
+	require.Equal(t, uint64(2), stats.Stores[0].Prefixes[0].Writes+stats.Stores[0].Prefixes[1].Writes)
+}
@@ -0,0 +1,226 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/storage"
+)
+
+const (
+	checkpointHeightKey     = "m/ck/height"   // the height being migrated
+	checkpointCompletedKey  = "m/ck/done"     // set once SS and SC are migrated
+	checkpointVerifiedKey   = "m/ck/verified" // the VerificationResult
+	checkpointTreePrefix    = "m/ck/sc/"      // m/ck/sc/<storeKey>, set once the tree is restored
+	checkpointStoragePrefix = "m/ck/ss/"      // m/ck/ss/<storeKey> -> the last key restored to SS
+)
+
+// VerificationResult is the result of verifying the migrated state against its
+// source.
+type VerificationResult uint8
+
+const (
+	// VerificationPending means the migrated state was not verified yet.
+	VerificationPending VerificationResult = iota
+	// VerificationPassed means the commit hashes of all migrated stores match
+	// the source.
+	VerificationPassed
+	// VerificationFailed means the commit hash of at least one migrated store
+	// differs from the source.
+	VerificationFailed
+)
+
+func (r VerificationResult) String() string {
+	switch r {
+	case VerificationPending:
+		return "pending"
+	case VerificationPassed:
+		return "passed"
+	case VerificationFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(r))
+	}
+}
+
+// Status reflects the progress of a migration, as recorded by its checkpoints.
+type Status struct {
+	// Height is the height being migrated, or 0 if no migration was started.
+	Height uint64
+	// Completed is true once the whole state at Height is migrated.
+	Completed bool
+	// Verification is the result of the last verification pass.
+	Verification VerificationResult
+	// Stores reflects the progress of every store the migration reached, in
+	// ascending store key order.
+	Stores []StoreStatus
+}
+
+// StoreStatus reflects the progress of a migration for a single store.
+type StoreStatus struct {
+	StoreKey string
+	// TreeRestored is true once the SC tree of the store is restored.
+	TreeRestored bool
+	// RestoredKey is the last key of the store restored to SS, or nil if none
+	// was.
+	RestoredKey []byte
+}
+
+var (
+	_ commitment.RestoreCheckpointer = (*checkpoints)(nil)
+	_ storage.RestoreCheckpointer    = (*checkpoints)(nil)
+)
+
+// checkpoints persists the progress of a migration in the migration db, so an
+// interrupted migration resumes where it stopped.
+type checkpoints struct {
+	db store.RawDB
+}
+
+func (c *checkpoints) set(key, value []byte) error {
+	batch := c.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set(key, value); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
+func (c *checkpoints) height() (uint64, error) {
+	bz, err := c.db.Get([]byte(checkpointHeightKey))
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid migration height length %d", len(bz))
+	}
+
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+func (c *checkpoints) setHeight(height uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, height)
+	return c.set([]byte(checkpointHeightKey), bz)
+}
+
+func (c *checkpoints) isCompleted() (bool, error) {
+	return c.db.Has([]byte(checkpointCompletedKey))
+}
+
+func (c *checkpoints) setCompleted() error {
+	return c.set([]byte(checkpointCompletedKey), []byte{1})
+}
+
+func (c *checkpoints) verification() (VerificationResult, error) {
+	bz, err := c.db.Get([]byte(checkpointVerifiedKey))
+	if err != nil || len(bz) == 0 {
+		return VerificationPending, err
+	}
+
+	return VerificationResult(bz[0]), nil
+}
+
+func (c *checkpoints) setVerification(result VerificationResult) error {
+	return c.set([]byte(checkpointVerifiedKey), []byte{byte(result)})
+}
+
+// IsTreeRestored implements commitment.RestoreCheckpointer.
+func (c *checkpoints) IsTreeRestored(storeKey string) (bool, error) {
+	return c.db.Has([]byte(checkpointTreePrefix + storeKey))
+}
+
+// SetTreeRestored implements commitment.RestoreCheckpointer.
+func (c *checkpoints) SetTreeRestored(storeKey string) error {
+	return c.set([]byte(checkpointTreePrefix+storeKey), []byte{1})
+}
+
+// RestoredKey implements storage.RestoreCheckpointer.
+func (c *checkpoints) RestoredKey(storeKey []byte) ([]byte, error) {
+	return c.db.Get(append([]byte(checkpointStoragePrefix), storeKey...))
+}
+
+// SetRestoredKey implements storage.RestoreCheckpointer.
+func (c *checkpoints) SetRestoredKey(storeKey, key []byte) error {
+	return c.set(append([]byte(checkpointStoragePrefix), storeKey...), key)
+}
+
+// LoadStatus returns the Status of the migration whose checkpoints are recorded
+// in the given migration db.
+func LoadStatus(db store.RawDB) (Status, error) {
+	c := &checkpoints{db: db}
+
+	var (
+		status Status
+		err    error
+	)
+	if status.Height, err = c.height(); err != nil {
+		return Status{}, fmt.Errorf("failed to get migration height: %w", err)
+	}
+	if status.Completed, err = c.isCompleted(); err != nil {
+		return Status{}, fmt.Errorf("failed to get migration completion: %w", err)
+	}
+	if status.Verification, err = c.verification(); err != nil {
+		return Status{}, fmt.Errorf("failed to get migration verification: %w", err)
+	}
+
+	stores := make(map[string]*StoreStatus)
+	storeStatus := func(storeKey string) *StoreStatus {
+		s, ok := stores[storeKey]
+		if !ok {
+			s = &StoreStatus{StoreKey: storeKey}
+			stores[storeKey] = s
+		}
+		return s
+	}
+
+	err = iteratePrefix(db, []byte(checkpointTreePrefix), func(storeKey, _ []byte) {
+		storeStatus(string(storeKey)).TreeRestored = true
+	})
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to get tree checkpoints: %w", err)
+	}
+	err = iteratePrefix(db, []byte(checkpointStoragePrefix), func(storeKey, key []byte) {
+		storeStatus(string(storeKey)).RestoredKey = key
+	})
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to get storage checkpoints: %w", err)
+	}
+
+	for _, s := range stores {
+		status.Stores = append(status.Stores, *s)
+	}
+	slices.SortFunc(status.Stores, func(a, b StoreStatus) int {
+		return strings.Compare(a.StoreKey, b.StoreKey)
+	})
+
+	return status, nil
+}
+
+// iteratePrefix calls fn with the suffix and value of every key with the given
+// prefix, in ascending key order.
+func iteratePrefix(db store.RawDB, prefix []byte, fn func(suffix, value []byte)) error {
+	// the prefixes end with '/', so incrementing their last byte never overflows
+	end := bytes.Clone(prefix)
+	end[len(end)-1]++
+
+	itr, err := db.Iterator(prefix, end)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		suffix := bytes.Clone(itr.Key()[len(prefix):])
+		fn(suffix, bytes.Clone(itr.Value()))
+	}
+
+	return itr.Error()
+}
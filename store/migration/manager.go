@@ -1,8 +1,11 @@
 package migration
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"cosmossdk.io/store/v2"
 	"cosmossdk.io/store/v2/commitment"
 	"cosmossdk.io/store/v2/internal/encoding"
+	"cosmossdk.io/store/v2/proof"
 	"cosmossdk.io/store/v2/snapshots"
 	"cosmossdk.io/store/v2/storage"
 )
@@ -41,9 +45,14 @@ type Manager struct {
 	stateCommitment *commitment.CommitStore
 
 	db              store.RawDB
+	checkpoints     *checkpoints
 	mtx             sync.Mutex // mutex for migratedVersion
 	migratedVersion uint64
 
+	// verificationSource is the source the migrated state is verified against
+	// by Start, if set
+	verificationSource CommitInfoReader
+
 	chChangeset <-chan *VersionedChangeset
 	chDone      <-chan struct{}
 }
@@ -56,11 +65,25 @@ func NewManager(db store.RawDB, sm *snapshots.Manager, ss *storage.StorageStore,
 		stateStorage:     ss,
 		stateCommitment:  sc,
 		db:               db,
+		checkpoints:      &checkpoints{db: db},
 	}
 }
 
+// CommitInfoReader provides the commit info of the source state of a migration.
+type CommitInfoReader interface {
+	GetCommitInfo(version uint64) (*proof.CommitInfo, error)
+}
+
+// SetVerificationSource sets the source whose commit hashes the migrated state
+// is verified against by Start, before catching up the committed Changesets.
+func (m *Manager) SetVerificationSource(source CommitInfoReader) {
+	m.verificationSource = source
+}
+
 // Start starts the whole migration process.
 // It migrates the whole state at the given version to the new store/v2 (both SC and SS).
+// If an earlier migration was interrupted, it is resumed at its own version instead,
+// since the Changesets committed after that version are already recorded.
 // It also catches up the Changesets which are committed while the migration is in progress.
 // `chChangeset` is the channel to receive the committed Changesets from the RootStore.
 // `chDone` is the channel to receive the done signal from the RootStore.
@@ -75,9 +98,23 @@ func (m *Manager) Start(version uint64, chChangeset <-chan *VersionedChangeset,
 		}
 	}()
 
+	height, err := m.checkpoints.height()
+	if err != nil {
+		return fmt.Errorf("failed to get migration height: %w", err)
+	}
+	if height != 0 && height != version {
+		m.logger.Info("resuming migration", "height", height, "version", version)
+		version = height
+	}
+
 	if err := m.Migrate(version); err != nil {
 		return fmt.Errorf("failed to migrate state: %w", err)
 	}
+	if m.verificationSource != nil {
+		if err := m.Verify(m.verificationSource); err != nil {
+			return err
+		}
+	}
 
 	return m.Sync()
 }
@@ -88,7 +125,32 @@ func (m *Manager) GetStateCommitment() *commitment.CommitStore {
 }
 
 // Migrate migrates the whole state at the given height to the new store/v2.
+// The progress is checkpointed per store, and per key range of every store in
+// SS, so a Migrate interrupted at the same height resumes where it stopped.
 func (m *Manager) Migrate(height uint64) error {
+	ckptHeight, err := m.checkpoints.height()
+	if err != nil {
+		return fmt.Errorf("failed to get migration height: %w", err)
+	}
+	switch {
+	case ckptHeight == 0:
+		if err := m.checkpoints.setHeight(height); err != nil {
+			return fmt.Errorf("failed to set migration height: %w", err)
+		}
+	case ckptHeight != height:
+		return fmt.Errorf("migration at height %d was started already, cannot migrate height %d", ckptHeight, height)
+	default:
+		completed, err := m.checkpoints.isCompleted()
+		if err != nil {
+			return fmt.Errorf("failed to get migration completion: %w", err)
+		}
+		if completed {
+			m.setMigratedVersion(height)
+			return nil
+		}
+		m.logger.Info("resuming interrupted migration", "height", height)
+	}
+
 	// create the migration stream and snapshot,
 	// which acts as protoio.Reader and snapshots.WriteCloser.
 	ms := NewMigrationStream(defaultChannelBufferSize)
@@ -102,25 +164,100 @@ func (m *Manager) Migrate(height uint64) error {
 
 	eg := new(errgroup.Group)
 	eg.Go(func() error {
-		return m.stateStorage.Restore(height, chStorage)
+		err := m.stateStorage.RestoreWithCheckpoints(height, chStorage, m.checkpoints)
+		// keep draining, so the SC restore is not blocked by a failed SS restore
+		for range chStorage {
+		}
+		return err
 	})
 	eg.Go(func() error {
 		defer close(chStorage)
-		_, err := m.stateCommitment.Restore(height, 0, ms, chStorage)
+		_, err := m.stateCommitment.RestoreWithCheckpoints(height, 0, ms, chStorage, m.checkpoints)
 		return err
 	})
 
 	if err := eg.Wait(); err != nil {
+		// unblock the snapshot of the source, so the migration can be retried
+		go ms.drain()
+		m.snapshotsManager.AbortMigration()
 		return err
 	}
 
-	m.mtx.Lock()
-	m.migratedVersion = height
-	m.mtx.Unlock()
+	if err := m.checkpoints.setCompleted(); err != nil {
+		return fmt.Errorf("failed to set migration completion: %w", err)
+	}
+	m.setMigratedVersion(height)
+
+	return nil
+}
+
+// Verify compares the commit hashes of the migrated stores with the ones of the
+// given source at the migrated height, and records the result. It returns an
+// error listing the stores whose commit hashes differ.
+func (m *Manager) Verify(source CommitInfoReader) error {
+	height, err := m.checkpoints.height()
+	if err != nil {
+		return fmt.Errorf("failed to get migration height: %w", err)
+	}
+	completed, err := m.checkpoints.isCompleted()
+	if err != nil {
+		return fmt.Errorf("failed to get migration completion: %w", err)
+	}
+	if !completed {
+		return fmt.Errorf("migration is not completed yet")
+	}
+
+	sourceInfo, err := source.GetCommitInfo(height)
+	if err != nil {
+		return fmt.Errorf("failed to get source commit info: %w", err)
+	}
+	migratedInfo, err := m.stateCommitment.GetCommitInfo(height)
+	if err != nil {
+		return fmt.Errorf("failed to get migrated commit info: %w", err)
+	}
+	if sourceInfo == nil || migratedInfo == nil {
+		return fmt.Errorf("commit info at height %d not found", height)
+	}
+
+	// compare both ways, so a store missing on either side is reported
+	var mismatches []string
+	for _, si := range sourceInfo.StoreInfos {
+		if !bytes.Equal(si.CommitID.Hash, migratedInfo.GetStoreCommitID(si.Name).Hash) {
+			mismatches = append(mismatches, string(si.Name))
+		}
+	}
+	for _, si := range migratedInfo.StoreInfos {
+		if sourceInfo.GetStoreCommitID(si.Name).Hash == nil && !slices.Contains(mismatches, string(si.Name)) {
+			mismatches = append(mismatches, string(si.Name))
+		}
+	}
+
+	result := VerificationPassed
+	if len(mismatches) > 0 {
+		result = VerificationFailed
+	}
+	if err := m.checkpoints.setVerification(result); err != nil {
+		return fmt.Errorf("failed to set migration verification: %w", err)
+	}
+	if result == VerificationFailed {
+		slices.Sort(mismatches)
+		return fmt.Errorf("commit hashes of stores %s differ from the source at height %d", strings.Join(mismatches, ", "), height)
+	}
 
 	return nil
 }
 
+// Status returns the Status of the migration.
+func (m *Manager) Status() (Status, error) {
+	return LoadStatus(m.db)
+}
+
+func (m *Manager) setMigratedVersion(version uint64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.migratedVersion = version
+}
+
 // writeChangeset writes the Changeset to the db.
 func (m *Manager) writeChangeset() error {
 	for vc := range m.chChangeset {
@@ -197,9 +334,7 @@ func (m *Manager) Sync() error {
 				return fmt.Errorf("failed to write changeset to storage: %w", err)
 			}
 
-			m.mtx.Lock()
-			m.migratedVersion = version
-			m.mtx.Unlock()
+			m.setMigratedVersion(version)
 
 			version += 1
 		}
@@ -1,9 +1,12 @@
 package migration
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
+	protoio "github.com/cosmos/gogoproto/io"
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/stretchr/testify/require"
 
 	corestore "cosmossdk.io/core/store"
@@ -11,6 +14,7 @@ import (
 	"cosmossdk.io/store/v2/commitment"
 	"cosmossdk.io/store/v2/commitment/iavl"
 	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/proof"
 	"cosmossdk.io/store/v2/snapshots"
 	"cosmossdk.io/store/v2/storage"
 	"cosmossdk.io/store/v2/storage/pebbledb"
@@ -103,3 +107,154 @@ func TestMigrateState(t *testing.T) {
 		}
 	}
 }
+
+var errInterrupted = errors.New("interrupted")
+
+// interruptedSnapshotter fails the snapshot of the CommitStore once it wrote
+// limit items.
+type interruptedSnapshotter struct {
+	*commitment.CommitStore
+	limit int
+}
+
+func (s *interruptedSnapshotter) Snapshot(version uint64, protoWriter protoio.Writer) error {
+	return s.CommitStore.Snapshot(version, &limitedWriter{Writer: protoWriter, limit: s.limit})
+}
+
+type limitedWriter struct {
+	protoio.Writer
+	limit int
+}
+
+func (w *limitedWriter) WriteMsg(msg proto.Message) error {
+	if w.limit == 0 {
+		return errInterrupted
+	}
+	w.limit--
+	return w.Writer.WriteMsg(msg)
+}
+
+// commitInfoReader returns the commit info of the CommitStore, with the hash of
+// the mismatched store changed.
+type commitInfoReader struct {
+	*commitment.CommitStore
+	mismatched string
+}
+
+func (r *commitInfoReader) GetCommitInfo(version uint64) (*proof.CommitInfo, error) {
+	cInfo, err := r.CommitStore.GetCommitInfo(version)
+	if err != nil || cInfo == nil {
+		return cInfo, err
+	}
+	for i, si := range cInfo.StoreInfos {
+		if string(si.Name) == r.mismatched {
+			cInfo.StoreInfos[i].CommitID.Hash = []byte("mismatched")
+		}
+	}
+	return cInfo, nil
+}
+
+func TestMigrateResume(t *testing.T) {
+	m, orgCommitStore := setupMigrationManager(t)
+
+	// the values are large enough for SS to write several batches per store
+	keyCount := 1000
+	value := func(storeKey string, i int) []byte {
+		return []byte(fmt.Sprintf("%s-value-%04d-%0200d", storeKey, i, i))
+	}
+	for version := uint64(1); version <= 2; version++ {
+		cs := corestore.NewChangeset()
+		for _, storeKey := range storeKeys {
+			for i := int(version-1) * keyCount / 2; i < int(version)*keyCount/2; i++ {
+				cs.Add([]byte(storeKey), []byte(fmt.Sprintf("key-%04d", i)), value(storeKey, i), false)
+			}
+		}
+		require.NoError(t, orgCommitStore.WriteBatch(cs))
+		_, err := orgCommitStore.Commit(version)
+		require.NoError(t, err)
+	}
+
+	// interrupt the migration midway through the second store, as the tree of
+	// every store has 2*keyCount-1 nodes
+	snapshotsStore, err := snapshots.NewStore(t.TempDir())
+	require.NoError(t, err)
+	snapshotter := &interruptedSnapshotter{CommitStore: orgCommitStore, limit: 3 * keyCount}
+	sm := snapshots.NewManager(snapshotsStore, snapshots.NewSnapshotOptions(1500, 2), snapshotter, nil, nil, log.NewNopLogger())
+	interrupted := NewManager(m.db, sm, m.stateStorage, m.stateCommitment, log.NewNopLogger())
+	require.ErrorIs(t, interrupted.Migrate(2), errInterrupted)
+
+	status, err := interrupted.Status()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), status.Height)
+	require.False(t, status.Completed)
+	// the stores are snapshotted in no particular order, but only the first
+	// one is restored completely
+	var restored []StoreStatus
+	for _, store := range status.Stores {
+		if store.TreeRestored {
+			restored = append(restored, store)
+		}
+	}
+	require.Len(t, restored, 1)
+	require.NotNil(t, restored[0].RestoredKey)
+
+	// a migration at another height is rejected
+	require.Error(t, m.Migrate(3))
+
+	// the migration resumes from the checkpoints
+	require.NoError(t, m.Migrate(2))
+	require.Equal(t, uint64(2), m.GetMigratedVersion())
+	for _, storeKey := range storeKeys {
+		for i := 0; i < keyCount; i++ {
+			key := []byte(fmt.Sprintf("key-%04d", i))
+			val, err := m.stateCommitment.Get([]byte(storeKey), 2, key)
+			require.NoError(t, err)
+			require.Equal(t, value(storeKey, i), val)
+			val, err = m.stateStorage.Get([]byte(storeKey), 2, key)
+			require.NoError(t, err)
+			require.Equal(t, value(storeKey, i), val)
+		}
+	}
+
+	require.NoError(t, m.Verify(orgCommitStore))
+	status, err = m.Status()
+	require.NoError(t, err)
+	require.True(t, status.Completed)
+	require.Equal(t, VerificationPassed, status.Verification)
+	for _, store := range status.Stores {
+		require.True(t, store.TreeRestored)
+		require.Equal(t, []byte(fmt.Sprintf("key-%04d", keyCount-1)), store.RestoredKey)
+	}
+
+	// migrating a completed height again is a no-op
+	require.NoError(t, m.Migrate(2))
+}
+
+func TestMigrateVerify(t *testing.T) {
+	m, orgCommitStore := setupMigrationManager(t)
+
+	cs := corestore.NewChangeset()
+	for _, storeKey := range storeKeys {
+		cs.Add([]byte(storeKey), []byte("key"), []byte("value"), false)
+	}
+	require.NoError(t, orgCommitStore.WriteBatch(cs))
+	_, err := orgCommitStore.Commit(1)
+	require.NoError(t, err)
+
+	// the migration must be completed first
+	require.Error(t, m.Verify(orgCommitStore))
+
+	require.NoError(t, m.Migrate(1))
+	err = m.Verify(&commitInfoReader{CommitStore: orgCommitStore, mismatched: "store2"})
+	require.ErrorContains(t, err, "store2")
+	require.NotContains(t, err.Error(), "store1")
+
+	status, err := m.Status()
+	require.NoError(t, err)
+	require.Equal(t, VerificationFailed, status.Verification)
+
+	require.NoError(t, m.Verify(orgCommitStore))
+	status, err = m.Status()
+	require.NoError(t, err)
+	require.Equal(t, VerificationPassed, status.Verification)
+}
@@ -58,6 +58,10 @@ func (ms *MigrationStream) ReadMsg(msg proto.Message) error {
 	// It doesn't require any deserialization, just a type assertion.
 	item := <-ms.chBuffer
 	if item == nil {
+		// the writer may have failed before closing the stream
+		if err := ms.err.Load(); err != nil {
+			return err.(error)
+		}
 		return io.EOF
 	}
 
@@ -77,3 +81,10 @@ func (ms *MigrationStream) Close() error {
 	close(ms.chBuffer)
 	return nil
 }
+
+// drain discards the remaining messages of the stream, so the writer is not
+// blocked once the reader stopped early.
+func (ms *MigrationStream) drain() {
+	for range ms.chBuffer {
+	}
+}
@@ -0,0 +1,255 @@
+package proof
+
+import (
+	"bytes"
+	"fmt"
+
+	ics23 "github.com/cosmos/ics23/go"
+
+	"cosmossdk.io/errors"
+	storeerrors "cosmossdk.io/store/v2/errors"
+)
+
+// KVPair defines a key/value pair proven by a RangeProof.
+type KVPair struct {
+	Key   []byte
+	Value []byte
+}
+
+// RangeProof proves the complete set of key/value pairs of a single store within
+// the domain [Start, End) at a given version. A nil Start or End leaves the
+// respective side of the domain unbounded. Start and End are informational: a
+// RangeProof is always verified against the store and domain the verifier
+// asked for, and the ics23 spec pinned for its Type.
+//
+// Completeness is proven by chaining existence proofs of neighboring leaves:
+// the Left proof (if any) proves the largest key < Start, each entry of Pairs
+// is proven by the corresponding existence proof, and the Right proof (if any)
+// proves the smallest key >= End. Every proof in that sequence must be the left
+// neighbor of the next one in the tree. A missing Left (Right) proof requires
+// the first (last) proof of the sequence to be the left-most (right-most) leaf.
+type RangeProof struct {
+	Type  string
+	Start []byte
+	End   []byte
+
+	Left    *ics23.ExistenceProof
+	Entries []*ics23.ExistenceProof
+	Right   *ics23.ExistenceProof
+
+	// StoreProof proves the store's root hash against the CommitInfo hash.
+	StoreProof *CommitmentOp
+}
+
+// NewIAVLRangeProof returns a new RangeProof for an IAVL tree.
+func NewIAVLRangeProof(start, end []byte) *RangeProof {
+	return &RangeProof{
+		Type:  ProofOpIAVLCommitment,
+		Start: start,
+		End:   end,
+	}
+}
+
+// Pairs returns the key/value pairs proven by the RangeProof in ascending order.
+func (rp *RangeProof) Pairs() []KVPair {
+	var pairs []KVPair
+	for _, e := range rp.Entries {
+		pairs = append(pairs, KVPair{Key: e.Key, Value: e.Value})
+	}
+
+	return pairs
+}
+
+// sequence returns all existence proofs of the RangeProof in key order.
+func (rp *RangeProof) sequence() []*ics23.ExistenceProof {
+	seq := make([]*ics23.ExistenceProof, 0, len(rp.Entries)+2)
+	if rp.Left != nil {
+		seq = append(seq, rp.Left)
+	}
+	seq = append(seq, rp.Entries...)
+	if rp.Right != nil {
+		seq = append(seq, rp.Right)
+	}
+
+	return seq
+}
+
+// StoreRoot returns the store root hash proven by the RangeProof.
+func (rp *RangeProof) StoreRoot() ([]byte, error) {
+	seq := rp.sequence()
+	if len(seq) == 0 {
+		// an empty tree has no leaves to prove anything with
+		return emptyHash(), nil
+	}
+
+	root, err := seq[0].Calculate()
+	if err != nil {
+		return nil, errors.Wrapf(storeerrors.ErrInvalidProof, "could not calculate root for proof: %v", err)
+	}
+
+	return root, nil
+}
+
+// rangeProofSpec returns the ics23 spec of the trees proven by range proofs of
+// the given type. The spec is never taken from the proof itself, as it would
+// let the prover choose how its proofs are verified.
+func rangeProofSpec(proofType string) (*ics23.ProofSpec, error) {
+	switch proofType {
+	case ProofOpIAVLCommitment:
+		return ics23.IavlSpec, nil
+	default:
+		return nil, errors.Wrapf(storeerrors.ErrInvalidProof, "unsupported range proof type %q", proofType)
+	}
+}
+
+// Verify verifies that the RangeProof proves exactly the given pairs for the
+// domain [start, end) of the store with the given key, against the given
+// CommitInfo root hash.
+func (rp *RangeProof) Verify(root, storeKey, start, end []byte, pairs []KVPair) error {
+	spec, err := rangeProofSpec(rp.Type)
+	if err != nil {
+		return err
+	}
+	if rp.StoreProof == nil || rp.StoreProof.Proof == nil {
+		return errors.Wrap(storeerrors.ErrInvalidProof, "range proof is missing the store proof")
+	}
+	if !bytes.Equal(rp.StoreProof.Key, storeKey) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "store proof key %X does not match store key %X", rp.StoreProof.Key, storeKey)
+	}
+
+	if len(pairs) != len(rp.Entries) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "expected %d pairs, got %d", len(rp.Entries), len(pairs))
+	}
+	for i, e := range rp.Entries {
+		if !bytes.Equal(pairs[i].Key, e.Key) || !bytes.Equal(pairs[i].Value, e.Value) {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "pair %d does not match proof", i)
+		}
+	}
+
+	storeRoot, err := rp.StoreRoot()
+	if err != nil {
+		return err
+	}
+
+	if err := rp.verifyStoreRoot(spec, storeRoot, start, end); err != nil {
+		return err
+	}
+
+	// the store proof is rebuilt for the store key and the commit info spec, so
+	// that it can't prove the root of another store or tree
+	storeOp := NewSimpleMerkleCommitmentOp(storeKey, rp.StoreProof.Proof)
+	roots, err := storeOp.Run([][]byte{storeRoot})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(roots[0], root) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "calculated root %X does not match expected root %X", roots[0], root)
+	}
+
+	return nil
+}
+
+// verifyStoreRoot verifies the range proof for the domain [start, end) against
+// the given store root hash.
+func (rp *RangeProof) verifyStoreRoot(spec *ics23.ProofSpec, storeRoot, start, end []byte) error {
+	for _, e := range rp.sequence() {
+		if err := e.Verify(spec, storeRoot, e.Key, e.Value); err != nil {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "invalid existence proof for key %X: %v", e.Key, err)
+		}
+	}
+
+	// the boundary proofs must lie outside of the domain, while all entries must
+	// lie within it in strictly ascending order
+	if rp.Left != nil && (start == nil || bytes.Compare(rp.Left.Key, start) >= 0) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "left proof key %X is not left of the range", rp.Left.Key)
+	}
+	if rp.Right != nil && (end == nil || bytes.Compare(rp.Right.Key, end) < 0) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "right proof key %X is not right of the range", rp.Right.Key)
+	}
+	for i, e := range rp.Entries {
+		if start != nil && bytes.Compare(e.Key, start) < 0 {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "key %X is before the range start", e.Key)
+		}
+		if end != nil && bytes.Compare(e.Key, end) >= 0 {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "key %X is after the range end", e.Key)
+		}
+		if i > 0 && bytes.Compare(rp.Entries[i-1].Key, e.Key) >= 0 {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "keys are not in ascending order")
+		}
+	}
+
+	seq := rp.sequence()
+	if len(seq) == 0 {
+		if !bytes.Equal(storeRoot, emptyHash()) {
+			return errors.Wrap(storeerrors.ErrInvalidProof, "empty range proof for a non-empty store")
+		}
+		return nil
+	}
+
+	if rp.Left == nil && !ics23.IsLeftMost(spec.InnerSpec, seq[0].Path) {
+		return errors.Wrap(storeerrors.ErrInvalidProof, "left proof missing, first proof must be left-most")
+	}
+	if rp.Right == nil && !ics23.IsRightMost(spec.InnerSpec, seq[len(seq)-1].Path) {
+		return errors.Wrap(storeerrors.ErrInvalidProof, "right proof missing, last proof must be right-most")
+	}
+	for i := 1; i < len(seq); i++ {
+		if !isLeftNeighbor(spec.InnerSpec, seq[i-1].Path, seq[i].Path) {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "key %X is not the left neighbor of key %X", seq[i-1].Key, seq[i].Key)
+		}
+	}
+
+	return nil
+}
+
+// isLeftNeighbor wraps ics23.IsLeftNeighbor, which panics on malformed paths.
+func isLeftNeighbor(spec *ics23.InnerSpec, left, right []*ics23.InnerOp) (ok bool) {
+	if len(left) == 0 || len(right) == 0 {
+		return false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+
+	return ics23.IsLeftNeighbor(spec, left, right)
+}
+
+// VerifyMembership verifies that the given chain of CommitmentOps, as returned
+// by a proven RootStore query, proves the existence of key with value against
+// the given CommitInfo root hash.
+func VerifyMembership(root []byte, ops []CommitmentOp, key, value []byte) error {
+	return verifyChain(root, ops, key, [][]byte{value})
+}
+
+// VerifyNonMembership verifies that the given chain of CommitmentOps, as
+// returned by a proven RootStore query, proves the absence of key against the
+// given CommitInfo root hash.
+func VerifyNonMembership(root []byte, ops []CommitmentOp, key []byte) error {
+	return verifyChain(root, ops, key, nil)
+}
+
+func verifyChain(root []byte, ops []CommitmentOp, key []byte, args [][]byte) error {
+	if len(ops) == 0 {
+		return errors.Wrap(storeerrors.ErrInvalidProof, "proof is empty")
+	}
+	if !bytes.Equal(ops[0].Key, key) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "proof key %X does not match key %X", ops[0].Key, key)
+	}
+
+	for i, op := range ops {
+		res, err := op.Run(args)
+		if err != nil {
+			return fmt.Errorf("failed to run proof op %d: %w", i, err)
+		}
+
+		args = res
+	}
+
+	if !bytes.Equal(args[0], root) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "calculated root %X does not match expected root %X", args[0], root)
+	}
+
+	return nil
+}
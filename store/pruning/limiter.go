@@ -0,0 +1,124 @@
+package pruning
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cosmossdk.io/store/v2/metrics"
+)
+
+// Limiter throttles pruning to a budget of keys and bytes deleted per second,
+// using a token bucket for each. Up to one second of unused budget is saved up.
+// A zero budget is unlimited, as is a nil Limiter.
+type Limiter struct {
+	keysPerSecond  uint64
+	bytesPerSecond uint64
+
+	mtx sync.Mutex
+	// keys and bytes reflect the available budget, which is negative while the
+	// budget is exceeded
+	keys  float64
+	bytes float64
+	last  time.Time
+
+	// totalKeys and totalBytes reflect the work charged to the Limiter so far
+	totalKeys  uint64
+	totalBytes uint64
+
+	telemetry metrics.StoreMetrics
+}
+
+// NewLimiter returns a new Limiter with the given budget.
+func NewLimiter(keysPerSecond, bytesPerSecond uint64) *Limiter {
+	return &Limiter{
+		keysPerSecond:  keysPerSecond,
+		bytesPerSecond: bytesPerSecond,
+		keys:           float64(keysPerSecond),
+		bytes:          float64(bytesPerSecond),
+		last:           time.Now(),
+	}
+}
+
+// SetMetrics sets the telemetry handler on the Limiter, which reports the keys
+// and bytes charged to it.
+func (l *Limiter) SetMetrics(m metrics.StoreMetrics) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.telemetry = m
+}
+
+// Wait charges the given number of keys and bytes to the budget, and blocks
+// while the budget is exceeded. It returns early with the context error if ctx
+// is done.
+func (l *Limiter) Wait(ctx context.Context, keys, bytes int) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	delay := l.charge(keys, bytes)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// charge charges the given work to the budget, and returns how long the caller
+// must wait for the budget to be available again.
+func (l *Limiter) charge(keys, bytes int) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	l.totalKeys += uint64(keys)
+	l.totalBytes += uint64(bytes)
+	if l.telemetry != nil {
+		if keys > 0 {
+			l.telemetry.IncrCounter(float32(keys), "pruning", "keys")
+		}
+		if bytes > 0 {
+			l.telemetry.IncrCounter(float32(bytes), "pruning", "bytes")
+		}
+	}
+
+	var delay float64
+	if l.keysPerSecond > 0 {
+		l.keys = min(l.keys+elapsed*float64(l.keysPerSecond), float64(l.keysPerSecond)) - float64(keys)
+		if l.keys < 0 {
+			delay = max(delay, -l.keys/float64(l.keysPerSecond))
+		}
+	}
+	if l.bytesPerSecond > 0 {
+		l.bytes = min(l.bytes+elapsed*float64(l.bytesPerSecond), float64(l.bytesPerSecond)) - float64(bytes)
+		if l.bytes < 0 {
+			delay = max(delay, -l.bytes/float64(l.bytesPerSecond))
+		}
+	}
+
+	return time.Duration(delay * float64(time.Second))
+}
+
+// Totals returns the number of keys and bytes charged to the Limiter so far.
+func (l *Limiter) Totals() (keys, bytes uint64) {
+	if l == nil {
+		return 0, 0
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.totalKeys, l.totalBytes
+}
@@ -0,0 +1,295 @@
+package pruning
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/metrics"
+)
+
+const (
+	targetVersionKey = "p/target" // the version pruning was last scheduled up to
+	prunedVersionKey = "p/pruned" // the version pruning last completed up to
+)
+
+// Pruner defines a backend which can be pruned by the Manager.
+type Pruner interface {
+	// PruneWithLimiter prunes all versions up to and including the given version,
+	// charging the work done to the given Limiter. If ctx is done, it returns the
+	// context error, and pruning the same version again resumes the work.
+	PruneWithLimiter(ctx context.Context, version uint64, limiter *Limiter) error
+}
+
+// Options defines the configuration of the Manager.
+type Options struct {
+	store.PruneOptions
+
+	// KeysPerSecond is the number of keys the Manager may delete per second. If
+	// set to 0, it is unlimited.
+	KeysPerSecond uint64
+
+	// BytesPerSecond is the number of bytes the Manager may delete per second.
+	// If set to 0, it is unlimited.
+	BytesPerSecond uint64
+}
+
+// Progress reflects the progress of the Manager.
+type Progress struct {
+	// TargetVersion is the version pruning is scheduled up to.
+	TargetVersion uint64
+	// PrunedVersion is the version pruning completed up to.
+	PrunedVersion uint64
+	// Running is true while the Manager is pruning.
+	Running bool
+	// KeysPruned and BytesPruned reflect the work done since the Manager started.
+	KeysPruned  uint64
+	BytesPruned uint64
+}
+
+// Manager prunes the SS and SC backends in the background, instead of inline
+// with Commit, within the budget of a Limiter. The scheduled and completed
+// versions are persisted, so an interrupted run is resumed once the Manager is
+// started again.
+type Manager struct {
+	logger  log.Logger
+	db      store.RawDB
+	opts    Options
+	pruners []Pruner
+	limiter *Limiter
+
+	telemetry metrics.StoreMetrics
+
+	mtx           sync.Mutex
+	targetVersion uint64
+	prunedVersion uint64
+	running       bool
+
+	chWake chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewManager returns a new Manager pruning the given SS and SC backends, in that
+// order, whose state is persisted in db.
+func NewManager(db store.RawDB, ss, sc Pruner, opts Options, logger log.Logger) (*Manager, error) {
+	m := &Manager{
+		logger:  logger.With("module", "pruning_manager"),
+		db:      db,
+		opts:    opts,
+		pruners: []Pruner{ss, sc},
+		limiter: NewLimiter(opts.KeysPerSecond, opts.BytesPerSecond),
+		chWake:  make(chan struct{}, 1),
+	}
+
+	var err error
+	if m.targetVersion, err = m.getVersion(targetVersionKey); err != nil {
+		return nil, err
+	}
+	if m.prunedVersion, err = m.getVersion(prunedVersionKey); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SetMetrics sets the telemetry handler on the Manager.
+func (m *Manager) SetMetrics(telemetry metrics.StoreMetrics) {
+	m.telemetry = telemetry
+	m.limiter.SetMetrics(telemetry)
+}
+
+func (m *Manager) getVersion(key string) (uint64, error) {
+	bz, err := m.db.Get([]byte(key))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	if bz == nil {
+		return 0, nil
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid %s value length %d", key, len(bz))
+	}
+
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+func (m *Manager) setVersion(key string, version uint64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+
+	batch := m.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set([]byte(key), bz); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
+// Start starts pruning in the background, resuming any scheduled pruning that
+// did not complete.
+func (m *Manager) Start() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.done != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go m.run(ctx, m.done)
+
+	m.wake()
+}
+
+// Stop interrupts any pruning in progress and stops the Manager. The
+// interrupted pruning is resumed once the Manager is started again.
+func (m *Manager) Stop() {
+	m.mtx.Lock()
+	cancel, done := m.cancel, m.done
+	m.cancel, m.done = nil, nil
+	m.mtx.Unlock()
+
+	if done == nil {
+		return
+	}
+
+	cancel()
+	<-done
+}
+
+// SignalCommit schedules pruning according to the prune options, once the
+// given version has been committed.
+func (m *Manager) SignalCommit(version uint64) error {
+	if prune, pruneVersion := m.opts.ShouldPrune(version); prune {
+		return m.Schedule(pruneVersion)
+	}
+
+	return nil
+}
+
+// Schedule schedules pruning of all versions up to and including the given
+// version. It returns once the schedule is persisted, without waiting for the
+// pruning to complete.
+func (m *Manager) Schedule(version uint64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if version <= m.targetVersion {
+		return nil
+	}
+	if err := m.setVersion(targetVersionKey, version); err != nil {
+		return fmt.Errorf("failed to schedule pruning: %w", err)
+	}
+	m.targetVersion = version
+
+	if m.telemetry != nil {
+		m.telemetry.SetGauge(float32(version), "pruning", "target_version")
+	}
+
+	m.wake()
+	return nil
+}
+
+// wake wakes up the pruning goroutine without blocking. The caller must hold mtx.
+func (m *Manager) wake() {
+	select {
+	case m.chWake <- struct{}{}:
+	default:
+	}
+}
+
+// Progress returns the progress of the Manager.
+func (m *Manager) Progress() Progress {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	keys, bytes := m.limiter.Totals()
+	return Progress{
+		TargetVersion: m.targetVersion,
+		PrunedVersion: m.prunedVersion,
+		Running:       m.running,
+		KeysPruned:    keys,
+		BytesPruned:   bytes,
+	}
+}
+
+func (m *Manager) run(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.chWake:
+		}
+
+		m.mtx.Lock()
+		target, pruned := m.targetVersion, m.prunedVersion
+		m.running = target > pruned
+		m.mtx.Unlock()
+
+		if target <= pruned {
+			continue
+		}
+
+		err := m.prune(ctx, target)
+
+		m.mtx.Lock()
+		m.running = false
+		if err == nil {
+			m.prunedVersion = target
+			// pruning may have been scheduled further in the meantime
+			if m.targetVersion > target {
+				m.wake()
+			}
+		}
+		m.mtx.Unlock()
+
+		switch {
+		case err == nil:
+		case errors.Is(err, context.Canceled):
+			m.logger.Info("pruning interrupted", "target_version", target)
+			return
+		default:
+			// the pruning is retried once it is scheduled again
+			m.logger.Error("failed to prune", "target_version", target, "err", err)
+			if m.telemetry != nil {
+				m.telemetry.IncrCounter(1, "pruning", "errors")
+			}
+		}
+	}
+}
+
+// prune prunes all backends up to the given version, and persists its completion.
+func (m *Manager) prune(ctx context.Context, version uint64) error {
+	if m.telemetry != nil {
+		now := time.Now()
+		defer m.telemetry.MeasureSince(now, "pruning", "prune")
+	}
+
+	for _, p := range m.pruners {
+		if err := p.PruneWithLimiter(ctx, version, m.limiter); err != nil {
+			return err
+		}
+	}
+
+	if err := m.setVersion(prunedVersionKey, version); err != nil {
+		return fmt.Errorf("failed to persist pruned version: %w", err)
+	}
+
+	if m.telemetry != nil {
+		m.telemetry.SetGauge(float32(version), "pruning", "pruned_version")
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package pruning
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2"
+	dbm "cosmossdk.io/store/v2/db"
+)
+
+// mockPruner records the versions it pruned. While blocked, it waits for its
+// context to be done.
+type mockPruner struct {
+	mtx     sync.Mutex
+	pruned  []uint64
+	blocked bool
+	started chan struct{}
+}
+
+func newMockPruner() *mockPruner {
+	return &mockPruner{started: make(chan struct{}, 16)}
+}
+
+func (p *mockPruner) PruneWithLimiter(ctx context.Context, version uint64, limiter *Limiter) error {
+	p.started <- struct{}{}
+
+	p.mtx.Lock()
+	blocked := p.blocked
+	p.mtx.Unlock()
+	if blocked {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	if err := limiter.Wait(ctx, 10, 100); err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.pruned = append(p.pruned, version)
+	return nil
+}
+
+func (p *mockPruner) setBlocked(blocked bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.blocked = blocked
+}
+
+func (p *mockPruner) prunedVersions() []uint64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]uint64(nil), p.pruned...)
+}
+
+func waitPruned(t *testing.T, m *Manager, version uint64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return m.Progress().PrunedVersion == version
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_Schedule(t *testing.T) {
+	ss, sc := newMockPruner(), newMockPruner()
+	opts := Options{PruneOptions: store.PruneOptions{KeepRecent: 2, Interval: 5}}
+	m, err := NewManager(dbm.NewMemDB(), ss, sc, opts, log.NewNopLogger())
+	require.NoError(t, err)
+
+	m.Start()
+	defer m.Stop()
+
+	for v := uint64(1); v <= 10; v++ {
+		require.NoError(t, m.SignalCommit(v))
+	}
+	waitPruned(t, m, 7)
+
+	// both backends are pruned, without pruning any version twice
+	require.Contains(t, ss.prunedVersions(), uint64(7))
+	require.Equal(t, ss.prunedVersions(), sc.prunedVersions())
+
+	// scheduling an already pruned version is a no-op
+	require.NoError(t, m.Schedule(3))
+	progress := m.Progress()
+	require.Equal(t, uint64(7), progress.TargetVersion)
+	require.False(t, progress.Running)
+	require.NotZero(t, progress.KeysPruned)
+	require.NotZero(t, progress.BytesPruned)
+}
+
+func TestManager_Resume(t *testing.T) {
+	db := dbm.NewMemDB()
+	ss, sc := newMockPruner(), newMockPruner()
+	ss.setBlocked(true)
+
+	m, err := NewManager(db, ss, sc, Options{}, log.NewNopLogger())
+	require.NoError(t, err)
+	m.Start()
+
+	require.NoError(t, m.Schedule(10))
+	<-ss.started
+	require.True(t, m.Progress().Running)
+
+	// stopping interrupts the pruning in progress
+	m.Stop()
+	progress := m.Progress()
+	require.Equal(t, uint64(10), progress.TargetVersion)
+	require.Zero(t, progress.PrunedVersion)
+	require.Empty(t, sc.prunedVersions())
+
+	// a new manager on the same database resumes the pruning once started
+	ss.setBlocked(false)
+	m, err = NewManager(db, ss, sc, Options{}, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), m.Progress().TargetVersion)
+
+	m.Start()
+	defer m.Stop()
+	waitPruned(t, m, 10)
+	require.Equal(t, []uint64{10}, ss.prunedVersions())
+	require.Equal(t, []uint64{10}, sc.prunedVersions())
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	// an unlimited budget never waits
+	var unlimited *Limiter
+	require.NoError(t, unlimited.Wait(ctx, 1_000_000, 1_000_000))
+	require.NoError(t, NewLimiter(0, 0).Wait(ctx, 1_000_000, 1_000_000))
+
+	// the work exceeding the saved up budget is waited for
+	l := NewLimiter(1000, 0)
+	start := time.Now()
+	require.NoError(t, l.Wait(ctx, 1000, 0))
+	require.NoError(t, l.Wait(ctx, 200, 0))
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	keys, bytes := l.Totals()
+	require.Equal(t, uint64(1200), keys)
+	require.Zero(t, bytes)
+
+	// the bytes budget is enforced independently
+	l = NewLimiter(0, 100)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	require.ErrorIs(t, l.Wait(ctx, 0, 1000), context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -14,9 +14,12 @@ import (
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/v2"
+	storeerrors "cosmossdk.io/store/v2/errors"
 	"cosmossdk.io/store/v2/metrics"
 	"cosmossdk.io/store/v2/migration"
 	"cosmossdk.io/store/v2/proof"
+	"cosmossdk.io/store/v2/pruning"
+	"cosmossdk.io/store/v2/storage"
 )
 
 var _ store.RootStore = (*Store)(nil)
@@ -47,6 +50,18 @@ type Store struct {
 	// telemetry reflects a telemetry agent responsible for emitting metrics (if any)
 	telemetry metrics.StoreMetrics
 
+	// wal reflects the optional changeset write-ahead log used to recover from a
+	// crash in between writing the SS and SC backends
+	wal *ChangesetWAL
+
+	// pruningManager reflects the optional manager pruning the SS and SC backends
+	// in the background
+	pruningManager *pruning.Manager
+
+	// stats reflects the optional tracker of the statistics of the stores, which
+	// records every committed changeset
+	stats *metrics.StatsTracker
+
 	// Migration related fields
 	// migrationManager reflects the migration manager used to migrate state from v1 to v2
 	migrationManager *migration.Manager
@@ -57,6 +72,13 @@ type Store struct {
 	chDone chan struct{}
 	// isMigrating reflects whether the store is currently migrating
 	isMigrating bool
+
+	// readOnly reflects whether the store serves the secondary instances of the
+	// SS and SC backends of a primary store, and so rejects all writes
+	readOnly bool
+	// catchUpMtx guards the reads of a read-only store against catching up
+	// with the primary store
+	catchUpMtx sync.RWMutex
 }
 
 func New(
@@ -76,11 +98,118 @@ func New(
 	}, nil
 }
 
+// NewReadOnly creates a read-only RootStore over the secondary instances of the
+// SS and SC backends of a primary RootStore, which may run in another process,
+// e.g. as created by rocksdb.NewSecondary and a CommitStore over the database of
+// db.NewRocksDBSecondary. Both backends are caught up with the primary once, and
+// backends which cannot be opened as secondary instances, such as PebbleDB,
+// which does not support access from multiple processes, fail with
+// errors.ErrSecondaryUnsupported.
+//
+// The store serves StateAt, Query and QueryRange for the versions committed to
+// both backends as of the last call to LoadLatestVersion or
+// TryCatchUpWithPrimary, which is expected to be called periodically to follow
+// the primary. All methods writing state return errors.ErrReadOnly.
+func NewReadOnly(
+	logger log.Logger,
+	ss store.VersionedDatabase,
+	sc store.Committer,
+	m metrics.StoreMetrics,
+) (*Store, error) {
+	ssSecondary, ok := ss.(store.Secondary)
+	if !ok {
+		return nil, fmt.Errorf("SS backend: %w", storeerrors.ErrSecondaryUnsupported)
+	}
+	scSecondary, ok := sc.(store.Secondary)
+	if !ok {
+		return nil, fmt.Errorf("SC backend: %w", storeerrors.ErrSecondaryUnsupported)
+	}
+	// the backends wrapping databases, such as storage.StorageStore, only know
+	// whether their databases are secondary instances once caught up
+	if err := scSecondary.TryCatchUpWithPrimary(); err != nil {
+		return nil, fmt.Errorf("failed to catch up SC store: %w", err)
+	}
+	if err := ssSecondary.TryCatchUpWithPrimary(); err != nil {
+		return nil, fmt.Errorf("failed to catch up SS store: %w", err)
+	}
+
+	return &Store{
+		logger:          logger.With("module", "root_store"),
+		initialVersion:  1,
+		stateStorage:    ss,
+		stateCommitment: sc,
+		telemetry:       m,
+		readOnly:        true,
+	}, nil
+}
+
+// TryCatchUpWithPrimary catches up the SS and SC backends of a read-only store
+// with the primary store, and then serves the latest version committed to both.
+func (s *Store) TryCatchUpWithPrimary() error {
+	if !s.readOnly {
+		return fmt.Errorf("cannot catch up a primary store")
+	}
+
+	if s.telemetry != nil {
+		now := time.Now()
+		defer s.telemetry.MeasureSince(now, "root_store", "catch_up")
+	}
+
+	s.catchUpMtx.Lock()
+	defer s.catchUpMtx.Unlock()
+
+	if err := s.stateCommitment.(store.Secondary).TryCatchUpWithPrimary(); err != nil {
+		return fmt.Errorf("failed to catch up SC store: %w", err)
+	}
+	if err := s.stateStorage.(store.Secondary).TryCatchUpWithPrimary(); err != nil {
+		return fmt.Errorf("failed to catch up SS store: %w", err)
+	}
+
+	return s.loadReadOnlyVersion()
+}
+
+// loadReadOnlyVersion sets the version served by a read-only store to the latest
+// version committed to both SS and SC, as the primary commits them concurrently.
+func (s *Store) loadReadOnlyVersion() error {
+	scVersion, err := s.stateCommitment.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	ssVersion, err := s.stateStorage.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+
+	version := min(scVersion, ssVersion)
+	cInfo := &proof.CommitInfo{Version: version}
+	if version > 0 {
+		if cInfo, err = s.stateCommitment.GetCommitInfo(version); err != nil {
+			return err
+		}
+		if cInfo == nil {
+			return fmt.Errorf("commit info not found for version %d", version)
+		}
+	}
+
+	s.logger.Debug("loaded read-only version", "version", version)
+	s.lastCommitInfo = cInfo
+
+	return nil
+}
+
 // Close closes the store and resets all internal fields. Note, Close() is NOT
 // idempotent and should only be called once.
 func (s *Store) Close() (err error) {
+	if s.pruningManager != nil {
+		// interrupt pruning before closing the backends, it is resumed once restarted
+		s.pruningManager.Stop()
+	}
+
 	err = errors.Join(err, s.stateStorage.Close())
 	err = errors.Join(err, s.stateCommitment.Close())
+	if s.wal != nil {
+		err = errors.Join(err, s.wal.Close())
+	}
 
 	s.stateStorage = nil
 	s.stateCommitment = nil
@@ -92,9 +221,117 @@ func (s *Store) Close() (err error) {
 
 func (s *Store) SetMetrics(m metrics.Metrics) {
 	s.telemetry = m
+	if ss, ok := s.stateStorage.(*storage.StorageStore); ok {
+		ss.SetMetrics(m)
+	}
+	if s.pruningManager != nil {
+		s.pruningManager.SetMetrics(m)
+	}
+}
+
+// SetChangesetWAL sets the changeset write-ahead log on the RootStore. It must
+// be set prior to loading a version, so any incomplete commit is recovered.
+func (s *Store) SetChangesetWAL(wal *ChangesetWAL) {
+	s.wal = wal
+}
+
+// SetPruningManager sets the pruning manager on the RootStore. Once set, pruning
+// is scheduled on it after every commit according to its prune options, and
+// Prune only schedules pruning instead of pruning inline. The SS and SC
+// backends should then be created without prune options of their own. The
+// RootStore stops the manager when closed.
+func (s *Store) SetPruningManager(pm *pruning.Manager) {
+	s.pruningManager = pm
+}
+
+// EnableStats enables the tracking of the number of keys and bytes of every
+// store, the write rate of their prefixes and their most written keys, which
+// are then returned by Stats and emitted as telemetry after every commit. The
+// size of the existing stores is counted by iterating them in SS at the latest
+// version, so it must be called once the latest version is loaded.
+//
+// Note, every commit then reads the previous value of each written key from SS.
+func (s *Store) EnableStats(cfg metrics.StatsConfig) error {
+	stats := metrics.NewStatsTracker(cfg)
+
+	version, err := s.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version > 0 {
+		cInfo, err := s.stateCommitment.GetCommitInfo(version)
+		if err != nil {
+			return fmt.Errorf("failed to get commit info for version %d: %w", version, err)
+		}
+		if cInfo == nil {
+			return fmt.Errorf("commit info not found for version %d", version)
+		}
+
+		for _, si := range cInfo.StoreInfos {
+			keys, size, err := s.storeSize(si.Name, version)
+			if err != nil {
+				return fmt.Errorf("failed to count the size of store %s: %w", si.Name, err)
+			}
+			stats.SetStoreSize(string(si.Name), keys, size)
+		}
+	}
+
+	s.stats = stats
+	return nil
+}
+
+// storeSize returns the number of keys and bytes of the given store in SS at
+// the given version.
+func (s *Store) storeSize(storeKey []byte, version uint64) (keys, size int64, err error) {
+	itr, err := s.stateStorage.Iterator(storeKey, version, nil, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		keys++
+		size += int64(len(itr.Key()) + len(itr.Value()))
+	}
+
+	return keys, size, itr.Error()
+}
+
+// Stats returns the statistics of the stores. An error is returned if stats
+// are not enabled.
+func (s *Store) Stats() (metrics.Stats, error) {
+	if s.stats == nil {
+		return metrics.Stats{}, fmt.Errorf("stats are not enabled")
+	}
+
+	return s.stats.Stats(), nil
+}
+
+// recordStats records the changeset about to be committed at the given version
+// in the stats, before it is applied to SS.
+func (s *Store) recordStats(version uint64, cs *corestore.Changeset) {
+	err := s.stats.RecordChangeset(version, cs, func(storeKey, key []byte) ([]byte, error) {
+		if version <= 1 {
+			return nil, nil
+		}
+		return s.stateStorage.Get(storeKey, version-1, key)
+	})
+	if err != nil {
+		// the stats are best effort, so failing to record them is not fatal
+		s.logger.Error("failed to record stats", "version", version, "err", err)
+		return
+	}
+
+	if s.telemetry != nil {
+		s.stats.Emit(s.telemetry)
+	}
 }
 
 func (s *Store) SetInitialVersion(v uint64) error {
+	if s.readOnly {
+		return storeerrors.ErrReadOnly
+	}
+
 	s.initialVersion = v
 
 	return s.stateCommitment.SetInitialVersion(v)
@@ -110,6 +347,9 @@ func (s *Store) StateLatest() (uint64, corestore.ReaderMap, error) {
 }
 
 func (s *Store) StateAt(v uint64) (corestore.ReaderMap, error) {
+	s.catchUpMtx.RLock()
+	defer s.catchUpMtx.RUnlock()
+
 	// TODO(bez): We may want to avoid relying on the SC metadata here. Instead,
 	// we should add a VersionExists() method to the VersionedDatabase interface.
 	//
@@ -133,6 +373,9 @@ func (s *Store) GetStateCommitment() store.Committer {
 // If an internal CommitInfo is not set, a new one will be returned with only the
 // latest version set, which is based off of the SC view.
 func (s *Store) LastCommitID() (proof.CommitID, error) {
+	s.catchUpMtx.RLock()
+	defer s.catchUpMtx.RUnlock()
+
 	if s.lastCommitInfo != nil {
 		return s.lastCommitInfo.CommitID(), nil
 	}
@@ -163,6 +406,9 @@ func (s *Store) Query(storeKey []byte, version uint64, key []byte, prove bool) (
 		defer s.telemetry.MeasureSince(now, "root_store", "query")
 	}
 
+	s.catchUpMtx.RLock()
+	defer s.catchUpMtx.RUnlock()
+
 	val, err := s.stateStorage.Get(storeKey, version, key)
 	if err != nil || val == nil {
 		// fallback to querying SC backend if not found in SS backend
@@ -199,12 +445,114 @@ func (s *Store) Query(storeKey []byte, version uint64, key []byte, prove bool) (
 	return result, nil
 }
 
+func (s *Store) QueryRange(storeKey []byte, version uint64, start, end []byte, limit int, prove bool) (store.RangeQueryResult, error) {
+	// the limit bounds the work of a query, in particular of a proven one which
+	// would otherwise prove the whole store
+	if limit <= 0 || limit > store.MaxRangeQueryLimit {
+		return store.RangeQueryResult{}, fmt.Errorf("range query limit must be within (0, %d], got %d", store.MaxRangeQueryLimit, limit)
+	}
+
+	if s.telemetry != nil {
+		now := time.Now()
+		defer s.telemetry.MeasureSince(now, "root_store", "query_range")
+	}
+
+	s.catchUpMtx.RLock()
+	defer s.catchUpMtx.RUnlock()
+
+	result := store.RangeQueryResult{
+		Start:   start,
+		End:     end,
+		Version: version,
+	}
+
+	// a proven range must be served from SC, as the proof commits to the exact
+	// set of pairs in the tree
+	if prove {
+		rp, err := s.stateCommitment.GetRangeProof(storeKey, version, start, end, limit)
+		if err != nil {
+			return store.RangeQueryResult{}, fmt.Errorf("failed to get SC store range proof: %w", err)
+		}
+
+		// a truncated proof ends before the next key of the domain
+		if !bytes.Equal(rp.End, end) {
+			result.End = rp.End
+			result.NextKey = rp.End
+		}
+		result.Pairs = rp.Pairs()
+		result.Proof = rp
+		return result, nil
+	}
+
+	itr, err := s.stateStorage.Iterator(storeKey, version, start, end)
+	if err != nil {
+		return store.RangeQueryResult{}, fmt.Errorf("failed to query SS store: %w", err)
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		if len(result.Pairs) == limit {
+			result.End = bytes.Clone(itr.Key())
+			result.NextKey = result.End
+			break
+		}
+		result.Pairs = append(result.Pairs, proof.KVPair{
+			Key:   bytes.Clone(itr.Key()),
+			Value: bytes.Clone(itr.Value()),
+		})
+	}
+	if err := itr.Error(); err != nil {
+		return store.RangeQueryResult{}, fmt.Errorf("failed to iterate SS store: %w", err)
+	}
+
+	return result, nil
+}
+
+// changedKeysReader is implemented by the SC backends which can list the keys
+// changed by a range of versions, such as commitment.CommitStore.
+type changedKeysReader interface {
+	ChangedKeys(storeKey []byte, from, to uint64) ([][]byte, error)
+}
+
+// StateDiff calls fn with every key of the given store within the range
+// [start, end) which was added, modified or deleted from version from to version
+// to, in ascending key order, as reflected by SS. While SC can extract the
+// changesets of the versions in between, only the keys they changed are read
+// from SS; otherwise both versions of the range are iterated. See storage.Diff.
+func (s *Store) StateDiff(storeKey []byte, from, to uint64, start, end []byte, fn func(storage.KeyDiff) error) error {
+	if sc, ok := s.stateCommitment.(changedKeysReader); ok {
+		keys, err := sc.ChangedKeys(storeKey, from, to)
+		if err == nil {
+			keys = slices.DeleteFunc(keys, func(key []byte) bool {
+				return (start != nil && bytes.Compare(key, start) < 0) || (end != nil && bytes.Compare(key, end) >= 0)
+			})
+			return storage.DiffKeys(s.stateStorage, storeKey, from, to, keys, fn)
+		}
+		s.logger.Debug("iterating the state diff from SS", "from", from, "to", to, "err", err)
+	}
+
+	return storage.Diff(s.stateStorage, storeKey, from, to, start, end, fn)
+}
+
 func (s *Store) LoadLatestVersion() error {
 	if s.telemetry != nil {
 		now := time.Now()
 		defer s.telemetry.MeasureSince(now, "root_store", "load_latest_version")
 	}
 
+	if s.readOnly {
+		s.catchUpMtx.Lock()
+		defer s.catchUpMtx.Unlock()
+
+		return s.loadReadOnlyVersion()
+	}
+
+	if s.wal != nil {
+		if err := s.recoverWAL(); err != nil {
+			return fmt.Errorf("failed to recover changeset WAL: %w", err)
+		}
+	}
+
 	lv, err := s.GetLatestVersion()
 	if err != nil {
 		return err
@@ -213,12 +561,74 @@ func (s *Store) LoadLatestVersion() error {
 	return s.loadVersion(lv)
 }
 
+// recoverWAL brings the SS and SC backends back to the same version after a
+// crash that interrupted a Commit. For each Changeset recorded in the WAL, the
+// backend that did not persist it is replayed. If neither backend persisted it,
+// the entry is discarded, as the block is expected to be executed again.
+func (s *Store) recoverWAL() error {
+	entries, err := s.wal.Entries()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		scVersion, err := s.stateCommitment.GetLatestVersion()
+		if err != nil {
+			return err
+		}
+		ssVersion, err := s.stateStorage.GetLatestVersion()
+		if err != nil {
+			return err
+		}
+
+		version := entry.Version
+		switch {
+		case scVersion >= version && ssVersion >= version:
+			// the commit completed prior to the crash
+
+		case scVersion >= version:
+			s.logger.Info("replaying changeset WAL to SS", "version", version, "ss_version", ssVersion)
+			if err := s.stateStorage.ApplyChangeset(version, entry.Changeset); err != nil {
+				return fmt.Errorf("failed to replay SS version %d: %w", version, err)
+			}
+
+		case ssVersion >= version && scVersion+1 == version:
+			s.logger.Info("replaying changeset WAL to SC", "version", version, "sc_version", scVersion)
+			if err := s.stateCommitment.LoadVersion(scVersion); err != nil {
+				return fmt.Errorf("failed to load SC version %d: %w", scVersion, err)
+			}
+			if err := s.stateCommitment.WriteBatch(entry.Changeset); err != nil {
+				return fmt.Errorf("failed to replay SC version %d: %w", version, err)
+			}
+			if _, err := s.stateCommitment.Commit(version); err != nil {
+				return fmt.Errorf("failed to replay SC version %d: %w", version, err)
+			}
+
+		case ssVersion >= version:
+			return fmt.Errorf("cannot replay changeset WAL version %d; SC is at version %d", version, scVersion)
+
+		default:
+			s.logger.Info("discarding changeset WAL", "version", version, "ss_version", ssVersion, "sc_version", scVersion)
+		}
+
+		if err := s.wal.Delete(version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Store) LoadVersion(version uint64) error {
 	if s.telemetry != nil {
 		now := time.Now()
 		defer s.telemetry.MeasureSince(now, "root_store", "load_version")
 	}
 
+	if s.readOnly {
+		return storeerrors.ErrReadOnly
+	}
+
 	return s.loadVersion(version)
 }
 
@@ -255,6 +665,10 @@ func (s *Store) WorkingHash(cs *corestore.Changeset) ([]byte, error) {
 		defer s.telemetry.MeasureSince(now, "root_store", "working_hash")
 	}
 
+	if s.readOnly {
+		return nil, storeerrors.ErrReadOnly
+	}
+
 	if s.workingHash == nil {
 		if err := s.writeSC(cs); err != nil {
 			return nil, err
@@ -277,6 +691,10 @@ func (s *Store) Commit(cs *corestore.Changeset) ([]byte, error) {
 		defer s.telemetry.MeasureSince(now, "root_store", "commit")
 	}
 
+	if s.readOnly {
+		return nil, storeerrors.ErrReadOnly
+	}
+
 	if s.workingHash == nil {
 		return nil, fmt.Errorf("working hash is nil; must call WorkingHash() before Commit()")
 	}
@@ -287,6 +705,19 @@ func (s *Store) Commit(cs *corestore.Changeset) ([]byte, error) {
 		s.logger.Debug("commit header and version mismatch", "header_height", s.commitHeader.Height, "version", version)
 	}
 
+	// durably record the changeset before touching either backend, so a crash
+	// in between the two can be recovered from
+	if s.wal != nil && !s.isMigrating {
+		if err := s.wal.Write(version, cs); err != nil {
+			return nil, fmt.Errorf("failed to write changeset WAL: %w", err)
+		}
+	}
+
+	// the previous values of the written keys must be read before SS is written
+	if s.stats != nil && !s.isMigrating {
+		s.recordStats(version, cs)
+	}
+
 	eg := new(errgroup.Group)
 
 	// commit SS async
@@ -323,11 +754,27 @@ func (s *Store) Commit(cs *corestore.Changeset) ([]byte, error) {
 
 	s.workingHash = nil
 
+	if s.pruningManager != nil && !s.isMigrating {
+		// the commit has succeeded, so failing to schedule pruning is not fatal
+		if err := s.pruningManager.SignalCommit(version); err != nil {
+			s.logger.Error("failed to schedule pruning", "version", version, "err", err)
+		}
+	}
+
 	return s.lastCommitInfo.Hash(), nil
 }
 
-// Prune prunes the root store to the provided version.
+// Prune prunes the root store to the provided version. If a pruning manager is
+// set, the pruning is only scheduled on it.
 func (s *Store) Prune(version uint64) error {
+	if s.readOnly {
+		return storeerrors.ErrReadOnly
+	}
+
+	if s.pruningManager != nil {
+		return s.pruningManager.Schedule(version)
+	}
+
 	if s.telemetry != nil {
 		now := time.Now()
 		defer s.telemetry.MeasureSince(now, "root_store", "prune")
@@ -348,6 +795,10 @@ func (s *Store) Prune(version uint64) error {
 // An error is returned if migration is already in progress.
 // NOTE: This method should only be called once after loadVersion.
 func (s *Store) StartMigration() error {
+	if s.readOnly {
+		return storeerrors.ErrReadOnly
+	}
+
 	if s.isMigrating {
 		return fmt.Errorf("migration already in progress")
 	}
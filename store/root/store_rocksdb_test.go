@@ -0,0 +1,133 @@
+//go:build rocksdb
+// +build rocksdb
+
+package root
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/commitment/iavl"
+	dbm "cosmossdk.io/store/v2/db"
+	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/rocksdb"
+)
+
+func TestReadOnlyStore(t *testing.T) {
+	noopLog := log.NewNopLogger()
+	ssDir, scDir := t.TempDir(), t.TempDir()
+
+	newSC := func(db store.RawDB) *commitment.CommitStore {
+		tree := iavl.NewIavlTree(dbm.NewPrefixDB(db, []byte("t1/")), noopLog, iavl.DefaultConfig())
+		tree2 := iavl.NewIavlTree(dbm.NewPrefixDB(db, []byte("t2/")), noopLog, iavl.DefaultConfig())
+		sc, err := commitment.NewCommitStore(map[string]commitment.Tree{testStoreKey: tree, testStoreKey2: tree2}, db, nil, noopLog)
+		require.NoError(t, err)
+		return sc
+	}
+
+	// the primary store
+	ssDB, err := rocksdb.New(ssDir)
+	require.NoError(t, err)
+	scDB, err := dbm.NewRocksDB("sc", scDir)
+	require.NoError(t, err)
+	primary, err := New(noopLog, storage.NewStorageStore(ssDB, nil, noopLog), newSC(scDB), nil, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, primary.Close())
+		require.NoError(t, scDB.Close())
+	}()
+
+	commit := func(v uint64) {
+		cs := corestore.NewChangeset()
+		cs.Add(testStoreKeyBytes, []byte("key"), []byte(fmt.Sprintf("val%03d", v)), false)
+		cs.Add(testStoreKey2Bytes, []byte(fmt.Sprintf("key%03d", v)), []byte("val"), false)
+
+		_, err := primary.WorkingHash(cs)
+		require.NoError(t, err)
+		_, err = primary.Commit(cs)
+		require.NoError(t, err)
+	}
+	for v := uint64(1); v <= 3; v++ {
+		commit(v)
+	}
+
+	// the read-only store opens the databases held by the primary store as
+	// secondary instances, which keep their own logs
+	ssSecondary, err := rocksdb.NewSecondary(ssDir, t.TempDir())
+	require.NoError(t, err)
+	ss := storage.NewStorageStore(ssSecondary, nil, noopLog)
+	ss.EnableReadCache(16)
+	scSecondary, err := dbm.NewRocksDBSecondary("sc", scDir, t.TempDir())
+	require.NoError(t, err)
+	rs, err := NewReadOnly(noopLog, ss, newSC(scSecondary), nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, rs.Close())
+		require.NoError(t, scSecondary.Close())
+	}()
+	require.NoError(t, rs.LoadLatestVersion())
+
+	primaryID, err := primary.LastCommitID()
+	require.NoError(t, err)
+	readOnlyID, err := rs.LastCommitID()
+	require.NoError(t, err)
+	require.Equal(t, primaryID, readOnlyID)
+
+	res, err := rs.Query(testStoreKeyBytes, 3, []byte("key"), true)
+	require.NoError(t, err)
+	require.Equal(t, []byte("val003"), res.Value)
+	require.NotEmpty(t, res.ProofOps)
+
+	// an iterator opened before catching up keeps reading its version
+	ro, err := rs.StateAt(3)
+	require.NoError(t, err)
+	reader, err := ro.GetReader(testStoreKey2Bytes)
+	require.NoError(t, err)
+	itr, err := reader.Iterator(nil, nil)
+	require.NoError(t, err)
+
+	// writes by the primary are only visible once caught up
+	commit(4)
+	_, err = rs.StateAt(4)
+	require.Error(t, err)
+	res, err = rs.Query(testStoreKeyBytes, 4, []byte("key"), false)
+	require.NoError(t, err)
+	require.Equal(t, []byte("val003"), res.Value)
+
+	require.NoError(t, rs.TryCatchUpWithPrimary())
+
+	latest, err := rs.GetLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), latest)
+	res, err = rs.Query(testStoreKeyBytes, 4, []byte("key"), true)
+	require.NoError(t, err)
+	require.Equal(t, []byte("val004"), res.Value)
+	require.NotEmpty(t, res.ProofOps)
+	_, err = rs.StateAt(4)
+	require.NoError(t, err)
+
+	var keys int
+	for ; itr.Valid(); itr.Next() {
+		keys++
+	}
+	require.NoError(t, itr.Error())
+	require.NoError(t, itr.Close())
+	require.Equal(t, 3, keys)
+
+	// the read-only store has no write path
+	_, err = rs.WorkingHash(corestore.NewChangeset())
+	require.ErrorIs(t, err, storeerrors.ErrReadOnly)
+	_, err = rs.Commit(corestore.NewChangeset())
+	require.ErrorIs(t, err, storeerrors.ErrReadOnly)
+	require.ErrorIs(t, rs.LoadVersion(2), storeerrors.ErrReadOnly)
+	require.ErrorIs(t, rs.Prune(2), storeerrors.ErrReadOnly)
+	require.ErrorIs(t, rs.SetInitialVersion(2), storeerrors.ErrReadOnly)
+	require.ErrorIs(t, rs.StartMigration(), storeerrors.ErrReadOnly)
+}
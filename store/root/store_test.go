@@ -2,8 +2,11 @@ package root
 
 import (
 	"fmt"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	coreheader "cosmossdk.io/core/header"
@@ -13,7 +16,12 @@ import (
 	"cosmossdk.io/store/v2/commitment"
 	"cosmossdk.io/store/v2/commitment/iavl"
 	dbm "cosmossdk.io/store/v2/db"
+	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/metrics"
+	"cosmossdk.io/store/v2/proof"
+	"cosmossdk.io/store/v2/pruning"
 	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/pebbledb"
 	"cosmossdk.io/store/v2/storage/sqlite"
 )
 
@@ -166,6 +174,226 @@ func (s *RootStoreTestSuite) TestQueryProof() {
 	s.Require().Equal(expRoots[0], cInfo.Hash())
 }
 
+func (s *RootStoreTestSuite) TestQueryAbsenceProof() {
+	cs := corestore.NewChangeset()
+	cs.Add(testStoreKeyBytes, []byte("key1"), []byte("value1"), false)
+	cs.Add(testStoreKeyBytes, []byte("key3"), []byte("value3"), false)
+
+	_, err := s.rootStore.WorkingHash(cs)
+	s.Require().NoError(err)
+	_, err = s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	cInfo, err := s.rootStore.GetStateCommitment().GetCommitInfo(1)
+	s.Require().NoError(err)
+
+	// absent keys in between, before and after the existing keys
+	for _, key := range []string{"key2", "key0", "key4"} {
+		result, err := s.rootStore.Query(testStoreKeyBytes, 1, []byte(key), true)
+		s.Require().NoError(err)
+		s.Require().Nil(result.Value)
+		s.Require().NoError(proof.VerifyNonMembership(cInfo.Hash(), result.ProofOps, []byte(key)))
+		s.Require().Error(proof.VerifyMembership(cInfo.Hash(), result.ProofOps, []byte(key), []byte("value2")))
+	}
+
+	// an existing key cannot be proven absent
+	result, err := s.rootStore.Query(testStoreKeyBytes, 1, []byte("key1"), true)
+	s.Require().NoError(err)
+	s.Require().NoError(proof.VerifyMembership(cInfo.Hash(), result.ProofOps, []byte("key1"), []byte("value1")))
+	s.Require().Error(proof.VerifyNonMembership(cInfo.Hash(), result.ProofOps, []byte("key1")))
+}
+
+func (s *RootStoreTestSuite) TestQueryRangeProof() {
+	cs := corestore.NewChangeset()
+	for i := 0; i < 20; i++ {
+		cs.Add(testStoreKeyBytes, []byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%03d", i)), false)
+	}
+	cs.Add(testStoreKey2Bytes, []byte("key000"), []byte("value000"), false)
+
+	_, err := s.rootStore.WorkingHash(cs)
+	s.Require().NoError(err)
+	_, err = s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	cInfo, err := s.rootStore.GetStateCommitment().GetCommitInfo(1)
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name       string
+		start, end []byte
+		expected   int
+	}{
+		{"bounded", []byte("key005"), []byte("key010"), 5},
+		{"prefix", []byte("key01"), []byte("key02"), 10},
+		{"unbounded start", nil, []byte("key003"), 3},
+		{"unbounded end", []byte("key017"), nil, 3},
+		{"unbounded", nil, nil, 20},
+		{"empty in between", []byte("key005a"), []byte("key005b"), 0},
+		{"empty after", []byte("key100"), nil, 0},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			result, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, tc.start, tc.end, store.MaxRangeQueryLimit, false)
+			s.Require().NoError(err)
+			s.Require().Nil(result.Proof)
+			s.Require().Len(result.Pairs, tc.expected)
+
+			proven, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, tc.start, tc.end, store.MaxRangeQueryLimit, true)
+			s.Require().NoError(err)
+			s.Require().NotNil(proven.Proof)
+			s.Require().Equal(result.Pairs, proven.Pairs)
+			s.Require().NoError(proven.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, tc.start, tc.end, proven.Pairs))
+
+			if len(proven.Pairs) > 1 {
+				// omitting a pair must fail verification
+				omitted := *proven.Proof
+				omitted.Entries = append(omitted.Entries[:1:1], omitted.Entries[2:]...)
+				s.Require().Error(omitted.Verify(cInfo.Hash(), testStoreKeyBytes, tc.start, tc.end, omitted.Pairs()))
+
+				// so must a tampered value
+				pairs := slices.Clone(proven.Pairs)
+				pairs[0].Value = []byte("tampered")
+				s.Require().Error(proven.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, tc.start, tc.end, pairs))
+			}
+		})
+	}
+
+	// a range proof of one store does not verify against another store's root
+	result, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, []byte("key000"), []byte("key001"), store.MaxRangeQueryLimit, true)
+	s.Require().NoError(err)
+	s.Require().NoError(result.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, []byte("key000"), []byte("key001"), result.Pairs))
+	s.Require().Error(result.Proof.Verify(cInfo.Hash(), testStoreKey2Bytes, []byte("key000"), []byte("key001"), result.Pairs))
+	_, result.Proof.StoreProof, err = cInfo.GetStoreProof(testStoreKey2Bytes)
+	s.Require().NoError(err)
+	s.Require().Error(result.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, []byte("key000"), []byte("key001"), result.Pairs))
+
+	// nor does a range proof of another store relabeled with the store key
+	other, err := s.rootStore.QueryRange(testStoreKey2Bytes, 1, []byte("key000"), []byte("key001"), store.MaxRangeQueryLimit, true)
+	s.Require().NoError(err)
+	s.Require().Equal(result.Pairs, other.Pairs)
+	s.Require().NoError(other.Proof.Verify(cInfo.Hash(), testStoreKey2Bytes, []byte("key000"), []byte("key001"), other.Pairs))
+	other.Proof.StoreProof.Key = testStoreKeyBytes
+	s.Require().Error(other.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, []byte("key000"), []byte("key001"), other.Pairs))
+
+	// a proof of a narrower range does not verify for the requested range,
+	// whatever domain the proof claims
+	narrowed, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, []byte("key006"), []byte("key010"), store.MaxRangeQueryLimit, true)
+	s.Require().NoError(err)
+	s.Require().Len(narrowed.Pairs, 4)
+	narrowed.Proof.Start = []byte("key005")
+	s.Require().Error(narrowed.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, []byte("key005"), []byte("key010"), narrowed.Pairs))
+	s.Require().Error(narrowed.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, []byte("key006"), []byte("key011"), narrowed.Pairs))
+	s.Require().Error(narrowed.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, nil, nil, narrowed.Pairs))
+
+	// the spec is pinned by the proof type
+	narrowed.Proof.Type = proof.ProofOpSMTCommitment
+	s.Require().Error(narrowed.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, []byte("key006"), []byte("key010"), narrowed.Pairs))
+}
+
+func (s *RootStoreTestSuite) TestQueryRangeLimit() {
+	cs := corestore.NewChangeset()
+	for i := 0; i < 20; i++ {
+		cs.Add(testStoreKeyBytes, []byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%03d", i)), false)
+	}
+
+	_, err := s.rootStore.WorkingHash(cs)
+	s.Require().NoError(err)
+	_, err = s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	cInfo, err := s.rootStore.GetStateCommitment().GetCommitInfo(1)
+	s.Require().NoError(err)
+
+	// the limit is required and bounded
+	for _, limit := range []int{0, -1, store.MaxRangeQueryLimit + 1} {
+		_, err = s.rootStore.QueryRange(testStoreKeyBytes, 1, nil, nil, limit, true)
+		s.Require().Error(err)
+		_, err = s.rootStore.QueryRange(testStoreKeyBytes, 1, nil, nil, limit, false)
+		s.Require().Error(err)
+	}
+
+	for _, prove := range []bool{false, true} {
+		// the whole store is read by pages, following the continuation key
+		var (
+			start []byte
+			pairs []proof.KVPair
+			pages int
+		)
+		for {
+			result, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, start, nil, 7, prove)
+			s.Require().NoError(err)
+			s.Require().LessOrEqual(len(result.Pairs), 7)
+			s.Require().Equal(result.NextKey, result.End)
+			if prove {
+				// the proof covers the truncated domain only
+				s.Require().NoError(result.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, result.Start, result.End, result.Pairs))
+				if result.NextKey != nil {
+					s.Require().Error(result.Proof.Verify(cInfo.Hash(), testStoreKeyBytes, result.Start, nil, result.Pairs))
+				}
+			}
+			pairs = append(pairs, result.Pairs...)
+			pages++
+			if result.NextKey == nil {
+				break
+			}
+			start = result.NextKey
+		}
+		s.Require().Equal(3, pages)
+		s.Require().Len(pairs, 20)
+		for i, pair := range pairs {
+			s.Require().Equal([]byte(fmt.Sprintf("key%03d", i)), pair.Key)
+		}
+	}
+}
+
+func (s *RootStoreTestSuite) TestStateDiff() {
+	for _, cs := range []*corestore.Changeset{
+		corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{testStoreKey: {
+			{Key: []byte("key000"), Value: []byte("value000")},
+			{Key: []byte("key001"), Value: []byte("value001")},
+		}}),
+		corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{testStoreKey: {
+			{Key: []byte("key000"), Remove: true},
+			{Key: []byte("key001"), Value: []byte("value001-2")},
+			{Key: []byte("key002"), Value: []byte("value002")},
+		}}),
+	} {
+		_, err := s.rootStore.WorkingHash(cs)
+		s.Require().NoError(err)
+		_, err = s.rootStore.Commit(cs)
+		s.Require().NoError(err)
+	}
+
+	stateDiff := func(from, to uint64, start, end []byte) []storage.KeyDiff {
+		var diffs []storage.KeyDiff
+		err := s.rootStore.(*Store).StateDiff(testStoreKeyBytes, from, to, start, end, func(diff storage.KeyDiff) error {
+			diffs = append(diffs, diff)
+			return nil
+		})
+		s.Require().NoError(err)
+		return diffs
+	}
+
+	s.Require().Equal([]storage.KeyDiff{
+		{Type: storage.DiffDeleted, Key: []byte("key000"), OldValue: []byte("value000")},
+		{Type: storage.DiffModified, Key: []byte("key001"), OldValue: []byte("value001"), NewValue: []byte("value001-2")},
+		{Type: storage.DiffAdded, Key: []byte("key002"), NewValue: []byte("value002")},
+	}, stateDiff(1, 2, nil, nil))
+	s.Require().Equal([]storage.KeyDiff{
+		{Type: storage.DiffModified, Key: []byte("key001"), OldValue: []byte("value001-2"), NewValue: []byte("value001")},
+	}, stateDiff(2, 1, []byte("key001"), []byte("key002")))
+
+	// the diff from SC changesets matches the diff of the full versions in SS
+	var diffs []storage.KeyDiff
+	err := storage.Diff(s.rootStore.GetStateStorage(), testStoreKeyBytes, 1, 2, nil, nil, func(diff storage.KeyDiff) error {
+		diffs = append(diffs, diff)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(stateDiff(1, 2, nil, nil), diffs)
+}
+
 func (s *RootStoreTestSuite) TestLoadVersion() {
 	// write and commit a few changesets
 	for v := 1; v <= 5; v++ {
@@ -293,6 +521,49 @@ func (s *RootStoreTestSuite) TestCommit() {
 	}
 }
 
+func (s *RootStoreTestSuite) TestPruningManager() {
+	rs := s.rootStore.(*Store)
+	opts := pruning.Options{PruneOptions: store.PruneOptions{KeepRecent: 2, Interval: 5}}
+	pm, err := pruning.NewManager(dbm.NewMemDB(), rs.stateStorage.(*storage.StorageStore), rs.stateCommitment.(*commitment.CommitStore), opts, log.NewNopLogger())
+	s.Require().NoError(err)
+	rs.SetPruningManager(pm)
+	pm.Start()
+
+	for v := uint64(1); v <= 10; v++ {
+		cs := corestore.NewChangeset()
+		cs.Add(testStoreKeyBytes, []byte("key"), []byte(fmt.Sprintf("val%03d", v)), false)
+
+		_, err := s.rootStore.WorkingHash(cs)
+		s.Require().NoError(err)
+		_, err = s.rootStore.Commit(cs)
+		s.Require().NoError(err)
+	}
+
+	// pruning is scheduled by the commits and runs in the background
+	s.Require().Eventually(func() bool {
+		return pm.Progress().PrunedVersion == 7
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = rs.stateStorage.Get(testStoreKeyBytes, 7, []byte("key"))
+	s.Require().Error(err)
+	bz, err := rs.stateStorage.Get(testStoreKeyBytes, 8, []byte("key"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("val008"), bz)
+
+	cInfo, err := rs.stateCommitment.GetCommitInfo(7)
+	s.Require().NoError(err)
+	s.Require().Nil(cInfo)
+	cInfo, err = rs.stateCommitment.GetCommitInfo(8)
+	s.Require().NoError(err)
+	s.Require().NotNil(cInfo)
+
+	// Prune only schedules pruning on the manager
+	s.Require().NoError(s.rootStore.Prune(8))
+	s.Require().Eventually(func() bool {
+		return pm.Progress().PrunedVersion == 8
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func (s *RootStoreTestSuite) TestStateAt() {
 	// write keys over multiple versions
 	for v := uint64(1); v <= 5; v++ {
@@ -335,3 +606,75 @@ func (s *RootStoreTestSuite) TestStateAt() {
 		}
 	}
 }
+
+func TestReadOnlyStore_Unsupported(t *testing.T) {
+	noopLog := log.NewNopLogger()
+
+	ssDB, err := pebbledb.New(t.TempDir())
+	require.NoError(t, err)
+	ss := storage.NewStorageStore(ssDB, nil, noopLog)
+	defer ss.Close()
+	scDB, err := dbm.NewPebbleDB("sc", t.TempDir())
+	require.NoError(t, err)
+	defer scDB.Close()
+	tree := iavl.NewIavlTree(dbm.NewPrefixDB(scDB, []byte("t1/")), noopLog, iavl.DefaultConfig())
+	sc, err := commitment.NewCommitStore(map[string]commitment.Tree{testStoreKey: tree}, scDB, nil, noopLog)
+	require.NoError(t, err)
+
+	// PebbleDB does not support access from multiple processes
+	_, err = NewReadOnly(noopLog, ss, sc, nil)
+	require.ErrorIs(t, err, storeerrors.ErrSecondaryUnsupported)
+}
+
+func (s *RootStoreTestSuite) TestStats() {
+	commit := func(cs *corestore.Changeset) {
+		_, err := s.rootStore.WorkingHash(cs)
+		s.Require().NoError(err)
+		_, err = s.rootStore.Commit(cs)
+		s.Require().NoError(err)
+	}
+
+	// the sizes of the stores committed before enabling stats are counted
+	cs := corestore.NewChangeset()
+	cs.Add(testStoreKeyBytes, []byte("a1"), []byte("value"), false)
+	cs.Add(testStoreKeyBytes, []byte("a2"), []byte("value"), false)
+	cs.Add(testStoreKey2Bytes, []byte("b1"), []byte("value"), false)
+	commit(cs)
+
+	rs := s.rootStore.(*Store)
+	_, err := rs.Stats()
+	s.Require().Error(err)
+	s.Require().NoError(rs.EnableStats(metrics.StatsConfig{PrefixLength: 1, MaxPrefixes: 1, RateWindow: 1, HotKeys: 1}))
+
+	for v := 2; v <= 4; v++ {
+		cs := corestore.NewChangeset()
+		cs.Add(testStoreKeyBytes, []byte("a1"), []byte(fmt.Sprintf("value%d", v)), false)
+		if v == 4 {
+			cs.Add(testStoreKeyBytes, []byte("a2"), nil, true)
+			cs.Add(testStoreKeyBytes, []byte("c1"), []byte("v"), false)
+		}
+		commit(cs)
+	}
+
+	stats, err := rs.Stats()
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(4), stats.Version)
+	s.Require().Len(stats.Stores, 3)
+
+	store1 := stats.Stores[0]
+	s.Require().Equal(testStoreKey, store1.StoreKey)
+	s.Require().Equal(int64(2), store1.Keys)
+	s.Require().Equal(int64(len("a1value4")+len("c1v")), store1.Bytes)
+	s.Require().Equal([]metrics.PrefixStats{
+		{Prefix: metrics.HexBytes("a"), Writes: 4, WritesPerBlock: 2},
+	}, store1.Prefixes)
+	// the prefixes beyond MaxPrefixes are counted together
+	s.Require().Equal(&metrics.PrefixStats{Writes: 1, WritesPerBlock: 1}, store1.OtherPrefixes)
+	s.Require().Equal([]metrics.HotKey{{Key: metrics.HexBytes("a1"), Writes: 3}}, store1.HotKeys)
+
+	store2 := stats.Stores[1]
+	s.Require().Equal(testStoreKey2, store2.StoreKey)
+	s.Require().Equal(int64(1), store2.Keys)
+	s.Require().Equal(int64(len("b1value")), store2.Bytes)
+	s.Require().Empty(store2.Prefixes)
+}
@@ -0,0 +1,112 @@
+package root
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/internal/encoding"
+	"cosmossdk.io/store/v2/migration"
+	"cosmossdk.io/store/v2/storage/util"
+)
+
+const (
+	walChangesetPrefix = "w/cs_"
+	walChangesetKeyFmt = walChangesetPrefix + "%x" // w/cs_<version>
+)
+
+// ChangesetWAL defines a durable write-ahead log of the Changeset being
+// committed by the RootStore. An entry is synced to disk before either the SS
+// or SC backend is written to, so that a crash in between the two leaves enough
+// information to bring both backends back to the same version on restart.
+//
+// Only the most recent entry is required for recovery, so writing an entry
+// also removes the entry of the previous version.
+type ChangesetWAL struct {
+	db store.RawDB
+}
+
+// NewChangesetWAL returns a new ChangesetWAL backed by the given database.
+func NewChangesetWAL(db store.RawDB) *ChangesetWAL {
+	return &ChangesetWAL{db: db}
+}
+
+func walChangesetKey(version uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, version)
+	return []byte(fmt.Sprintf(walChangesetKeyFmt, buf))
+}
+
+// Write durably records the Changeset for the given version.
+func (w *ChangesetWAL) Write(version uint64, cs *corestore.Changeset) (err error) {
+	csBytes, err := encoding.MarshalChangeset(cs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal changeset: %w", err)
+	}
+
+	batch := w.db.NewBatch()
+	defer func() {
+		err = errors.Join(err, batch.Close())
+	}()
+
+	if version > 0 {
+		if err := batch.Delete(walChangesetKey(version - 1)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Set(walChangesetKey(version), csBytes); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
+// Entries returns all Changesets recorded in the WAL in ascending version order.
+func (w *ChangesetWAL) Entries() ([]*migration.VersionedChangeset, error) {
+	prefix := []byte(walChangesetPrefix)
+
+	itr, err := w.db.Iterator(prefix, util.CopyIncr(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var entries []*migration.VersionedChangeset
+	for ; itr.Valid(); itr.Next() {
+		var buf []byte
+		if _, err := fmt.Sscanf(string(itr.Key()), walChangesetKeyFmt, &buf); err != nil || len(buf) != 8 {
+			return nil, fmt.Errorf("invalid changeset WAL key: %s", itr.Key())
+		}
+
+		cs := corestore.NewChangeset()
+		if err := encoding.UnmarshalChangeset(cs, itr.Value()); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal changeset: %w", err)
+		}
+
+		entries = append(entries, &migration.VersionedChangeset{
+			Version:   binary.BigEndian.Uint64(buf),
+			Changeset: cs,
+		})
+	}
+
+	return entries, itr.Error()
+}
+
+// Delete removes the entry of the given version from the WAL.
+func (w *ChangesetWAL) Delete(version uint64) error {
+	batch := w.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Delete(walChangesetKey(version)); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
+// Close closes the underlying database.
+func (w *ChangesetWAL) Close() error {
+	return w.db.Close()
+}
@@ -0,0 +1,185 @@
+package root
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/commitment/iavl"
+	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/sqlite"
+)
+
+type WALTestSuite struct {
+	suite.Suite
+
+	// the databases below outlive a single Store instance in order to simulate
+	// a process restart
+	ss     store.VersionedDatabase
+	scDB   store.RawDB
+	treeDB store.RawDB
+	walDB  store.RawDB
+}
+
+func TestWALTestSuite(t *testing.T) {
+	suite.Run(t, &WALTestSuite{})
+}
+
+func (s *WALTestSuite) SetupTest() {
+	sqliteDB, err := sqlite.New(s.T().TempDir())
+	s.Require().NoError(err)
+
+	s.ss = storage.NewStorageStore(sqliteDB, nil, log.NewNopLogger())
+	s.scDB = dbm.NewMemDB()
+	s.treeDB = dbm.NewMemDB()
+	s.walDB = dbm.NewMemDB()
+}
+
+func (s *WALTestSuite) TearDownTest() {
+	s.Require().NoError(s.ss.Close())
+}
+
+// newStore returns a new Store on top of the suite's databases, which mimics
+// opening the store after a restart.
+func (s *WALTestSuite) newStore() *Store {
+	noopLog := log.NewNopLogger()
+
+	tree := iavl.NewIavlTree(dbm.NewPrefixDB(s.treeDB, []byte(testStoreKey)), noopLog, iavl.DefaultConfig())
+	sc, err := commitment.NewCommitStore(map[string]commitment.Tree{testStoreKey: tree}, s.scDB, nil, noopLog)
+	s.Require().NoError(err)
+
+	rs, err := New(noopLog, s.ss, sc, nil, nil)
+	s.Require().NoError(err)
+
+	rootStore := rs.(*Store)
+	rootStore.SetChangesetWAL(NewChangesetWAL(s.walDB))
+	s.Require().NoError(rootStore.LoadLatestVersion())
+
+	return rootStore
+}
+
+func (s *WALTestSuite) changeset(version uint64) *corestore.Changeset {
+	cs := corestore.NewChangeset()
+	cs.Add(testStoreKeyBytes, []byte("key"), []byte(fmt.Sprintf("val%03d", version)), false)
+	cs.Add(testStoreKeyBytes, []byte(fmt.Sprintf("key%03d", version)), []byte("val"), false)
+
+	return cs
+}
+
+func (s *WALTestSuite) commit(rs *Store, version uint64) []byte {
+	cs := s.changeset(version)
+
+	wHash, err := rs.WorkingHash(cs)
+	s.Require().NoError(err)
+
+	cHash, err := rs.Commit(cs)
+	s.Require().NoError(err)
+	s.Require().Equal(wHash, cHash)
+
+	return cHash
+}
+
+func (s *WALTestSuite) requireConsistent(rs *Store, version uint64, hash []byte) {
+	scVersion, err := rs.GetStateCommitment().GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(version, scVersion)
+
+	ssVersion, err := rs.GetStateStorage().GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(version, ssVersion)
+
+	cInfo, err := rs.GetStateCommitment().GetCommitInfo(version)
+	s.Require().NoError(err)
+	s.Require().Equal(hash, cInfo.Hash())
+
+	bz, err := rs.GetStateStorage().Get(testStoreKeyBytes, version, []byte("key"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte(fmt.Sprintf("val%03d", version)), bz)
+
+	entries, err := rs.wal.Entries()
+	s.Require().NoError(err)
+	s.Require().Empty(entries)
+}
+
+func (s *WALTestSuite) TestWriteKeepsLatestEntry() {
+	rs := s.newStore()
+	for v := uint64(1); v <= 3; v++ {
+		s.commit(rs, v)
+	}
+
+	entries, err := rs.wal.Entries()
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().Equal(uint64(3), entries[0].Version)
+	s.Require().Equal(s.changeset(3), entries[0].Changeset)
+
+	// a clean restart drops the completed entry
+	rs = s.newStore()
+	entries, err = rs.wal.Entries()
+	s.Require().NoError(err)
+	s.Require().Empty(entries)
+}
+
+func (s *WALTestSuite) TestRecoverSS() {
+	rs := s.newStore()
+	for v := uint64(1); v <= 2; v++ {
+		s.commit(rs, v)
+	}
+
+	// simulate a crash after SC was committed but before SS was written
+	cs := s.changeset(3)
+	_, err := rs.WorkingHash(cs)
+	s.Require().NoError(err)
+	s.Require().NoError(rs.wal.Write(3, cs))
+	s.Require().NoError(rs.commitSC(cs))
+	hash := rs.lastCommitInfo.Hash()
+
+	ssVersion, err := s.ss.GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(2), ssVersion)
+
+	rs = s.newStore()
+	s.requireConsistent(rs, 3, hash)
+}
+
+func (s *WALTestSuite) TestRecoverSC() {
+	rs := s.newStore()
+	for v := uint64(1); v <= 2; v++ {
+		s.commit(rs, v)
+	}
+
+	// simulate a crash after SS was written but before SC was committed
+	cs := s.changeset(3)
+	wHash, err := rs.WorkingHash(cs)
+	s.Require().NoError(err)
+	s.Require().NoError(rs.wal.Write(3, cs))
+	s.Require().NoError(s.ss.ApplyChangeset(3, cs))
+
+	scVersion, err := rs.GetStateCommitment().GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(2), scVersion)
+
+	rs = s.newStore()
+	s.requireConsistent(rs, 3, wHash)
+
+	// the store continues committing from the recovered version
+	hash := s.commit(rs, 4)
+	s.requireConsistent(s.newStore(), 4, hash)
+}
+
+func (s *WALTestSuite) TestDiscardUntouched() {
+	rs := s.newStore()
+	hash := s.commit(rs, 1)
+
+	// simulate a crash after the WAL was written but before either backend
+	s.Require().NoError(rs.wal.Write(2, s.changeset(2)))
+
+	rs = s.newStore()
+	s.requireConsistent(rs, 1, hash)
+}
@@ -6,6 +6,7 @@ import (
 	"compress/zlib"
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
 	"testing"
 	"time"
@@ -161,6 +162,32 @@ func (m *mockStorageSnapshotter) Restore(version uint64, chStorage <-chan *cores
 	return nil
 }
 
+// mockVersionedStorageSnapshotter records the state restored at every version.
+type mockVersionedStorageSnapshotter struct {
+	versions []uint64
+	state    map[string]string
+}
+
+func (m *mockVersionedStorageSnapshotter) Restore(version uint64, chStorage <-chan *corestore.StateChanges) error {
+	if m.state == nil {
+		m.state = make(map[string]string)
+	}
+	m.versions = append(m.versions, version)
+
+	for changes := range chStorage {
+		for _, kv := range changes.StateChanges {
+			key := fmt.Sprintf("%s/%s", changes.Actor, kv.Key)
+			if kv.Remove {
+				delete(m.state, key)
+			} else {
+				m.state[key] = string(kv.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
 type mockErrorCommitSnapshotter struct{}
 
 var _ snapshots.CommitSnapshotter = (*mockErrorCommitSnapshotter)(nil)
@@ -11,6 +11,8 @@ import (
 	"sort"
 	"sync"
 
+	protoio "github.com/cosmos/gogoproto/io"
+
 	corestore "cosmossdk.io/core/store"
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
@@ -187,14 +189,55 @@ func (m *Manager) Create(height uint64) (*types.Snapshot, error) {
 
 	// Spawn goroutine to generate snapshot chunks and pass their io.ReadClosers through a channel
 	ch := make(chan io.ReadCloser)
-	go m.createSnapshot(height, ch)
+	go m.createSnapshot(height, ch, func(protoWriter protoio.Writer) error {
+		return m.commitSnapshotter.Snapshot(height, protoWriter)
+	})
 
 	return m.store.Save(height, types.CurrentFormat, ch)
 }
 
+// CreateDelta creates a delta snapshot on top of the latest snapshot and returns its metadata.
+// The delta snapshot only contains the changes of the commitment state made since the latest
+// snapshot, while extensions are snapshotted in full.
+func (m *Manager) CreateDelta(height uint64) (*types.Snapshot, error) {
+	if m == nil {
+		return nil, errorsmod.Wrap(storeerrors.ErrLogic, "Snapshot Manager is nil")
+	}
+
+	deltaSnapshotter, ok := m.commitSnapshotter.(CommitDeltaSnapshotter)
+	if !ok {
+		return nil, errorsmod.Wrap(storeerrors.ErrLogic, "commit snapshotter does not support delta snapshots")
+	}
+
+	err := m.begin(opSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer m.end()
+
+	latest, err := m.store.GetLatest()
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "failed to examine latest snapshot")
+	}
+	if latest == nil {
+		return nil, errorsmod.Wrap(storeerrors.ErrLogic, "no snapshot exists to base a delta snapshot on")
+	}
+	if latest.Height >= height {
+		return nil, errorsmod.Wrapf(storeerrors.ErrConflict,
+			"a more recent snapshot already exists at height %v", latest.Height)
+	}
+
+	ch := make(chan io.ReadCloser)
+	go m.createSnapshot(height, ch, func(protoWriter protoio.Writer) error {
+		return deltaSnapshotter.SnapshotDelta(latest.Height, height, protoWriter)
+	})
+
+	return m.store.SaveDelta(height, latest.Height, ch)
+}
+
 // createSnapshot do the heavy work of snapshotting after the validations of request are done
 // the produced chunks are written to the channel.
-func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
+func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser, commitSnapshot func(protoWriter protoio.Writer) error) {
 	streamWriter := NewStreamWriter(ch)
 	if streamWriter == nil {
 		return
@@ -205,7 +248,7 @@ func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
 		}
 	}()
 
-	if err := m.commitSnapshotter.Snapshot(height, streamWriter); err != nil {
+	if err := commitSnapshot(streamWriter); err != nil {
 		streamWriter.CloseWithError(err)
 		return
 	}
@@ -265,6 +308,12 @@ func (m *Manager) EndMigration(commitSnapshotter CommitSnapshotter) {
 	m.commitSnapshotter = commitSnapshotter
 }
 
+// AbortMigration ends a migration which failed, keeping the current
+// commitSnapshotter, so the migration can be started again.
+func (m *Manager) AbortMigration() {
+	m.end()
+}
+
 // List lists snapshots, mirroring ABCI ListSnapshots. It can be concurrent with other operations.
 func (m *Manager) List() ([]*types.Snapshot, error) {
 	return m.store.List()
@@ -310,7 +359,7 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	defer m.mtx.Unlock()
 
 	// check multistore supported format preemptive
-	if snapshot.Format != types.CurrentFormat {
+	if !m.isFormatSupported(snapshot.Format) {
 		return errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
 	}
 	if snapshot.Height == 0 {
@@ -320,6 +369,9 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 		return errorsmod.Wrapf(types.ErrInvalidMetadata,
 			"snapshot height %v cannot exceed %v", snapshot.Height, int64(math.MaxInt64))
 	}
+	if err := m.validateDeltaBase(snapshot); err != nil {
+		return err
+	}
 
 	err := m.beginLocked(opRestore)
 	if err != nil {
@@ -353,18 +405,86 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	return nil
 }
 
+// isFormatSupported returns if the manager can restore snapshots of the given format.
+func (m *Manager) isFormatSupported(format uint32) bool {
+	switch format {
+	case types.CurrentFormat:
+		return true
+	case types.DeltaFormat:
+		_, ok := m.commitSnapshotter.(CommitDeltaSnapshotter)
+		return ok
+	default:
+		return false
+	}
+}
+
+// validateDeltaBase checks that a delta snapshot can be restored on top of the
+// current commitment state, which must be at the snapshot's base height.
+func (m *Manager) validateDeltaBase(snapshot types.Snapshot) error {
+	if snapshot.Format != types.DeltaFormat {
+		return nil
+	}
+
+	base := snapshot.Metadata.BaseHeight
+	if base == 0 || base >= snapshot.Height {
+		return errorsmod.Wrapf(types.ErrInvalidMetadata,
+			"delta snapshot base height %v must be in [1, %v)", base, snapshot.Height)
+	}
+
+	latest, err := m.commitSnapshotter.(CommitDeltaSnapshotter).GetLatestVersion()
+	if err != nil {
+		return errorsmod.Wrap(err, "failed to get latest version")
+	}
+	if latest != base {
+		return errorsmod.Wrapf(types.ErrInvalidMetadata,
+			"delta snapshot base height %v does not match the latest version %v", base, latest)
+	}
+
+	return nil
+}
+
+// loadChunkStream loads the chunk files of the given chunk IDs, in order. Up to
+// chunkBufferSize chunk files are read into memory concurrently ahead of the
+// restore consuming them.
 func (m *Manager) loadChunkStream(height uint64, format uint32, chunkIDs <-chan uint32) <-chan io.ReadCloser {
+	type loadResult struct {
+		chunkID uint32
+		body    []byte
+		err     error
+	}
+
+	// pending holds the in-flight loads in chunk order, bounding the read-ahead
+	pending := make(chan chan loadResult, chunkBufferSize)
+	go func() {
+		defer close(pending)
+
+		for chunkID := range chunkIDs {
+			result := make(chan loadResult, 1)
+			pending <- result
+			go func(chunkID uint32) {
+				body, err := m.store.readChunkFile(height, format, chunkID)
+				result <- loadResult{chunkID: chunkID, body: body, err: err}
+			}(chunkID)
+		}
+	}()
+
 	chunks := make(chan io.ReadCloser, chunkBufferSize)
 	go func() {
 		defer close(chunks)
 
-		for chunkID := range chunkIDs {
-			chunk, err := m.store.loadChunkFile(height, format, chunkID)
-			if err != nil {
-				m.logger.Error("load chunk file failed", "height", height, "format", format, "chunk", chunkID, "err", err)
-				break
+		failed := false
+		for result := range pending {
+			res := <-result
+			if failed {
+				// keep draining, so the loads of the remaining chunk IDs do not block
+				continue
+			}
+			if res.err != nil {
+				m.logger.Error("load chunk file failed", "height", height, "format", format, "chunk", res.chunkID, "err", res.err)
+				failed = true
+				continue
 			}
-			chunks <- chunk
+			chunks <- io.NopCloser(bytes.NewReader(res.body))
 		}
 	}()
 
@@ -398,9 +518,17 @@ func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.
 		return payload.Payload, nil
 	}
 
+	if snapshot.Format == types.DeltaFormat {
+		nextItem, err = m.restoreDelta(snapshot, streamReader)
+		if err != nil {
+			return errorsmod.Wrap(err, "multistore delta restore")
+		}
+
+		return m.restoreExtensions(snapshot, &nextItem, payloadReader)
+	}
+
 	// chStorage is the channel to pass the KV pairs to the storage snapshotter.
 	chStorage := make(chan *corestore.StateChanges, defaultStorageChannelBufferSize)
-	defer close(chStorage)
 
 	storageErrs := make(chan error, 1)
 	go func() {
@@ -412,10 +540,51 @@ func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.
 	}()
 
 	nextItem, err = m.commitSnapshotter.Restore(snapshot.Height, snapshot.Format, streamReader, chStorage)
+	// the storage snapshotter only returns once the channel is closed
+	close(chStorage)
 	if err != nil {
 		return errorsmod.Wrap(err, "multistore restore")
 	}
 
+	if err := m.restoreExtensions(snapshot, &nextItem, payloadReader); err != nil {
+		return err
+	}
+
+	// wait for storage snapshotter to complete
+	if err := <-storageErrs; err != nil {
+		return errorsmod.Wrap(err, "storage snapshotter")
+	}
+
+	return nil
+}
+
+// restoreDelta restores the commitment state of a delta snapshot, and the
+// storage state of every version it contains.
+func (m *Manager) restoreDelta(snapshot types.Snapshot, protoReader protoio.Reader) (types.SnapshotItem, error) {
+	deltaSnapshotter, ok := m.commitSnapshotter.(CommitDeltaSnapshotter)
+	if !ok {
+		return types.SnapshotItem{}, errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
+	}
+
+	apply := func(version uint64, cs *corestore.Changeset) error {
+		chStorage := make(chan *corestore.StateChanges, len(cs.Changes))
+		for i := range cs.Changes {
+			chStorage <- &cs.Changes[i]
+		}
+		close(chStorage)
+
+		if err := m.storageSnapshotter.Restore(version, chStorage); err != nil {
+			return errorsmod.Wrapf(err, "storage snapshotter at version %d", version)
+		}
+		return nil
+	}
+
+	return deltaSnapshotter.RestoreDelta(snapshot.Metadata.BaseHeight, snapshot.Height, protoReader, apply)
+}
+
+// restoreExtensions restores the extension snapshots, starting with the given item
+// which is advanced by the payload reader.
+func (m *Manager) restoreExtensions(snapshot types.Snapshot, nextItem *types.SnapshotItem, payloadReader ExtensionPayloadReader) error {
 	for {
 		if nextItem.Item == nil {
 			// end of stream
@@ -438,15 +607,10 @@ func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.
 		}
 
 		if nextItem.GetExtensionPayload() != nil {
-			return errorsmod.Wrapf(err, "extension %s don't exhausted payload stream", metadata.Name)
+			return errorsmod.Wrapf(storeerrors.ErrLogic, "extension %s don't exhausted payload stream", metadata.Name)
 		}
 	}
 
-	// wait for storage snapshotter to complete
-	if err := <-storageErrs; err != nil {
-		return errorsmod.Wrap(err, "storage snapshotter")
-	}
-
 	return nil
 }
 
@@ -474,7 +638,8 @@ func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
 	default:
 	}
 
-	// Verify the chunk hash.
+	// Verify the chunk hash before the chunk is written to disk, so that a chunk
+	// supplied by a peer is never persisted unverified.
 	hash := sha256.Sum256(chunk)
 	expected := m.restoreSnapshot.Metadata.ChunkHashes[m.restoreChunkIndex]
 	if !bytes.Equal(hash[:], expected) {
@@ -516,7 +681,7 @@ func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
 
 // RestoreLocalSnapshot restores app state from a local snapshot.
 func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
-	snapshot, ch, err := m.store.Load(height, format)
+	snapshot, err := m.store.Get(height, format)
 	if err != nil {
 		return err
 	}
@@ -534,7 +699,13 @@ func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
 	}
 	defer m.endLocked()
 
-	return m.doRestoreSnapshot(*snapshot, ch)
+	chChunkIDs := make(chan uint32, snapshot.Chunks)
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chChunkIDs <- i
+	}
+	close(chChunkIDs)
+
+	return m.doRestoreSnapshot(*snapshot, m.loadChunkStream(height, format, chChunkIDs))
 }
 
 // sortedExtensionNames sort extension names for deterministic iteration.
@@ -577,6 +748,41 @@ func (m *Manager) shouldTakeSnapshot(height int64) bool {
 	return m.opts.Interval > 0 && uint64(height)%m.opts.Interval == 0
 }
 
+// shouldTakeDelta returns true if the next snapshot should be a delta snapshot, i.e. if delta
+// snapshots are enabled and less than MaxDeltas deltas were taken since the last full snapshot.
+func (m *Manager) shouldTakeDelta() bool {
+	if m.opts.MaxDeltas == 0 {
+		return false
+	}
+	if _, ok := m.commitSnapshotter.(CommitDeltaSnapshotter); !ok {
+		return false
+	}
+
+	snapshot, err := m.store.GetLatest()
+	if err != nil || snapshot == nil {
+		return false
+	}
+
+	deltas := uint32(0)
+	for snapshot.Format == types.DeltaFormat {
+		deltas++
+		if deltas >= m.opts.MaxDeltas {
+			return false
+		}
+
+		snapshot, err = m.store.Get(snapshot.Metadata.BaseHeight, types.DeltaFormat)
+		if err != nil {
+			return false
+		}
+		if snapshot == nil {
+			// the base is a full snapshot
+			break
+		}
+	}
+
+	return true
+}
+
 func (m *Manager) snapshot(height int64) {
 	m.logger.Info("creating state snapshot", "height", height)
 
@@ -585,7 +791,12 @@ func (m *Manager) snapshot(height int64) {
 		return
 	}
 
-	snapshot, err := m.Create(uint64(height))
+	create := m.Create
+	if m.shouldTakeDelta() {
+		create = m.CreateDelta
+	}
+
+	snapshot, err := create(uint64(height))
 	if err != nil {
 		m.logger.Error("failed to create state snapshot", "height", height, "err", err)
 		return
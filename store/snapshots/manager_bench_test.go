@@ -0,0 +1,139 @@
+package snapshots_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/commitment/iavl"
+	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/snapshots"
+	"cosmossdk.io/store/v2/snapshots/types"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/pebbledb"
+)
+
+const (
+	benchStores       = 8
+	benchKeysPerStore = 10_000
+)
+
+func newBenchCommitStore(b *testing.B) *commitment.CommitStore {
+	b.Helper()
+
+	trees := make(map[string]commitment.Tree)
+	for i := 0; i < benchStores; i++ {
+		trees[fmt.Sprintf("store%d", i)] = iavl.NewIavlTree(dbm.NewMemDB(), log.NewNopLogger(), iavl.DefaultConfig())
+	}
+	sc, err := commitment.NewCommitStore(trees, dbm.NewMemDB(), nil, log.NewNopLogger())
+	require.NoError(b, err)
+
+	return sc
+}
+
+// benchSnapshot creates a snapshot of benchStores stores with benchKeysPerStore
+// random key/value pairs each, and returns it along with its chunks.
+func benchSnapshot(b *testing.B) (*types.Snapshot, [][]byte) {
+	b.Helper()
+
+	rng := rand.New(rand.NewSource(567320))
+	sc := newBenchCommitStore(b)
+	cs := corestore.NewChangeset()
+	for i := 0; i < benchStores; i++ {
+		storeKey := []byte(fmt.Sprintf("store%d", i))
+		for j := 0; j < benchKeysPerStore; j++ {
+			key := make([]byte, 32)
+			val := make([]byte, 128)
+			_, _ = rng.Read(key)
+			_, _ = rng.Read(val)
+			cs.Add(storeKey, key, val, false)
+		}
+	}
+	require.NoError(b, sc.WriteBatch(cs))
+	_, err := sc.Commit(1)
+	require.NoError(b, err)
+
+	store, err := snapshots.NewStore(b.TempDir())
+	require.NoError(b, err)
+	manager := snapshots.NewManager(store, opts, sc, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+	snapshot, err := manager.Create(1)
+	require.NoError(b, err)
+
+	chunks := make([][]byte, snapshot.Chunks)
+	for i := range chunks {
+		chunks[i], err = manager.LoadChunk(snapshot.Height, snapshot.Format, uint32(i))
+		require.NoError(b, err)
+	}
+
+	return snapshot, chunks
+}
+
+// BenchmarkRestore measures a state sync restore into fresh IAVL trees and a
+// PebbleDB storage, with the pipeline limited to a single CPU and a single
+// storage worker versus using all CPUs.
+func BenchmarkRestore(b *testing.B) {
+	snapshot, chunks := benchSnapshot(b)
+
+	procs := []int{1}
+	if n := runtime.NumCPU(); n > 1 {
+		procs = append(procs, n)
+	}
+
+	for _, procs := range procs {
+		b.Run(fmt.Sprintf("procs_%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				db, err := pebbledb.New(b.TempDir())
+				require.NoError(b, err)
+				db.SetSync(false)
+				ss := storage.NewStorageStore(db, nil, log.NewNopLogger())
+				ss.SetRestoreWorkers(procs)
+
+				store, err := snapshots.NewStore(b.TempDir())
+				require.NoError(b, err)
+				manager := snapshots.NewManager(store, opts, newBenchCommitStore(b), ss, nil, log.NewNopLogger())
+				b.StartTimer()
+
+				require.NoError(b, manager.Restore(*snapshot))
+				for j, chunk := range chunks {
+					done, err := manager.RestoreChunk(chunk)
+					require.NoError(b, err)
+					require.Equal(b, j == len(chunks)-1, done)
+				}
+
+				b.StopTimer()
+				require.NoError(b, ss.Close())
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+// BenchmarkStreamReader measures reading all items of a snapshot stream,
+// which is decompressed concurrently with decoding the items.
+func BenchmarkStreamReader(b *testing.B) {
+	_, chunks := benchSnapshot(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		streamReader, err := snapshots.NewStreamReader(makeChunks(chunks))
+		require.NoError(b, err)
+
+		var item types.SnapshotItem
+		for {
+			item.Reset()
+			if err := streamReader.ReadMsg(&item); err != nil {
+				break
+			}
+		}
+		require.NoError(b, streamReader.Close())
+	}
+}
@@ -2,12 +2,18 @@ package snapshots_test
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/commitment/iavl"
+	dbm "cosmossdk.io/store/v2/db"
 	"cosmossdk.io/store/v2/snapshots"
 	"cosmossdk.io/store/v2/snapshots/types"
 )
@@ -188,6 +194,9 @@ func TestManager_Restore(t *testing.T) {
 	_, err = manager.RestoreChunk([]byte{9, 9, 9})
 	require.Error(t, err)
 	require.True(t, errors.Is(err, types.ErrChunkHashMismatch))
+	// and the invalid chunk must not be persisted
+	_, err = os.Stat(store.PathChunk(3, types.CurrentFormat, 0))
+	require.True(t, os.IsNotExist(err))
 
 	// Feeding the chunks should work
 	for i, chunk := range chunks {
@@ -254,3 +263,125 @@ func TestManager_TakeError(t *testing.T) {
 	_, err = manager.Create(1)
 	require.Error(t, err)
 }
+
+func newDeltaCommitStore(t *testing.T) *commitment.CommitStore {
+	t.Helper()
+
+	trees := make(map[string]commitment.Tree)
+	for _, storeKey := range []string{"store1", "store2"} {
+		trees[storeKey] = iavl.NewIavlTree(dbm.NewMemDB(), log.NewNopLogger(), iavl.DefaultConfig())
+	}
+	sc, err := commitment.NewCommitStore(trees, dbm.NewMemDB(), nil, log.NewNopLogger())
+	require.NoError(t, err)
+
+	return sc
+}
+
+func commitDeltaVersion(t *testing.T, sc *commitment.CommitStore, version uint64) {
+	t.Helper()
+
+	cs := corestore.NewChangeset()
+	for i := version; i < version+3; i++ {
+		cs.Add([]byte("store1"), []byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%03d", version)), false)
+	}
+	if version > 2 {
+		cs.Add([]byte("store1"), []byte(fmt.Sprintf("key%03d", version-2)), nil, true)
+	}
+	if version%2 == 0 {
+		cs.Add([]byte("store2"), []byte("key"), []byte(fmt.Sprintf("value%03d", version)), false)
+	}
+
+	require.NoError(t, sc.WriteBatch(cs))
+	_, err := sc.Commit(version)
+	require.NoError(t, err)
+}
+
+func restoreSnapshot(t *testing.T, target, source *snapshots.Manager, snapshot *types.Snapshot) {
+	t.Helper()
+
+	require.NoError(t, target.Restore(*snapshot))
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := source.LoadChunk(snapshot.Height, snapshot.Format, i)
+		require.NoError(t, err)
+		done, err := target.RestoreChunk(chunk)
+		require.NoError(t, err)
+		require.Equal(t, i == snapshot.Chunks-1, done)
+	}
+}
+
+func TestManager_Delta(t *testing.T) {
+	sourceSC := newDeltaCommitStore(t)
+	sourceExt := newExtSnapshotter(3)
+	source := snapshots.NewManager(setupStore(t), opts, sourceSC, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+	require.NoError(t, source.RegisterExtensions(sourceExt))
+
+	// a delta snapshot requires a base snapshot
+	emptyStore, err := snapshots.NewStore(t.TempDir())
+	require.NoError(t, err)
+	_, err = snapshots.NewManager(emptyStore, opts, sourceSC, &mockStorageSnapshotter{}, nil, log.NewNopLogger()).CreateDelta(4)
+	require.Error(t, err)
+
+	for v := uint64(1); v <= 4; v++ {
+		commitDeltaVersion(t, sourceSC, v)
+	}
+	full, err := source.Create(4)
+	require.NoError(t, err)
+	require.Equal(t, types.CurrentFormat, full.Format)
+
+	for v := uint64(5); v <= 7; v++ {
+		commitDeltaVersion(t, sourceSC, v)
+	}
+	delta1, err := source.CreateDelta(7)
+	require.NoError(t, err)
+	require.Equal(t, types.DeltaFormat, delta1.Format)
+	require.Equal(t, uint64(4), delta1.Metadata.BaseHeight)
+
+	commitDeltaVersion(t, sourceSC, 8)
+	delta2, err := source.CreateDelta(8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), delta2.Metadata.BaseHeight)
+
+	// the metadata survives the ABCI conversion used to advertise snapshots
+	abciSnapshot, err := delta2.ToABCI()
+	require.NoError(t, err)
+	offered, err := types.SnapshotFromABCI(&abciSnapshot)
+	require.NoError(t, err)
+	require.Equal(t, *delta2, offered)
+
+	targetSC := newDeltaCommitStore(t)
+	targetStorage := &mockVersionedStorageSnapshotter{}
+	targetExt := newExtSnapshotter(0)
+	targetStore, err := snapshots.NewStore(t.TempDir())
+	require.NoError(t, err)
+	target := snapshots.NewManager(targetStore, opts, targetSC, targetStorage, nil, log.NewNopLogger())
+	require.NoError(t, target.RegisterExtensions(targetExt))
+
+	// a delta cannot be restored before its base
+	err = target.Restore(offered)
+	require.ErrorIs(t, err, types.ErrInvalidMetadata)
+
+	restoreSnapshot(t, target, source, full)
+	restoreSnapshot(t, target, source, delta1)
+	restoreSnapshot(t, target, source, &offered)
+
+	require.Equal(t, []uint64{4, 5, 6, 7, 8}, targetStorage.versions)
+	require.Equal(t, "value008", targetStorage.state["store2/key"])
+	require.NotContains(t, targetStorage.state, "store1/key006")
+	require.Equal(t, "value008", targetStorage.state["store1/key010"])
+
+	// extensions are snapshotted in full by every snapshot
+	require.Len(t, targetExt.state, 9)
+
+	for v := uint64(5); v <= 8; v++ {
+		expected, err := sourceSC.GetCommitInfo(v)
+		require.NoError(t, err)
+		actual, err := targetSC.GetCommitInfo(v)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), actual.Hash(), "version %d", v)
+	}
+
+	// managers without delta support reject the format during negotiation
+	manager := snapshots.NewManager(setupStore(t), opts, &mockCommitSnapshotter{}, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+	err = manager.Restore(offered)
+	require.ErrorIs(t, err, types.ErrUnknownFormat)
+}
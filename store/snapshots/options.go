@@ -8,6 +8,10 @@ type SnapshotOptions struct {
 
 	// KeepRecent defines how many snapshots to keep in heights.
 	KeepRecent uint32
+
+	// MaxDeltas defines how many delta snapshots are taken in between two full
+	// snapshots. Delta snapshots are disabled if it is 0.
+	MaxDeltas uint32
 }
 
 func NewSnapshotOptions(interval uint64, keepRecent uint32) SnapshotOptions {
@@ -17,6 +17,24 @@ type CommitSnapshotter interface {
 	Restore(version uint64, format uint32, protoReader protoio.Reader, chStorage chan<- *corestore.StateChanges) (types.SnapshotItem, error)
 }
 
+// CommitDeltaSnapshotter defines an API for creating and restoring delta snapshots
+// of the commitment state, which only contain the changes made after a base version.
+type CommitDeltaSnapshotter interface {
+	CommitSnapshotter
+
+	// GetLatestVersion returns the latest version of the commitment state.
+	GetLatestVersion() (uint64, error)
+
+	// SnapshotDelta writes the changes made in the versions (base, version] of the
+	// commitment state.
+	SnapshotDelta(base, version uint64, protoWriter protoio.Writer) error
+
+	// RestoreDelta applies the changes of the versions (base, version] from the
+	// snapshot reader on top of the commitment state at the base version. The
+	// changes of each version are passed to apply once they were committed.
+	RestoreDelta(base, version uint64, protoReader protoio.Reader, apply func(version uint64, cs *corestore.Changeset) error) (types.SnapshotItem, error)
+}
+
 // StorageSnapshotter defines an API for restoring snapshots of the storage state.
 type StorageSnapshotter interface {
 	// Restore restores the storage state from the given channel.
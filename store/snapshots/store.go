@@ -203,32 +203,61 @@ func (s *Store) loadChunkFile(height uint64, format, chunk uint32) (io.ReadClose
 	return os.Open(path)
 }
 
-// Prune removes old snapshots. The given number of most recent heights (regardless of format) are retained.
+// readChunkFile reads the content of a chunk from disk, and errors if it does not exist.
+func (s *Store) readChunkFile(height uint64, format, chunk uint32) ([]byte, error) {
+	return os.ReadFile(s.PathChunk(height, format, chunk))
+}
+
+// Prune removes old snapshots. The given number of most recent heights (regardless of format) are retained,
+// along with the heights of the snapshots the retained delta snapshots are based on.
 func (s *Store) Prune(retain uint32) (uint64, error) {
 	metadata, err := os.ReadDir(s.pathMetadataDir())
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to list snapshot metadata")
 	}
 
-	pruned := uint64(0)
-	prunedHeights := make(map[uint64]bool)
+	heights := make([]uint64, len(metadata))
+	formats := make([]uint32, len(metadata))
+	for i, entry := range metadata {
+		heights[i], formats[i], err = s.parseMetadataFilename(entry.Name())
+		if err != nil {
+			return 0, err
+		}
+	}
+
 	skip := make(map[uint64]bool)
 	for i := len(metadata) - 1; i >= 0; i-- {
-		height, format, err := s.parseMetadataFilename(metadata[i].Name())
+		if skip[heights[i]] || uint32(len(skip)) < retain {
+			skip[heights[i]] = true
+		}
+	}
+	// bases always have a lower height than their deltas, so a single pass in
+	// descending order retains whole delta chains
+	for i := len(metadata) - 1; i >= 0; i-- {
+		if !skip[heights[i]] || formats[i] != types.DeltaFormat {
+			continue
+		}
+		snapshot, err := s.Get(heights[i], formats[i])
 		if err != nil {
-			return 0, err
+			return 0, errors.Wrap(err, "failed to prune snapshots")
 		}
+		if snapshot != nil {
+			skip[snapshot.Metadata.BaseHeight] = true
+		}
+	}
 
-		if skip[height] || uint32(len(skip)) < retain {
-			skip[height] = true
+	pruned := uint64(0)
+	prunedHeights := make(map[uint64]bool)
+	for i := len(metadata) - 1; i >= 0; i-- {
+		if skip[heights[i]] {
 			continue
 		}
-		err = s.Delete(height, format)
+		err = s.Delete(heights[i], formats[i])
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to prune snapshots")
 		}
 		pruned++
-		prunedHeights[height] = true
+		prunedHeights[heights[i]] = true
 	}
 	// Since Delete() deletes a specific format, while we want to prune a height, we clean up
 	// the height directory as well
@@ -246,6 +275,24 @@ func (s *Store) Prune(retain uint32) (uint64, error) {
 // Save saves a snapshot to disk, returning it.
 func (s *Store) Save(
 	height uint64, format uint32, chunks <-chan io.ReadCloser,
+) (*types.Snapshot, error) {
+	return s.save(height, format, 0, chunks)
+}
+
+// SaveDelta saves a delta snapshot based on the snapshot at baseHeight to disk, returning it.
+func (s *Store) SaveDelta(
+	height, baseHeight uint64, chunks <-chan io.ReadCloser,
+) (*types.Snapshot, error) {
+	if baseHeight == 0 || baseHeight >= height {
+		DrainChunks(chunks)
+		return nil, errors.Wrapf(storeerrors.ErrLogic, "delta snapshot base height %v must be in [1, %v)", baseHeight, height)
+	}
+
+	return s.save(height, types.DeltaFormat, baseHeight, chunks)
+}
+
+func (s *Store) save(
+	height uint64, format uint32, baseHeight uint64, chunks <-chan io.ReadCloser,
 ) (*types.Snapshot, error) {
 	defer DrainChunks(chunks)
 	if height == 0 {
@@ -269,6 +316,9 @@ func (s *Store) Save(
 	snapshot := &types.Snapshot{
 		Height: height,
 		Format: format,
+		Metadata: types.Metadata{
+			BaseHeight: baseHeight,
+		},
 	}
 
 	// create height directory or do nothing
@@ -261,6 +261,46 @@ func TestStore_Prune(t *testing.T) {
 	assert.Empty(t, snapshots)
 }
 
+func TestStore_PruneDelta(t *testing.T) {
+	store, err := snapshots.NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Save(1, types.CurrentFormat, makeChunks([][]byte{{1}}))
+	require.NoError(t, err)
+	_, err = store.Save(2, types.CurrentFormat, makeChunks([][]byte{{2}}))
+	require.NoError(t, err)
+	_, err = store.SaveDelta(3, 2, makeChunks([][]byte{{3}}))
+	require.NoError(t, err)
+	snapshot, err := store.SaveDelta(4, 3, makeChunks([][]byte{{4}}))
+	require.NoError(t, err)
+	require.Equal(t, types.DeltaFormat, snapshot.Format)
+	require.Equal(t, uint64(3), snapshot.Metadata.BaseHeight)
+
+	// a delta snapshot must be based on a lower height
+	_, err = store.SaveDelta(5, 5, makeChunks([][]byte{{5}}))
+	require.Error(t, err)
+
+	// retaining the latest delta snapshot retains its whole chain of bases
+	pruned, err := store.Prune(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pruned)
+
+	snapshots, err := store.List()
+	require.NoError(t, err)
+	heights := make([]uint64, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		heights = append(heights, snapshot.Height)
+	}
+	require.Equal(t, []uint64{4, 3, 2}, heights)
+
+	// a new full snapshot releases the chain
+	_, err = store.Save(6, types.CurrentFormat, makeChunks([][]byte{{6}}))
+	require.NoError(t, err)
+	pruned, err = store.Prune(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pruned)
+}
+
 func TestStore_Save(t *testing.T) {
 	store := setupStore(t)
 	// Saving a snapshot should work
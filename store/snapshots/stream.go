@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"compress/zlib"
 	"io"
+	"sync"
 
 	protoio "github.com/cosmos/gogoproto/io"
 	"github.com/cosmos/gogoproto/proto"
@@ -17,6 +18,11 @@ const (
 	snapshotBufferSize = int(snapshotChunkSize)
 	// Do not change compression level without new snapshot format (must be uniform across nodes)
 	snapshotCompressionLevel = 7
+
+	// readAheadBlockSize and readAheadBlocks bound the decompressed data buffered
+	// ahead of the restore by the decompression goroutine.
+	readAheadBlockSize = 1 << 20
+	readAheadBlocks    = 16
 )
 
 type WriteCloser interface {
@@ -77,10 +83,14 @@ func (sw *StreamWriter) CloseWithError(err error) {
 }
 
 // StreamReader set up a restore stream pipeline
-// chan io.ReadCloser -> chunkReader -> zlib -> delimited Protobuf -> ExportNode
+// chan io.ReadCloser -> chunkReader -> zlib -> readAhead -> delimited Protobuf -> ExportNode
+//
+// The zlib stream is decompressed in a separate goroutine, so decompression
+// runs concurrently with decoding and applying the snapshot items.
 type StreamReader struct {
 	chunkReader *ChunkReader
 	zReader     io.ReadCloser
+	readAhead   *readAheadReader
 	protoReader protoio.ReadCloser
 }
 
@@ -91,10 +101,12 @@ func NewStreamReader(chunks <-chan io.ReadCloser) (*StreamReader, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "zlib failure")
 	}
-	protoReader := protoio.NewDelimitedReader(zReader, snapshotMaxItemSize)
+	readAhead := newReadAheadReader(zReader, readAheadBlockSize, readAheadBlocks)
+	protoReader := protoio.NewDelimitedReader(readAhead, snapshotMaxItemSize)
 	return &StreamReader{
 		chunkReader: chunkReader,
 		zReader:     zReader,
+		readAhead:   readAhead,
 		protoReader: protoReader,
 	}, nil
 }
@@ -110,6 +122,8 @@ func (sr *StreamReader) Close() error {
 	if err1 := sr.protoReader.Close(); err1 != nil {
 		err = err1
 	}
+	// the read-ahead goroutine must be stopped before closing the readers it reads from
+	sr.readAhead.Close()
 	if err2 := sr.zReader.Close(); err2 != nil {
 		err = err2
 	}
@@ -118,3 +132,87 @@ func (sr *StreamReader) Close() error {
 	}
 	return err
 }
+
+// readBlock is a block of data read by a readAheadReader.
+type readBlock struct {
+	data []byte
+	err  error
+}
+
+// readAheadReader reads from the underlying reader in a separate goroutine,
+// buffering up to a fixed number of blocks ahead of its consumer.
+type readAheadReader struct {
+	blocks  chan readBlock
+	done    chan struct{}
+	wg      sync.WaitGroup
+	current []byte
+	err     error
+}
+
+func newReadAheadReader(r io.Reader, blockSize, blocks int) *readAheadReader {
+	ra := &readAheadReader{
+		blocks: make(chan readBlock, blocks),
+		done:   make(chan struct{}),
+	}
+
+	ra.wg.Add(1)
+	go func() {
+		defer ra.wg.Done()
+		defer close(ra.blocks)
+
+		for {
+			select {
+			case <-ra.done:
+				return
+			default:
+			}
+
+			buf := make([]byte, blockSize)
+			n, err := io.ReadFull(r, buf)
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			if n > 0 || err != nil {
+				select {
+				case ra.blocks <- readBlock{data: buf[:n], err: err}:
+				case <-ra.done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return ra
+}
+
+// Read implements io.Reader.
+func (ra *readAheadReader) Read(p []byte) (int, error) {
+	for len(ra.current) == 0 {
+		if ra.err != nil {
+			return 0, ra.err
+		}
+		block, ok := <-ra.blocks
+		if !ok {
+			return 0, io.EOF
+		}
+		ra.current, ra.err = block.data, block.err
+	}
+
+	n := copy(p, ra.current)
+	ra.current = ra.current[n:]
+	return n, nil
+}
+
+// Close stops the read-ahead goroutine and waits for it to return. It does not
+// close the underlying reader.
+func (ra *readAheadReader) Close() {
+	select {
+	case <-ra.done:
+	default:
+		close(ra.done)
+	}
+	ra.wg.Wait()
+}
@@ -4,3 +4,8 @@ package types
 // must be identical across all nodes for a given height, so this must be bumped when the binary
 // snapshot output changes.
 const CurrentFormat uint32 = 3
+
+// DeltaFormat is the format used for delta snapshots, which only contain the changes made
+// since the snapshot at Metadata.BaseHeight. A delta snapshot can only be restored on top of
+// the state at its base height, e.g. after restoring the base snapshot.
+const DeltaFormat uint32 = 4
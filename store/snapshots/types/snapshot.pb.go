@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: cosmos/store/snapshots/v1/snapshot.proto
+// source: cosmos/store/v2/snapshot.proto
 
 package types
 
@@ -36,7 +36,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{0}
+	return fileDescriptor_13a42296d2263b1a, []int{0}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -103,13 +103,16 @@ func (m *Snapshot) GetMetadata() Metadata {
 // Metadata contains SDK-specific snapshot metadata.
 type Metadata struct {
 	ChunkHashes [][]byte `protobuf:"bytes,1,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
+	// base_height is the height of the snapshot a delta snapshot must be applied
+	// on top of. It is unset for full snapshots.
+	BaseHeight uint64 `protobuf:"varint,2,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
 }
 
 func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{1}
+	return fileDescriptor_13a42296d2263b1a, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -145,6 +148,13 @@ func (m *Metadata) GetChunkHashes() [][]byte {
 	return nil
 }
 
+func (m *Metadata) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
 // SnapshotItem is an item contained in a rootmulti.Store snapshot.
 //
 // Since: cosmos-sdk 0.46
@@ -152,11 +162,12 @@ type SnapshotItem struct {
 	// item is the specific type of snapshot item.
 	//
 	// Types that are valid to be assigned to Item:
-	//
 	//	*SnapshotItem_Store
 	//	*SnapshotItem_IAVL
 	//	*SnapshotItem_Extension
 	//	*SnapshotItem_ExtensionPayload
+	//	*SnapshotItem_DeltaVersion
+	//	*SnapshotItem_Change
 	Item isSnapshotItem_Item `protobuf_oneof:"item"`
 }
 
@@ -164,7 +175,7 @@ func (m *SnapshotItem) Reset()         { *m = SnapshotItem{} }
 func (m *SnapshotItem) String() string { return proto.CompactTextString(m) }
 func (*SnapshotItem) ProtoMessage()    {}
 func (*SnapshotItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{2}
+	return fileDescriptor_13a42296d2263b1a, []int{2}
 }
 func (m *SnapshotItem) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -211,11 +222,19 @@ type SnapshotItem_Extension struct {
 type SnapshotItem_ExtensionPayload struct {
 	ExtensionPayload *SnapshotExtensionPayload `protobuf:"bytes,4,opt,name=extension_payload,json=extensionPayload,proto3,oneof" json:"extension_payload,omitempty"`
 }
+type SnapshotItem_DeltaVersion struct {
+	DeltaVersion *SnapshotDeltaVersion `protobuf:"bytes,7,opt,name=delta_version,json=deltaVersion,proto3,oneof" json:"delta_version,omitempty"`
+}
+type SnapshotItem_Change struct {
+	Change *SnapshotChangeItem `protobuf:"bytes,8,opt,name=change,proto3,oneof" json:"change,omitempty"`
+}
 
 func (*SnapshotItem_Store) isSnapshotItem_Item()            {}
 func (*SnapshotItem_IAVL) isSnapshotItem_Item()             {}
 func (*SnapshotItem_Extension) isSnapshotItem_Item()        {}
 func (*SnapshotItem_ExtensionPayload) isSnapshotItem_Item() {}
+func (*SnapshotItem_DeltaVersion) isSnapshotItem_Item()     {}
+func (*SnapshotItem_Change) isSnapshotItem_Item()           {}
 
 func (m *SnapshotItem) GetItem() isSnapshotItem_Item {
 	if m != nil {
@@ -252,6 +271,20 @@ func (m *SnapshotItem) GetExtensionPayload() *SnapshotExtensionPayload {
 	return nil
 }
 
+func (m *SnapshotItem) GetDeltaVersion() *SnapshotDeltaVersion {
+	if x, ok := m.GetItem().(*SnapshotItem_DeltaVersion); ok {
+		return x.DeltaVersion
+	}
+	return nil
+}
+
+func (m *SnapshotItem) GetChange() *SnapshotChangeItem {
+	if x, ok := m.GetItem().(*SnapshotItem_Change); ok {
+		return x.Change
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SnapshotItem) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -259,6 +292,8 @@ func (*SnapshotItem) XXX_OneofWrappers() []interface{} {
 		(*SnapshotItem_IAVL)(nil),
 		(*SnapshotItem_Extension)(nil),
 		(*SnapshotItem_ExtensionPayload)(nil),
+		(*SnapshotItem_DeltaVersion)(nil),
+		(*SnapshotItem_Change)(nil),
 	}
 }
 
@@ -273,7 +308,7 @@ func (m *SnapshotStoreItem) Reset()         { *m = SnapshotStoreItem{} }
 func (m *SnapshotStoreItem) String() string { return proto.CompactTextString(m) }
 func (*SnapshotStoreItem) ProtoMessage()    {}
 func (*SnapshotStoreItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{3}
+	return fileDescriptor_13a42296d2263b1a, []int{3}
 }
 func (m *SnapshotStoreItem) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -325,7 +360,7 @@ func (m *SnapshotIAVLItem) Reset()         { *m = SnapshotIAVLItem{} }
 func (m *SnapshotIAVLItem) String() string { return proto.CompactTextString(m) }
 func (*SnapshotIAVLItem) ProtoMessage()    {}
 func (*SnapshotIAVLItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{4}
+	return fileDescriptor_13a42296d2263b1a, []int{4}
 }
 func (m *SnapshotIAVLItem) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -382,6 +417,115 @@ func (m *SnapshotIAVLItem) GetHeight() int32 {
 	return 0
 }
 
+// SnapshotDeltaVersion marks the start of the changes of a single version in a
+// delta snapshot. It is followed by a SnapshotStoreItem and the store's
+// SnapshotChangeItems for every store changed in that version.
+type SnapshotDeltaVersion struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *SnapshotDeltaVersion) Reset()         { *m = SnapshotDeltaVersion{} }
+func (m *SnapshotDeltaVersion) String() string { return proto.CompactTextString(m) }
+func (*SnapshotDeltaVersion) ProtoMessage()    {}
+func (*SnapshotDeltaVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13a42296d2263b1a, []int{5}
+}
+func (m *SnapshotDeltaVersion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotDeltaVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotDeltaVersion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotDeltaVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotDeltaVersion.Merge(m, src)
+}
+func (m *SnapshotDeltaVersion) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotDeltaVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotDeltaVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotDeltaVersion proto.InternalMessageInfo
+
+func (m *SnapshotDeltaVersion) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// SnapshotChangeItem is a single key/value change of a store in a delta
+// snapshot.
+type SnapshotChangeItem struct {
+	Key    []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value  []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Delete bool   `protobuf:"varint,3,opt,name=delete,proto3" json:"delete,omitempty"`
+}
+
+func (m *SnapshotChangeItem) Reset()         { *m = SnapshotChangeItem{} }
+func (m *SnapshotChangeItem) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChangeItem) ProtoMessage()    {}
+func (*SnapshotChangeItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13a42296d2263b1a, []int{6}
+}
+func (m *SnapshotChangeItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotChangeItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotChangeItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotChangeItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChangeItem.Merge(m, src)
+}
+func (m *SnapshotChangeItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotChangeItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChangeItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChangeItem proto.InternalMessageInfo
+
+func (m *SnapshotChangeItem) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SnapshotChangeItem) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SnapshotChangeItem) GetDelete() bool {
+	if m != nil {
+		return m.Delete
+	}
+	return false
+}
+
 // SnapshotExtensionMeta contains metadata about an external snapshotter.
 //
 // Since: cosmos-sdk 0.46
@@ -394,7 +538,7 @@ func (m *SnapshotExtensionMeta) Reset()         { *m = SnapshotExtensionMeta{} }
 func (m *SnapshotExtensionMeta) String() string { return proto.CompactTextString(m) }
 func (*SnapshotExtensionMeta) ProtoMessage()    {}
 func (*SnapshotExtensionMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{5}
+	return fileDescriptor_13a42296d2263b1a, []int{7}
 }
 func (m *SnapshotExtensionMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -448,7 +592,7 @@ func (m *SnapshotExtensionPayload) Reset()         { *m = SnapshotExtensionPaylo
 func (m *SnapshotExtensionPayload) String() string { return proto.CompactTextString(m) }
 func (*SnapshotExtensionPayload) ProtoMessage()    {}
 func (*SnapshotExtensionPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d5cca1aa5b69183, []int{6}
+	return fileDescriptor_13a42296d2263b1a, []int{8}
 }
 func (m *SnapshotExtensionPayload) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -485,52 +629,58 @@ func (m *SnapshotExtensionPayload) GetPayload() []byte {
 }
 
 func init() {
-	proto.RegisterType((*Snapshot)(nil), "cosmos.store.snapshots.v1.Snapshot")
-	proto.RegisterType((*Metadata)(nil), "cosmos.store.snapshots.v1.Metadata")
-	proto.RegisterType((*SnapshotItem)(nil), "cosmos.store.snapshots.v1.SnapshotItem")
-	proto.RegisterType((*SnapshotStoreItem)(nil), "cosmos.store.snapshots.v1.SnapshotStoreItem")
-	proto.RegisterType((*SnapshotIAVLItem)(nil), "cosmos.store.snapshots.v1.SnapshotIAVLItem")
-	proto.RegisterType((*SnapshotExtensionMeta)(nil), "cosmos.store.snapshots.v1.SnapshotExtensionMeta")
-	proto.RegisterType((*SnapshotExtensionPayload)(nil), "cosmos.store.snapshots.v1.SnapshotExtensionPayload")
-}
-
-func init() {
-	proto.RegisterFile("cosmos/store/snapshots/v1/snapshot.proto", fileDescriptor_3d5cca1aa5b69183)
-}
-
-var fileDescriptor_3d5cca1aa5b69183 = []byte{
-	// 496 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0x8e, 0xd7, 0xb4, 0x74, 0x2f, 0x41, 0xea, 0xac, 0x81, 0x02, 0x87, 0x2c, 0x84, 0x03, 0x91,
-	0x80, 0x94, 0x65, 0x1c, 0xb9, 0x50, 0x98, 0x94, 0x09, 0x90, 0x26, 0x4f, 0xe2, 0xc0, 0x65, 0xf2,
-	0x56, 0xd3, 0x44, 0x6d, 0xe2, 0xaa, 0xf6, 0x22, 0xfa, 0x2f, 0xf8, 0x23, 0xfc, 0x8f, 0x1d, 0x77,
-	0xe4, 0x34, 0x50, 0xfb, 0x47, 0x90, 0xed, 0x26, 0xa0, 0x6d, 0x45, 0xdb, 0xed, 0x7d, 0x2f, 0xdf,
-	0xf7, 0xf9, 0xf9, 0xcb, 0x33, 0x44, 0xa7, 0x5c, 0x14, 0x5c, 0xf4, 0x85, 0xe4, 0x33, 0xd6, 0x17,
-	0x25, 0x9d, 0x8a, 0x8c, 0x4b, 0xd1, 0xaf, 0x76, 0x1b, 0x10, 0x4f, 0x67, 0x5c, 0x72, 0xfc, 0xc8,
-	0x30, 0x63, 0xcd, 0x8c, 0x1b, 0x66, 0x5c, 0xed, 0x3e, 0xde, 0x1e, 0xf1, 0x11, 0xd7, 0xac, 0xbe,
-	0xaa, 0x8c, 0x20, 0xfc, 0x81, 0xa0, 0x7b, 0xb4, 0xa2, 0xe1, 0x87, 0xd0, 0xc9, 0x58, 0x3e, 0xca,
-	0xa4, 0x87, 0x02, 0x14, 0xd9, 0x64, 0x85, 0x54, 0xff, 0x2b, 0x9f, 0x15, 0x54, 0x7a, 0x1b, 0x01,
-	0x8a, 0xee, 0x93, 0x15, 0x52, 0xfd, 0xd3, 0xec, 0xac, 0x1c, 0x0b, 0xaf, 0x65, 0xfa, 0x06, 0x61,
-	0x0c, 0x76, 0x46, 0x45, 0xe6, 0xd9, 0x01, 0x8a, 0x5c, 0xa2, 0x6b, 0xbc, 0x0f, 0xdd, 0x82, 0x49,
-	0x3a, 0xa4, 0x92, 0x7a, 0xed, 0x00, 0x45, 0x4e, 0xf2, 0x34, 0x5e, 0x3b, 0x6c, 0xfc, 0x69, 0x45,
-	0x1d, 0xd8, 0xe7, 0x97, 0x3b, 0x16, 0x69, 0xa4, 0xe1, 0x4b, 0xe8, 0xd6, 0xdf, 0xf0, 0x13, 0x70,
-	0xf5, 0x81, 0xc7, 0xea, 0x00, 0x26, 0x3c, 0x14, 0xb4, 0x22, 0x97, 0x38, 0xba, 0x97, 0xea, 0x56,
-	0xf8, 0x6b, 0x03, 0xdc, 0xfa, 0x7a, 0x07, 0x92, 0x15, 0xf8, 0x3d, 0xb4, 0xf5, 0x71, 0xfa, 0x86,
-	0x4e, 0xf2, 0xe2, 0x3f, 0x33, 0xd4, 0xba, 0x23, 0xf5, 0x49, 0x89, 0x53, 0x8b, 0x18, 0x31, 0xfe,
-	0x00, 0x76, 0x4e, 0xab, 0x89, 0x8e, 0xc3, 0x49, 0x9e, 0xdf, 0xc2, 0xe4, 0xe0, 0xed, 0xe7, 0x8f,
-	0xca, 0x63, 0xd0, 0x5d, 0x5c, 0xee, 0xd8, 0x0a, 0xa5, 0x16, 0xd1, 0x26, 0xf8, 0x10, 0x36, 0xd9,
-	0x37, 0xc9, 0x4a, 0x91, 0xf3, 0x52, 0x07, 0xe9, 0x24, 0xaf, 0x6e, 0xe1, 0xb8, 0x5f, 0x6b, 0x54,
-	0x1e, 0xa9, 0x45, 0xfe, 0x9a, 0xe0, 0x13, 0xd8, 0x6a, 0xc0, 0xf1, 0x94, 0xce, 0x27, 0x9c, 0x0e,
-	0xf5, 0xcf, 0x70, 0x92, 0xbd, 0xbb, 0x38, 0x1f, 0x1a, 0x69, 0x6a, 0x91, 0x1e, 0xbb, 0xd2, 0x1b,
-	0x74, 0xc0, 0xce, 0x25, 0x2b, 0xc2, 0x67, 0xb0, 0x75, 0x2d, 0x28, 0xb5, 0x00, 0x25, 0x2d, 0x4c,
-	0xc8, 0x9b, 0x44, 0xd7, 0xe1, 0x04, 0x7a, 0x57, 0xc3, 0xc0, 0x3d, 0x68, 0x8d, 0xd9, 0x5c, 0xd3,
-	0x5c, 0xa2, 0x4a, 0xbc, 0x0d, 0xed, 0x8a, 0x4e, 0xce, 0x98, 0x8e, 0xd6, 0x25, 0x06, 0x60, 0x0f,
-	0xee, 0x55, 0x6c, 0xd6, 0x04, 0xd4, 0x22, 0x35, 0xfc, 0x67, 0x65, 0xd5, 0xfd, 0xda, 0xf5, 0xca,
-	0x86, 0xef, 0xe0, 0xc1, 0x8d, 0x41, 0xdd, 0x34, 0xda, 0xba, 0xfd, 0x0e, 0x5f, 0x83, 0xb7, 0x2e,
-	0x13, 0x35, 0x52, 0x9d, 0xac, 0x19, 0xbf, 0x86, 0x83, 0x37, 0xe7, 0x0b, 0x1f, 0x5d, 0x2c, 0x7c,
-	0xf4, 0x7b, 0xe1, 0xa3, 0xef, 0x4b, 0xdf, 0xba, 0x58, 0xfa, 0xd6, 0xcf, 0xa5, 0x6f, 0x7d, 0x09,
-	0x4d, 0xf6, 0x62, 0x38, 0x8e, 0x73, 0x7e, 0xed, 0x35, 0xcb, 0xf9, 0x94, 0x89, 0x93, 0x8e, 0x7e,
-	0x97, 0x7b, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xef, 0xe9, 0x8e, 0x10, 0xf4, 0x03, 0x00, 0x00,
+	proto.RegisterType((*Snapshot)(nil), "cosmos.store.v2.Snapshot")
+	proto.RegisterType((*Metadata)(nil), "cosmos.store.v2.Metadata")
+	proto.RegisterType((*SnapshotItem)(nil), "cosmos.store.v2.SnapshotItem")
+	proto.RegisterType((*SnapshotStoreItem)(nil), "cosmos.store.v2.SnapshotStoreItem")
+	proto.RegisterType((*SnapshotIAVLItem)(nil), "cosmos.store.v2.SnapshotIAVLItem")
+	proto.RegisterType((*SnapshotDeltaVersion)(nil), "cosmos.store.v2.SnapshotDeltaVersion")
+	proto.RegisterType((*SnapshotChangeItem)(nil), "cosmos.store.v2.SnapshotChangeItem")
+	proto.RegisterType((*SnapshotExtensionMeta)(nil), "cosmos.store.v2.SnapshotExtensionMeta")
+	proto.RegisterType((*SnapshotExtensionPayload)(nil), "cosmos.store.v2.SnapshotExtensionPayload")
+}
+
+func init() { proto.RegisterFile("cosmos/store/v2/snapshot.proto", fileDescriptor_13a42296d2263b1a) }
+
+var fileDescriptor_13a42296d2263b1a = []byte{
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcf, 0x6e, 0xd3, 0x30,
+	0x1c, 0x4e, 0xd6, 0xb4, 0xcb, 0x7e, 0xc9, 0xc4, 0x66, 0x8d, 0x29, 0x70, 0xc8, 0xba, 0xa0, 0x41,
+	0xb9, 0xa4, 0x28, 0x70, 0x02, 0x21, 0xb4, 0x0e, 0x50, 0x26, 0x0d, 0x84, 0x3c, 0x34, 0x21, 0x2e,
+	0x95, 0xb7, 0x98, 0xa6, 0x6a, 0x12, 0x57, 0xb5, 0x57, 0xd1, 0xb7, 0xe0, 0x1d, 0x78, 0x15, 0x0e,
+	0x3b, 0xee, 0xc8, 0x69, 0x42, 0xed, 0x8b, 0x20, 0x3b, 0xc9, 0x28, 0x6d, 0x23, 0xb8, 0xf9, 0xfb,
+	0xfc, 0xfb, 0xbe, 0xfc, 0xfe, 0x39, 0xe0, 0x5e, 0x30, 0x9e, 0x32, 0xde, 0xe6, 0x82, 0x8d, 0x68,
+	0x7b, 0x1c, 0xb4, 0x79, 0x46, 0x86, 0x3c, 0x66, 0xc2, 0x1f, 0x8e, 0x98, 0x60, 0xe8, 0x4e, 0x7e,
+	0xef, 0xab, 0x7b, 0x7f, 0x1c, 0xdc, 0xdf, 0xe9, 0xb1, 0x1e, 0x53, 0x77, 0x6d, 0x79, 0xca, 0xc3,
+	0xbc, 0xef, 0x3a, 0x98, 0xa7, 0x85, 0x12, 0xed, 0x42, 0x23, 0xa6, 0xfd, 0x5e, 0x2c, 0x1c, 0xbd,
+	0xa9, 0xb7, 0x0c, 0x5c, 0x20, 0xc9, 0x7f, 0x61, 0xa3, 0x94, 0x08, 0x67, 0xad, 0xa9, 0xb7, 0x36,
+	0x71, 0x81, 0x24, 0x7f, 0x11, 0x5f, 0x66, 0x03, 0xee, 0xd4, 0x72, 0x3e, 0x47, 0x08, 0x81, 0x11,
+	0x13, 0x1e, 0x3b, 0x46, 0x53, 0x6f, 0xd9, 0x58, 0x9d, 0xd1, 0x0b, 0x30, 0x53, 0x2a, 0x48, 0x44,
+	0x04, 0x71, 0xea, 0x4d, 0xbd, 0x65, 0x05, 0xf7, 0xfc, 0x85, 0x14, 0xfd, 0x77, 0x45, 0x40, 0xc7,
+	0xb8, 0xba, 0xd9, 0xd3, 0xf0, 0xad, 0xc0, 0x7b, 0x0f, 0x66, 0x79, 0x87, 0xf6, 0xc1, 0x56, 0x9f,
+	0xe9, 0x4a, 0x5b, 0xca, 0x1d, 0xbd, 0x59, 0x6b, 0xd9, 0xd8, 0x52, 0x5c, 0xa8, 0x28, 0xb4, 0x07,
+	0xd6, 0x39, 0xe1, 0xb4, 0x5b, 0x14, 0xb3, 0xa6, 0x8a, 0x01, 0x49, 0x85, 0x8a, 0xf1, 0x7e, 0xd4,
+	0xc0, 0x2e, 0xab, 0x3e, 0x16, 0x34, 0x45, 0xcf, 0xa1, 0xae, 0xb2, 0x50, 0x85, 0x5b, 0x81, 0xb7,
+	0x94, 0x5a, 0x19, 0x7d, 0x2a, 0x09, 0x29, 0x09, 0x35, 0x9c, 0x4b, 0xd0, 0x21, 0x18, 0x7d, 0x32,
+	0x4e, 0xd4, 0x67, 0xac, 0x60, 0xbf, 0x52, 0x7a, 0x7c, 0x78, 0x76, 0x22, 0x95, 0x1d, 0x73, 0x7a,
+	0xb3, 0x67, 0x48, 0x14, 0x6a, 0x58, 0x49, 0xd1, 0x5b, 0xd8, 0xa0, 0x5f, 0x05, 0xcd, 0x78, 0x9f,
+	0x65, 0xaa, 0x97, 0x56, 0xf0, 0xb0, 0xd2, 0xe7, 0x4d, 0x19, 0x29, 0x5b, 0x12, 0x6a, 0xf8, 0x8f,
+	0x14, 0x7d, 0x82, 0xed, 0x5b, 0xd0, 0x1d, 0x92, 0x49, 0xc2, 0x48, 0xa4, 0xa6, 0x60, 0x05, 0x8f,
+	0xff, 0xed, 0xf7, 0x21, 0x17, 0x84, 0x1a, 0xde, 0xa2, 0x0b, 0x1c, 0x3a, 0x81, 0xcd, 0x88, 0x26,
+	0x82, 0x74, 0xc7, 0x74, 0xa4, 0xb2, 0x5c, 0x57, 0xae, 0x07, 0x95, 0xae, 0xaf, 0x65, 0xf4, 0x59,
+	0x1e, 0x1c, 0x6a, 0xd8, 0x8e, 0xe6, 0x30, 0x7a, 0x29, 0x17, 0x87, 0x64, 0x3d, 0xea, 0x98, 0xca,
+	0xe6, 0x41, 0xa5, 0xcd, 0x91, 0x0a, 0x2b, 0x1a, 0x5e, 0x88, 0x3a, 0x0d, 0x30, 0xfa, 0x82, 0xa6,
+	0xde, 0x23, 0xd8, 0x5e, 0x9a, 0x8b, 0x5c, 0xbe, 0x8c, 0xa4, 0xf9, 0x24, 0x37, 0xb0, 0x3a, 0x7b,
+	0x09, 0x6c, 0x2d, 0x4e, 0x01, 0x6d, 0x41, 0x6d, 0x40, 0x27, 0x2a, 0xcc, 0xc6, 0xf2, 0x88, 0x76,
+	0xa0, 0x3e, 0x26, 0xc9, 0x25, 0x55, 0x93, 0xb4, 0x71, 0x0e, 0x90, 0x03, 0xeb, 0x65, 0xcd, 0x72,
+	0x32, 0x35, 0x5c, 0xc2, 0xb9, 0xe7, 0x22, 0x5b, 0x5c, 0x2f, 0x9f, 0x8b, 0xf7, 0x04, 0x76, 0x56,
+	0x75, 0x61, 0xde, 0x49, 0xff, 0xcb, 0xc9, 0xfb, 0x08, 0x68, 0xb9, 0xe0, 0xff, 0xce, 0x70, 0x17,
+	0x1a, 0x11, 0x4d, 0xa8, 0xa0, 0x2a, 0x41, 0x13, 0x17, 0xc8, 0x3b, 0x82, 0xbb, 0x2b, 0x77, 0x66,
+	0x55, 0x8b, 0xaa, 0xde, 0xb8, 0xf7, 0x0c, 0x9c, 0xaa, 0x45, 0x91, 0x05, 0x95, 0x4b, 0x96, 0x27,
+	0x59, 0xc2, 0xce, 0xab, 0xab, 0xa9, 0xab, 0x5f, 0x4f, 0x5d, 0xfd, 0xd7, 0xd4, 0xd5, 0xbf, 0xcd,
+	0x5c, 0xed, 0x7a, 0xe6, 0x6a, 0x3f, 0x67, 0xae, 0xf6, 0xf9, 0x20, 0x9f, 0x34, 0x8f, 0x06, 0x7e,
+	0x9f, 0x2d, 0xff, 0xbd, 0x78, 0x5b, 0x4c, 0x86, 0x94, 0x9f, 0x37, 0xd4, 0xef, 0xe9, 0xe9, 0xef,
+	0x01, 0x00, 0xc5, 0x33, 0x41, 0x60, 0xe7, 0x04, 0x00, 0x00,
 }
 
 func (m *Snapshot) Marshal() (dAtA []byte, err error) {
@@ -608,6 +758,11 @@ func (m *Metadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BaseHeight != 0 {
+		i = encodeVarintSnapshot(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.ChunkHashes) > 0 {
 		for iNdEx := len(m.ChunkHashes) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.ChunkHashes[iNdEx])
@@ -736,6 +891,48 @@ func (m *SnapshotItem_ExtensionPayload) MarshalToSizedBuffer(dAtA []byte) (int,
 	}
 	return len(dAtA) - i, nil
 }
+func (m *SnapshotItem_DeltaVersion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotItem_DeltaVersion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeltaVersion != nil {
+		{
+			size, err := m.DeltaVersion.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSnapshot(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *SnapshotItem_Change) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotItem_Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Change != nil {
+		{
+			size, err := m.Change.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSnapshot(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
 func (m *SnapshotStoreItem) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -813,6 +1010,81 @@ func (m *SnapshotIAVLItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SnapshotDeltaVersion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotDeltaVersion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotDeltaVersion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintSnapshot(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotChangeItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotChangeItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotChangeItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Delete {
+		i--
+		if m.Delete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintSnapshot(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintSnapshot(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SnapshotExtensionMeta) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -925,6 +1197,9 @@ func (m *Metadata) Size() (n int) {
 			n += 1 + l + sovSnapshot(uint64(l))
 		}
 	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovSnapshot(uint64(m.BaseHeight))
+	}
 	return n
 }
 
@@ -988,6 +1263,30 @@ func (m *SnapshotItem_ExtensionPayload) Size() (n int) {
 	}
 	return n
 }
+func (m *SnapshotItem_DeltaVersion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeltaVersion != nil {
+		l = m.DeltaVersion.Size()
+		n += 1 + l + sovSnapshot(uint64(l))
+	}
+	return n
+}
+func (m *SnapshotItem_Change) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Change != nil {
+		l = m.Change.Size()
+		n += 1 + l + sovSnapshot(uint64(l))
+	}
+	return n
+}
 func (m *SnapshotStoreItem) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1024,6 +1323,38 @@ func (m *SnapshotIAVLItem) Size() (n int) {
 	return n
 }
 
+func (m *SnapshotDeltaVersion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovSnapshot(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *SnapshotChangeItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovSnapshot(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovSnapshot(uint64(l))
+	}
+	if m.Delete {
+		n += 2
+	}
+	return n
+}
+
 func (m *SnapshotExtensionMeta) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1294,6 +1625,25 @@ func (m *Metadata) Unmarshal(dAtA []byte) error {
 			m.ChunkHashes = append(m.ChunkHashes, make([]byte, postIndex-iNdEx))
 			copy(m.ChunkHashes[len(m.ChunkHashes)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSnapshot(dAtA[iNdEx:])
@@ -1484,6 +1834,76 @@ func (m *SnapshotItem) Unmarshal(dAtA []byte) error {
 			}
 			m.Item = &SnapshotItem_ExtensionPayload{v}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeltaVersion", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SnapshotDeltaVersion{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Item = &SnapshotItem_DeltaVersion{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Change", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SnapshotChangeItem{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Item = &SnapshotItem_Change{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSnapshot(dAtA[iNdEx:])
@@ -1743,6 +2163,213 @@ func (m *SnapshotIAVLItem) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SnapshotDeltaVersion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSnapshot
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotDeltaVersion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotDeltaVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSnapshot(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotChangeItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSnapshot
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotChangeItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotChangeItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSnapshot
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Delete = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSnapshot(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSnapshot
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SnapshotExtensionMeta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,505 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	corestore "cosmossdk.io/core/store"
+	storeerrors "cosmossdk.io/store/v2/errors"
+)
+
+const (
+	// DefaultMaxSegments defines the default number of segments after which
+	// adjacent segments are merged.
+	DefaultMaxSegments = 16
+)
+
+// Options defines the configuration of an Archive.
+type Options struct {
+	// MaxSegments defines the number of segment files after which the smallest
+	// adjacent segments are compacted into a single segment. If set to 0,
+	// DefaultMaxSegments is used.
+	MaxSegments int
+}
+
+// DefaultOptions returns the default archive options.
+func DefaultOptions() Options {
+	return Options{MaxSegments: DefaultMaxSegments}
+}
+
+// Archive implements a cold-tier, read-only store for historical versions of
+// state storage (SS). Changesets are appended to a durable pending log as they
+// are committed and, once their version falls behind the hot database's
+// pruning horizon, they are moved into compressed, immutable segment files.
+//
+// Each segment covers a contiguous range of versions and segments never
+// overlap, so a read at version v is served by the newest segment that holds
+// an entry for the key at a version <= v.
+type Archive struct {
+	dir  string
+	opts Options
+
+	// mtx serializes writers, i.e. Append, Flush and compaction.
+	mtx     sync.Mutex
+	pending *pendingLog
+
+	// segMtx guards the segments slice, which is sorted by version range.
+	segMtx   sync.RWMutex
+	segments []*segment
+}
+
+// Open opens, or creates, an Archive in the given directory.
+func Open(dir string, opts Options) (*Archive, error) {
+	if opts.MaxSegments <= 0 {
+		opts.MaxSegments = DefaultMaxSegments
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	// remove any segment that was not fully written prior to a crash
+	tmpFiles, err := filepath.Glob(filepath.Join(dir, "*"+segmentTmpExt))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range tmpFiles {
+		if err := os.Remove(f); err != nil {
+			return nil, err
+		}
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	a := &Archive{dir: dir, opts: opts}
+
+	for _, path := range paths {
+		seg, err := openSegment(path)
+		if err != nil {
+			_ = a.closeSegments()
+			return nil, err
+		}
+
+		a.segments = append(a.segments, seg)
+	}
+
+	if err := a.removeSuperseded(); err != nil {
+		_ = a.closeSegments()
+		return nil, err
+	}
+
+	a.pending, err = openPendingLog(dir)
+	if err != nil {
+		_ = a.closeSegments()
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// removeSuperseded drops segments whose version range is covered by another
+// segment. This may only happen if the process crashed during compaction,
+// after the merged segment was written but before its inputs were removed.
+func (a *Archive) removeSuperseded() error {
+	var kept []*segment
+	for _, seg := range a.segments {
+		if n := len(kept); n > 0 {
+			last := kept[n-1]
+
+			switch {
+			case seg.start <= last.start && seg.end >= last.end:
+				// seg covers last
+				last.obsolete.Store(true)
+				if err := last.release(); err != nil {
+					return err
+				}
+				kept[n-1] = seg
+				continue
+
+			case seg.start >= last.start && seg.end <= last.end:
+				// last covers seg
+				seg.obsolete.Store(true)
+				if err := seg.release(); err != nil {
+					return err
+				}
+				continue
+
+			case seg.start <= last.end:
+				return fmt.Errorf("overlapping archive segments: %s and %s", last.path, seg.path)
+			}
+		}
+
+		kept = append(kept, seg)
+	}
+
+	a.segments = kept
+	return nil
+}
+
+// IsEmpty returns true if the archive holds no segments and no pending
+// changesets.
+func (a *Archive) IsEmpty() bool {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.segMtx.RLock()
+	defer a.segMtx.RUnlock()
+
+	return len(a.segments) == 0 && a.pending.lastVersion == 0
+}
+
+// ArchivedVersion returns the latest version that has been moved into segment
+// files. All versions <= the archived version must be read from the archive.
+func (a *Archive) ArchivedVersion() uint64 {
+	a.segMtx.RLock()
+	defer a.segMtx.RUnlock()
+
+	if len(a.segments) == 0 {
+		return 0
+	}
+
+	return a.segments[len(a.segments)-1].end
+}
+
+// EarliestVersion returns the earliest version that can be read from the
+// archive.
+func (a *Archive) EarliestVersion() uint64 {
+	a.segMtx.RLock()
+	defer a.segMtx.RUnlock()
+
+	if len(a.segments) == 0 {
+		return 0
+	}
+
+	return a.segments[0].start
+}
+
+// Append records the changeset committed at the given version. It must be
+// called before the changeset is written to the hot database. Appending a
+// version lower than a previously appended one, e.g. after a rollback, discards
+// all pending changesets at or above that version.
+func (a *Archive) Append(version uint64, cs *corestore.Changeset) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if archived := a.ArchivedVersion(); version <= archived {
+		return fmt.Errorf("cannot append version %d to archive; already archived up to version %d", version, archived)
+	}
+
+	if version < a.pending.lastVersion {
+		if err := a.pending.Rewrite(func(v uint64) bool { return v < version }); err != nil {
+			return err
+		}
+	}
+
+	return a.pending.Append(version, cs)
+}
+
+// Flush moves all pending changesets with a version <= the given version into
+// a new segment file, compacting segments if needed. It must be called before
+// the same versions are pruned from the hot database.
+func (a *Archive) Flush(version uint64) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	archived := a.ArchivedVersion()
+	if version <= archived {
+		return nil
+	}
+
+	var (
+		entries []entry
+		start   = archived + 1
+		seen    bool
+	)
+
+	err := a.pending.scan(func(v uint64, cs *corestore.Changeset, _ int64) (bool, error) {
+		if v <= archived || v > version {
+			return true, nil
+		}
+
+		// the first segment starts at the earliest recorded version
+		if len(a.segments) == 0 && (!seen || v < start) {
+			start = v
+		}
+		seen = true
+
+		for _, changes := range cs.Changes {
+			for _, kv := range changes.StateChanges {
+				entries = append(entries, entry{
+					storeKey: changes.Actor,
+					key:      kv.Key,
+					version:  v,
+					value:    kv.Value,
+					remove:   kv.Remove,
+				})
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read archive pending log: %w", err)
+	}
+
+	// nothing has ever been recorded for these versions, so there is nothing to
+	// archive
+	if len(a.segments) == 0 && !seen {
+		return nil
+	}
+
+	// Sort entries while preserving the order in which they were appended. If a
+	// version was re-applied after a crash, the last write of a key wins.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return compareEntry(entries[i].storeKey, entries[i].key, entries[i].version, &entries[j]) < 0
+	})
+
+	w, err := newSegmentWriter(a.dir, start, version)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		if i+1 < len(entries) && compareEntry(entries[i].storeKey, entries[i].key, entries[i].version, &entries[i+1]) == 0 {
+			continue
+		}
+
+		if err := w.Add(&entries[i]); err != nil {
+			w.Abort()
+			return err
+		}
+	}
+
+	if err := w.Finish(); err != nil {
+		w.Abort()
+		return err
+	}
+
+	seg, err := openSegment(w.path)
+	if err != nil {
+		return err
+	}
+
+	a.segMtx.Lock()
+	a.segments = append(a.segments, seg)
+	a.segMtx.Unlock()
+
+	if err := a.pending.Rewrite(func(v uint64) bool { return v > version }); err != nil {
+		return err
+	}
+
+	return a.compact()
+}
+
+// compact merges the smallest pair of adjacent segments until the number of
+// segments no longer exceeds the configured maximum.
+func (a *Archive) compact() error {
+	for {
+		a.segMtx.RLock()
+		segments := slices.Clone(a.segments)
+		a.segMtx.RUnlock()
+
+		if len(segments) <= a.opts.MaxSegments {
+			return nil
+		}
+
+		idx := 0
+		for i := 1; i < len(segments)-1; i++ {
+			if segments[i].size+segments[i+1].size < segments[idx].size+segments[idx+1].size {
+				idx = i
+			}
+		}
+
+		merged, err := mergeSegments(a.dir, segments[idx], segments[idx+1])
+		if err != nil {
+			return fmt.Errorf("failed to compact archive segments: %w", err)
+		}
+
+		a.segMtx.Lock()
+		a.segments = slices.Replace(a.segments, idx, idx+2, merged)
+		a.segMtx.Unlock()
+
+		for _, seg := range segments[idx : idx+2] {
+			seg.obsolete.Store(true)
+			if err := seg.release(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// mergeSegments merges two adjacent segments into a single new segment.
+func mergeSegments(dir string, older, newer *segment) (*segment, error) {
+	w, err := newSegmentWriter(dir, older.start, newer.end)
+	if err != nil {
+		return nil, err
+	}
+
+	err = func() error {
+		a, b := newCursor(older), newCursor(newer)
+		if err := a.load(0); err != nil {
+			return err
+		}
+		if err := b.load(0); err != nil {
+			return err
+		}
+
+		for a.valid() || b.valid() {
+			c := a
+			if !a.valid() || (b.valid() && compareEntry(b.entry().storeKey, b.entry().key, b.entry().version, a.entry()) < 0) {
+				c = b
+			}
+
+			if err := w.Add(c.entry()); err != nil {
+				return err
+			}
+			if err := c.next(); err != nil {
+				return err
+			}
+		}
+
+		return w.Finish()
+	}()
+	if err != nil {
+		w.Abort()
+		return nil, err
+	}
+
+	return openSegment(w.path)
+}
+
+// acquireSegments returns the segments which may hold entries at or below the
+// given version, ordered from oldest to newest. The caller must release them.
+func (a *Archive) acquireSegments(version uint64) []*segment {
+	a.segMtx.RLock()
+	defer a.segMtx.RUnlock()
+
+	var segments []*segment
+	for _, seg := range a.segments {
+		if seg.start > version {
+			break
+		}
+
+		seg.acquire()
+		segments = append(segments, seg)
+	}
+
+	return segments
+}
+
+func releaseSegments(segments []*segment) error {
+	var errs []string
+	for _, seg := range segments {
+		if err := seg.release(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to release archive segments: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Has returns true if the key exists at the given version.
+func (a *Archive) Has(storeKey []byte, version uint64, key []byte) (bool, error) {
+	val, err := a.Get(storeKey, version, key)
+	if err != nil {
+		return false, err
+	}
+
+	return val != nil, nil
+}
+
+// Get returns the value of the key at the given version, or nil if the key did
+// not exist at that version.
+func (a *Archive) Get(storeKey []byte, version uint64, key []byte) ([]byte, error) {
+	if earliest := a.EarliestVersion(); version < earliest {
+		return nil, storeerrors.ErrVersionPruned{EarliestVersion: earliest}
+	}
+
+	segments := a.acquireSegments(version)
+	defer releaseSegments(segments) //nolint:errcheck // release errors are not actionable for readers
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		e, err := segments[i].get(storeKey, key, version)
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			continue
+		}
+		if e.remove {
+			return nil, nil
+		}
+
+		return slices.Clone(e.value), nil
+	}
+
+	return nil, nil
+}
+
+// Iterator returns an iterator over the domain [start, end) of the given store
+// key at the given version.
+func (a *Archive) Iterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error) {
+	return a.newIterator(storeKey, version, start, end, false)
+}
+
+// ReverseIterator returns an iterator over the domain [start, end) of the given
+// store key at the given version in reverse order.
+func (a *Archive) ReverseIterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error) {
+	return a.newIterator(storeKey, version, start, end, true)
+}
+
+func (a *Archive) newIterator(storeKey []byte, version uint64, start, end []byte, reverse bool) (corestore.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, storeerrors.ErrKeyEmpty
+	}
+	if start != nil && end != nil && bytes.Compare(start, end) > 0 {
+		return nil, storeerrors.ErrStartAfterEnd
+	}
+	if earliest := a.EarliestVersion(); version < earliest {
+		return nil, storeerrors.ErrVersionPruned{EarliestVersion: earliest}
+	}
+
+	itr, err := newIterator(a.acquireSegments(version), storeKey, version, start, end, reverse)
+	if err != nil {
+		return nil, err
+	}
+
+	return itr, nil
+}
+
+// Close closes the pending log and all segment files.
+func (a *Archive) Close() error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	err := a.pending.Close()
+	if segErr := a.closeSegments(); segErr != nil && err == nil {
+		err = segErr
+	}
+
+	return err
+}
+
+func (a *Archive) closeSegments() error {
+	a.segMtx.Lock()
+	defer a.segMtx.Unlock()
+
+	err := releaseSegments(a.segments)
+	a.segments = nil
+
+	return err
+}
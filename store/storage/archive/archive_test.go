@@ -0,0 +1,266 @@
+package archive_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2"
+	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/archive"
+	"cosmossdk.io/store/v2/storage/pebbledb"
+)
+
+const storeKey1 = "store1"
+
+var storeKey1Bytes = []byte(storeKey1)
+
+// reader defines the read API shared by the Archive and the StorageStore.
+type reader interface {
+	Get(storeKey []byte, version uint64, key []byte) ([]byte, error)
+	Has(storeKey []byte, version uint64, key []byte) (bool, error)
+	Iterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error)
+	ReverseIterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error)
+}
+
+// model tracks the expected state of storeKey1 at every version.
+type model map[uint64]map[string]string
+
+// changeset returns a deterministic changeset for the given version which
+// updates a few keys and occasionally removes one, along with the updated model.
+func changeset(m model, version uint64) *corestore.Changeset {
+	state := make(map[string]string)
+	for k, v := range m[version-1] {
+		state[k] = v
+	}
+
+	var pairs corestore.KVPairs
+	for i := uint64(0); i < 3; i++ {
+		key := fmt.Sprintf("key%03d", (version*7+i)%20)
+		val := fmt.Sprintf("val-%d-%d", version, i)
+		pairs = append(pairs, corestore.KVPair{Key: []byte(key), Value: []byte(val)})
+		state[key] = val
+	}
+	if version%4 == 0 {
+		key := fmt.Sprintf("key%03d", (version*3)%20)
+		pairs = append(pairs, corestore.KVPair{Key: []byte(key), Remove: true})
+		delete(state, key)
+	}
+
+	m[version] = state
+	return corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{storeKey1: pairs})
+}
+
+func requireState(t *testing.T, r reader, m model, version uint64) {
+	t.Helper()
+
+	expected := m[version]
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key%03d", i)
+
+		bz, err := r.Get(storeKey1Bytes, version, []byte(key))
+		require.NoError(t, err)
+
+		ok, err := r.Has(storeKey1Bytes, version, []byte(key))
+		require.NoError(t, err)
+
+		val, exists := expected[key]
+		require.Equal(t, exists, ok, "version %d key %s", version, key)
+		if exists {
+			require.Equal(t, val, string(bz), "version %d key %s", version, key)
+		} else {
+			require.Nil(t, bz, "version %d key %s", version, key)
+		}
+	}
+
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	itr, err := r.Iterator(storeKey1Bytes, version, nil, nil)
+	require.NoError(t, err)
+
+	var got []string
+	for ; itr.Valid(); itr.Next() {
+		require.Equal(t, expected[string(itr.Key())], string(itr.Value()))
+		got = append(got, string(itr.Key()))
+	}
+	require.NoError(t, itr.Error())
+	require.NoError(t, itr.Close())
+	require.Equal(t, keys, got, "version %d", version)
+
+	rItr, err := r.ReverseIterator(storeKey1Bytes, version, []byte("key005"), []byte("key015"))
+	require.NoError(t, err)
+
+	got = nil
+	for ; rItr.Valid(); rItr.Next() {
+		got = append(got, string(rItr.Key()))
+	}
+	require.NoError(t, rItr.Error())
+	require.NoError(t, rItr.Close())
+
+	var want []string
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i] >= "key005" && keys[i] < "key015" {
+			want = append(want, keys[i])
+		}
+	}
+	require.Equal(t, want, got, "version %d", version)
+}
+
+func TestArchive_FlushAndRead(t *testing.T) {
+	a, err := archive.Open(t.TempDir(), archive.DefaultOptions())
+	require.NoError(t, err)
+	defer a.Close()
+
+	require.True(t, a.IsEmpty())
+
+	m := model{}
+	for v := uint64(1); v <= 50; v++ {
+		require.NoError(t, a.Append(v, changeset(m, v)))
+	}
+	require.False(t, a.IsEmpty())
+
+	require.NoError(t, a.Flush(30))
+	require.Equal(t, uint64(1), a.EarliestVersion())
+	require.Equal(t, uint64(30), a.ArchivedVersion())
+
+	for v := uint64(1); v <= 30; v++ {
+		requireState(t, a, m, v)
+	}
+
+	// archived versions cannot be appended again
+	require.Error(t, a.Append(30, changeset(model{}, 30)))
+}
+
+func TestArchive_Compaction(t *testing.T) {
+	dir := t.TempDir()
+
+	a, err := archive.Open(dir, archive.Options{MaxSegments: 2})
+	require.NoError(t, err)
+
+	m := model{}
+	for v := uint64(1); v <= 60; v++ {
+		require.NoError(t, a.Append(v, changeset(m, v)))
+		if v%5 == 0 {
+			require.NoError(t, a.Flush(v))
+		}
+	}
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+
+	for v := uint64(1); v <= 60; v++ {
+		requireState(t, a, m, v)
+	}
+
+	// reopen the archive and ensure all state is preserved
+	require.NoError(t, a.Close())
+
+	a, err = archive.Open(dir, archive.Options{MaxSegments: 2})
+	require.NoError(t, err)
+	defer a.Close()
+
+	require.Equal(t, uint64(60), a.ArchivedVersion())
+	for v := uint64(1); v <= 60; v++ {
+		requireState(t, a, m, v)
+	}
+}
+
+func TestArchive_PendingLogRecovery(t *testing.T) {
+	dir := t.TempDir()
+
+	a, err := archive.Open(dir, archive.DefaultOptions())
+	require.NoError(t, err)
+
+	m := model{}
+	for v := uint64(1); v <= 10; v++ {
+		require.NoError(t, a.Append(v, changeset(m, v)))
+	}
+	require.NoError(t, a.Close())
+
+	// simulate a torn write at the tail of the pending log
+	f, err := os.OpenFile(filepath.Join(dir, "pending.log"), os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0x00, 0x00, 0x01, 0x00, 0xde, 0xad})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	a, err = archive.Open(dir, archive.DefaultOptions())
+	require.NoError(t, err)
+	defer a.Close()
+
+	// a rollback discards versions >= the re-applied version
+	delete(m, 10)
+	delete(m, 9)
+	require.NoError(t, a.Append(9, changeset(m, 9)))
+	require.NoError(t, a.Append(10, changeset(m, 10)))
+
+	require.NoError(t, a.Flush(10))
+	for v := uint64(1); v <= 10; v++ {
+		requireState(t, a, m, v)
+	}
+}
+
+func TestStorageStore_Archive(t *testing.T) {
+	db, err := pebbledb.New(t.TempDir())
+	require.NoError(t, err)
+	db.SetSync(false)
+
+	a, err := archive.Open(t.TempDir(), archive.Options{MaxSegments: 3})
+	require.NoError(t, err)
+
+	ss := storage.NewStorageStore(db, &store.PruneOptions{KeepRecent: 10, Interval: 10}, log.NewNopLogger())
+	require.NoError(t, ss.EnableArchive(a))
+	defer ss.Close()
+
+	m := model{}
+	for v := uint64(1); v <= 100; v++ {
+		require.NoError(t, ss.ApplyChangeset(v, changeset(m, v)))
+	}
+
+	// the hot database no longer holds the archived versions
+	require.Equal(t, uint64(89), a.ArchivedVersion())
+	_, err = db.Get(storeKey1Bytes, 50, []byte("key001"))
+	require.ErrorIs(t, err, storeerrors.ErrVersionPruned{EarliestVersion: 90})
+
+	for v := uint64(1); v <= 89; v++ {
+		requireState(t, ss, m, v)
+	}
+
+	// versions above the archived version are still served by the database
+	for v := uint64(90); v <= 100; v++ {
+		for k, val := range m[v] {
+			bz, err := ss.Get(storeKey1Bytes, v, []byte(k))
+			require.NoError(t, err)
+			require.Equal(t, val, string(bz))
+		}
+	}
+}
+
+func TestStorageStore_ArchiveNonEmptyDatabase(t *testing.T) {
+	db, err := pebbledb.New(t.TempDir())
+	require.NoError(t, err)
+	db.SetSync(false)
+
+	ss := storage.NewStorageStore(db, nil, log.NewNopLogger())
+	defer ss.Close()
+
+	require.NoError(t, ss.ApplyChangeset(1, changeset(model{}, 1)))
+
+	a, err := archive.Open(t.TempDir(), archive.DefaultOptions())
+	require.NoError(t, err)
+	defer a.Close()
+
+	require.Error(t, ss.EnableArchive(a))
+}
@@ -0,0 +1,239 @@
+package archive
+
+import (
+	"bytes"
+	"slices"
+
+	corestore "cosmossdk.io/core/store"
+)
+
+var _ corestore.Iterator = (*iterator)(nil)
+
+// segmentIterator iterates over the keys of a single segment, yielding the
+// latest entry of each key with a version <= the target version. Yielded
+// entries may be tombstones, since a removal in a newer segment must shadow
+// values held by older segments.
+type segmentIterator struct {
+	c *cursor
+
+	storeKey   []byte
+	start, end []byte
+	version    uint64
+	reverse    bool
+
+	cur *entry
+	err error
+}
+
+func newSegmentIterator(seg *segment, storeKey []byte, version uint64, start, end []byte, reverse bool) *segmentIterator {
+	itr := &segmentIterator{
+		c:        newCursor(seg),
+		storeKey: storeKey,
+		start:    start,
+		end:      end,
+		version:  version,
+		reverse:  reverse,
+	}
+
+	switch {
+	case !reverse:
+		itr.err = itr.c.seekGE(storeKey, start, 0)
+	case end == nil:
+		itr.err = itr.c.seekLast(storeKey)
+	default:
+		itr.err = itr.c.seekLT(storeKey, end, 0)
+	}
+
+	itr.advance()
+	return itr
+}
+
+func (itr *segmentIterator) valid() bool {
+	return itr.err == nil && itr.cur != nil
+}
+
+func (itr *segmentIterator) inDomain(e *entry) bool {
+	if !bytes.Equal(e.storeKey, itr.storeKey) {
+		return false
+	}
+	if itr.reverse {
+		return itr.start == nil || bytes.Compare(e.key, itr.start) >= 0
+	}
+
+	return itr.end == nil || bytes.Compare(e.key, itr.end) < 0
+}
+
+// advance moves the iterator to the next key which has an entry visible at the
+// target version.
+func (itr *segmentIterator) advance() {
+	itr.cur = nil
+
+	for itr.err == nil && itr.c.valid() {
+		e := itr.c.entry()
+		if !itr.inDomain(e) {
+			return
+		}
+
+		var (
+			key   = e.key
+			found *entry
+		)
+
+		// Entries of a key are ordered by ascending version, so in forward order
+		// the last visible entry wins, while in reverse order the first one does.
+		for itr.c.valid() {
+			e := itr.c.entry()
+			if !bytes.Equal(e.storeKey, itr.storeKey) || !bytes.Equal(e.key, key) {
+				break
+			}
+
+			if e.version <= itr.version && (!itr.reverse || found == nil) {
+				found = e
+			}
+
+			if itr.reverse {
+				itr.err = itr.c.prev()
+			} else {
+				itr.err = itr.c.next()
+			}
+			if itr.err != nil {
+				return
+			}
+		}
+
+		if found != nil {
+			itr.cur = found
+			return
+		}
+	}
+}
+
+// iterator merges the segment iterators of all segments holding entries at or
+// below the target version. For keys present in several segments, the entry
+// from the newest segment wins.
+type iterator struct {
+	segments []*segment
+	itrs     []*segmentIterator
+
+	start, end []byte
+	reverse    bool
+
+	key, value []byte
+	valid      bool
+	err        error
+}
+
+func newIterator(segments []*segment, storeKey []byte, version uint64, start, end []byte, reverse bool) (*iterator, error) {
+	itr := &iterator{
+		segments: segments,
+		start:    start,
+		end:      end,
+		reverse:  reverse,
+	}
+
+	for _, seg := range segments {
+		segItr := newSegmentIterator(seg, storeKey, version, start, end, reverse)
+		if segItr.err != nil {
+			_ = releaseSegments(segments)
+			return nil, segItr.err
+		}
+
+		itr.itrs = append(itr.itrs, segItr)
+	}
+
+	itr.valid = true
+	itr.Next()
+
+	return itr, nil
+}
+
+// Domain returns the domain of the iterator.
+func (itr *iterator) Domain() ([]byte, []byte) {
+	return itr.start, itr.end
+}
+
+func (itr *iterator) Valid() bool {
+	return itr.valid
+}
+
+func (itr *iterator) Key() []byte {
+	itr.assertIsValid()
+	return slices.Clone(itr.key)
+}
+
+func (itr *iterator) Value() []byte {
+	itr.assertIsValid()
+	return slices.Clone(itr.value)
+}
+
+func (itr *iterator) Next() {
+	if !itr.valid {
+		return
+	}
+
+	for {
+		// find the next key across all segments, preferring newer segments
+		var winner *segmentIterator
+		for _, segItr := range itr.itrs {
+			if segItr.err != nil {
+				itr.err, itr.valid = segItr.err, false
+				return
+			}
+			if !segItr.valid() {
+				continue
+			}
+
+			if winner == nil {
+				winner = segItr
+				continue
+			}
+
+			cmp := bytes.Compare(segItr.cur.key, winner.cur.key)
+			if itr.reverse {
+				cmp = -cmp
+			}
+			if cmp <= 0 {
+				winner = segItr
+			}
+		}
+
+		if winner == nil {
+			itr.valid = false
+			return
+		}
+
+		cur := winner.cur
+		key := cur.key
+
+		for _, segItr := range itr.itrs {
+			if segItr.valid() && bytes.Equal(segItr.cur.key, key) {
+				segItr.advance()
+			}
+		}
+
+		if !cur.remove {
+			itr.key, itr.value = key, cur.value
+			return
+		}
+	}
+}
+
+func (itr *iterator) Error() error {
+	return itr.err
+}
+
+func (itr *iterator) Close() error {
+	itr.valid = false
+	itr.itrs = nil
+
+	err := releaseSegments(itr.segments)
+	itr.segments = nil
+
+	return err
+}
+
+func (itr *iterator) assertIsValid() {
+	if !itr.valid {
+		panic("iterator is invalid")
+	}
+}
@@ -0,0 +1,232 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/store/v2/internal/encoding"
+)
+
+const (
+	pendingLogName = "pending.log"
+
+	// recordHeaderSize is the size of a pending log record header:
+	// length (4) | crc32 (4)
+	recordHeaderSize = 8
+)
+
+// pendingLog is an append-only log of versioned changesets that have been
+// committed to the hot database but are not yet part of an archive segment.
+// Each record is framed with its length and a CRC32 checksum so that a torn
+// write at the tail of the log, e.g. caused by a crash, can be detected and
+// discarded on open.
+type pendingLog struct {
+	path string
+	file *os.File
+
+	// lastVersion is the highest version appended to the log, if any.
+	lastVersion uint64
+}
+
+func openPendingLog(dir string) (*pendingLog, error) {
+	path := fmt.Sprintf("%s/%s", dir, pendingLogName)
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive pending log: %w", err)
+	}
+
+	pl := &pendingLog{path: path, file: f}
+
+	// scan the log to find the last valid record and truncate any torn tail
+	var validSize int64
+	err = pl.scan(func(version uint64, _ *corestore.Changeset, end int64) (bool, error) {
+		pl.lastVersion = version
+		validSize = end
+		return true, nil
+	})
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	if err := f.Truncate(validSize); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(validSize, io.SeekStart); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return pl, nil
+}
+
+// Append writes the changeset for the given version to the log and syncs it
+// to disk.
+func (pl *pendingLog) Append(version uint64, cs *corestore.Changeset) error {
+	record, err := encodeRecord(version, cs)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pl.file.Write(record); err != nil {
+		return fmt.Errorf("failed to write archive pending log: %w", err)
+	}
+	if err := pl.file.Sync(); err != nil {
+		return err
+	}
+
+	pl.lastVersion = version
+	return nil
+}
+
+// scan iterates over all valid records in the log, stopping at the first torn
+// or corrupt record. The callback receives the end offset of the record and
+// returns false to stop scanning.
+func (pl *pendingLog) scan(fn func(version uint64, cs *corestore.Changeset, end int64) (bool, error)) error {
+	if _, err := pl.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = pl.file.Seek(0, io.SeekEnd)
+	}()
+
+	var (
+		r      = bufio.NewReader(pl.file)
+		offset int64
+		header [recordHeaderSize]byte
+	)
+
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+
+		length := binary.BigEndian.Uint32(header[0:])
+		checksum := binary.BigEndian.Uint32(header[4:])
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		if crc32.ChecksumIEEE(payload) != checksum {
+			return nil
+		}
+
+		version, cs, err := decodeRecord(payload)
+		if err != nil {
+			return nil
+		}
+
+		offset += int64(recordHeaderSize) + int64(length)
+
+		ok, err := fn(version, cs, offset)
+		if err != nil || !ok {
+			return err
+		}
+	}
+}
+
+// Rewrite atomically replaces the log with only the records accepted by keep.
+func (pl *pendingLog) Rewrite(keep func(version uint64) bool) error {
+	tmpPath := pl.path + segmentTmpExt
+
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	var lastVersion uint64
+	err = pl.scan(func(version uint64, cs *corestore.Changeset, _ int64) (bool, error) {
+		if !keep(version) {
+			return true, nil
+		}
+
+		record, err := encodeRecord(version, cs)
+		if err != nil {
+			return false, err
+		}
+		if _, err := tmp.Write(record); err != nil {
+			return false, err
+		}
+
+		lastVersion = version
+		return true, nil
+	})
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to rewrite archive pending log: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, pl.path); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := pl.file.Close(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	pl.file = tmp
+	pl.lastVersion = lastVersion
+	_, err = pl.file.Seek(0, io.SeekEnd)
+
+	return err
+}
+
+// Close closes the log file.
+func (pl *pendingLog) Close() error {
+	return pl.file.Close()
+}
+
+func encodeRecord(version uint64, cs *corestore.Changeset) ([]byte, error) {
+	csBz, err := encoding.MarshalChangeset(cs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal changeset: %w", err)
+	}
+
+	var payload bytes.Buffer
+	if err := encoding.EncodeUvarint(&payload, version); err != nil {
+		return nil, err
+	}
+	payload.Write(csBz)
+
+	record := make([]byte, recordHeaderSize+payload.Len())
+	binary.BigEndian.PutUint32(record[0:], uint32(payload.Len()))
+	binary.BigEndian.PutUint32(record[4:], crc32.ChecksumIEEE(payload.Bytes()))
+	copy(record[recordHeaderSize:], payload.Bytes())
+
+	return record, nil
+}
+
+func decodeRecord(payload []byte) (uint64, *corestore.Changeset, error) {
+	version, n, err := encoding.DecodeUvarint(payload)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cs := corestore.NewChangeset()
+	if err := encoding.UnmarshalChangeset(cs, payload[n:]); err != nil {
+		return 0, nil, err
+	}
+
+	return version, cs, nil
+}
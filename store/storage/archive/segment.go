@@ -0,0 +1,628 @@
+package archive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"cosmossdk.io/store/v2/internal/encoding"
+)
+
+const (
+	segmentMagic  = "CSARCH01"
+	segmentExt    = ".seg"
+	segmentTmpExt = ".tmp"
+
+	// footerSize is the size of the fixed-size segment footer:
+	// indexOffset (8) | indexLength (8) | start (8) | end (8) | magic (8)
+	footerSize = 40
+
+	// defaultBlockSize defines the uncompressed size, in bytes, at which a block
+	// of entries is flushed to the segment file.
+	defaultBlockSize = 64 * 1024
+)
+
+// entry defines a single versioned key/value record stored in a segment. A
+// removed entry marks the key as deleted as of the entry's version.
+type entry struct {
+	storeKey []byte
+	key      []byte
+	version  uint64
+	value    []byte
+	remove   bool
+}
+
+// compareEntry orders entries by (storeKey, key, version) ascending.
+func compareEntry(storeKey, key []byte, version uint64, e *entry) int {
+	if c := bytes.Compare(storeKey, e.storeKey); c != 0 {
+		return c
+	}
+	if c := bytes.Compare(key, e.key); c != 0 {
+		return c
+	}
+
+	switch {
+	case version < e.version:
+		return -1
+	case version > e.version:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func encodeEntry(w io.Writer, e *entry) error {
+	if err := encoding.EncodeBytes(w, e.storeKey); err != nil {
+		return err
+	}
+	if err := encoding.EncodeBytes(w, e.key); err != nil {
+		return err
+	}
+	if err := encoding.EncodeUvarint(w, e.version); err != nil {
+		return err
+	}
+	if e.remove {
+		return encoding.EncodeUvarint(w, 1)
+	}
+	if err := encoding.EncodeUvarint(w, 0); err != nil {
+		return err
+	}
+
+	return encoding.EncodeBytes(w, e.value)
+}
+
+func decodeEntry(buf []byte) (entry, int, error) {
+	var (
+		e     entry
+		total int
+	)
+
+	storeKey, n, err := encoding.DecodeBytes(buf)
+	if err != nil {
+		return e, 0, err
+	}
+	buf, total = buf[n:], total+n
+
+	key, n, err := encoding.DecodeBytes(buf)
+	if err != nil {
+		return e, 0, err
+	}
+	buf, total = buf[n:], total+n
+
+	version, n, err := encoding.DecodeUvarint(buf)
+	if err != nil {
+		return e, 0, err
+	}
+	buf, total = buf[n:], total+n
+
+	remove, n, err := encoding.DecodeUvarint(buf)
+	if err != nil {
+		return e, 0, err
+	}
+	buf, total = buf[n:], total+n
+
+	e.storeKey, e.key, e.version = storeKey, key, version
+	switch remove {
+	case 0:
+		e.value, n, err = encoding.DecodeBytes(buf)
+		if err != nil {
+			return e, 0, err
+		}
+		total += n
+
+	case 1:
+		e.remove = true
+
+	default:
+		return e, 0, fmt.Errorf("invalid remove flag: %d", remove)
+	}
+
+	return e, total, nil
+}
+
+// blockHandle defines the location of a compressed block within a segment file
+// along with the first entry of the block, which is used to binary search the
+// block index.
+type blockHandle struct {
+	first  entry
+	offset uint64
+	length uint64
+}
+
+// segmentWriter writes a new, immutable segment file. Entries must be added in
+// ascending (storeKey, key, version) order. The segment is written to a
+// temporary file and only becomes visible once Finish renames it into place.
+type segmentWriter struct {
+	path string
+	file *os.File
+
+	start, end uint64
+
+	offset  uint64
+	block   bytes.Buffer
+	first   *entry
+	last    *entry
+	handles []blockHandle
+	size    int
+}
+
+func newSegmentWriter(dir string, start, end uint64) (*segmentWriter, error) {
+	path := segmentPath(dir, start, end)
+
+	f, err := os.Create(path + segmentTmpExt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive segment: %w", err)
+	}
+
+	if _, err := f.Write([]byte(segmentMagic)); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &segmentWriter{
+		path:   path,
+		file:   f,
+		start:  start,
+		end:    end,
+		offset: uint64(len(segmentMagic)),
+	}, nil
+}
+
+// Add appends an entry to the segment.
+func (w *segmentWriter) Add(e *entry) error {
+	if w.last != nil && compareEntry(e.storeKey, e.key, e.version, w.last) <= 0 {
+		return fmt.Errorf("archive segment entries must be strictly ascending")
+	}
+
+	if w.first == nil {
+		first := cloneEntry(e)
+		w.first = &first
+	}
+	last := cloneEntry(e)
+	w.last = &last
+
+	if err := encodeEntry(&w.block, e); err != nil {
+		return err
+	}
+
+	w.size++
+	if w.block.Len() >= defaultBlockSize {
+		return w.flushBlock()
+	}
+
+	return nil
+}
+
+func (w *segmentWriter) flushBlock() error {
+	if w.block.Len() == 0 {
+		return nil
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(w.block.Bytes()); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	if _, err := w.file.Write(compressed.Bytes()); err != nil {
+		return fmt.Errorf("failed to write archive segment block: %w", err)
+	}
+
+	w.handles = append(w.handles, blockHandle{
+		first:  *w.first,
+		offset: w.offset,
+		length: uint64(compressed.Len()),
+	})
+
+	w.offset += uint64(compressed.Len())
+	w.block.Reset()
+	w.first = nil
+
+	return nil
+}
+
+// Finish flushes any buffered entries, writes the block index and footer, syncs
+// the file to disk and atomically moves it into place.
+func (w *segmentWriter) Finish() error {
+	if err := w.flushBlock(); err != nil {
+		return err
+	}
+
+	var index bytes.Buffer
+	if err := encoding.EncodeUvarint(&index, uint64(len(w.handles))); err != nil {
+		return err
+	}
+	for i := range w.handles {
+		h := &w.handles[i]
+		if err := encodeEntry(&index, &h.first); err != nil {
+			return err
+		}
+		if err := encoding.EncodeUvarint(&index, h.offset); err != nil {
+			return err
+		}
+		if err := encoding.EncodeUvarint(&index, h.length); err != nil {
+			return err
+		}
+	}
+
+	var footer [footerSize]byte
+	binary.BigEndian.PutUint64(footer[0:], w.offset)
+	binary.BigEndian.PutUint64(footer[8:], uint64(index.Len()))
+	binary.BigEndian.PutUint64(footer[16:], w.start)
+	binary.BigEndian.PutUint64(footer[24:], w.end)
+	copy(footer[32:], segmentMagic)
+
+	if _, err := w.file.Write(index.Bytes()); err != nil {
+		return err
+	}
+	if _, err := w.file.Write(footer[:]); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(w.path+segmentTmpExt, w.path)
+}
+
+// Abort discards the partially written segment.
+func (w *segmentWriter) Abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.path + segmentTmpExt)
+}
+
+// segment defines a read-only, immutable archive segment file containing all
+// changes for the versions [start, end]. A segment is reference counted so
+// that it can be replaced by compaction while readers are still using it.
+type segment struct {
+	path string
+	file *os.File
+	size int64
+
+	start, end uint64
+	handles    []blockHandle
+
+	refs     atomic.Int32
+	obsolete atomic.Bool
+
+	mtx       sync.Mutex
+	lastIdx   int
+	lastBlock []entry
+}
+
+func openSegment(path string) (*segment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	seg, err := loadSegment(f, path)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to open archive segment %s: %w", path, err)
+	}
+
+	return seg, nil
+}
+
+func loadSegment(f *os.File, path string) (*segment, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < int64(len(segmentMagic)+footerSize) {
+		return nil, errors.New("segment file too small")
+	}
+
+	var footer [footerSize]byte
+	if _, err := f.ReadAt(footer[:], info.Size()-footerSize); err != nil {
+		return nil, err
+	}
+	if string(footer[32:]) != segmentMagic {
+		return nil, errors.New("invalid segment footer")
+	}
+
+	indexOffset := binary.BigEndian.Uint64(footer[0:])
+	indexLength := binary.BigEndian.Uint64(footer[8:])
+	if indexOffset+indexLength+footerSize != uint64(info.Size()) {
+		return nil, errors.New("invalid segment index bounds")
+	}
+
+	index := make([]byte, indexLength)
+	if _, err := f.ReadAt(index, int64(indexOffset)); err != nil {
+		return nil, err
+	}
+
+	count, n, err := encoding.DecodeUvarint(index)
+	if err != nil {
+		return nil, err
+	}
+	index = index[n:]
+
+	handles := make([]blockHandle, count)
+	for i := range handles {
+		e, n, err := decodeEntry(index)
+		if err != nil {
+			return nil, err
+		}
+		index = index[n:]
+
+		offset, n, err := encoding.DecodeUvarint(index)
+		if err != nil {
+			return nil, err
+		}
+		index = index[n:]
+
+		length, n, err := encoding.DecodeUvarint(index)
+		if err != nil {
+			return nil, err
+		}
+		index = index[n:]
+
+		handles[i] = blockHandle{first: e, offset: offset, length: length}
+	}
+
+	seg := &segment{
+		path:    path,
+		file:    f,
+		size:    info.Size(),
+		start:   binary.BigEndian.Uint64(footer[16:]),
+		end:     binary.BigEndian.Uint64(footer[24:]),
+		handles: handles,
+		lastIdx: -1,
+	}
+	seg.refs.Store(1)
+
+	return seg, nil
+}
+
+// acquire increments the segment's reference count.
+func (s *segment) acquire() {
+	s.refs.Add(1)
+}
+
+// release decrements the segment's reference count. Once the last reference is
+// released, the underlying file is closed and, if the segment was replaced by
+// compaction, removed from disk.
+func (s *segment) release() error {
+	if s.refs.Add(-1) > 0 {
+		return nil
+	}
+
+	err := s.file.Close()
+	if s.obsolete.Load() {
+		err = errors.Join(err, os.Remove(s.path))
+	}
+
+	return err
+}
+
+// readBlock returns the decoded entries of the block at the given index.
+func (s *segment) readBlock(idx int) ([]entry, error) {
+	s.mtx.Lock()
+	if s.lastIdx == idx {
+		block := s.lastBlock
+		s.mtx.Unlock()
+		return block, nil
+	}
+	s.mtx.Unlock()
+
+	h := s.handles[idx]
+	compressed := make([]byte, h.length)
+	if _, err := s.file.ReadAt(compressed, int64(h.offset)); err != nil {
+		return nil, fmt.Errorf("failed to read archive segment block: %w", err)
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	if err := zr.Close(); err != nil {
+		return nil, err
+	}
+
+	var block []entry
+	for len(raw) > 0 {
+		e, n, err := decodeEntry(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode archive segment entry: %w", err)
+		}
+		block = append(block, e)
+		raw = raw[n:]
+	}
+
+	s.mtx.Lock()
+	s.lastIdx, s.lastBlock = idx, block
+	s.mtx.Unlock()
+
+	return block, nil
+}
+
+// get returns the latest entry for the given key with a version <= the given
+// version, if any.
+func (s *segment) get(storeKey, key []byte, version uint64) (*entry, error) {
+	if version < s.start {
+		return nil, nil
+	}
+
+	c := newCursor(s)
+	if err := c.seekLE(storeKey, key, version); err != nil {
+		return nil, err
+	}
+	if !c.valid() {
+		return nil, nil
+	}
+
+	e := c.entry()
+	if !bytes.Equal(e.storeKey, storeKey) || !bytes.Equal(e.key, key) {
+		return nil, nil
+	}
+
+	return e, nil
+}
+
+// cursor defines a bidirectional cursor over the entries of a segment.
+type cursor struct {
+	seg      *segment
+	blockIdx int
+	block    []entry
+	pos      int
+}
+
+func newCursor(seg *segment) *cursor {
+	return &cursor{seg: seg, blockIdx: -1}
+}
+
+func (c *cursor) valid() bool {
+	return c.blockIdx >= 0 && c.blockIdx < len(c.seg.handles) && c.pos >= 0 && c.pos < len(c.block)
+}
+
+func (c *cursor) entry() *entry {
+	return &c.block[c.pos]
+}
+
+func (c *cursor) load(idx int) error {
+	c.blockIdx = idx
+	if idx < 0 || idx >= len(c.seg.handles) {
+		c.block = nil
+		return nil
+	}
+
+	block, err := c.seg.readBlock(idx)
+	if err != nil {
+		return err
+	}
+
+	c.block = block
+	return nil
+}
+
+func (c *cursor) next() error {
+	c.pos++
+	if c.pos < len(c.block) {
+		return nil
+	}
+
+	if err := c.load(c.blockIdx + 1); err != nil {
+		return err
+	}
+
+	c.pos = 0
+	return nil
+}
+
+func (c *cursor) prev() error {
+	c.pos--
+	if c.pos >= 0 {
+		return nil
+	}
+
+	if err := c.load(c.blockIdx - 1); err != nil {
+		return err
+	}
+
+	c.pos = len(c.block) - 1
+	return nil
+}
+
+// blockFor returns the index of the last block whose first entry is <= (or <,
+// if strict is set) the given target, or -1 if no such block exists.
+func (c *cursor) blockFor(storeKey, key []byte, version uint64, strict bool) int {
+	handles := c.seg.handles
+	i := sort.Search(len(handles), func(i int) bool {
+		cmp := compareEntry(storeKey, key, version, &handles[i].first)
+		if strict {
+			return cmp <= 0
+		}
+		return cmp < 0
+	})
+
+	return i - 1
+}
+
+// seekGE positions the cursor at the first entry >= the given target.
+func (c *cursor) seekGE(storeKey, key []byte, version uint64) error {
+	idx := c.blockFor(storeKey, key, version, false)
+	if idx < 0 {
+		idx = 0
+	}
+	if err := c.load(idx); err != nil {
+		return err
+	}
+
+	c.pos = sort.Search(len(c.block), func(i int) bool {
+		return compareEntry(storeKey, key, version, &c.block[i]) <= 0
+	})
+	if c.pos == len(c.block) && c.blockIdx < len(c.seg.handles) {
+		if err := c.load(c.blockIdx + 1); err != nil {
+			return err
+		}
+		c.pos = 0
+	}
+
+	return nil
+}
+
+// seekLE positions the cursor at the last entry <= the given target.
+func (c *cursor) seekLE(storeKey, key []byte, version uint64) error {
+	if err := c.load(c.blockFor(storeKey, key, version, false)); err != nil {
+		return err
+	}
+
+	c.pos = sort.Search(len(c.block), func(i int) bool {
+		return compareEntry(storeKey, key, version, &c.block[i]) < 0
+	}) - 1
+
+	return nil
+}
+
+// seekLT positions the cursor at the last entry < the given target.
+func (c *cursor) seekLT(storeKey, key []byte, version uint64) error {
+	if err := c.load(c.blockFor(storeKey, key, version, true)); err != nil {
+		return err
+	}
+
+	c.pos = sort.Search(len(c.block), func(i int) bool {
+		return compareEntry(storeKey, key, version, &c.block[i]) <= 0
+	}) - 1
+
+	return nil
+}
+
+// seekLast positions the cursor at the last entry of the given store key.
+func (c *cursor) seekLast(storeKey []byte) error {
+	// Every key of storeKey is strictly less than the target, as the target
+	// store key is the smallest byte slice greater than storeKey.
+	return c.seekLT(append(storeKey[:len(storeKey):len(storeKey)], 0), nil, 0)
+}
+
+func cloneEntry(e *entry) entry {
+	return entry{
+		storeKey: bytes.Clone(e.storeKey),
+		key:      bytes.Clone(e.key),
+		version:  e.version,
+		value:    bytes.Clone(e.value),
+		remove:   e.remove,
+	}
+}
+
+func segmentPath(dir string, start, end uint64) string {
+	return fmt.Sprintf("%s/%020d-%020d%s", dir, start, end, segmentExt)
+}
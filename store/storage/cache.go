@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"cosmossdk.io/store/v2/metrics"
+)
+
+// cacheKey identifies a key of a store, regardless of the version.
+type cacheKey struct {
+	storeKey string
+	key      string
+}
+
+// cacheEntry identifies a key of a store read at a given version.
+type cacheEntry struct {
+	cacheKey
+	version uint64
+}
+
+// readCache is a bounded LRU cache of the values read from the database, keyed
+// on (storeKey, key, version). The value of a key read at a version stays valid
+// until a changeset writes the key at that version or below, or the version is
+// pruned, which the StorageStore reports through the invalidate methods.
+type readCache struct {
+	mtx sync.Mutex
+	lru *simplelru.LRU
+	// versions indexes the cached versions of every key, for invalidation
+	versions map[cacheKey]map[uint64]struct{}
+	// epoch is advanced by every invalidation, so a value read from the
+	// database concurrently with a write is not cached once stale
+	epoch uint64
+
+	telemetry metrics.StoreMetrics
+}
+
+func newReadCache(size int) *readCache {
+	c := &readCache{versions: make(map[cacheKey]map[uint64]struct{})}
+
+	lru, err := simplelru.NewLRU(size, func(k, _ interface{}) {
+		entry := k.(cacheEntry)
+		versions := c.versions[entry.cacheKey]
+		delete(versions, entry.version)
+		if len(versions) == 0 {
+			delete(c.versions, entry.cacheKey)
+		}
+	})
+	if err != nil {
+		// NewLRU only fails for a non-positive size
+		panic(err)
+	}
+	c.lru = lru
+
+	return c
+}
+
+// get returns the cached value of the given key at the given version, and
+// whether it is cached at all. Otherwise, the returned epoch must be passed to
+// add once the value is read from the database.
+func (c *readCache) get(storeKey []byte, version uint64, key []byte) (value []byte, ok bool, epoch uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	v, ok := c.lru.Get(cacheEntry{cacheKey{string(storeKey), string(key)}, version})
+	if c.telemetry != nil {
+		if ok {
+			c.telemetry.IncrCounter(1, "storage", "cache", "hit")
+		} else {
+			c.telemetry.IncrCounter(1, "storage", "cache", "miss")
+		}
+	}
+	if !ok {
+		return nil, false, c.epoch
+	}
+
+	return v.([]byte), true, c.epoch
+}
+
+// add caches the value of the given key at the given version, which is nil if
+// the key does not exist, unless the cache was invalidated since the given
+// epoch.
+func (c *readCache) add(storeKey []byte, version uint64, key, value []byte, epoch uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+
+	ck := cacheKey{string(storeKey), string(key)}
+	versions, ok := c.versions[ck]
+	if !ok {
+		versions = make(map[uint64]struct{})
+		c.versions[ck] = versions
+	}
+	versions[version] = struct{}{}
+	c.lru.Add(cacheEntry{ck, version}, bytes.Clone(value))
+}
+
+// invalidateChanges removes the keys written at the given version from the
+// cache, for all versions from the given one onwards.
+func (c *readCache) invalidateChanges(version uint64, storeKey []byte, keys [][]byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.epoch++
+	for _, key := range keys {
+		ck := cacheKey{string(storeKey), string(key)}
+
+		var stale []uint64
+		for v := range c.versions[ck] {
+			if v >= version {
+				stale = append(stale, v)
+			}
+		}
+		for _, v := range stale {
+			c.lru.Remove(cacheEntry{ck, v})
+		}
+	}
+}
+
+// invalidateVersions removes all versions up to and including the given one
+// from the cache.
+func (c *readCache) invalidateVersions(version uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.epoch++
+	for _, k := range c.lru.Keys() {
+		if entry := k.(cacheEntry); entry.version <= version {
+			c.lru.Remove(entry)
+		}
+	}
+}
+
+// purge removes all entries from the cache.
+func (c *readCache) purge() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.epoch++
+	c.lru.Purge()
+}
@@ -1,10 +1,12 @@
 package storage
 
 import (
+	"context"
 	"io"
 
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/pruning"
 )
 
 // Database is an interface that wraps the storage database methods. A wrapper
@@ -24,3 +26,9 @@ type Database interface {
 
 	io.Closer
 }
+
+// LimitedPruner is an optional interface of a Database which can prune within
+// the budget of a pruning.Limiter, and resume a prune interrupted by its context.
+type LimitedPruner interface {
+	PruneWithLimiter(ctx context.Context, version uint64, limiter *pruning.Limiter) error
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+
+	corestore "cosmossdk.io/core/store"
+)
+
+// DiffType is the type of change of a key between two versions.
+type DiffType uint8
+
+const (
+	// DiffAdded means the key only exists in the newer version.
+	DiffAdded DiffType = iota
+	// DiffModified means the key exists in both versions, with different values.
+	DiffModified
+	// DiffDeleted means the key only exists in the older version.
+	DiffDeleted
+)
+
+func (t DiffType) String() string {
+	switch t {
+	case DiffAdded:
+		return "added"
+	case DiffModified:
+		return "modified"
+	case DiffDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// KeyDiff is the change of a single key between two versions.
+type KeyDiff struct {
+	Type DiffType
+	Key  []byte
+	// OldValue is the value at the older version, or nil if the key was added.
+	OldValue []byte
+	// NewValue is the value at the newer version, or nil if the key was deleted.
+	NewValue []byte
+}
+
+// VersionedIterator creates iterators over a store at a given version, such as
+// a StorageStore.
+type VersionedIterator interface {
+	Iterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error)
+}
+
+// VersionedGetter reads the keys of a store at a given version, such as a
+// StorageStore.
+type VersionedGetter interface {
+	Get(storeKey []byte, version uint64, key []byte) ([]byte, error)
+}
+
+// Diff calls fn with every key of the given store within the range [start, end)
+// which was added, modified or deleted from version from to version to, in
+// ascending key order. A nil start or end leaves the range unbounded on that
+// side. Both versions of the range are iterated side by side, so the changes
+// are streamed without being buffered, and both versions must not be pruned.
+// Diff stops at the first error returned by fn, and returns it.
+//
+// When the keys which may have changed are known, e.g. from the changesets of
+// the versions in between, DiffKeys avoids iterating the unchanged keys.
+func Diff(db VersionedIterator, storeKey []byte, from, to uint64, start, end []byte, fn func(KeyDiff) error) (err error) {
+	oldItr, err := db.Iterator(storeKey, from, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to iterate version %d: %w", from, err)
+	}
+	defer func() {
+		if cErr := oldItr.Close(); err == nil {
+			err = cErr
+		}
+	}()
+
+	newItr, err := db.Iterator(storeKey, to, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to iterate version %d: %w", to, err)
+	}
+	defer func() {
+		if cErr := newItr.Close(); err == nil {
+			err = cErr
+		}
+	}()
+
+	for oldItr.Valid() || newItr.Valid() {
+		var (
+			diff KeyDiff
+			cmp  int
+		)
+		switch {
+		case !newItr.Valid():
+			cmp = -1
+		case !oldItr.Valid():
+			cmp = 1
+		default:
+			cmp = bytes.Compare(oldItr.Key(), newItr.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			diff = KeyDiff{Type: DiffDeleted, Key: bytes.Clone(oldItr.Key()), OldValue: bytes.Clone(oldItr.Value())}
+			oldItr.Next()
+		case cmp > 0:
+			diff = KeyDiff{Type: DiffAdded, Key: bytes.Clone(newItr.Key()), NewValue: bytes.Clone(newItr.Value())}
+			newItr.Next()
+		default:
+			unchanged := bytes.Equal(oldItr.Value(), newItr.Value())
+			if !unchanged {
+				diff = KeyDiff{
+					Type:     DiffModified,
+					Key:      bytes.Clone(newItr.Key()),
+					OldValue: bytes.Clone(oldItr.Value()),
+					NewValue: bytes.Clone(newItr.Value()),
+				}
+			}
+			oldItr.Next()
+			newItr.Next()
+			if unchanged {
+				continue
+			}
+		}
+
+		if err := fn(diff); err != nil {
+			return err
+		}
+	}
+
+	if err := oldItr.Error(); err != nil {
+		return err
+	}
+	return newItr.Error()
+}
+
+// DiffKeys calls fn with each of the given keys of the given store which was
+// added, modified or deleted from version from to version to, in the order of
+// keys, and skips the keys which are unchanged. Only the given keys are read,
+// at both versions, which must not be pruned. DiffKeys stops at the first error
+// returned by fn, and returns it.
+func DiffKeys(db VersionedGetter, storeKey []byte, from, to uint64, keys [][]byte, fn func(KeyDiff) error) error {
+	for _, key := range keys {
+		oldValue, err := db.Get(storeKey, from, key)
+		if err != nil {
+			return fmt.Errorf("failed to get key %X at version %d: %w", key, from, err)
+		}
+		newValue, err := db.Get(storeKey, to, key)
+		if err != nil {
+			return fmt.Errorf("failed to get key %X at version %d: %w", key, to, err)
+		}
+
+		diff := KeyDiff{Key: key, OldValue: oldValue, NewValue: newValue}
+		switch {
+		case bytes.Equal(oldValue, newValue):
+			continue
+		case oldValue == nil:
+			diff.Type = DiffAdded
+		case newValue == nil:
+			diff.Type = DiffDeleted
+		default:
+			diff.Type = DiffModified
+		}
+
+		if err := fn(diff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Diff calls fn with every key of the given store within the range [start, end)
+// which was added, modified or deleted from version from to version to. See Diff.
+func (ss *StorageStore) Diff(storeKey []byte, from, to uint64, start, end []byte, fn func(KeyDiff) error) error {
+	return Diff(ss, storeKey, from, to, start, end, fn)
+}
@@ -41,8 +41,10 @@ func (b *Batch) Size() int {
 	return b.batch.Len()
 }
 
+// Reset prepares the Batch for reuse. Since Write closes the underlying pebble
+// batch, a new one is created.
 func (b *Batch) Reset() error {
-	b.batch.Reset()
+	b.batch = b.storage.NewBatch()
 	return nil
 }
 
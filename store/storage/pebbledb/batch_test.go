@@ -0,0 +1,30 @@
+package pebbledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch_ResetAfterWrite(t *testing.T) {
+	db, err := New(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	storeKey := []byte("store1")
+	b, err := db.NewBatch(1)
+	require.NoError(t, err)
+	require.NoError(t, b.Set(storeKey, []byte("key1"), []byte("value1")))
+	require.NoError(t, b.Write())
+
+	// Write closes the pebble batch, so Reset must make the batch reusable
+	require.NoError(t, b.Reset())
+	require.NoError(t, b.Set(storeKey, []byte("key2"), []byte("value2")))
+	require.NoError(t, b.Write())
+
+	for key, value := range map[string]string{"key1": "value1", "key2": "value2"} {
+		bz, err := db.Get(storeKey, 1, []byte(key))
+		require.NoError(t, err)
+		require.Equal(t, []byte(value), bz)
+	}
+}
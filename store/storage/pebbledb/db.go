@@ -2,6 +2,7 @@ package pebbledb
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/store/v2"
 	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/pruning"
 	"cosmossdk.io/store/v2/storage"
 )
 
@@ -25,10 +27,14 @@ const (
 	StorePrefixTpl   = "s/k:%s/"         // s/k:<storeKey>
 	latestVersionKey = "s/_latest"       // NB: latestVersionKey key must be lexically smaller than StorePrefixTpl
 	pruneHeightKey   = "s/_prune_height" // NB: pruneHeightKey key must be lexically smaller than StorePrefixTpl
+	pruneCursorKey   = "s/_prune_cursor" // NB: pruneCursorKey key must be lexically smaller than StorePrefixTpl
 	tombstoneVal     = "TOMBSTONE"
 )
 
-var _ storage.Database = (*Database)(nil)
+var (
+	_ storage.Database      = (*Database)(nil)
+	_ storage.LimitedPruner = (*Database)(nil)
+)
 
 type Database struct {
 	storage *pebble.DB
@@ -196,6 +202,14 @@ func (db *Database) Get(storeKey []byte, targetVersion uint64, key []byte) ([]by
 //
 // See: https://github.com/cockroachdb/cockroach/blob/33623e3ee420174a4fd3226d1284b03f0e3caaac/pkg/storage/mvcc.go#L3182
 func (db *Database) Prune(version uint64) error {
+	return db.PruneWithLimiter(context.Background(), version, nil)
+}
+
+// PruneWithLimiter implements storage.LimitedPruner. It behaves like Prune, but
+// charges every batch of deleted keys to the given Limiter. Along with every
+// batch, the key to continue from is persisted, so a prune of the same version
+// interrupted by ctx resumes from there.
+func (db *Database) PruneWithLimiter(ctx context.Context, version uint64, limiter *pruning.Limiter) (err error) {
 	itr, err := db.storage.NewIter(&pebble.IterOptions{LowerBound: []byte("s/k:")})
 	if err != nil {
 		return err
@@ -203,17 +217,53 @@ func (db *Database) Prune(version uint64) error {
 	defer itr.Close()
 
 	batch := db.storage.NewBatch()
-	defer batch.Close()
+	defer func() {
+		err = errors.Join(err, batch.Close())
+	}()
 
 	var (
-		batchCounter                              int
+		batchCounter, batchBytes                  int
 		prevKey, prevKeyPrefixed, prevPrefixedVal []byte
 		prevKeyVersion                            uint64
 	)
 
-	for itr.First(); itr.Valid(); {
+	// commit commits the pending deletes along with the key to resume from.
+	commit := func(cursor []byte) error {
+		if err := batch.Set([]byte(pruneCursorKey), encodePruneCursor(version, cursor), nil); err != nil {
+			return err
+		}
+		if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
+			return err
+		}
+		batch.Reset()
+
+		keys, bytes := batchCounter, batchBytes
+		batchCounter, batchBytes = 0, 0
+		return limiter.Wait(ctx, keys, bytes)
+	}
+
+	cursor, err := db.getPruneCursor(version)
+	if err != nil {
+		return err
+	}
+	if cursor != nil {
+		itr.SeekGE(cursor)
+	} else {
+		itr.First()
+	}
+
+	for itr.Valid() {
 		prefixedKey := slices.Clone(itr.Key())
 
+		if err := ctx.Err(); err != nil {
+			// the previous key is yet to be considered for deletion
+			cursor := prevKeyPrefixed
+			if cursor == nil {
+				cursor = prefixedKey
+			}
+			return errors.Join(err, commit(cursor))
+		}
+
 		keyBz, verBz, ok := SplitMVCCKey(prefixedKey)
 		if !ok {
 			return fmt.Errorf("invalid PebbleDB MVCC key: %s", prefixedKey)
@@ -239,13 +289,13 @@ func (db *Database) Prune(version uint64) error {
 			}
 
 			batchCounter++
+			batchBytes += len(prevKeyPrefixed) + len(prevPrefixedVal)
 			if batchCounter >= PruneCommitBatchSize {
-				if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
+				// the current key is yet to be considered for deletion, so that is
+				// where an interrupted prune resumes
+				if err := commit(prefixedKey); err != nil {
 					return err
 				}
-
-				batchCounter = 0
-				batch.Reset()
 			}
 		}
 
@@ -257,16 +307,47 @@ func (db *Database) Prune(version uint64) error {
 		itr.Next()
 	}
 
-	// commit any leftover delete ops in batch
-	if batchCounter > 0 {
-		if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
-			return err
-		}
+	// commit any leftover delete ops in batch, and clear the cursor
+	if err := batch.Delete([]byte(pruneCursorKey), nil); err != nil {
+		return err
+	}
+	if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
+		return err
+	}
+	if err := limiter.Wait(ctx, batchCounter, batchBytes); err != nil {
+		return err
 	}
 
 	return db.setPruneHeight(version)
 }
 
+// getPruneCursor returns the key an interrupted prune of the given version
+// resumes from, if any.
+func (db *Database) getPruneCursor(version uint64) ([]byte, error) {
+	bz, closer, err := db.storage.Get([]byte(pruneCursorKey))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	defer closer.Close()
+
+	if len(bz) < VersionSize || binary.LittleEndian.Uint64(bz) != version {
+		// the cursor of a prune of another version cannot be resumed from
+		return nil, nil
+	}
+
+	return slices.Clone(bz[VersionSize:]), nil
+}
+
+func encodePruneCursor(version uint64, key []byte) []byte {
+	bz := make([]byte, VersionSize, VersionSize+len(key))
+	binary.LittleEndian.PutUint64(bz, version)
+	return append(bz, key...)
+}
+
 func (db *Database) Iterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error) {
 	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
 		return nil, storeerrors.ErrKeyEmpty
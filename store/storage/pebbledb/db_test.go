@@ -2,11 +2,15 @@ package pebbledb
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/v2"
+	storeerrors "cosmossdk.io/store/v2/errors"
 	"cosmossdk.io/store/v2/storage"
 )
 
@@ -23,7 +27,79 @@ func TestStorageTestSuite(t *testing.T) {
 			return storage.NewStorageStore(db, nil, log.NewNopLogger()), err
 		},
 		EmptyBatchSize: 12,
+		RestoreWorkers: 4,
 	}
 
 	suite.Run(t, s)
 }
+
+func TestStorageTestSuite_ReadCache(t *testing.T) {
+	s := &storage.StorageTestSuite{
+		NewDB: func(dir string) (store.VersionedDatabase, error) {
+			db, err := New(dir)
+			if err != nil {
+				return nil, err
+			}
+			db.SetSync(false)
+
+			ss := storage.NewStorageStore(db, nil, log.NewNopLogger())
+			// small enough for the suite to evict entries
+			ss.EnableReadCache(64)
+			return ss, nil
+		},
+		EmptyBatchSize: 12,
+		RestoreWorkers: 4,
+	}
+
+	suite.Run(t, s)
+}
+
+// counterMetrics records the counters incremented through it.
+type counterMetrics map[string]float32
+
+func (m counterMetrics) MeasureSince(time.Time, ...string) {}
+func (m counterMetrics) SetGauge(float32, ...string)       {}
+func (m counterMetrics) IncrCounter(val float32, keys ...string) {
+	m[keys[len(keys)-1]] += val
+}
+
+func TestReadCache_Metrics(t *testing.T) {
+	db, err := New(t.TempDir())
+	require.NoError(t, err)
+	ss := storage.NewStorageStore(db, nil, log.NewNopLogger())
+	defer ss.Close()
+
+	ss.EnableReadCache(16)
+	counters := counterMetrics{}
+	ss.SetMetrics(counters)
+
+	storeKey := []byte("store1")
+	require.NoError(t, ss.ApplyChangeset(1, corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{
+		"store1": {{Key: []byte("key"), Value: []byte("value")}},
+	})))
+
+	for i := 0; i < 3; i++ {
+		bz, err := ss.Get(storeKey, 1, []byte("key"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), bz)
+	}
+	require.Equal(t, counterMetrics{"miss": 1, "hit": 2}, counters)
+
+	// a write of the key invalidates it for the versions from its own onwards
+	require.NoError(t, ss.ApplyChangeset(2, corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{
+		"store1": {{Key: []byte("key"), Value: []byte("value2")}},
+	})))
+	_, err = ss.Get(storeKey, 1, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, counterMetrics{"miss": 1, "hit": 3}, counters)
+	bz, err := ss.Get(storeKey, 2, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), bz)
+	require.Equal(t, counterMetrics{"miss": 2, "hit": 3}, counters)
+
+	// pruning invalidates the pruned versions
+	require.NoError(t, ss.Prune(1))
+	_, err = ss.Get(storeKey, 1, []byte("key"))
+	require.ErrorIs(t, err, storeerrors.ErrVersionPruned{EarliestVersion: 2})
+	require.Equal(t, counterMetrics{"miss": 3, "hit": 3}, counters)
+}
@@ -81,6 +81,12 @@ func newPebbleDBIterator(src *pebble.Iterator, prefix, mvccStart, mvccEnd []byte
 			// that is invalid since curKeyVersionDecoded <= requested iterator version,
 			// so there exists at least one version of currKey SeekLT may move to.
 			itr.valid = itr.source.SeekLT(MVCCEncode(currKey, itr.version+1))
+
+			// the initial key may have been deleted at the requested version, in
+			// which case it is skipped like any other tombstoned key
+			if itr.valid && itr.cursorTombstoned() {
+				itr.Next()
+			}
 		}
 	}
 	return itr
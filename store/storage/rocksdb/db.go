@@ -28,6 +28,7 @@ const (
 
 var (
 	_ storage.Database = (*Database)(nil)
+	_ store.Secondary  = (*Database)(nil)
 
 	defaultWriteOpts = grocksdb.NewDefaultWriteOptions()
 	defaultReadOpts  = grocksdb.NewDefaultReadOptions()
@@ -66,6 +67,38 @@ func New(dataDir string) (*Database, error) {
 	}, nil
 }
 
+// NewSecondary opens a Database as the secondary instance of the database in
+// dataDir, which may be written by a primary instance in another process. The
+// secondary keeps its own logs in secondaryDir, and follows the writes of the
+// primary on every TryCatchUpWithPrimary.
+func NewSecondary(dataDir, secondaryDir string) (*Database, error) {
+	storage, cfHandle, err := OpenRocksDBAsSecondary(dataDir, secondaryDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open RocksDB as secondary: %w", err)
+	}
+
+	return NewWithDB(storage, cfHandle)
+}
+
+// TryCatchUpWithPrimary implements store.Secondary. It also reloads the earliest
+// version, which the primary advances when pruning.
+func (db *Database) TryCatchUpWithPrimary() error {
+	if err := db.storage.TryCatchUpWithPrimary(); err != nil {
+		return err
+	}
+
+	slice, err := db.storage.GetFullHistoryTsLow(db.cfHandle)
+	if err != nil {
+		return fmt.Errorf("failed to get full_history_ts_low: %w", err)
+	}
+
+	if tsLowBz := copyAndFreeSlice(slice); len(tsLowBz) > 0 {
+		db.tsLow = binary.LittleEndian.Uint64(tsLowBz)
+	}
+
+	return nil
+}
+
 func NewWithDB(storage *grocksdb.DB, cfHandle *grocksdb.ColumnFamilyHandle) (*Database, error) {
 	slice, err := storage.GetFullHistoryTsLow(cfHandle)
 	if err != nil {
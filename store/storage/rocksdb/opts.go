@@ -93,6 +93,35 @@ func OpenRocksDB(dataDir string) (*grocksdb.DB, *grocksdb.ColumnFamilyHandle, er
 	return db, cfHandles[1], nil
 }
 
+// OpenRocksDBAsSecondary opens a RocksDB database connection as the secondary
+// instance of the database in dataDir, which may be written by a primary
+// instance in another process. The secondary keeps its own logs in secondaryDir.
+// It returns the same column family handle as `OpenRocksDB`.
+func OpenRocksDBAsSecondary(dataDir, secondaryDir string) (*grocksdb.DB, *grocksdb.ColumnFamilyHandle, error) {
+	opts := grocksdb.NewDefaultOptions()
+	// a secondary instance requires all files to be kept open
+	opts.SetMaxOpenFiles(-1)
+
+	db, cfHandles, err := grocksdb.OpenDbAsSecondaryColumnFamilies(
+		opts,
+		dataDir,
+		secondaryDir,
+		[]string{
+			CFNameDefault,
+			CFNameStateStorage,
+		},
+		[]*grocksdb.Options{
+			opts,
+			NewRocksDBOpts(false),
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, cfHandles[1], nil
+}
+
 // OpenRocksDBAndTrimHistory opens a RocksDB handle similar to `OpenRocksDB`,
 // but it also trims the versions newer than target one, such that it can be used
 // for rollback.
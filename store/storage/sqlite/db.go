@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/store/v2"
 	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/pruning"
 	"cosmossdk.io/store/v2/storage"
 )
 
@@ -22,6 +24,11 @@ const (
 	reservedStoreKey = "_RESERVED_"
 	keyLatestHeight  = "latest_height"
 	keyPruneHeight   = "prune_height"
+	keyPruneCursor   = "prune_cursor"
+
+	// pruneWindowSize defines the number of rows, by id, considered for deletion
+	// in a single transaction by PruneWithLimiter.
+	pruneWindowSize = 1000
 
 	reservedUpsertStmt = `
 	INSERT INTO state_storage(store_key, key, value, version)
@@ -43,7 +50,10 @@ const (
 	`
 )
 
-var _ storage.Database = (*Database)(nil)
+var (
+	_ storage.Database      = (*Database)(nil)
+	_ storage.LimitedPruner = (*Database)(nil)
+)
 
 type Database struct {
 	storage *sql.DB
@@ -216,6 +226,139 @@ func (db *Database) Prune(version uint64) error {
 	return nil
 }
 
+// PruneWithLimiter implements storage.LimitedPruner. It behaves like Prune, but
+// deletes the rows in windows of ids, each in its own transaction, charging the
+// deleted rows to the given Limiter. Along with every window, the id to continue
+// from is persisted, so a prune of the same version interrupted by ctx resumes
+// from there.
+func (db *Database) PruneWithLimiter(ctx context.Context, version uint64, limiter *pruning.Limiter) error {
+	pruneStmt := `DELETE FROM state_storage
+	WHERE id > ? AND id <= ? AND version < (
+		SELECT max(version) FROM state_storage t2 WHERE
+		t2.store_key = state_storage.store_key AND
+		t2.key = state_storage.key AND
+		t2.version <= ?
+	) AND store_key != ?
+	RETURNING length(key) + length(value);
+	`
+
+	cursor, err := db.getPruneCursor(version)
+	if err != nil {
+		return err
+	}
+
+	// rows written from now on are of greater versions and are not pruned
+	var maxID uint64
+	if err := db.storage.QueryRow("SELECT COALESCE(max(id), 0) FROM state_storage").Scan(&maxID); err != nil {
+		return fmt.Errorf("failed to query row: %w", err)
+	}
+
+	for from := cursor; from < maxID; from += pruneWindowSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		to := min(from+pruneWindowSize, maxID)
+		keys, size, err := db.pruneWindow(pruneStmt, version, from, to)
+		if err != nil {
+			return err
+		}
+		if err := limiter.Wait(ctx, keys, size); err != nil {
+			return err
+		}
+	}
+
+	tx, err := db.storage.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to create SQL transaction: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM state_storage WHERE store_key = ? AND key = ?", reservedStoreKey, keyPruneCursor); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to exec SQL statement: %w", err)
+	}
+
+	// set the prune height so we can return <nil> for queries below this height
+	if _, err := tx.Exec(reservedUpsertStmt, reservedStoreKey, keyPruneHeight, version, 0, version); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to exec SQL statement: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to write SQL transaction: %w", err)
+	}
+
+	db.earliestVersion = version + 1
+
+	return nil
+}
+
+// pruneWindow prunes the rows with ids in (from, to] in a single transaction,
+// along with persisting to as the id to continue from. It returns the number of
+// deleted rows and their size.
+func (db *Database) pruneWindow(pruneStmt string, version, from, to uint64) (keys, size int, err error) {
+	tx, err := db.storage.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create SQL transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.Query(pruneStmt, from, to, version, reservedStoreKey)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to exec SQL statement: %w", err)
+	}
+	for rows.Next() {
+		var rowSize int
+		if err := rows.Scan(&rowSize); err != nil {
+			_ = rows.Close()
+			return 0, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		keys++
+		size += rowSize
+	}
+	if err := rows.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("received unexpected error: %w", err)
+	}
+
+	// the cursor is kept per version, as it only applies to a prune of the same version
+	if _, err := tx.Exec(reservedUpsertStmt, reservedStoreKey, keyPruneCursor, to, version, to); err != nil {
+		return 0, 0, fmt.Errorf("failed to exec SQL statement: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to write SQL transaction: %w", err)
+	}
+
+	return keys, size, nil
+}
+
+// getPruneCursor returns the id an interrupted prune of the given version
+// continues from, or 0 if there is none.
+func (db *Database) getPruneCursor(version uint64) (uint64, error) {
+	var cursor uint64
+	err := db.storage.QueryRow(
+		"SELECT value FROM state_storage WHERE store_key = ? AND key = ? AND version = ?",
+		reservedStoreKey, keyPruneCursor, version,
+	).Scan(&cursor)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to query row: %w", err)
+	}
+
+	return cursor, nil
+}
+
 func (db *Database) Iterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error) {
 	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
 		return nil, storeerrors.ErrKeyEmpty
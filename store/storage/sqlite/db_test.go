@@ -26,6 +26,24 @@ func TestStorageTestSuite(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func TestStorageTestSuite_ReadCache(t *testing.T) {
+	s := &storage.StorageTestSuite{
+		NewDB: func(dir string) (store.VersionedDatabase, error) {
+			db, err := New(dir)
+			if err != nil {
+				return nil, err
+			}
+
+			ss := storage.NewStorageStore(db, nil, log.NewNopLogger())
+			// small enough for the suite to evict entries
+			ss.EnableReadCache(64)
+			return ss, nil
+		},
+		EmptyBatchSize: 0,
+	}
+	suite.Run(t, s)
+}
+
 func TestDatabase_ReverseIterator(t *testing.T) {
 	db, err := New(t.TempDir())
 	require.NoError(t, err)
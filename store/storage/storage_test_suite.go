@@ -1,15 +1,18 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/pruning"
 )
 
 const (
@@ -25,6 +28,8 @@ type StorageTestSuite struct {
 	NewDB          func(dir string) (store.VersionedDatabase, error)
 	EmptyBatchSize int
 	SkipTests      []string
+	// RestoreWorkers is the number of restore workers used by TestDatabase_Restore.
+	RestoreWorkers int
 }
 
 func (s *StorageTestSuite) TestDatabase_Close() {
@@ -75,6 +80,58 @@ func (s *StorageTestSuite) TestDatabase_VersionedKeys() {
 	}
 }
 
+func (s *StorageTestSuite) TestDatabase_GetAboveLatestVersion() {
+	db, err := s.NewDB(s.T().TempDir())
+	s.Require().NoError(err)
+	defer db.Close()
+
+	s.Require().NoError(db.ApplyChangeset(1, corestore.NewChangesetWithPairs(
+		map[string]corestore.KVPairs{
+			storeKey1: {
+				{Key: []byte("key1"), Value: []byte("value001")},
+				{Key: []byte("key2"), Value: []byte("value001")},
+			},
+		},
+	)))
+
+	// reads above the latest version reflect the latest version
+	for _, key := range []string{"key1", "key2", "key3"} {
+		for i := 0; i < 2; i++ {
+			_, err := db.Get(storeKey1Bytes, 5, []byte(key))
+			s.Require().NoError(err)
+		}
+	}
+
+	// until a later version changes the keys
+	s.Require().NoError(db.ApplyChangeset(2, corestore.NewChangesetWithPairs(
+		map[string]corestore.KVPairs{
+			storeKey1: {
+				{Key: []byte("key1"), Value: []byte("value002")},
+				{Key: []byte("key2"), Remove: true},
+				{Key: []byte("key3"), Value: []byte("value002")},
+			},
+		},
+	)))
+
+	bz, err := db.Get(storeKey1Bytes, 5, []byte("key1"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("value002"), bz)
+	ok, err := db.Has(storeKey1Bytes, 5, []byte("key2"))
+	s.Require().NoError(err)
+	s.Require().False(ok)
+	bz, err = db.Get(storeKey1Bytes, 5, []byte("key3"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("value002"), bz)
+
+	// while the earlier version is unchanged
+	bz, err = db.Get(storeKey1Bytes, 1, []byte("key1"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("value001"), bz)
+	ok, err = db.Has(storeKey1Bytes, 1, []byte("key2"))
+	s.Require().NoError(err)
+	s.Require().True(ok)
+}
+
 func (s *StorageTestSuite) TestDatabase_GetVersionedKey() {
 	db, err := s.NewDB(s.T().TempDir())
 	s.Require().NoError(err)
@@ -636,6 +693,113 @@ func (s *StorageTestSuite) TestDatabase_Prune_KeepRecent() {
 	s.Require().Equal([]byte("val200"), bz)
 }
 
+func (s *StorageTestSuite) TestDatabase_Restore() {
+	db, err := s.NewDB(s.T().TempDir())
+	s.Require().NoError(err)
+	defer db.Close()
+
+	ss, ok := db.(*StorageStore)
+	if !ok {
+		s.T().Skip("database is not a StorageStore")
+	}
+	ss.SetRestoreWorkers(s.RestoreWorkers)
+
+	storeKeys := []string{"store1", "store2", "store3"}
+	chStorage := make(chan *corestore.StateChanges)
+	go func() {
+		defer close(chStorage)
+		// enough pairs to span multiple batches
+		for i := 0; i < 10_000; i++ {
+			for _, storeKey := range storeKeys {
+				chStorage <- &corestore.StateChanges{
+					Actor: []byte(storeKey),
+					StateChanges: []corestore.KVPair{
+						{Key: []byte(fmt.Sprintf("key%05d", i)), Value: []byte(fmt.Sprintf("%s-val%05d", storeKey, i))},
+					},
+				}
+			}
+		}
+	}()
+	s.Require().NoError(ss.Restore(10, chStorage))
+
+	lv, err := db.GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(10), lv)
+
+	for _, storeKey := range storeKeys {
+		for _, i := range []int{0, 5_000, 9_999} {
+			bz, err := db.Get([]byte(storeKey), 10, []byte(fmt.Sprintf("key%05d", i)))
+			s.Require().NoError(err)
+			s.Require().Equal([]byte(fmt.Sprintf("%s-val%05d", storeKey, i)), bz)
+		}
+	}
+
+	// a snapshot must not be restored onto an existing version
+	chStorage = make(chan *corestore.StateChanges)
+	close(chStorage)
+	s.Require().Error(ss.Restore(10, chStorage))
+}
+
+func (s *StorageTestSuite) TestDatabase_PruneWithLimiter() {
+	if slices.Contains(s.SkipTests, s.T().Name()) {
+		s.T().SkipNow()
+	}
+
+	db, err := s.NewDB(s.T().TempDir())
+	s.Require().NoError(err)
+	defer db.Close()
+
+	ss, ok := db.(*StorageStore)
+	if !ok {
+		s.T().Skip("database is not a StorageStore")
+	}
+
+	// write 10 versions of 300 keys
+	for v := uint64(1); v <= 10; v++ {
+		keys := make([][]byte, 300)
+		vals := make([][]byte, 300)
+		for i := range keys {
+			keys[i] = []byte(fmt.Sprintf("key%03d", i))
+			vals[i] = []byte(fmt.Sprintf("val%03d-%03d", i, v))
+		}
+		DBApplyChangeset(s.T(), db, v, storeKey1, keys, vals)
+	}
+
+	// interrupt pruning by the budget running out before it completes
+	limiter := pruning.NewLimiter(100, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	s.Require().ErrorIs(ss.PruneWithLimiter(ctx, 5, limiter), context.DeadlineExceeded)
+
+	// versions above the prune height are unaffected by the interruption
+	for v := uint64(6); v <= 10; v++ {
+		bz, err := db.Get(storeKey1Bytes, v, []byte("key150"))
+		s.Require().NoError(err)
+		s.Require().Equal([]byte(fmt.Sprintf("val150-%03d", v)), bz)
+	}
+
+	// resume pruning to completion
+	s.Require().NoError(ss.PruneWithLimiter(context.Background(), 5, nil))
+
+	for v := uint64(1); v <= 5; v++ {
+		_, err := db.Get(storeKey1Bytes, v, []byte("key000"))
+		s.Require().Error(err)
+	}
+	for v := uint64(6); v <= 10; v++ {
+		for _, key := range []string{"key000", "key150", "key299"} {
+			bz, err := db.Get(storeKey1Bytes, v, []byte(key))
+			s.Require().NoError(err)
+			s.Require().Equal([]byte(fmt.Sprintf("val%s-%03d", key[3:], v)), bz)
+		}
+	}
+
+	// pruning a version again is idempotent
+	s.Require().NoError(ss.PruneWithLimiter(context.Background(), 5, nil))
+	bz, err := db.Get(storeKey1Bytes, 6, []byte("key299"))
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("val299-006"), bz)
+}
+
 func DBApplyChangeset(
 	t *testing.T,
 	db store.VersionedDatabase,
@@ -660,3 +824,79 @@ func DBApplyChangeset(
 
 	require.NoError(t, db.ApplyChangeset(version, cs))
 }
+
+func (s *StorageTestSuite) TestDatabase_Diff() {
+	db, err := s.NewDB(s.T().TempDir())
+	s.Require().NoError(err)
+	defer db.Close()
+
+	cs := corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{storeKey1: {
+		{Key: []byte("a"), Value: []byte("a1")},
+		{Key: []byte("b"), Value: []byte("b1")},
+		{Key: []byte("c"), Value: []byte("c1")},
+	}})
+	s.Require().NoError(db.ApplyChangeset(1, cs))
+	cs = corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{storeKey1: {
+		{Key: []byte("b"), Value: []byte("b2")},
+		{Key: []byte("c"), Remove: true},
+		{Key: []byte("d"), Value: []byte("d2")},
+	}})
+	s.Require().NoError(db.ApplyChangeset(2, cs))
+	cs = corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{"store2": {
+		{Key: []byte("a"), Value: []byte("a3")},
+	}})
+	s.Require().NoError(db.ApplyChangeset(3, cs))
+
+	diff := func(from, to uint64) []KeyDiff {
+		var diffs []KeyDiff
+		s.Require().NoError(Diff(db, storeKey1Bytes, from, to, nil, nil, func(d KeyDiff) error {
+			diffs = append(diffs, d)
+			return nil
+		}))
+		return diffs
+	}
+
+	s.Require().Equal([]KeyDiff{
+		{Type: DiffModified, Key: []byte("b"), OldValue: []byte("b1"), NewValue: []byte("b2")},
+		{Type: DiffDeleted, Key: []byte("c"), OldValue: []byte("c1")},
+		{Type: DiffAdded, Key: []byte("d"), NewValue: []byte("d2")},
+	}, diff(1, 2))
+
+	// the diff can be taken backwards
+	s.Require().Equal([]KeyDiff{
+		{Type: DiffModified, Key: []byte("b"), OldValue: []byte("b2"), NewValue: []byte("b1")},
+		{Type: DiffAdded, Key: []byte("c"), NewValue: []byte("c1")},
+		{Type: DiffDeleted, Key: []byte("d"), OldValue: []byte("d2")},
+	}, diff(2, 1))
+
+	// the changes of other stores are not included
+	s.Require().Empty(diff(2, 3))
+
+	// the diff can be limited to a range of keys
+	var diffs []KeyDiff
+	s.Require().NoError(Diff(db, storeKey1Bytes, 1, 2, []byte("c"), []byte("d"), func(d KeyDiff) error {
+		diffs = append(diffs, d)
+		return nil
+	}))
+	s.Require().Equal([]KeyDiff{{Type: DiffDeleted, Key: []byte("c"), OldValue: []byte("c1")}}, diffs)
+
+	// only the given keys are diffed by DiffKeys, and unchanged keys are skipped
+	diffs = nil
+	s.Require().NoError(DiffKeys(db, storeKey1Bytes, 1, 2, [][]byte{[]byte("a"), []byte("c"), []byte("d")}, func(d KeyDiff) error {
+		diffs = append(diffs, d)
+		return nil
+	}))
+	s.Require().Equal([]KeyDiff{
+		{Type: DiffDeleted, Key: []byte("c"), OldValue: []byte("c1")},
+		{Type: DiffAdded, Key: []byte("d"), NewValue: []byte("d2")},
+	}, diffs)
+
+	// an error stops the diff
+	errStop := fmt.Errorf("stop")
+	var count int
+	s.Require().ErrorIs(Diff(db, storeKey1Bytes, 1, 2, nil, nil, func(KeyDiff) error {
+		count++
+		return errStop
+	}), errStop)
+	s.Require().Equal(1, count)
+}
@@ -1,22 +1,37 @@
 package storage
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+
+	"golang.org/x/sync/errgroup"
 
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/v2"
+	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/metrics"
+	"cosmossdk.io/store/v2/pruning"
 	"cosmossdk.io/store/v2/snapshots"
+	"cosmossdk.io/store/v2/storage/archive"
 )
 
 const (
 	// TODO: it is a random number, need to be tuned
 	defaultBatchBufferSize = 100000
+
+	// defaultRestoreShardBufferSize is the number of changes buffered for each
+	// restore worker.
+	defaultRestoreShardBufferSize = 1024
 )
 
 var (
 	_ store.VersionedDatabase      = (*StorageStore)(nil)
 	_ snapshots.StorageSnapshotter = (*StorageStore)(nil)
+	_ pruning.Pruner               = (*StorageStore)(nil)
 )
 
 // StorageStore is a wrapper around the store.VersionedDatabase interface.
@@ -26,6 +41,18 @@ type StorageStore struct {
 
 	// pruneOptions defines the pruning configuration.
 	pruneOptions *store.PruneOptions
+
+	// archive defines the optional cold-tier archive. When set, versions that
+	// fall behind the pruning horizon are moved into archive segment files
+	// instead of being discarded.
+	archive *archive.Archive
+
+	// restoreWorkers defines the number of workers writing restored snapshot
+	// state concurrently.
+	restoreWorkers int
+
+	// cache defines the optional read cache of the values read from the database.
+	cache *readCache
 }
 
 // NewStorageStore returns a reference to a new StorageStore.
@@ -41,18 +68,127 @@ func NewStorageStore(db Database, pruneOpts *store.PruneOptions, logger log.Logg
 	}
 }
 
+// EnableArchive sets the cold-tier archive on the StorageStore. Once enabled,
+// Prune moves the pruned versions into the archive and reads for archived
+// versions fall through to it. It must be called before any changeset is
+// applied. An error is returned if the database already holds versions which
+// were never recorded by the archive, since those could not be served
+// correctly once pruned.
+func (ss *StorageStore) EnableArchive(a *archive.Archive) error {
+	latestVersion, err := ss.db.GetLatestVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get latest version: %w", err)
+	}
+	if latestVersion > 0 && a.IsEmpty() {
+		return fmt.Errorf("cannot enable archive on a non-empty database at version %d", latestVersion)
+	}
+
+	ss.archive = a
+	return nil
+}
+
+// SetRestoreWorkers sets the number of workers writing the state restored from
+// a snapshot concurrently, each with its own batch. It defaults to a single
+// worker, and must only be raised for databases which support concurrent
+// batches, e.g. PebbleDB and RocksDB but not SQLite.
+func (ss *StorageStore) SetRestoreWorkers(workers int) {
+	ss.restoreWorkers = workers
+}
+
+// EnableReadCache sets a bounded LRU cache of up to size values read from the
+// database, keyed on (storeKey, key, version), on the StorageStore. Applying a
+// changeset invalidates the cached values of the keys it writes, and pruning the
+// cached values of the pruned versions. Reads served by the archive are not
+// cached.
+func (ss *StorageStore) EnableReadCache(size int) {
+	ss.cache = newReadCache(size)
+}
+
+// SetMetrics sets the telemetry handler on the StorageStore, which reports the
+// hits and misses of the read cache, if enabled.
+func (ss *StorageStore) SetMetrics(m metrics.StoreMetrics) {
+	if ss.cache != nil {
+		ss.cache.mtx.Lock()
+		ss.cache.telemetry = m
+		ss.cache.mtx.Unlock()
+	}
+}
+
+// TryCatchUpWithPrimary implements store.Secondary, for a StorageStore whose
+// database is a secondary instance. The read cache, if enabled, is cleared, as
+// the primary may have written or pruned any version since.
+func (ss *StorageStore) TryCatchUpWithPrimary() error {
+	secondary, ok := ss.db.(store.Secondary)
+	if !ok {
+		return fmt.Errorf("SS database: %w", storeerrors.ErrSecondaryUnsupported)
+	}
+
+	if err := secondary.TryCatchUpWithPrimary(); err != nil {
+		return err
+	}
+
+	if ss.cache != nil {
+		ss.cache.purge()
+	}
+
+	return nil
+}
+
+// isArchived returns true if the given version must be served by the archive.
+func (ss *StorageStore) isArchived(version uint64) bool {
+	if ss.archive == nil {
+		return false
+	}
+
+	archivedVersion := ss.archive.ArchivedVersion()
+	return archivedVersion > 0 && version <= archivedVersion
+}
+
 // Has returns true if the key exists in the store.
 func (ss *StorageStore) Has(storeKey []byte, version uint64, key []byte) (bool, error) {
+	if ss.isArchived(version) {
+		return ss.archive.Has(storeKey, version, key)
+	}
+
+	if ss.cache != nil {
+		if value, ok, _ := ss.cache.get(storeKey, version, key); ok {
+			return value != nil, nil
+		}
+	}
+
 	return ss.db.Has(storeKey, version, key)
 }
 
 // Get returns the value associated with the given key.
 func (ss *StorageStore) Get(storeKey []byte, version uint64, key []byte) ([]byte, error) {
-	return ss.db.Get(storeKey, version, key)
+	if ss.isArchived(version) {
+		return ss.archive.Get(storeKey, version, key)
+	}
+	if ss.cache == nil {
+		return ss.db.Get(storeKey, version, key)
+	}
+
+	value, ok, epoch := ss.cache.get(storeKey, version, key)
+	if ok {
+		return value, nil
+	}
+	value, err := ss.db.Get(storeKey, version, key)
+	if err != nil {
+		return nil, err
+	}
+	ss.cache.add(storeKey, version, key, value, epoch)
+
+	return value, nil
 }
 
 // ApplyChangeset applies the given changeset to the storage.
 func (ss *StorageStore) ApplyChangeset(version uint64, cs *corestore.Changeset) error {
+	if ss.archive != nil {
+		if err := ss.archive.Append(version, cs); err != nil {
+			return fmt.Errorf("failed to append changeset to archive: %w", err)
+		}
+	}
+
 	b, err := ss.db.NewBatch(version)
 	if err != nil {
 		return err
@@ -75,6 +211,15 @@ func (ss *StorageStore) ApplyChangeset(version uint64, cs *corestore.Changeset)
 	if err := b.Write(); err != nil {
 		return err
 	}
+	if ss.cache != nil {
+		for _, pairs := range cs.Changes {
+			keys := make([][]byte, len(pairs.StateChanges))
+			for i, kvPair := range pairs.StateChanges {
+				keys[i] = kvPair.Key
+			}
+			ss.cache.invalidateChanges(version, pairs.Actor, keys)
+		}
+	}
 
 	if prune, pruneVersion := ss.pruneOptions.ShouldPrune(version); prune {
 		if err := ss.Prune(pruneVersion); err != nil {
@@ -97,41 +242,200 @@ func (ss *StorageStore) SetLatestVersion(version uint64) error {
 
 // Iterator returns an iterator over the specified domain and prefix.
 func (ss *StorageStore) Iterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error) {
+	if ss.isArchived(version) {
+		return ss.archive.Iterator(storeKey, version, start, end)
+	}
+
 	return ss.db.Iterator(storeKey, version, start, end)
 }
 
 // ReverseIterator returns an iterator over the specified domain and prefix in reverse.
 func (ss *StorageStore) ReverseIterator(storeKey []byte, version uint64, start, end []byte) (corestore.Iterator, error) {
+	if ss.isArchived(version) {
+		return ss.archive.ReverseIterator(storeKey, version, start, end)
+	}
+
 	return ss.db.ReverseIterator(storeKey, version, start, end)
 }
 
-// Prune prunes the store up to the given version.
+// Prune prunes the store up to the given version. If an archive is enabled,
+// the pruned versions are moved into the archive before they are removed from
+// the database.
 func (ss *StorageStore) Prune(version uint64) error {
+	if ss.cache != nil {
+		defer ss.cache.invalidateVersions(version)
+	}
+	if ss.archive != nil {
+		if err := ss.archive.Flush(version); err != nil {
+			return fmt.Errorf("failed to archive versions up to %d: %w", version, err)
+		}
+	}
+
 	return ss.db.Prune(version)
 }
 
-// Restore restores the store from the given channel.
+// PruneWithLimiter implements pruning.Pruner. It behaves like Prune, but the
+// database is pruned within the budget of the given Limiter if it supports it,
+// see LimitedPruner. Otherwise, it is pruned by a single call to Prune.
+func (ss *StorageStore) PruneWithLimiter(ctx context.Context, version uint64, limiter *pruning.Limiter) error {
+	if ss.cache != nil {
+		defer ss.cache.invalidateVersions(version)
+	}
+	if ss.archive != nil {
+		if err := ss.archive.Flush(version); err != nil {
+			return fmt.Errorf("failed to archive versions up to %d: %w", version, err)
+		}
+	}
+
+	if lp, ok := ss.db.(LimitedPruner); ok {
+		return lp.PruneWithLimiter(ctx, version, limiter)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ss.db.Prune(version)
+}
+
+// RestoreCheckpointer records the keys restored by
+// StorageStore.RestoreWithCheckpoints, so an interrupted restore can be resumed.
+// It relies on the keys of every store being restored in ascending order.
+type RestoreCheckpointer interface {
+	// RestoredKey returns the last key of the given store which was restored, or
+	// nil if none was.
+	RestoredKey(storeKey []byte) ([]byte, error)
+	// SetRestoredKey records that the keys of the given store were restored up
+	// to and including the given key.
+	SetRestoredKey(storeKey, key []byte) error
+}
+
+// Restore restores the store from the given channel. With more than one restore
+// worker, the changes are sharded by store key across the workers, which write
+// their own batches concurrently.
 func (ss *StorageStore) Restore(version uint64, chStorage <-chan *corestore.StateChanges) error {
+	return ss.RestoreWithCheckpoints(version, chStorage, nil)
+}
+
+// RestoreWithCheckpoints is like Restore, but records the last key of every
+// store written by each batch with the given RestoreCheckpointer. The keys
+// restored by an earlier run at the same version are skipped.
+func (ss *StorageStore) RestoreWithCheckpoints(version uint64, chStorage <-chan *corestore.StateChanges, ckpt RestoreCheckpointer) error {
 	latestVersion, err := ss.db.GetLatestVersion()
 	if err != nil {
 		return fmt.Errorf("failed to get latest version: %w", err)
 	}
-	if version <= latestVersion {
+	if ss.cache != nil {
+		defer ss.cache.purge()
+	}
+
+	// an interrupted restore may have written the version already
+	if version < latestVersion || (version == latestVersion && ckpt == nil) {
 		return fmt.Errorf("the snapshot version %d is not greater than latest version %d", version, latestVersion)
 	}
 
+	if ss.restoreWorkers <= 1 {
+		return ss.restoreBatches(version, chStorage, ckpt)
+	}
+
+	shards := make([]chan *corestore.StateChanges, ss.restoreWorkers)
+	eg := new(errgroup.Group)
+	for i := range shards {
+		shard := make(chan *corestore.StateChanges, defaultRestoreShardBufferSize)
+		shards[i] = shard
+		eg.Go(func() error {
+			err := ss.restoreBatches(version, shard, ckpt)
+			// keep draining the shard, so the restore is not blocked by a failed worker
+			for range shard {
+			}
+			return err
+		})
+	}
+
+	for changes := range chStorage {
+		h := fnv.New32a()
+		_, _ = h.Write(changes.Actor)
+		shards[h.Sum32()%uint32(len(shards))] <- changes
+	}
+	for _, shard := range shards {
+		close(shard)
+	}
+
+	return eg.Wait()
+}
+
+// restoreBatches writes the changes received from the given channel to the
+// database at the given version, in batches of bounded size.
+func (ss *StorageStore) restoreBatches(version uint64, chStorage <-chan *corestore.StateChanges, ckpt RestoreCheckpointer) error {
 	b, err := ss.db.NewBatch(version)
 	if err != nil {
 		return err
 	}
 
+	// archived reflects the changes restored to the database which still need
+	// to be recorded by the archive, if enabled
+	archived := corestore.NewChangeset()
+	// restored and pending reflect the last key of every store which is
+	// checkpointed, and which is written by the current batch, respectively
+	restored := make(map[string][]byte)
+	pending := make(map[string][]byte)
+
+	flush := func() error {
+		if ss.archive != nil && archived.Size() > 0 {
+			if err := ss.archive.Append(version, archived); err != nil {
+				return fmt.Errorf("failed to append restored changes to archive: %w", err)
+			}
+			archived = corestore.NewChangeset()
+		}
+		if err := b.Write(); err != nil {
+			return err
+		}
+
+		for storeKey, key := range pending {
+			if err := ckpt.SetRestoredKey([]byte(storeKey), key); err != nil {
+				return fmt.Errorf("failed to set restore checkpoint: %w", err)
+			}
+			restored[storeKey] = key
+		}
+		clear(pending)
+
+		return nil
+	}
+
 	for kvPair := range chStorage {
+		if ckpt != nil {
+			last, ok := restored[string(kvPair.Actor)]
+			if !ok {
+				if last, err = ckpt.RestoredKey(kvPair.Actor); err != nil {
+					return fmt.Errorf("failed to get restore checkpoint: %w", err)
+				}
+				restored[string(kvPair.Actor)] = last
+			}
+
+			changes := unrestoredChanges(kvPair.StateChanges, last)
+			if len(changes) == 0 {
+				continue
+			}
+			kvPair = &corestore.StateChanges{Actor: kvPair.Actor, StateChanges: changes}
+		}
+
+		if ss.archive != nil {
+			archived.Changes = append(archived.Changes, *kvPair)
+		}
+
 		for _, kv := range kvPair.StateChanges {
-			if err := b.Set(kvPair.Actor, kv.Key, kv.Value); err != nil {
+			if kv.Remove {
+				// only delta snapshots remove keys restored by their base snapshot
+				if err := b.Delete(kvPair.Actor, kv.Key); err != nil {
+					return err
+				}
+			} else if err := b.Set(kvPair.Actor, kv.Key, kv.Value); err != nil {
 				return err
 			}
+			if ckpt != nil {
+				pending[string(kvPair.Actor)] = kv.Key
+			}
 			if b.Size() > defaultBatchBufferSize {
-				if err := b.Write(); err != nil {
+				if err := flush(); err != nil {
 					return err
 				}
 				if err := b.Reset(); err != nil {
@@ -142,15 +446,36 @@ func (ss *StorageStore) Restore(version uint64, chStorage <-chan *corestore.Stat
 	}
 
 	if b.Size() > 0 {
-		if err := b.Write(); err != nil {
-			return err
-		}
+		return flush()
 	}
 
 	return nil
 }
 
+// unrestoredChanges returns the changes whose keys are greater than the last
+// restored key, which are the ones an interrupted restore did not write yet.
+func unrestoredChanges(changes []corestore.KVPair, last []byte) []corestore.KVPair {
+	if last == nil {
+		return changes
+	}
+
+	var res []corestore.KVPair
+	for _, kv := range changes {
+		if bytes.Compare(kv.Key, last) > 0 {
+			res = append(res, kv)
+		}
+	}
+
+	return res
+}
+
 // Close closes the store.
 func (ss *StorageStore) Close() error {
+	if ss.archive != nil {
+		if err := ss.archive.Close(); err != nil {
+			return errors.Join(err, ss.db.Close())
+		}
+	}
+
 	return ss.db.Close()
 }
@@ -31,6 +31,15 @@ type RootStore interface {
 	// and key tuple. Queries should be routed to the underlying SS engine.
 	Query(storeKey []byte, version uint64, key []byte, prove bool) (QueryResult, error)
 
+	// QueryRange performs a query on the RootStore for the key/value pairs of a
+	// given store key and version within the domain [start, end), up to limit
+	// pairs, which must be within (0, MaxRangeQueryLimit]. If the domain holds
+	// more pairs, the result is truncated to the domain [start, NextKey), and the
+	// query continues with NextKey as start. If prove is true, the result
+	// contains a RangeProof of the complete set of pairs of its domain, which are
+	// then served from the SC backend.
+	QueryRange(storeKey []byte, version uint64, start, end []byte, limit int, prove bool) (RangeQueryResult, error)
+
 	// LoadVersion loads the RootStore to the given version.
 	LoadVersion(version uint64) error
 
@@ -105,3 +114,20 @@ type QueryResult struct {
 	Version  uint64
 	ProofOps []proof.CommitmentOp
 }
+
+// MaxRangeQueryLimit is the maximum number of pairs returned by a range query.
+const MaxRangeQueryLimit = 1000
+
+// RangeQueryResult defines the response type to performing a range query on a
+// RootStore. Its domain is [Start, End), where End is NextKey if the query was
+// truncated by its limit.
+type RangeQueryResult struct {
+	Start   []byte
+	End     []byte
+	Pairs   []proof.KVPair
+	Version uint64
+	Proof   *proof.RangeProof
+	// NextKey is the start of the remainder of the queried domain, or nil if
+	// the result holds all of its pairs.
+	NextKey []byte
+}
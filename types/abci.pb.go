@@ -710,6 +710,111 @@ func (m *SearchBlocksResult) GetBlocks() []*types1.Block {
 	return nil
 }
 
+// VoteExtension is the vote extension of a validator, made of the vote
+// extensions of the modules registering a vote extension handler with the
+// VoteExtensionManager of baseapp.
+//
+// Since: cosmos-sdk 0.51
+type VoteExtension struct {
+	// extensions are the vote extensions of the modules, sorted by module name.
+	Extensions []ModuleVoteExtension `protobuf:"bytes,1,rep,name=extensions,proto3" json:"extensions"`
+}
+
+func (m *VoteExtension) Reset()      { *m = VoteExtension{} }
+func (*VoteExtension) ProtoMessage() {}
+func (*VoteExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e37629bc7eb0df8, []int{11}
+}
+func (m *VoteExtension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VoteExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VoteExtension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VoteExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoteExtension.Merge(m, src)
+}
+func (m *VoteExtension) XXX_Size() int {
+	return m.Size()
+}
+func (m *VoteExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoteExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoteExtension proto.InternalMessageInfo
+
+func (m *VoteExtension) GetExtensions() []ModuleVoteExtension {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+// ModuleVoteExtension is the vote extension of a module.
+//
+// Since: cosmos-sdk 0.51
+type ModuleVoteExtension struct {
+	// module is the name of the module.
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// extension is the encoded vote extension of the module.
+	Extension []byte `protobuf:"bytes,2,opt,name=extension,proto3" json:"extension,omitempty"`
+}
+
+func (m *ModuleVoteExtension) Reset()      { *m = ModuleVoteExtension{} }
+func (*ModuleVoteExtension) ProtoMessage() {}
+func (*ModuleVoteExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e37629bc7eb0df8, []int{12}
+}
+func (m *ModuleVoteExtension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ModuleVoteExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ModuleVoteExtension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ModuleVoteExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModuleVoteExtension.Merge(m, src)
+}
+func (m *ModuleVoteExtension) XXX_Size() int {
+	return m.Size()
+}
+func (m *ModuleVoteExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModuleVoteExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModuleVoteExtension proto.InternalMessageInfo
+
+func (m *ModuleVoteExtension) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *ModuleVoteExtension) GetExtension() []byte {
+	if m != nil {
+		return m.Extension
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TxResponse)(nil), "cosmos.base.abci.v1beta1.TxResponse")
 	proto.RegisterType((*ABCIMessageLog)(nil), "cosmos.base.abci.v1beta1.ABCIMessageLog")
@@ -722,6 +827,8 @@ func init() {
 	proto.RegisterType((*TxMsgData)(nil), "cosmos.base.abci.v1beta1.TxMsgData")
 	proto.RegisterType((*SearchTxsResult)(nil), "cosmos.base.abci.v1beta1.SearchTxsResult")
 	proto.RegisterType((*SearchBlocksResult)(nil), "cosmos.base.abci.v1beta1.SearchBlocksResult")
+	proto.RegisterType((*VoteExtension)(nil), "cosmos.base.abci.v1beta1.VoteExtension")
+	proto.RegisterType((*ModuleVoteExtension)(nil), "cosmos.base.abci.v1beta1.ModuleVoteExtension")
 }
 
 func init() {
@@ -729,68 +836,72 @@ func init() {
 }
 
 var fileDescriptor_4e37629bc7eb0df8 = []byte{
-	// 968 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x4f, 0x6f, 0x1b, 0xb7,
-	0x13, 0xd5, 0x6a, 0x37, 0x2b, 0x8b, 0xb2, 0x7e, 0xf9, 0x81, 0x30, 0x6c, 0x3a, 0x4d, 0x25, 0x55,
-	0x49, 0x01, 0xa1, 0x40, 0x57, 0x88, 0x13, 0x14, 0x4d, 0x4e, 0x89, 0xdc, 0x7f, 0x06, 0x92, 0x1e,
-	0xd6, 0x0a, 0x0a, 0xf4, 0x22, 0x50, 0x12, 0x43, 0x2d, 0xac, 0x5d, 0x0a, 0x4b, 0xca, 0x96, 0x6f,
-	0xbd, 0xb5, 0xc7, 0x9e, 0x7a, 0xee, 0xb5, 0xfd, 0x24, 0x39, 0xf4, 0xe0, 0xa3, 0x0f, 0x81, 0xdb,
-	0xda, 0xb7, 0x7e, 0x8a, 0x62, 0x86, 0xd4, 0x9f, 0xd4, 0x95, 0x9b, 0x93, 0x87, 0x6f, 0x86, 0xd4,
-	0xbc, 0x37, 0x6f, 0x49, 0x93, 0x7b, 0x03, 0xa5, 0x53, 0xa5, 0xdb, 0x7d, 0xae, 0x45, 0x9b, 0xf7,
-	0x07, 0x49, 0xfb, 0xf8, 0x41, 0x5f, 0x18, 0xfe, 0x00, 0x17, 0xd1, 0x24, 0x57, 0x46, 0x51, 0x66,
-	0x8b, 0x22, 0x28, 0x8a, 0x10, 0x77, 0x45, 0x77, 0xb6, 0xa4, 0x92, 0x0a, 0x8b, 0xda, 0x10, 0xd9,
-	0xfa, 0x3b, 0xef, 0x19, 0x91, 0x0d, 0x45, 0x9e, 0x26, 0x99, 0xb1, 0x67, 0x9a, 0xd3, 0x89, 0xd0,
-	0x2e, 0x79, 0x77, 0x25, 0x89, 0x78, 0xbb, 0x3f, 0x56, 0x83, 0x23, 0x97, 0xdd, 0x95, 0x4a, 0xc9,
-	0xb1, 0x68, 0xe3, 0xaa, 0x3f, 0x7d, 0xd5, 0xe6, 0xd9, 0xa9, 0x4d, 0x35, 0x7f, 0xf3, 0x09, 0xe9,
-	0xce, 0x62, 0xa1, 0x27, 0x2a, 0xd3, 0x82, 0x6e, 0x93, 0x70, 0x24, 0x12, 0x39, 0x32, 0xcc, 0x6b,
-	0x78, 0x2d, 0x3f, 0x76, 0x2b, 0xda, 0x24, 0xa1, 0x99, 0x8d, 0xb8, 0x1e, 0xb1, 0x62, 0xc3, 0x6b,
-	0x95, 0x3b, 0xe4, 0xf2, 0xa2, 0x1e, 0x76, 0x67, 0x5f, 0x71, 0x3d, 0x8a, 0x5d, 0x86, 0xde, 0x25,
-	0xe5, 0x81, 0x1a, 0x0a, 0x3d, 0xe1, 0x03, 0xc1, 0x7c, 0x28, 0x8b, 0x97, 0x00, 0xa5, 0x24, 0x80,
-	0x05, 0x0b, 0x1a, 0x5e, 0xab, 0x1a, 0x63, 0x0c, 0xd8, 0x90, 0x1b, 0xce, 0x6e, 0x61, 0x31, 0xc6,
-	0x74, 0x87, 0x94, 0x72, 0x7e, 0xd2, 0x1b, 0x2b, 0xc9, 0x42, 0x84, 0xc3, 0x9c, 0x9f, 0x3c, 0x57,
-	0x92, 0xbe, 0x24, 0xc1, 0x58, 0x49, 0xcd, 0x4a, 0x0d, 0xbf, 0x55, 0xd9, 0x6b, 0x45, 0xeb, 0xe4,
-	0x8b, 0x9e, 0x75, 0xf6, 0x0f, 0x5e, 0x08, 0xad, 0xb9, 0x14, 0xcf, 0x95, 0xec, 0xec, 0xbc, 0xbe,
-	0xa8, 0x17, 0x7e, 0xfd, 0xbd, 0x7e, 0xfb, 0x6d, 0x5c, 0xc7, 0x78, 0x1c, 0xf4, 0x90, 0x64, 0xaf,
-	0x14, 0xdb, 0xb0, 0x3d, 0x40, 0x4c, 0xdf, 0x27, 0x44, 0x72, 0xdd, 0x3b, 0xe1, 0x99, 0x11, 0x43,
-	0x56, 0x46, 0x25, 0xca, 0x92, 0xeb, 0x6f, 0x10, 0xa0, 0xbb, 0x64, 0x03, 0xd2, 0x53, 0x2d, 0x86,
-	0x8c, 0x60, 0xb2, 0x24, 0xb9, 0x7e, 0xa9, 0xc5, 0x90, 0xde, 0x27, 0x45, 0x33, 0x63, 0x95, 0x86,
-	0xd7, 0xaa, 0xec, 0x6d, 0x45, 0x56, 0xf6, 0x68, 0x2e, 0x7b, 0xf4, 0x2c, 0x3b, 0x8d, 0x8b, 0x66,
-	0x06, 0x4a, 0x99, 0x24, 0x15, 0xda, 0xf0, 0x74, 0xc2, 0x36, 0xad, 0x52, 0x0b, 0x80, 0x3e, 0x22,
-	0xa1, 0x38, 0x16, 0x99, 0xd1, 0xac, 0x8a, 0x54, 0xb7, 0xa3, 0xe5, 0x70, 0x2d, 0xd3, 0xcf, 0x21,
-	0xdd, 0x09, 0x80, 0x58, 0xec, 0x6a, 0x9f, 0x04, 0x3f, 0xfc, 0x5c, 0x2f, 0x34, 0x7f, 0xf1, 0xc8,
-	0xff, 0xde, 0xe6, 0x49, 0x3f, 0x22, 0xe5, 0x54, 0xcb, 0x5e, 0x92, 0x0d, 0xc5, 0x0c, 0xa7, 0x5a,
-	0xed, 0x54, 0xff, 0xba, 0xa8, 0x2f, 0xc1, 0x78, 0x23, 0xd5, 0xf2, 0x00, 0x22, 0xfa, 0x7f, 0xe2,
-	0x83, 0xf0, 0x38, 0xe3, 0x18, 0x42, 0x7a, 0xb8, 0x68, 0xc6, 0xc7, 0x66, 0x3e, 0x5c, 0xaf, 0xfb,
-	0xa1, 0xc9, 0x93, 0x4c, 0xda, 0xde, 0xb6, 0x9c, 0xe8, 0x9b, 0x2b, 0xa0, 0x5e, 0xf6, 0xfa, 0xdd,
-	0x9b, 0x86, 0xd7, 0xcc, 0x49, 0x65, 0x25, 0x0b, 0x83, 0x00, 0xe7, 0x62, 0x8b, 0xe5, 0x18, 0x63,
-	0x7a, 0x40, 0x08, 0x37, 0x26, 0x4f, 0xfa, 0x53, 0x23, 0x34, 0x2b, 0x62, 0x07, 0xf7, 0x6e, 0x98,
-	0xfc, 0xbc, 0xd6, 0x69, 0xb3, 0xb2, 0xd9, 0xfd, 0xe6, 0x43, 0x52, 0x5e, 0x14, 0x01, 0xdb, 0x23,
-	0x71, 0xea, 0x7e, 0x10, 0x42, 0xba, 0x45, 0x6e, 0x1d, 0xf3, 0xf1, 0x54, 0x38, 0x05, 0xec, 0xa2,
-	0xb9, 0x4f, 0x4a, 0x5f, 0x72, 0x7d, 0x70, 0xdd, 0x19, 0xb0, 0x33, 0x58, 0xe7, 0x8c, 0x22, 0x26,
-	0xe7, 0xce, 0x80, 0xc9, 0x84, 0xb1, 0xd0, 0xd3, 0xb1, 0xa1, 0xdb, 0xce, 0xf6, 0xb0, 0x7d, 0xb3,
-	0x53, 0x64, 0x9e, 0xb3, 0xfe, 0x75, 0xf5, 0x1f, 0xfd, 0x43, 0xfd, 0x77, 0xb2, 0x02, 0x7d, 0x4c,
-	0xaa, 0x30, 0xdc, 0xdc, 0x7d, 0xd4, 0x9a, 0x05, 0xb8, 0xf9, 0xdf, 0xfd, 0xb8, 0x99, 0x6a, 0x39,
-	0xff, 0xfc, 0xe7, 0x2e, 0xfa, 0xc9, 0x23, 0xf4, 0x30, 0x49, 0xa7, 0x63, 0x6e, 0x12, 0x95, 0x2d,
-	0x2e, 0x87, 0x2f, 0x2c, 0x3b, 0xfc, 0x5c, 0x3c, 0xb4, 0xf8, 0x07, 0xeb, 0x67, 0xe1, 0x14, 0xeb,
-	0x6c, 0x40, 0x6b, 0x67, 0x17, 0x75, 0x0f, 0xa5, 0x40, 0x11, 0x3f, 0x25, 0x61, 0x8e, 0x4a, 0x20,
-	0xd5, 0xca, 0x5e, 0x63, 0xfd, 0x29, 0x56, 0xb1, 0xd8, 0xd5, 0x37, 0x9f, 0x92, 0xd2, 0x0b, 0x2d,
-	0x3f, 0x03, 0xb1, 0x76, 0x09, 0xd8, 0xb6, 0xb7, 0x62, 0x99, 0x52, 0xaa, 0x65, 0x17, 0x5c, 0x33,
-	0xbf, 0x56, 0xe0, 0xf4, 0x4d, 0xab, 0xed, 0x93, 0x10, 0xc6, 0xcf, 0xbc, 0xe6, 0xf7, 0x1e, 0x29,
-	0x77, 0x67, 0xf3, 0x43, 0x1e, 0x2f, 0x26, 0xe1, 0xdf, 0xcc, 0xc6, 0x6d, 0x58, 0x19, 0xd6, 0x35,
-	0x91, 0x8b, 0xef, 0x2e, 0x32, 0x5a, 0xf1, 0x8d, 0x47, 0x6e, 0x1f, 0x0a, 0x9e, 0x0f, 0x46, 0xdd,
-	0x99, 0x76, 0xce, 0xa8, 0x93, 0x8a, 0x51, 0x86, 0x8f, 0x7b, 0x03, 0x35, 0xcd, 0x8c, 0xf3, 0x17,
-	0x41, 0x68, 0x1f, 0x10, 0x30, 0xa8, 0x4d, 0x59, 0x77, 0xd9, 0x05, 0x6c, 0x9b, 0x70, 0x29, 0x7a,
-	0xd9, 0x34, 0xed, 0x8b, 0x1c, 0xef, 0xde, 0x20, 0x26, 0x00, 0x7d, 0x8d, 0x08, 0xd8, 0x16, 0x0b,
-	0xf0, 0x24, 0xbc, 0x82, 0x83, 0xb8, 0x0c, 0x48, 0x17, 0x00, 0x38, 0x75, 0x9c, 0xa4, 0x89, 0xc1,
-	0x8b, 0x38, 0x88, 0xed, 0x82, 0x7e, 0x42, 0x7c, 0x33, 0xd3, 0x2c, 0x44, 0x5e, 0xf7, 0xd7, 0x6b,
-	0xb3, 0x7c, 0x3e, 0x62, 0xd8, 0xe0, 0xe8, 0x9d, 0x83, 0x87, 0x90, 0x5e, 0x07, 0x5e, 0xa2, 0x1b,
-	0x18, 0xfa, 0xeb, 0x19, 0xfa, 0x37, 0x30, 0xf4, 0xff, 0x83, 0xa1, 0xbf, 0x96, 0xa1, 0x3f, 0x67,
-	0xd8, 0x26, 0x21, 0x3e, 0x93, 0x73, 0x92, 0x3b, 0xab, 0x9f, 0x97, 0x7d, 0x5e, 0xb1, 0xf9, 0xd8,
-	0x95, 0x59, 0x6a, 0x9d, 0xa7, 0xe7, 0x7f, 0xd6, 0x0a, 0xaf, 0x2f, 0x6b, 0xde, 0xd9, 0x65, 0xcd,
-	0xfb, 0xe3, 0xb2, 0xe6, 0xfd, 0x78, 0x55, 0x2b, 0x9c, 0x5d, 0xd5, 0x0a, 0xe7, 0x57, 0xb5, 0xc2,
-	0xb7, 0x4d, 0x99, 0x98, 0xd1, 0xb4, 0x1f, 0x0d, 0x54, 0xda, 0x76, 0xff, 0x07, 0xd8, 0x3f, 0x1f,
-	0xeb, 0xe1, 0x91, 0x7d, 0x9c, 0xfb, 0x21, 0xba, 0xe3, 0xe1, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0x4d, 0x51, 0x55, 0xd9, 0x29, 0x08, 0x00, 0x00,
+	// 1025 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xc1, 0x6f, 0x1b, 0xc5,
+	0x17, 0xf6, 0x7a, 0xdd, 0x75, 0xfc, 0x1c, 0xff, 0xfa, 0xd3, 0x10, 0x25, 0x9b, 0x52, 0x6c, 0xe3,
+	0x16, 0xc9, 0x42, 0xaa, 0xad, 0xa6, 0x15, 0xa2, 0x3d, 0xb5, 0x0e, 0x05, 0x22, 0xb5, 0x48, 0x6c,
+	0x5c, 0x90, 0xb8, 0x58, 0x63, 0x7b, 0x3a, 0x5e, 0xe2, 0xdd, 0xb1, 0x76, 0x66, 0x93, 0xcd, 0x8d,
+	0x1b, 0x1c, 0x39, 0x71, 0xe6, 0x0a, 0x7f, 0x49, 0x0f, 0x1c, 0x72, 0xcc, 0xa1, 0x0a, 0x90, 0xdc,
+	0xf8, 0x2b, 0xd0, 0xbc, 0x99, 0x8d, 0x1d, 0x82, 0x4d, 0x4f, 0x9e, 0xf9, 0xde, 0x9b, 0xb7, 0xef,
+	0xfb, 0xde, 0xb7, 0x3b, 0x86, 0x3b, 0x23, 0x21, 0x23, 0x21, 0xbb, 0x43, 0x2a, 0x59, 0x97, 0x0e,
+	0x47, 0x61, 0xf7, 0xf0, 0xfe, 0x90, 0x29, 0x7a, 0x1f, 0x37, 0x9d, 0x59, 0x22, 0x94, 0x20, 0xbe,
+	0x49, 0xea, 0xe8, 0xa4, 0x0e, 0xe2, 0x36, 0xe9, 0xd6, 0x06, 0x17, 0x5c, 0x60, 0x52, 0x57, 0xaf,
+	0x4c, 0xfe, 0xad, 0x77, 0x15, 0x8b, 0xc7, 0x2c, 0x89, 0xc2, 0x58, 0x99, 0x9a, 0xea, 0x78, 0xc6,
+	0xa4, 0x0d, 0xde, 0x5e, 0x08, 0x22, 0xde, 0x1d, 0x4e, 0xc5, 0xe8, 0xc0, 0x46, 0xb7, 0xb9, 0x10,
+	0x7c, 0xca, 0xba, 0xb8, 0x1b, 0xa6, 0xaf, 0xba, 0x34, 0x3e, 0x36, 0xa1, 0xd6, 0x6f, 0x2e, 0x40,
+	0x3f, 0x0b, 0x98, 0x9c, 0x89, 0x58, 0x32, 0xb2, 0x09, 0xde, 0x84, 0x85, 0x7c, 0xa2, 0x7c, 0xa7,
+	0xe9, 0xb4, 0xdd, 0xc0, 0xee, 0x48, 0x0b, 0x3c, 0x95, 0x4d, 0xa8, 0x9c, 0xf8, 0xc5, 0xa6, 0xd3,
+	0xae, 0xf4, 0xe0, 0xfc, 0xac, 0xe1, 0xf5, 0xb3, 0xcf, 0xa9, 0x9c, 0x04, 0x36, 0x42, 0x6e, 0x43,
+	0x65, 0x24, 0xc6, 0x4c, 0xce, 0xe8, 0x88, 0xf9, 0xae, 0x4e, 0x0b, 0xe6, 0x00, 0x21, 0x50, 0xd2,
+	0x1b, 0xbf, 0xd4, 0x74, 0xda, 0xb5, 0x00, 0xd7, 0x1a, 0x1b, 0x53, 0x45, 0xfd, 0x1b, 0x98, 0x8c,
+	0x6b, 0xb2, 0x05, 0xe5, 0x84, 0x1e, 0x0d, 0xa6, 0x82, 0xfb, 0x1e, 0xc2, 0x5e, 0x42, 0x8f, 0x9e,
+	0x0b, 0x4e, 0x5e, 0x42, 0x69, 0x2a, 0xb8, 0xf4, 0xcb, 0x4d, 0xb7, 0x5d, 0xdd, 0x69, 0x77, 0x96,
+	0xc9, 0xd7, 0x79, 0xda, 0xdb, 0xdd, 0x7b, 0xc1, 0xa4, 0xa4, 0x9c, 0x3d, 0x17, 0xbc, 0xb7, 0xf5,
+	0xfa, 0xac, 0x51, 0xf8, 0xf5, 0xf7, 0xc6, 0xcd, 0xab, 0xb8, 0x0c, 0xb0, 0x9c, 0xee, 0x21, 0x8c,
+	0x5f, 0x09, 0x7f, 0xcd, 0xf4, 0xa0, 0xd7, 0xe4, 0x3d, 0x00, 0x4e, 0xe5, 0xe0, 0x88, 0xc6, 0x8a,
+	0x8d, 0xfd, 0x0a, 0x2a, 0x51, 0xe1, 0x54, 0x7e, 0x8d, 0x00, 0xd9, 0x86, 0x35, 0x1d, 0x4e, 0x25,
+	0x1b, 0xfb, 0x80, 0xc1, 0x32, 0xa7, 0xf2, 0xa5, 0x64, 0x63, 0x72, 0x17, 0x8a, 0x2a, 0xf3, 0xab,
+	0x4d, 0xa7, 0x5d, 0xdd, 0xd9, 0xe8, 0x18, 0xd9, 0x3b, 0xb9, 0xec, 0x9d, 0xa7, 0xf1, 0x71, 0x50,
+	0x54, 0x99, 0x56, 0x4a, 0x85, 0x11, 0x93, 0x8a, 0x46, 0x33, 0x7f, 0xdd, 0x28, 0x75, 0x09, 0x90,
+	0x87, 0xe0, 0xb1, 0x43, 0x16, 0x2b, 0xe9, 0xd7, 0x90, 0xea, 0x66, 0x67, 0x3e, 0x5c, 0xc3, 0xf4,
+	0x99, 0x0e, 0xf7, 0x4a, 0x9a, 0x58, 0x60, 0x73, 0x1f, 0x97, 0x7e, 0xf8, 0xb9, 0x51, 0x68, 0xfd,
+	0xe2, 0xc0, 0xff, 0xae, 0xf2, 0x24, 0x1f, 0x42, 0x25, 0x92, 0x7c, 0x10, 0xc6, 0x63, 0x96, 0xe1,
+	0x54, 0x6b, 0xbd, 0xda, 0x5f, 0x67, 0x8d, 0x39, 0x18, 0xac, 0x45, 0x92, 0xef, 0xe9, 0x15, 0xf9,
+	0x3f, 0xb8, 0x5a, 0x78, 0x9c, 0x71, 0xa0, 0x97, 0x64, 0xff, 0xb2, 0x19, 0x17, 0x9b, 0xf9, 0x60,
+	0xb9, 0xee, 0xfb, 0x2a, 0x09, 0x63, 0x6e, 0x7a, 0xdb, 0xb0, 0xa2, 0xaf, 0x2f, 0x80, 0x72, 0xde,
+	0xeb, 0x77, 0x6f, 0x9a, 0x4e, 0x2b, 0x81, 0xea, 0x42, 0x54, 0x0f, 0x42, 0x3b, 0x17, 0x5b, 0xac,
+	0x04, 0xb8, 0x26, 0x7b, 0x00, 0x54, 0xa9, 0x24, 0x1c, 0xa6, 0x8a, 0x49, 0xbf, 0x88, 0x1d, 0xdc,
+	0x59, 0x31, 0xf9, 0x3c, 0xd7, 0x6a, 0xb3, 0x70, 0xd8, 0x3e, 0xf3, 0x01, 0x54, 0x2e, 0x93, 0x34,
+	0xdb, 0x03, 0x76, 0x6c, 0x1f, 0xa8, 0x97, 0x64, 0x03, 0x6e, 0x1c, 0xd2, 0x69, 0xca, 0xac, 0x02,
+	0x66, 0xd3, 0xda, 0x85, 0xf2, 0x67, 0x54, 0xee, 0x5d, 0x77, 0x86, 0x3e, 0x59, 0x5a, 0xe6, 0x8c,
+	0x22, 0x06, 0x73, 0x67, 0xe8, 0xc9, 0x78, 0x01, 0x93, 0xe9, 0x54, 0x91, 0x4d, 0x6b, 0x7b, 0x7d,
+	0x7c, 0xbd, 0x57, 0xf4, 0x1d, 0x6b, 0xfd, 0xeb, 0xea, 0x3f, 0xfc, 0x87, 0xfa, 0x6f, 0x65, 0x05,
+	0xf2, 0x08, 0x6a, 0x7a, 0xb8, 0x89, 0x7d, 0xa9, 0xa5, 0x5f, 0x6a, 0xba, 0x4b, 0xfd, 0xb8, 0x1e,
+	0x49, 0x9e, 0xbf, 0xfe, 0xb9, 0x8b, 0x7e, 0x72, 0x80, 0xec, 0x87, 0x51, 0x3a, 0xa5, 0x2a, 0x14,
+	0x71, 0x1e, 0x25, 0x9f, 0x1a, 0x76, 0xf8, 0xba, 0x38, 0x68, 0xf1, 0xf7, 0x97, 0xcf, 0xc2, 0x2a,
+	0xd6, 0x5b, 0xd3, 0xad, 0x9d, 0x9c, 0x35, 0x1c, 0x94, 0x02, 0x45, 0xfc, 0x18, 0xbc, 0x04, 0x95,
+	0x40, 0xaa, 0xd5, 0x9d, 0xe6, 0xf2, 0x2a, 0x46, 0xb1, 0xc0, 0xe6, 0xb7, 0x9e, 0x40, 0xf9, 0x85,
+	0xe4, 0x9f, 0x68, 0xb1, 0xb6, 0x41, 0xdb, 0x76, 0xb0, 0x60, 0x99, 0x72, 0x24, 0x79, 0xff, 0x78,
+	0x36, 0xff, 0xac, 0xe8, 0xea, 0xeb, 0x46, 0xdb, 0xc7, 0x9e, 0x1e, 0xbf, 0xef, 0xb4, 0xbe, 0x77,
+	0xa0, 0xd2, 0xcf, 0xf2, 0x22, 0x8f, 0x2e, 0x27, 0xe1, 0xae, 0x66, 0x63, 0x0f, 0x2c, 0x0c, 0xeb,
+	0x9a, 0xc8, 0xc5, 0xb7, 0x17, 0x19, 0xad, 0xf8, 0xc6, 0x81, 0x9b, 0xfb, 0x8c, 0x26, 0xa3, 0x49,
+	0x3f, 0x93, 0xd6, 0x19, 0x0d, 0xa8, 0x2a, 0xa1, 0xe8, 0x74, 0x30, 0x12, 0x69, 0xac, 0xac, 0xbf,
+	0x00, 0xa1, 0x5d, 0x8d, 0x68, 0x83, 0x9a, 0x90, 0x71, 0x97, 0xd9, 0xe8, 0x63, 0x33, 0xca, 0xd9,
+	0x20, 0x4e, 0xa3, 0x21, 0x4b, 0xf0, 0xdb, 0x5b, 0x0a, 0x40, 0x43, 0x5f, 0x20, 0xa2, 0x6d, 0x8b,
+	0x09, 0x58, 0x09, 0x3f, 0xc1, 0xa5, 0xa0, 0xa2, 0x91, 0xbe, 0x06, 0x74, 0xd5, 0x69, 0x18, 0x85,
+	0x0a, 0x3f, 0xc4, 0xa5, 0xc0, 0x6c, 0xc8, 0x47, 0xe0, 0xaa, 0x4c, 0xfa, 0x1e, 0xf2, 0xba, 0xbb,
+	0x5c, 0x9b, 0xf9, 0xf5, 0x11, 0xe8, 0x03, 0x96, 0xde, 0xa9, 0xf6, 0x10, 0xd2, 0xeb, 0xe9, 0x9b,
+	0x68, 0x05, 0x43, 0x77, 0x39, 0x43, 0x77, 0x05, 0x43, 0xf7, 0x3f, 0x18, 0xba, 0x4b, 0x19, 0xba,
+	0x39, 0xc3, 0x2e, 0x78, 0x78, 0x4d, 0xe6, 0x24, 0xb7, 0x16, 0x5f, 0x2f, 0x73, 0xbd, 0x62, 0xf3,
+	0x81, 0x4d, 0xb3, 0xd4, 0xbe, 0x85, 0xda, 0x57, 0x42, 0xb1, 0x67, 0x99, 0x62, 0xb1, 0x0c, 0x45,
+	0x4c, 0xf6, 0x01, 0x58, 0xbe, 0x91, 0xd6, 0x4c, 0xf7, 0x56, 0x98, 0x49, 0x8c, 0xd3, 0x29, 0xbb,
+	0x52, 0x22, 0xff, 0x60, 0xcd, 0xcb, 0xd8, 0x67, 0x7d, 0x09, 0xef, 0xfc, 0x4b, 0xba, 0xbe, 0xa7,
+	0x23, 0x84, 0xad, 0xf7, 0xed, 0x4e, 0xdf, 0x2c, 0x97, 0x25, 0xac, 0xff, 0xe7, 0x80, 0x29, 0xd9,
+	0x7b, 0x72, 0xfa, 0x67, 0xbd, 0xf0, 0xfa, 0xbc, 0xee, 0x9c, 0x9c, 0xd7, 0x9d, 0x3f, 0xce, 0xeb,
+	0xce, 0x8f, 0x17, 0xf5, 0xc2, 0xc9, 0x45, 0xbd, 0x70, 0x7a, 0x51, 0x2f, 0x7c, 0xd3, 0xe2, 0xa1,
+	0x9a, 0xa4, 0xc3, 0xce, 0x48, 0x44, 0x5d, 0xfb, 0x37, 0xc6, 0xfc, 0xdc, 0x93, 0xe3, 0x03, 0xf3,
+	0xdf, 0x62, 0xe8, 0xa1, 0xb9, 0x1f, 0xfc, 0x3d, 0x00, 0xbf, 0xce, 0xd7, 0x5e, 0xe8, 0x08, 0x00,
+	0x00,
 }
 
 func (m *TxResponse) Marshal() (dAtA []byte, err error) {
@@ -1403,6 +1514,80 @@ func (m *SearchBlocksResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *VoteExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VoteExtension) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Extensions) > 0 {
+		for iNdEx := len(m.Extensions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Extensions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAbci(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ModuleVoteExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ModuleVoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ModuleVoteExtension) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Extension) > 0 {
+		i -= len(m.Extension)
+		copy(dAtA[i:], m.Extension)
+		i = encodeVarintAbci(dAtA, i, uint64(len(m.Extension)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Module) > 0 {
+		i -= len(m.Module)
+		copy(dAtA[i:], m.Module)
+		i = encodeVarintAbci(dAtA, i, uint64(len(m.Module)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintAbci(dAtA []byte, offset int, v uint64) int {
 	offset -= sovAbci(v)
 	base := offset
@@ -1690,6 +1875,38 @@ func (m *SearchBlocksResult) Size() (n int) {
 	return n
 }
 
+func (m *VoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Extensions) > 0 {
+		for _, e := range m.Extensions {
+			l = e.Size()
+			n += 1 + l + sovAbci(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ModuleVoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Module)
+	if l > 0 {
+		n += 1 + l + sovAbci(uint64(l))
+	}
+	l = len(m.Extension)
+	if l > 0 {
+		n += 1 + l + sovAbci(uint64(l))
+	}
+	return n
+}
+
 func sovAbci(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1801,6 +2018,32 @@ func (this *SearchBlocksResult) String() string {
 	}, "")
 	return s
 }
+func (this *VoteExtension) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForExtensions := "[]ModuleVoteExtension{"
+	for _, f := range this.Extensions {
+		repeatedStringForExtensions += strings.Replace(strings.Replace(f.String(), "ModuleVoteExtension", "ModuleVoteExtension", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForExtensions += "}"
+	s := strings.Join([]string{`&VoteExtension{`,
+		`Extensions:` + repeatedStringForExtensions + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ModuleVoteExtension) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ModuleVoteExtension{`,
+		`Module:` + fmt.Sprintf("%v", this.Module) + `,`,
+		`Extension:` + fmt.Sprintf("%v", this.Extension) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringAbci(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -3579,6 +3822,206 @@ func (m *SearchBlocksResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *VoteExtension) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAbci
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VoteExtension: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VoteExtension: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extensions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAbci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAbci
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAbci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extensions = append(m.Extensions, ModuleVoteExtension{})
+			if err := m.Extensions[len(m.Extensions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAbci(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAbci
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ModuleVoteExtension) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAbci
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ModuleVoteExtension: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ModuleVoteExtension: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Module", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAbci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAbci
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAbci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Module = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAbci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAbci
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAbci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extension = append(m.Extension[:0], dAtA[iNdEx:postIndex]...)
+			if m.Extension == nil {
+				m.Extension = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAbci(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAbci
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipAbci(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -12,6 +12,8 @@ import (
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/gaskv"
 	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
 )
 
 // ExecMode defines the execution mode which can be set on a Context.
@@ -61,6 +63,8 @@ type Context struct {
 	streamingManager     storetypes.StreamingManager
 	cometInfo            comet.Info
 	headerInfo           header.Info
+	gasProfiler          *gasprofile.Profiler
+	gasProfileStack      *gasprofile.Stack
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -75,7 +79,6 @@ func (c Context) ChainID() string                               { return c.chain
 func (c Context) TxBytes() []byte                               { return c.txBytes }
 func (c Context) Logger() log.Logger                            { return c.logger }
 func (c Context) VoteInfos() []abci.VoteInfo                    { return c.voteInfo }
-func (c Context) GasMeter() storetypes.GasMeter                 { return c.gasMeter }
 func (c Context) BlockGasMeter() storetypes.GasMeter            { return c.blockGasMeter }
 func (c Context) IsCheckTx() bool                               { return c.checkTx }   // Deprecated: use core/transaction service instead
 func (c Context) IsReCheckTx() bool                             { return c.recheckTx } // Deprecated: use core/transaction service instead
@@ -89,6 +92,17 @@ func (c Context) TransientKVGasConfig() storetypes.GasConfig    { return c.trans
 func (c Context) StreamingManager() storetypes.StreamingManager { return c.streamingManager }
 func (c Context) CometInfo() comet.Info                         { return c.cometInfo }
 func (c Context) HeaderInfo() header.Info                       { return c.headerInfo }
+func (c Context) GasProfiler() *gasprofile.Profiler             { return c.gasProfiler }
+func (c Context) GasProfileStack() *gasprofile.Stack            { return c.gasProfileStack }
+
+// GasMeter returns the transaction GasMeter. When a gas profiler is set, the
+// consumed gas is attributed to the gas profile stack of the context.
+func (c Context) GasMeter() storetypes.GasMeter {
+	if c.gasProfiler != nil {
+		return c.gasProfiler.Meter(c.gasMeter, c.gasProfileStack)
+	}
+	return c.gasMeter
+}
 
 // BlockHeader returns the header by value.
 func (c Context) BlockHeader() cmtproto.Header {
@@ -214,6 +228,11 @@ func (c Context) WithVoteInfos(voteInfo []abci.VoteInfo) Context {
 
 // WithGasMeter returns a Context with an updated transaction GasMeter.
 func (c Context) WithGasMeter(meter storetypes.GasMeter) Context {
+	// the meter returned by GasMeter when profiling is unwrapped, so that gas
+	// isn't attributed twice
+	if profiled, ok := meter.(*gasprofile.Meter); ok {
+		meter = profiled.Unwrap()
+	}
 	c.gasMeter = meter
 	return c
 }
@@ -298,6 +317,32 @@ func (c Context) WithStreamingManager(sm storetypes.StreamingManager) Context {
 	return c
 }
 
+// WithGasProfiler returns a Context with an updated gas profiler, to which the
+// gas consumed on the GasMeter and KV stores of the Context is reported. A nil
+// profiler disables profiling.
+func (c Context) WithGasProfiler(p *gasprofile.Profiler) Context {
+	c.gasProfiler = p
+	return c
+}
+
+// WithGasProfileFrame returns a Context with frame pushed on its gas profile
+// stack, such as the name of the message handler being executed. It returns
+// the Context unchanged when no gas profiler is set.
+func (c Context) WithGasProfileFrame(frame string) Context {
+	if c.gasProfiler == nil {
+		return c
+	}
+	c.gasProfileStack = c.gasProfileStack.Push(frame)
+	return c
+}
+
+// WithGasProfileStack returns a Context with an updated gas profile stack, e.g.
+// to restore the stack of a Context derived from it.
+func (c Context) WithGasProfileStack(stack *gasprofile.Stack) Context {
+	c.gasProfileStack = stack
+	return c
+}
+
 // WithCometInfo returns a Context with an updated comet info
 func (c Context) WithCometInfo(cometInfo comet.Info) Context {
 	c.cometInfo = cometInfo
@@ -336,11 +381,17 @@ func (c Context) Value(key interface{}) interface{} {
 
 // KVStore fetches a KVStore from the MultiStore.
 func (c Context) KVStore(key storetypes.StoreKey) storetypes.KVStore {
+	if c.gasProfiler != nil {
+		return c.gasProfiler.KVStore(c.ms.GetKVStore(key), key, c.gasMeter, c.gasProfileStack, c.kvGasConfig)
+	}
 	return gaskv.NewStore(c.ms.GetKVStore(key), c.gasMeter, c.kvGasConfig)
 }
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key storetypes.StoreKey) storetypes.KVStore {
+	if c.gasProfiler != nil {
+		return c.gasProfiler.KVStore(c.ms.GetKVStore(key), key, c.gasMeter, c.gasProfileStack, c.transientKVGasConfig)
+	}
 	return gaskv.NewStore(c.ms.GetKVStore(key), c.gasMeter, c.transientKVGasConfig)
 }
 
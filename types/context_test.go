@@ -16,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/testutil"
 	"github.com/cosmos/cosmos-sdk/testutil/mock"
 	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
 )
 
 type contextTestSuite struct {
@@ -215,6 +216,30 @@ func (s *contextTestSuite) TestContextHeaderClone() {
 	}
 }
 
+func (s *contextTestSuite) TestContextGasProfiler() {
+	key := storetypes.NewKVStoreKey(s.T().Name())
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_"+s.T().Name()))
+
+	// frames are ignored when no profiler is set
+	s.Require().Nil(ctx.WithGasProfileFrame("ignored").GasProfileStack())
+
+	profiler := gasprofile.NewProfiler()
+	ctx = ctx.WithGasProfiler(profiler).WithGasProfileFrame("msg")
+	ctx.KVStore(key).Set([]byte("a"), []byte("b"))
+	ctx.GasMeter().ConsumeGas(10, "custom")
+
+	// re-setting the profiled meter doesn't attribute gas twice
+	ctx = ctx.WithGasMeter(ctx.GasMeter())
+	ctx.GasMeter().ConsumeGas(5, "custom")
+
+	s.Require().Equal([]gasprofile.Sample{
+		{Stack: []string{"msg", key.Name(), "0x61", storetypes.GasWriteCostFlatDesc}, Gas: 2000},
+		{Stack: []string{"msg", key.Name(), "0x61", storetypes.GasWritePerByteDesc}, Gas: 60},
+		{Stack: []string{"msg", "custom"}, Gas: 15},
+	}, profiler.Samples())
+	s.Require().Equal(uint64(2075), ctx.GasMeter().GasConsumed())
+}
+
 func (s *contextTestSuite) TestUnwrapSDKContext() {
 	sdkCtx := types.NewContext(nil, false, nil)
 	ctx := types.WrapSDKContext(sdkCtx)
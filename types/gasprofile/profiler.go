@@ -0,0 +1,184 @@
+// Package gasprofile implements a gas profiler, which attributes the gas
+// consumed by transactions and queries to call stacks of message handlers,
+// stores and collections, and aggregates it into flame-graph style reports.
+//
+// A Profiler is enabled on an sdk.Context with WithGasProfiler, or on every
+// transaction and query of a BaseApp with SetGasProfiler. The gas consumed on
+// the gas meter of the context is then attributed to the frames pushed on the
+// context with WithGasProfileFrame, followed by the store key, the collection
+// and the gas descriptor for the gas consumed by the KV stores of the context.
+package gasprofile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// Stack is an immutable call stack of frames. The nil Stack is the empty
+// stack.
+type Stack struct {
+	parent *Stack
+	frame  string
+	folded string
+}
+
+// Push returns the stack with frame pushed on top of s.
+func (s *Stack) Push(frame string) *Stack {
+	// frames are separated by semicolons in folded stacks
+	frame = strings.ReplaceAll(frame, ";", ":")
+	if s == nil {
+		return &Stack{frame: frame, folded: frame}
+	}
+	return &Stack{parent: s, frame: frame, folded: s.folded + ";" + frame}
+}
+
+// Frames returns the frames of the stack, from the bottom one.
+func (s *Stack) Frames() []string {
+	if s == nil {
+		return nil
+	}
+	return strings.Split(s.folded, ";")
+}
+
+// Collection is a named range of keys of a store, identified by their prefix,
+// such as a collection of the collections package.
+type Collection interface {
+	GetName() string
+	GetPrefix() []byte
+}
+
+// Sample is the gas attributed to a call stack.
+type Sample struct {
+	// Stack are the frames of the call stack, from the bottom one.
+	Stack []string
+	// Gas is the gas consumed by the call stack itself, excluding the stacks
+	// it is the prefix of.
+	Gas uint64
+}
+
+// Profiler attributes gas consumption to call stacks. It is safe for
+// concurrent use.
+type Profiler struct {
+	mu  sync.Mutex
+	gas map[string]uint64
+
+	// collections are the collections of each store, by store key name, sorted
+	// by decreasing prefix length so that the longest matching prefix wins
+	collections map[string][]Collection
+}
+
+// NewProfiler returns a new Profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{
+		gas:         make(map[string]uint64),
+		collections: make(map[string][]Collection),
+	}
+}
+
+// RegisterCollections registers collections of the store with the given key
+// name, so that the gas consumed by reading and writing their keys is
+// attributed to them. The gas of keys of no registered collection is
+// attributed to their first byte, which is the prefix of most stores.
+func (p *Profiler) RegisterCollections(storeKey string, collections ...Collection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	registered := append(p.collections[storeKey], collections...)
+	sort.SliceStable(registered, func(i, j int) bool {
+		return len(registered[i].GetPrefix()) > len(registered[j].GetPrefix())
+	})
+	p.collections[storeKey] = registered
+}
+
+// collectionFrame returns the frame of the collection of the key.
+func (p *Profiler) collectionFrame(storeKey string, key []byte) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, collection := range p.collections[storeKey] {
+		if bytes.HasPrefix(key, collection.GetPrefix()) {
+			return collection.GetName()
+		}
+	}
+	return fmt.Sprintf("0x%02x", key[0])
+}
+
+// record attributes gas to the stack.
+func (p *Profiler) record(stack *Stack, gas uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gas[stack.folded] += gas
+}
+
+// Samples returns the gas attributed to each call stack, sorted by stack.
+func (p *Profiler) Samples() []Sample {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stacks := make([]string, 0, len(p.gas))
+	for stack := range p.gas {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	samples := make([]Sample, len(stacks))
+	for i, stack := range stacks {
+		samples[i] = Sample{Stack: strings.Split(stack, ";"), Gas: p.gas[stack]}
+	}
+	return samples
+}
+
+// WriteFolded writes the samples in the folded stacks format, one
+// "frame;frame;frame gas" line per call stack, which is the input format of
+// flame graph tools such as flamegraph.pl, inferno or speedscope.
+func (p *Profiler) WriteFolded(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, sample := range p.Samples() {
+		if _, err := fmt.Fprintf(bw, "%s %d\n", strings.Join(sample.Stack, ";"), sample.Gas); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Reset discards the samples.
+func (p *Profiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gas = make(map[string]uint64)
+}
+
+// Meter returns a GasMeter consuming gas on meter, and attributing it to the
+// stack with the gas descriptor pushed on top. Refunds aren't attributed.
+func (p *Profiler) Meter(meter storetypes.GasMeter, stack *Stack) *Meter {
+	return &Meter{GasMeter: meter, profiler: p, stack: stack}
+}
+
+// Meter is a GasMeter attributing the gas it consumes to a call stack.
+type Meter struct {
+	storetypes.GasMeter
+	profiler *Profiler
+	stack    *Stack
+}
+
+// Unwrap returns the profiled GasMeter.
+func (m *Meter) Unwrap() storetypes.GasMeter {
+	return m.GasMeter
+}
+
+// ConsumeGas consumes gas on the profiled GasMeter and attributes it. Gas is
+// attributed before it is consumed, so that the gas consumed by an operation
+// running out of gas is attributed to it.
+func (m *Meter) ConsumeGas(amount storetypes.Gas, descriptor string) {
+	m.profiler.record(m.stack.Push(descriptor), amount)
+	m.GasMeter.ConsumeGas(amount, descriptor)
+}
@@ -0,0 +1,79 @@
+package gasprofile_test
+
+import (
+	"strings"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/dbadapter"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
+)
+
+type testCollection struct {
+	name   string
+	prefix []byte
+}
+
+func (c testCollection) GetName() string   { return c.name }
+func (c testCollection) GetPrefix() []byte { return c.prefix }
+
+func TestStack(t *testing.T) {
+	var stack *gasprofile.Stack
+	require.Nil(t, stack.Frames())
+
+	stack = stack.Push("a").Push("b;c")
+	require.Equal(t, []string{"a", "b:c"}, stack.Frames())
+	require.Equal(t, []string{"a", "b:c", "d"}, stack.Push("d").Frames())
+}
+
+func TestProfiler(t *testing.T) {
+	p := gasprofile.NewProfiler()
+	p.RegisterCollections("bank", testCollection{"balances", []byte{2}}, testCollection{"supply", []byte{2, 1}})
+
+	meter := storetypes.NewInfiniteGasMeter()
+	stack := (*gasprofile.Stack)(nil).Push("/cosmos.bank.v1beta1.MsgSend")
+	store := p.KVStore(dbadapter.Store{DB: dbm.NewMemDB()}, storetypes.NewKVStoreKey("bank"), meter, stack, storetypes.KVGasConfig())
+
+	store.Set([]byte{2, 0, 'a'}, []byte("10"))
+	require.Nil(t, store.Get([]byte{2, 1}))
+	require.False(t, store.Has([]byte{9}))
+	p.Meter(meter, stack).ConsumeGas(5, "txSize")
+
+	expected := []gasprofile.Sample{
+		{Stack: []string{"/cosmos.bank.v1beta1.MsgSend", "bank", "0x09", storetypes.GasHasDesc}, Gas: 1000},
+		{Stack: []string{"/cosmos.bank.v1beta1.MsgSend", "bank", "balances", storetypes.GasWriteCostFlatDesc}, Gas: 2000},
+		{Stack: []string{"/cosmos.bank.v1beta1.MsgSend", "bank", "balances", storetypes.GasWritePerByteDesc}, Gas: 150},
+		{Stack: []string{"/cosmos.bank.v1beta1.MsgSend", "bank", "supply", storetypes.GasReadCostFlatDesc}, Gas: 1000},
+		{Stack: []string{"/cosmos.bank.v1beta1.MsgSend", "bank", "supply", storetypes.GasReadPerByteDesc}, Gas: 6},
+		{Stack: []string{"/cosmos.bank.v1beta1.MsgSend", "txSize"}, Gas: 5},
+	}
+	require.Equal(t, expected, p.Samples())
+
+	// all the gas consumed on the meter is attributed
+	var total uint64
+	for _, sample := range expected {
+		total += sample.Gas
+	}
+	require.Equal(t, total, meter.GasConsumed())
+
+	var folded strings.Builder
+	require.NoError(t, p.WriteFolded(&folded))
+	require.Equal(t, `/cosmos.bank.v1beta1.MsgSend;bank;0x09;Has 1000
+/cosmos.bank.v1beta1.MsgSend;bank;balances;WriteFlat 2000
+/cosmos.bank.v1beta1.MsgSend;bank;balances;WritePerByte 150
+/cosmos.bank.v1beta1.MsgSend;bank;supply;ReadFlat 1000
+/cosmos.bank.v1beta1.MsgSend;bank;supply;ReadPerByte 6
+/cosmos.bank.v1beta1.MsgSend;txSize 5
+`, folded.String())
+
+	// the gas of an operation running out of gas is attributed
+	limited := storetypes.NewGasMeter(1500)
+	store = p.KVStore(dbadapter.Store{DB: dbm.NewMemDB()}, storetypes.NewKVStoreKey("bank"), limited, nil, storetypes.KVGasConfig())
+	p.Reset()
+	require.Panics(t, func() { store.Set([]byte{2}, []byte("1")) })
+	require.Equal(t, []gasprofile.Sample{{Stack: []string{"bank", "balances", storetypes.GasWriteCostFlatDesc}, Gas: 2000}}, p.Samples())
+}
@@ -0,0 +1,82 @@
+package gasprofile
+
+import (
+	"io"
+
+	"cosmossdk.io/store/gaskv"
+	storetypes "cosmossdk.io/store/types"
+)
+
+// KVStore returns a gas KV store consuming gas on meter, like gaskv.NewStore,
+// and attributing the gas of each operation to the stack with the store key
+// and the collection of the key of the operation pushed on top.
+func (p *Profiler) KVStore(parent storetypes.KVStore, key storetypes.StoreKey, meter storetypes.GasMeter, stack *Stack, gasConfig storetypes.GasConfig) storetypes.KVStore {
+	return &store{
+		parent:    parent,
+		profiler:  p,
+		storeKey:  key.Name(),
+		meter:     meter,
+		stack:     stack.Push(key.Name()),
+		gasConfig: gasConfig,
+	}
+}
+
+var _ storetypes.KVStore = &store{}
+
+// store is a KV store profiling the gas consumed by a gas KV store, which is
+// created for each operation with a Meter attributing its gas to the
+// collection of the key of the operation.
+type store struct {
+	parent    storetypes.KVStore
+	profiler  *Profiler
+	storeKey  string
+	meter     storetypes.GasMeter
+	stack     *Stack
+	gasConfig storetypes.GasConfig
+}
+
+// gasStore returns the gas KV store of an operation on key. Iterators are
+// attributed to the collection of their start key.
+func (s *store) gasStore(key []byte) storetypes.KVStore {
+	stack := s.stack
+	if len(key) > 0 {
+		stack = stack.Push(s.profiler.collectionFrame(s.storeKey, key))
+	}
+	return gaskv.NewStore(s.parent, s.profiler.Meter(s.meter, stack), s.gasConfig)
+}
+
+func (s *store) GetStoreType() storetypes.StoreType {
+	return s.parent.GetStoreType()
+}
+
+func (s *store) CacheWrap() storetypes.CacheWrap {
+	return s.gasStore(nil).CacheWrap()
+}
+
+func (s *store) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	return s.gasStore(nil).CacheWrapWithTrace(w, tc)
+}
+
+func (s *store) Get(key []byte) []byte {
+	return s.gasStore(key).Get(key)
+}
+
+func (s *store) Has(key []byte) bool {
+	return s.gasStore(key).Has(key)
+}
+
+func (s *store) Set(key, value []byte) {
+	s.gasStore(key).Set(key, value)
+}
+
+func (s *store) Delete(key []byte) {
+	s.gasStore(key).Delete(key)
+}
+
+func (s *store) Iterator(start, end []byte) storetypes.Iterator {
+	return s.gasStore(start).Iterator(start, end)
+}
+
+func (s *store) ReverseIterator(start, end []byte) storetypes.Iterator {
+	return s.gasStore(start).ReverseIterator(start, end)
+}
@@ -0,0 +1,162 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ Mempool  = (*LaneMempool)(nil)
+	_ Iterator = (*laneIterator)(nil)
+)
+
+// ErrNoMatchingLane is returned when inserting a transaction which isn't matched
+// by any lane of a LaneMempool.
+var ErrNoMatchingLane = errors.New("no lane matches tx")
+
+// Lane is a class of transactions, with its own mempool and its own share of
+// the block space.
+type Lane struct {
+	// Name is the name of the lane.
+	Name string
+
+	// Mempool holds the transactions of the lane, and defines their order.
+	Mempool Mempool
+
+	// Match returns true for the transactions of the lane. A nil Match matches
+	// every transaction, which is typically used by the last lane.
+	Match func(sdk.Tx) bool
+
+	// MaxBlockSpace is the percentage, from 1 to 100, of the block space, in
+	// both bytes and gas, which the transactions of the lane may use.
+	MaxBlockSpace uint64
+}
+
+func (l Lane) matches(tx sdk.Tx) bool {
+	return l.Match == nil || l.Match(tx)
+}
+
+// LaneMempool is a mempool which composes the mempools of several lanes, so that
+// a class of transactions, such as oracle or governance transactions, can't be
+// crowded out of blocks by others.
+//
+// Every transaction belongs to the first lane which matches it. Lanes are
+// iterated in order, each one iterating over the mempool of its lane, and the
+// DefaultProposalHandler fills proposals lane by lane, each lane using at most
+// its share of the block space.
+type LaneMempool struct {
+	lanes []Lane
+}
+
+// NewLaneMempool creates a new LaneMempool with the provided lanes, in priority
+// order. It panics if a lane has no name or mempool, if names are duplicated,
+// or if the block space of the lanes isn't between 1 and 100 percents or
+// exceeds 100 percents in total.
+func NewLaneMempool(lanes ...Lane) *LaneMempool {
+	names := make(map[string]struct{}, len(lanes))
+	var total uint64
+	for _, lane := range lanes {
+		if lane.Name == "" {
+			panic("lane name cannot be empty")
+		}
+		if _, ok := names[lane.Name]; ok {
+			panic(fmt.Sprintf("duplicate lane %s", lane.Name))
+		}
+		names[lane.Name] = struct{}{}
+
+		if lane.Mempool == nil {
+			panic(fmt.Sprintf("lane %s has no mempool", lane.Name))
+		}
+		if lane.MaxBlockSpace == 0 || lane.MaxBlockSpace > 100 {
+			panic(fmt.Sprintf("block space of lane %s must be between 1 and 100 percents, got %d", lane.Name, lane.MaxBlockSpace))
+		}
+		total += lane.MaxBlockSpace
+	}
+	if total > 100 {
+		panic(fmt.Sprintf("block space of the lanes exceeds 100 percents: %d", total))
+	}
+
+	return &LaneMempool{lanes: lanes}
+}
+
+// Lanes returns the lanes of the mempool, in priority order.
+func (mp *LaneMempool) Lanes() []Lane {
+	return mp.lanes
+}
+
+// MatchLane returns the index of the lane of the transaction, which is the
+// first lane matching it, or false if no lane matches it.
+func (mp *LaneMempool) MatchLane(tx sdk.Tx) (int, bool) {
+	for i, lane := range mp.lanes {
+		if lane.matches(tx) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Insert inserts the transaction into the mempool of its lane.
+func (mp *LaneMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	i, ok := mp.MatchLane(tx)
+	if !ok {
+		return ErrNoMatchingLane
+	}
+	return mp.lanes[i].Mempool.Insert(ctx, tx)
+}
+
+// Select returns an iterator over the transactions of every lane, in the order
+// of the lanes. The provided transactions are ignored.
+func (mp *LaneMempool) Select(ctx context.Context, _ [][]byte) Iterator {
+	return newLaneIterator(ctx, mp.lanes, 0)
+}
+
+// CountTx returns the number of transactions in the mempools of all lanes.
+func (mp *LaneMempool) CountTx() int {
+	var count int
+	for _, lane := range mp.lanes {
+		count += lane.Mempool.CountTx()
+	}
+	return count
+}
+
+// Remove removes the transaction from the mempool of its lane.
+func (mp *LaneMempool) Remove(tx sdk.Tx) error {
+	i, ok := mp.MatchLane(tx)
+	if !ok {
+		return ErrTxNotFound
+	}
+	return mp.lanes[i].Mempool.Remove(tx)
+}
+
+// laneIterator iterates over the mempools of the lanes in order.
+type laneIterator struct {
+	ctx   context.Context
+	lanes []Lane
+	lane  int
+	iter  Iterator
+}
+
+// newLaneIterator returns an iterator starting at the first non-empty lane from
+// the provided one, or nil if they are all empty.
+func newLaneIterator(ctx context.Context, lanes []Lane, lane int) Iterator {
+	for ; lane < len(lanes); lane++ {
+		if iter := lanes[lane].Mempool.Select(ctx, nil); iter != nil {
+			return &laneIterator{ctx: ctx, lanes: lanes, lane: lane, iter: iter}
+		}
+	}
+	return nil
+}
+
+func (i *laneIterator) Next() Iterator {
+	if iter := i.iter.Next(); iter != nil {
+		return &laneIterator{ctx: i.ctx, lanes: i.lanes, lane: i.lane, iter: iter}
+	}
+	return newLaneIterator(i.ctx, i.lanes, i.lane+1)
+}
+
+func (i *laneIterator) Tx() sdk.Tx {
+	return i.iter.Tx()
+}
@@ -0,0 +1,110 @@
+package mempool_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func newTestLaneMempool(oracle sdk.AccAddress) *mempool.LaneMempool {
+	return mempool.NewLaneMempool(
+		mempool.Lane{
+			Name:          "oracle",
+			Mempool:       mempool.NewSenderNonceMempool(mempool.SenderNonceSeedOpt(0), mempool.SenderNonceMaxTxOpt(0)),
+			Match:         func(tx sdk.Tx) bool { return tx.(testTx).address.Equals(oracle) },
+			MaxBlockSpace: 20,
+		},
+		mempool.Lane{
+			Name:          "default",
+			Mempool:       mempool.NewSenderNonceMempool(mempool.SenderNonceSeedOpt(0), mempool.SenderNonceMaxTxOpt(0)),
+			MaxBlockSpace: 80,
+		},
+	)
+}
+
+func TestLaneMempool(t *testing.T) {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 3)
+	mp := newTestLaneMempool(accounts[0].Address)
+
+	txs := []testTx{
+		{id: 0, nonce: 0, address: accounts[1].Address},
+		{id: 1, nonce: 0, address: accounts[0].Address},
+		{id: 2, nonce: 1, address: accounts[1].Address},
+		{id: 3, nonce: 1, address: accounts[0].Address},
+		{id: 4, nonce: 0, address: accounts[2].Address},
+	}
+	for _, tx := range txs {
+		require.NoError(t, mp.Insert(ctx, tx))
+	}
+	require.Equal(t, 5, mp.CountTx())
+	require.Equal(t, 2, mp.Lanes()[0].Mempool.CountTx())
+	require.Equal(t, 3, mp.Lanes()[1].Mempool.CountTx())
+
+	lane, ok := mp.MatchLane(txs[1])
+	require.True(t, ok)
+	require.Equal(t, 0, lane)
+	lane, ok = mp.MatchLane(txs[0])
+	require.True(t, ok)
+	require.Equal(t, 1, lane)
+
+	// the transactions of the oracle lane come first
+	selected := fetchTxs(mp.Select(ctx, nil), 100)
+	require.Len(t, selected, 5)
+	require.Equal(t, txs[1], selected[0])
+	require.Equal(t, txs[3], selected[1])
+	for _, tx := range selected[2:] {
+		require.NotEqual(t, accounts[0].Address, tx.(testTx).address)
+	}
+
+	require.NoError(t, mp.Remove(txs[1]))
+	require.NoError(t, mp.Remove(txs[0]))
+	require.ErrorIs(t, mp.Remove(txs[0]), mempool.ErrTxNotFound)
+	require.Equal(t, 1, mp.Lanes()[0].Mempool.CountTx())
+	require.Equal(t, 3, mp.CountTx())
+
+	require.NoError(t, mp.Remove(txs[3]))
+	selected = fetchTxs(mp.Select(ctx, nil), 100)
+	require.Len(t, selected, 2)
+
+	require.NoError(t, mp.Remove(txs[2]))
+	require.NoError(t, mp.Remove(txs[4]))
+	require.Nil(t, mp.Select(ctx, nil))
+}
+
+func TestLaneMempoolNoMatchingLane(t *testing.T) {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	mp := mempool.NewLaneMempool(mempool.Lane{
+		Name:          "oracle",
+		Mempool:       mempool.NewSenderNonceMempool(mempool.SenderNonceMaxTxOpt(0)),
+		Match:         func(tx sdk.Tx) bool { return tx.(testTx).address.Equals(accounts[0].Address) },
+		MaxBlockSpace: 100,
+	})
+
+	tx := testTx{address: accounts[1].Address}
+	require.ErrorIs(t, mp.Insert(ctx, tx), mempool.ErrNoMatchingLane)
+	require.ErrorIs(t, mp.Remove(tx), mempool.ErrTxNotFound)
+	require.Equal(t, 0, mp.CountTx())
+}
+
+func TestNewLaneMempoolInvalidLanes(t *testing.T) {
+	lane := func(name string, space uint64) mempool.Lane {
+		return mempool.Lane{Name: name, Mempool: mempool.NoOpMempool{}, MaxBlockSpace: space}
+	}
+
+	require.NotPanics(t, func() { mempool.NewLaneMempool(lane("a", 30), lane("b", 70)) })
+	require.Panics(t, func() { mempool.NewLaneMempool(lane("", 10)) })
+	require.Panics(t, func() { mempool.NewLaneMempool(lane("a", 10), lane("a", 10)) })
+	require.Panics(t, func() { mempool.NewLaneMempool(mempool.Lane{Name: "a", MaxBlockSpace: 10}) })
+	require.Panics(t, func() { mempool.NewLaneMempool(lane("a", 0)) })
+	require.Panics(t, func() { mempool.NewLaneMempool(lane("a", 101)) })
+	require.Panics(t, func() { mempool.NewLaneMempool(lane("a", 60), lane("b", 50)) })
+}
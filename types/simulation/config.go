@@ -9,6 +9,7 @@ type Config struct {
 	ExportParamsHeight int    // height to which export the randomly generated params
 	ExportStatePath    string // custom file path to save the exported app state JSON
 	ExportStatsPath    string // custom file path to save the exported simulation statistics JSON
+	GasProfilePath     string // custom file path to save the gas profile of the simulated txs
 
 	Seed               int64  // simulation random seed
 	InitialBlockHeight int    // initial block to start the simulation
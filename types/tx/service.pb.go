@@ -5,12 +5,15 @@ package tx
 
 import (
 	context "context"
+	types1 "cosmossdk.io/store/types"
 	fmt "fmt"
-	types1 "github.com/cometbft/cometbft/proto/tendermint/types"
+	types3 "github.com/cometbft/cometbft/abci/types"
+	types2 "github.com/cometbft/cometbft/proto/tendermint/types"
 	types "github.com/cosmos/cosmos-sdk/types"
 	query "github.com/cosmos/cosmos-sdk/types/query"
 	grpc1 "github.com/cosmos/gogoproto/grpc"
 	proto "github.com/cosmos/gogoproto/proto"
+	any "github.com/cosmos/gogoproto/types/any"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -104,6 +107,58 @@ func (BroadcastMode) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e0b00a618705eca7, []int{1}
 }
 
+// Kind is the kind of a TraceOperation.
+type TraceOperation_Kind int32
+
+const (
+	// KIND_UNSPECIFIED is the default value.
+	TraceOperation_KIND_UNSPECIFIED TraceOperation_Kind = 0
+	// KIND_READ is the read of a key.
+	TraceOperation_KIND_READ TraceOperation_Kind = 1
+	// KIND_HAS is the check of the existence of a key.
+	TraceOperation_KIND_HAS TraceOperation_Kind = 2
+	// KIND_WRITE is the write of a key-value pair.
+	TraceOperation_KIND_WRITE TraceOperation_Kind = 3
+	// KIND_DELETE is the deletion of a key.
+	TraceOperation_KIND_DELETE TraceOperation_Kind = 4
+	// KIND_ITERATE is the read of a key-value pair by an iterator.
+	TraceOperation_KIND_ITERATE TraceOperation_Kind = 5
+	// KIND_MSG is the dispatch of a message through the message router.
+	TraceOperation_KIND_MSG TraceOperation_Kind = 6
+	// KIND_EVENT is an event emitted by a message.
+	TraceOperation_KIND_EVENT TraceOperation_Kind = 7
+)
+
+var TraceOperation_Kind_name = map[int32]string{
+	0: "KIND_UNSPECIFIED",
+	1: "KIND_READ",
+	2: "KIND_HAS",
+	3: "KIND_WRITE",
+	4: "KIND_DELETE",
+	5: "KIND_ITERATE",
+	6: "KIND_MSG",
+	7: "KIND_EVENT",
+}
+
+var TraceOperation_Kind_value = map[string]int32{
+	"KIND_UNSPECIFIED": 0,
+	"KIND_READ":        1,
+	"KIND_HAS":         2,
+	"KIND_WRITE":       3,
+	"KIND_DELETE":      4,
+	"KIND_ITERATE":     5,
+	"KIND_MSG":         6,
+	"KIND_EVENT":       7,
+}
+
+func (x TraceOperation_Kind) String() string {
+	return proto.EnumName(TraceOperation_Kind_name, int32(x))
+}
+
+func (TraceOperation_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{20, 0}
+}
+
 // GetTxsEventRequest is the request type for the Service.TxsByEvents
 // RPC method.
 type GetTxsEventRequest struct {
@@ -393,6 +448,17 @@ type SimulateRequest struct {
 	//
 	// Since: cosmos-sdk 0.43
 	TxBytes []byte `protobuf:"bytes,2,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	// kv_overrides are raw key-value pairs set, or deleted, in the state of the
+	// simulation before the transaction is executed.
+	//
+	// Since: cosmos-sdk 0.51
+	KvOverrides []*types1.StoreKVPair `protobuf:"bytes,3,rep,name=kv_overrides,json=kvOverrides,proto3" json:"kv_overrides,omitempty"`
+	// typed_overrides are changes applied to the state of the simulation before
+	// the transaction is executed, by the handlers registered by the application
+	// for their types, e.g. to set the balance of an account.
+	//
+	// Since: cosmos-sdk 0.51
+	TypedOverrides []*any.Any `protobuf:"bytes,4,rep,name=typed_overrides,json=typedOverrides,proto3" json:"typed_overrides,omitempty"`
 }
 
 func (m *SimulateRequest) Reset()         { *m = SimulateRequest{} }
@@ -443,6 +509,20 @@ func (m *SimulateRequest) GetTxBytes() []byte {
 	return nil
 }
 
+func (m *SimulateRequest) GetKvOverrides() []*types1.StoreKVPair {
+	if m != nil {
+		return m.KvOverrides
+	}
+	return nil
+}
+
+func (m *SimulateRequest) GetTypedOverrides() []*any.Any {
+	if m != nil {
+		return m.TypedOverrides
+	}
+	return nil
+}
+
 // SimulateResponse is the response type for the
 // Service.SimulateRPC method.
 type SimulateResponse struct {
@@ -450,6 +530,11 @@ type SimulateResponse struct {
 	GasInfo *types.GasInfo `protobuf:"bytes,1,opt,name=gas_info,json=gasInfo,proto3" json:"gas_info,omitempty"`
 	// result is the result of the simulation.
 	Result *types.Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// state_diff is the key-value pairs written, or deleted, by the transaction,
+	// excluding the overrides of the request, sorted by store and key.
+	//
+	// Since: cosmos-sdk 0.51
+	StateDiff []*types1.StoreKVPair `protobuf:"bytes,3,rep,name=state_diff,json=stateDiff,proto3" json:"state_diff,omitempty"`
 }
 
 func (m *SimulateResponse) Reset()         { *m = SimulateResponse{} }
@@ -499,6 +584,13 @@ func (m *SimulateResponse) GetResult() *types.Result {
 	return nil
 }
 
+func (m *SimulateResponse) GetStateDiff() []*types1.StoreKVPair {
+	if m != nil {
+		return m.StateDiff
+	}
+	return nil
+}
+
 // GetTxRequest is the request type for the Service.GetTx
 // RPC method.
 type GetTxRequest struct {
@@ -666,8 +758,8 @@ func (m *GetBlockWithTxsRequest) GetPagination() *query.PageRequest {
 type GetBlockWithTxsResponse struct {
 	// txs are the transactions in the block.
 	Txs     []*Tx           `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
-	BlockId *types1.BlockID `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
-	Block   *types1.Block   `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	BlockId *types2.BlockID `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Block   *types2.Block   `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
 	// pagination defines a pagination for the response.
 	Pagination *query.PageResponse `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
 }
@@ -712,14 +804,14 @@ func (m *GetBlockWithTxsResponse) GetTxs() []*Tx {
 	return nil
 }
 
-func (m *GetBlockWithTxsResponse) GetBlockId() *types1.BlockID {
+func (m *GetBlockWithTxsResponse) GetBlockId() *types2.BlockID {
 	if m != nil {
 		return m.BlockId
 	}
 	return nil
 }
 
-func (m *GetBlockWithTxsResponse) GetBlock() *types1.Block {
+func (m *GetBlockWithTxsResponse) GetBlock() *types2.Block {
 	if m != nil {
 		return m.Block
 	}
@@ -1121,9 +1213,274 @@ func (m *TxDecodeAminoResponse) GetAminoJson() string {
 	return ""
 }
 
+// TraceTxRequest is the request type for the Service.TraceTx RPC method.
+//
+// Since: cosmos-sdk 0.51
+type TraceTxRequest struct {
+	// hash is the tx hash to trace, encoded as a hex string.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *TraceTxRequest) Reset()         { *m = TraceTxRequest{} }
+func (m *TraceTxRequest) String() string { return proto.CompactTextString(m) }
+func (*TraceTxRequest) ProtoMessage()    {}
+func (*TraceTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{18}
+}
+func (m *TraceTxRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceTxRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraceTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceTxRequest.Merge(m, src)
+}
+func (m *TraceTxRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceTxRequest proto.InternalMessageInfo
+
+func (m *TraceTxRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// TraceTxResponse is the response type for the Service.TraceTx RPC method.
+//
+// Since: cosmos-sdk 0.51
+type TraceTxResponse struct {
+	// height is the height of the block of the transaction.
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// index is the index of the transaction in its block.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// gas_info is the information about gas used in the re-execution.
+	GasInfo *types.GasInfo `protobuf:"bytes,3,opt,name=gas_info,json=gasInfo,proto3" json:"gas_info,omitempty"`
+	// result is the result of the re-execution, unset if it failed.
+	Result *types.Result `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	// error is the error of the re-execution, empty if it succeeded.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// operations are the operations performed by the transaction, in order.
+	Operations []*TraceOperation `protobuf:"bytes,6,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (m *TraceTxResponse) Reset()         { *m = TraceTxResponse{} }
+func (m *TraceTxResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceTxResponse) ProtoMessage()    {}
+func (*TraceTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{19}
+}
+func (m *TraceTxResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceTxResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraceTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceTxResponse.Merge(m, src)
+}
+func (m *TraceTxResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceTxResponse proto.InternalMessageInfo
+
+func (m *TraceTxResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *TraceTxResponse) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *TraceTxResponse) GetGasInfo() *types.GasInfo {
+	if m != nil {
+		return m.GasInfo
+	}
+	return nil
+}
+
+func (m *TraceTxResponse) GetResult() *types.Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *TraceTxResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *TraceTxResponse) GetOperations() []*TraceOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+// TraceOperation is an operation performed by a traced transaction.
+//
+// Since: cosmos-sdk 0.51
+type TraceOperation struct {
+	// kind is the kind of the operation.
+	Kind TraceOperation_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=cosmos.tx.v1beta1.TraceOperation_Kind" json:"kind,omitempty"`
+	// depth is the number of nested message dispatches the operation happened
+	// in, 0 for the ante and post handlers.
+	Depth uint32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// gas is the gas consumed by the operation. For key-value operations, it is
+	// the gas charged by the gas KV store, and for messages the gas consumed by
+	// their execution.
+	Gas uint64 `protobuf:"varint,3,opt,name=gas,proto3" json:"gas,omitempty"`
+	// store_key is the name of the store of a key-value operation.
+	StoreKey string `protobuf:"bytes,4,opt,name=store_key,json=storeKey,proto3" json:"store_key,omitempty"`
+	// key is the key of a key-value operation.
+	Key []byte `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	// value is the value of a key-value operation, if any.
+	Value []byte `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	// msg_type_url is the type URL of a dispatched message.
+	MsgTypeUrl string `protobuf:"bytes,7,opt,name=msg_type_url,json=msgTypeUrl,proto3" json:"msg_type_url,omitempty"`
+	// error is the error of a dispatched message, empty if it succeeded.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// event is an event emitted by a message.
+	Event *types3.Event `protobuf:"bytes,9,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (m *TraceOperation) Reset()         { *m = TraceOperation{} }
+func (m *TraceOperation) String() string { return proto.CompactTextString(m) }
+func (*TraceOperation) ProtoMessage()    {}
+func (*TraceOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0b00a618705eca7, []int{20}
+}
+func (m *TraceOperation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceOperation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraceOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceOperation.Merge(m, src)
+}
+func (m *TraceOperation) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceOperation proto.InternalMessageInfo
+
+func (m *TraceOperation) GetKind() TraceOperation_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return TraceOperation_KIND_UNSPECIFIED
+}
+
+func (m *TraceOperation) GetDepth() uint32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *TraceOperation) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *TraceOperation) GetStoreKey() string {
+	if m != nil {
+		return m.StoreKey
+	}
+	return ""
+}
+
+func (m *TraceOperation) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TraceOperation) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TraceOperation) GetMsgTypeUrl() string {
+	if m != nil {
+		return m.MsgTypeUrl
+	}
+	return ""
+}
+
+func (m *TraceOperation) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *TraceOperation) GetEvent() *types3.Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("cosmos.tx.v1beta1.OrderBy", OrderBy_name, OrderBy_value)
 	proto.RegisterEnum("cosmos.tx.v1beta1.BroadcastMode", BroadcastMode_name, BroadcastMode_value)
+	proto.RegisterEnum("cosmos.tx.v1beta1.TraceOperation_Kind", TraceOperation_Kind_name, TraceOperation_Kind_value)
 	proto.RegisterType((*GetTxsEventRequest)(nil), "cosmos.tx.v1beta1.GetTxsEventRequest")
 	proto.RegisterType((*GetTxsEventResponse)(nil), "cosmos.tx.v1beta1.GetTxsEventResponse")
 	proto.RegisterType((*BroadcastTxRequest)(nil), "cosmos.tx.v1beta1.BroadcastTxRequest")
@@ -1142,90 +1499,120 @@ func init() {
 	proto.RegisterType((*TxEncodeAminoResponse)(nil), "cosmos.tx.v1beta1.TxEncodeAminoResponse")
 	proto.RegisterType((*TxDecodeAminoRequest)(nil), "cosmos.tx.v1beta1.TxDecodeAminoRequest")
 	proto.RegisterType((*TxDecodeAminoResponse)(nil), "cosmos.tx.v1beta1.TxDecodeAminoResponse")
+	proto.RegisterType((*TraceTxRequest)(nil), "cosmos.tx.v1beta1.TraceTxRequest")
+	proto.RegisterType((*TraceTxResponse)(nil), "cosmos.tx.v1beta1.TraceTxResponse")
+	proto.RegisterType((*TraceOperation)(nil), "cosmos.tx.v1beta1.TraceOperation")
 }
 
 func init() { proto.RegisterFile("cosmos/tx/v1beta1/service.proto", fileDescriptor_e0b00a618705eca7) }
 
 var fileDescriptor_e0b00a618705eca7 = []byte{
-	// 1237 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x4d, 0x4f, 0x1b, 0x47,
-	0x18, 0x66, 0x6d, 0x83, 0xcd, 0x6b, 0x08, 0xce, 0x40, 0xc0, 0x38, 0xc4, 0x98, 0x0d, 0x1f, 0x8e,
-	0x55, 0xbc, 0x0a, 0x4d, 0xaa, 0x24, 0xaa, 0x54, 0xe1, 0x8f, 0x50, 0x92, 0x26, 0x44, 0x6b, 0xaa,
-	0x28, 0x55, 0x25, 0x6b, 0x6d, 0x4f, 0xec, 0x6d, 0xec, 0x5d, 0xe3, 0x19, 0xd0, 0x5a, 0x14, 0xb5,
-	0xea, 0xb1, 0x87, 0xaa, 0x52, 0x0f, 0xfd, 0x0f, 0xfd, 0x25, 0x3d, 0x46, 0xea, 0xa5, 0xc7, 0x0a,
-	0x7a, 0xea, 0xa9, 0x52, 0xff, 0x40, 0xb5, 0x33, 0xb3, 0xb6, 0xd7, 0xde, 0xc5, 0x90, 0x0b, 0xcc,
-	0xc7, 0xf3, 0xbe, 0xcf, 0x33, 0xef, 0xcc, 0x3c, 0x3b, 0x86, 0xd5, 0xaa, 0x49, 0x5a, 0x26, 0x51,
-	0xa8, 0xa5, 0x9c, 0xdc, 0xaf, 0x60, 0xaa, 0xdd, 0x57, 0x08, 0xee, 0x9c, 0xe8, 0x55, 0x9c, 0x6d,
-	0x77, 0x4c, 0x6a, 0xa2, 0x9b, 0x1c, 0x90, 0xa5, 0x56, 0x56, 0x00, 0x12, 0x2b, 0x75, 0xd3, 0xac,
-	0x37, 0xb1, 0xa2, 0xb5, 0x75, 0x45, 0x33, 0x0c, 0x93, 0x6a, 0x54, 0x37, 0x0d, 0xc2, 0x03, 0x12,
-	0x77, 0x45, 0xc6, 0x8a, 0x46, 0xb0, 0xa2, 0x55, 0xaa, 0x7a, 0x2f, 0xb1, 0xdd, 0x11, 0xa0, 0xc4,
-	0x28, 0x2d, 0xb5, 0xc4, 0x5c, 0x66, 0x30, 0xc1, 0xd1, 0x31, 0xee, 0x74, 0x7b, 0x98, 0xb6, 0x56,
-	0xd7, 0x0d, 0xc6, 0x26, 0xb0, 0x2b, 0x14, 0x1b, 0x35, 0xdc, 0x69, 0xe9, 0x06, 0x55, 0x68, 0xb7,
-	0x8d, 0x89, 0x52, 0x69, 0x9a, 0xd5, 0x77, 0xbe, 0xb3, 0xec, 0x2f, 0x9f, 0x95, 0xff, 0x93, 0x00,
-	0xed, 0x61, 0x7a, 0x68, 0x91, 0xe2, 0x09, 0x36, 0xa8, 0x8a, 0x8f, 0x8e, 0x31, 0xa1, 0x28, 0x01,
-	0x53, 0xd8, 0xee, 0x93, 0xb8, 0x94, 0x0a, 0xa6, 0xa7, 0x73, 0x81, 0xb8, 0xa4, 0x8a, 0x11, 0xf4,
-	0x0c, 0xa0, 0x2f, 0x21, 0x1e, 0x48, 0x49, 0xe9, 0xe8, 0xce, 0x66, 0x56, 0x54, 0xc8, 0xd6, 0x9b,
-	0x65, 0x7a, 0x9d, 0x4a, 0x65, 0x5f, 0x69, 0x75, 0x2c, 0xf2, 0xb2, 0x3c, 0x03, 0xd1, 0xe8, 0x21,
-	0x44, 0xcc, 0x4e, 0x0d, 0x77, 0xca, 0x95, 0x6e, 0x3c, 0x98, 0x92, 0xd2, 0x37, 0x76, 0x12, 0xd9,
-	0x91, 0x5a, 0x67, 0x0f, 0x6c, 0x48, 0xae, 0xab, 0x86, 0x4d, 0xde, 0x40, 0x08, 0x42, 0x6d, 0xad,
-	0x8e, 0xe3, 0xa1, 0x94, 0x94, 0x0e, 0xa9, 0xac, 0x8d, 0x16, 0x60, 0xb2, 0xa9, 0xb7, 0x74, 0x1a,
-	0x9f, 0x64, 0x83, 0xbc, 0x63, 0x8f, 0x32, 0x35, 0xf1, 0xa9, 0x94, 0x94, 0x9e, 0x56, 0x79, 0x47,
-	0xfe, 0x47, 0x82, 0x79, 0xd7, 0xaa, 0x49, 0xdb, 0x34, 0x08, 0x46, 0x5b, 0x10, 0xa4, 0x16, 0x5f,
-	0x73, 0x74, 0xe7, 0x96, 0x87, 0x92, 0x43, 0x4b, 0xb5, 0x11, 0x68, 0x0f, 0x66, 0xa8, 0x55, 0xee,
-	0x88, 0x38, 0x12, 0x0f, 0xb0, 0x88, 0x75, 0x57, 0x15, 0xd8, 0x4e, 0x0f, 0x04, 0x0a, 0xb0, 0x1a,
-	0xa5, 0xbd, 0x36, 0x41, 0xcf, 0x5d, 0xc5, 0x0c, 0xb2, 0x62, 0x6e, 0x8d, 0x2d, 0x26, 0x8f, 0x1e,
-	0xa9, 0xe6, 0x02, 0x4c, 0x52, 0x93, 0x6a, 0x4d, 0x51, 0x17, 0xde, 0x91, 0x31, 0xa0, 0x5c, 0xc7,
-	0xd4, 0x6a, 0x55, 0x8d, 0x50, 0x5b, 0x06, 0xdf, 0xe1, 0x65, 0x88, 0x50, 0xab, 0x5c, 0xe9, 0x52,
-	0x6c, 0xaf, 0x57, 0x4a, 0xcf, 0xa8, 0x61, 0x6a, 0xe5, 0xec, 0x2e, 0x7a, 0x00, 0xa1, 0x96, 0x59,
-	0xc3, 0x6c, 0x6b, 0x6f, 0xec, 0xa4, 0x3c, 0xca, 0xd0, 0xcb, 0xf7, 0xc2, 0xac, 0x61, 0x95, 0xa1,
-	0xe5, 0xaf, 0x61, 0xde, 0x45, 0x23, 0x4a, 0x5a, 0x84, 0xe8, 0x40, 0xa5, 0x18, 0xd5, 0x55, 0x0b,
-	0x05, 0xfd, 0x42, 0xc9, 0xaf, 0x61, 0xae, 0xa4, 0xb7, 0x8e, 0x9b, 0x1a, 0x75, 0xce, 0x12, 0xba,
-	0x07, 0x01, 0x6a, 0x89, 0x84, 0xde, 0x7b, 0xc5, 0x0a, 0x14, 0xa0, 0x96, 0x6b, 0xb1, 0x01, 0xd7,
-	0x62, 0xe5, 0x1f, 0x25, 0x88, 0xf5, 0x33, 0x0b, 0xd1, 0x9f, 0x42, 0xa4, 0xae, 0x91, 0xb2, 0x6e,
-	0xbc, 0x35, 0x05, 0xc1, 0x9a, 0xbf, 0xe2, 0x3d, 0x8d, 0xec, 0x1b, 0x6f, 0x4d, 0x35, 0x5c, 0xe7,
-	0x0d, 0xf4, 0x08, 0xa6, 0x3a, 0x98, 0x1c, 0x37, 0xa9, 0xb8, 0x1c, 0x29, 0xff, 0x58, 0x95, 0xe1,
-	0x54, 0x81, 0x97, 0x65, 0x98, 0x61, 0xc7, 0xd2, 0x59, 0x22, 0x82, 0x50, 0x43, 0x23, 0x0d, 0xa6,
-	0x61, 0x5a, 0x65, 0x6d, 0xf9, 0x0c, 0x66, 0x05, 0x46, 0x88, 0xdd, 0x18, 0x5b, 0x07, 0x56, 0x83,
-	0xa1, 0x8d, 0x08, 0x7c, 0xe0, 0x46, 0x58, 0xb0, 0xb8, 0x87, 0x69, 0xce, 0x36, 0x98, 0xd7, 0x3a,
-	0x6d, 0x1c, 0x5a, 0xc4, 0x11, 0xbb, 0x08, 0x53, 0x0d, 0xac, 0xd7, 0x1b, 0x94, 0x69, 0x09, 0xaa,
-	0xa2, 0x87, 0x9e, 0x7e, 0xb8, 0x5f, 0x0c, 0x9e, 0x6e, 0xf9, 0x5f, 0x09, 0x96, 0x46, 0xa8, 0xaf,
-	0x7b, 0x71, 0x1f, 0x40, 0x84, 0x99, 0x63, 0x59, 0xaf, 0x09, 0x29, 0xcb, 0xd9, 0xbe, 0x41, 0x66,
-	0xb9, 0x35, 0x32, 0x8a, 0xfd, 0x82, 0x1a, 0x66, 0xd0, 0xfd, 0x1a, 0xda, 0x86, 0x49, 0xd6, 0x14,
-	0x17, 0x74, 0xc9, 0x27, 0x44, 0xe5, 0x28, 0xb4, 0xe7, 0x5a, 0x71, 0xe8, 0x5a, 0x97, 0xda, 0xb5,
-	0xe4, 0x8f, 0x60, 0xee, 0xd0, 0x2a, 0xe0, 0xaa, 0x7d, 0xcb, 0xc6, 0xde, 0x5b, 0xf9, 0x31, 0xc4,
-	0xfa, 0xe8, 0x6b, 0x1d, 0x0e, 0xf9, 0x91, 0x4d, 0x54, 0x34, 0x06, 0x89, 0xae, 0x18, 0xb9, 0x6d,
-	0x93, 0x3a, 0x91, 0x82, 0xf4, 0x12, 0x8d, 0x0f, 0x61, 0xc1, 0x81, 0xef, 0xb6, 0x74, 0xc3, 0x74,
-	0xd8, 0xee, 0x00, 0x68, 0x76, 0xbf, 0xfc, 0x0d, 0x31, 0x0d, 0x71, 0xde, 0xa7, 0xd9, 0xc8, 0x33,
-	0x62, 0x1a, 0xf2, 0x13, 0xb8, 0x35, 0x14, 0x26, 0xa8, 0xd6, 0x60, 0x86, 0xc7, 0x55, 0x74, 0x43,
-	0xeb, 0x74, 0x05, 0x5d, 0x94, 0x8d, 0xe5, 0xd8, 0x90, 0xfc, 0xd8, 0xa6, 0xe4, 0x65, 0x71, 0x51,
-	0x5e, 0x21, 0xf4, 0x13, 0x9b, 0xd6, 0x15, 0x2a, 0x68, 0x2f, 0x97, 0x9b, 0xf9, 0x1c, 0xc2, 0xe2,
-	0x9b, 0x85, 0xe2, 0xb0, 0x70, 0xa0, 0x16, 0x8a, 0x6a, 0x39, 0xf7, 0xa6, 0xfc, 0xe5, 0xcb, 0xd2,
-	0xab, 0x62, 0x7e, 0xff, 0xe9, 0x7e, 0xb1, 0x10, 0x9b, 0x40, 0x31, 0x98, 0xe9, 0xcd, 0xec, 0x96,
-	0xf2, 0x31, 0x09, 0xdd, 0x84, 0xd9, 0xde, 0x48, 0xa1, 0x58, 0xca, 0xc7, 0x02, 0x99, 0xef, 0x25,
-	0x98, 0x75, 0xb9, 0x2d, 0x4a, 0x42, 0x22, 0xa7, 0x1e, 0xec, 0x16, 0xf2, 0xbb, 0xa5, 0xc3, 0xf2,
-	0x8b, 0x83, 0x42, 0x71, 0x28, 0xed, 0x0a, 0x2c, 0x0c, 0xcd, 0xe7, 0xbe, 0x38, 0xc8, 0x3f, 0x8f,
-	0x49, 0x89, 0x40, 0x44, 0x42, 0x4b, 0x30, 0x3f, 0x34, 0x5b, 0x7a, 0xf3, 0x32, 0x1f, 0x0b, 0xd8,
-	0x3a, 0x87, 0x26, 0x76, 0xd9, 0x4c, 0x70, 0xe7, 0xb7, 0x69, 0x08, 0x97, 0xf8, 0x73, 0x08, 0x9d,
-	0x42, 0xc4, 0x31, 0x4b, 0x24, 0x7b, 0x1c, 0x8a, 0x21, 0x8f, 0x4e, 0xdc, 0xbd, 0x14, 0x23, 0x2c,
-	0x65, 0xf3, 0x87, 0x3f, 0xfe, 0xfe, 0x25, 0x90, 0x7a, 0x22, 0x65, 0xe4, 0xdb, 0x8a, 0xc7, 0x53,
-	0xcc, 0x21, 0x3c, 0x82, 0x49, 0xe6, 0x7c, 0x68, 0xd5, 0x23, 0xeb, 0xa0, 0x6f, 0x26, 0x52, 0xfe,
-	0x00, 0xc1, 0xb9, 0xc1, 0x38, 0x57, 0xd1, 0x1d, 0xc5, 0xeb, 0x11, 0x46, 0x94, 0x53, 0xdb, 0x6b,
-	0xcf, 0xd0, 0x77, 0x10, 0x1d, 0xf8, 0xa8, 0xa1, 0x8d, 0xcb, 0xbe, 0x85, 0x7d, 0xfa, 0xcd, 0x71,
-	0x30, 0x21, 0x62, 0x8d, 0x89, 0xb8, 0x6d, 0x2f, 0x7c, 0xd1, 0x5b, 0x07, 0xfa, 0x16, 0xa2, 0x03,
-	0x0f, 0x15, 0x4f, 0x01, 0xa3, 0xcf, 0x37, 0x4f, 0x01, 0x1e, 0xef, 0x1d, 0x39, 0xc9, 0x04, 0xc4,
-	0x91, 0x1f, 0xfb, 0xaf, 0x12, 0xcc, 0x0d, 0x59, 0x2e, 0xba, 0xe7, 0x9d, 0xdb, 0xe3, 0x8b, 0x90,
-	0xc8, 0x5c, 0x05, 0x2a, 0xa4, 0x6c, 0x33, 0x29, 0x5b, 0x68, 0xc3, 0x67, 0x43, 0x98, 0xb3, 0x2a,
-	0xa7, 0xfc, 0x9b, 0x72, 0x86, 0xba, 0x10, 0x71, 0x6e, 0xa6, 0xe7, 0x41, 0x1c, 0xb2, 0x4d, 0xcf,
-	0x83, 0x38, 0x6c, 0x96, 0xf2, 0x3a, 0xd3, 0x90, 0xb4, 0xf7, 0x63, 0xd9, 0x43, 0x46, 0x8d, 0xd3,
-	0x31, 0x6a, 0xee, 0x45, 0x3e, 0xd4, 0x2e, 0x23, 0xf5, 0xa1, 0x76, 0x5b, 0xe6, 0x38, 0x6a, 0xcc,
-	0xe9, 0x7e, 0x92, 0x60, 0xd6, 0xe5, 0x83, 0x68, 0xeb, 0x92, 0xe4, 0x83, 0x6e, 0x97, 0x48, 0x8f,
-	0x07, 0x0a, 0x29, 0x19, 0x26, 0x65, 0xdd, 0x96, 0xb2, 0xea, 0x2b, 0x45, 0x61, 0x66, 0x27, 0x04,
-	0x0d, 0x38, 0xa4, 0x8f, 0xa0, 0x51, 0xfb, 0xf5, 0x11, 0xe4, 0x61, 0xb6, 0xe3, 0x04, 0xf1, 0x6d,
-	0xe1, 0x82, 0x72, 0x9f, 0xfd, 0x7e, 0x9e, 0x94, 0xde, 0x9f, 0x27, 0xa5, 0xbf, 0xce, 0x93, 0xd2,
-	0xcf, 0x17, 0xc9, 0x89, 0xf7, 0x17, 0xc9, 0x89, 0x3f, 0x2f, 0x92, 0x13, 0x5f, 0x6d, 0xd4, 0x75,
-	0xda, 0x38, 0xae, 0x64, 0xab, 0x66, 0xcb, 0x49, 0xc2, 0xff, 0x6d, 0x93, 0xda, 0x3b, 0xe7, 0x97,
-	0x91, 0x55, 0x99, 0x62, 0xbf, 0x8b, 0x3e, 0xfe, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x42, 0xa8, 0xd7,
-	0x97, 0x14, 0x0e, 0x00, 0x00,
+	// 1679 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x4f, 0x6f, 0xe3, 0xc6,
+	0x15, 0x5f, 0xea, 0x8f, 0x25, 0x3d, 0xc9, 0x36, 0x33, 0xab, 0xf5, 0xca, 0xf2, 0x46, 0x96, 0xe9,
+	0xbf, 0x71, 0x63, 0x09, 0x71, 0x93, 0x22, 0x59, 0xb4, 0x68, 0x25, 0x8b, 0x71, 0x14, 0x67, 0xed,
+	0x05, 0xa5, 0x24, 0x48, 0x51, 0x40, 0xa0, 0xc4, 0xb1, 0xcc, 0x5a, 0x22, 0xb5, 0xe4, 0xc8, 0xa0,
+	0xb0, 0x35, 0x5a, 0xf4, 0xd8, 0x43, 0x11, 0xa0, 0x87, 0x7e, 0x86, 0x02, 0xfd, 0x20, 0x3d, 0xf4,
+	0x10, 0xa0, 0x97, 0x1e, 0x7a, 0x28, 0x76, 0x7b, 0xea, 0xa9, 0x40, 0xbf, 0x40, 0xc1, 0xc7, 0xa1,
+	0x44, 0xc9, 0xfa, 0x63, 0xa7, 0x27, 0xcd, 0x9b, 0xf9, 0xbd, 0xf7, 0x7e, 0xef, 0xcd, 0xcc, 0x9b,
+	0x47, 0xc1, 0x66, 0xcb, 0xb4, 0xbb, 0xa6, 0x5d, 0x64, 0x4e, 0xf1, 0xe6, 0x83, 0x26, 0x65, 0xea,
+	0x07, 0x45, 0x9b, 0x5a, 0x37, 0x7a, 0x8b, 0x16, 0x7a, 0x96, 0xc9, 0x4c, 0xf2, 0x8e, 0x07, 0x28,
+	0x30, 0xa7, 0xc0, 0x01, 0xd9, 0x67, 0x6d, 0xd3, 0x6c, 0x77, 0x68, 0x51, 0xed, 0xe9, 0x45, 0xd5,
+	0x30, 0x4c, 0xa6, 0x32, 0xdd, 0x34, 0x6c, 0x4f, 0x21, 0xbb, 0xce, 0x57, 0x51, 0x6a, 0xf6, 0x2f,
+	0x8b, 0xaa, 0x31, 0xe0, 0x4b, 0xdb, 0xdc, 0x59, 0x53, 0xb5, 0x69, 0x51, 0x6d, 0xb6, 0xf4, 0xa1,
+	0x4f, 0x57, 0xe0, 0xa0, 0x1d, 0x0e, 0xb2, 0x99, 0x69, 0xd1, 0x21, 0xa0, 0xa3, 0xdb, 0x8c, 0x1a,
+	0xba, 0xd1, 0xe6, 0xa8, 0xec, 0x5d, 0xde, 0xcc, 0xe1, 0x6b, 0x87, 0x41, 0x37, 0xaf, 0xfa, 0xd4,
+	0x1a, 0x0c, 0x31, 0x3d, 0xb5, 0xad, 0x1b, 0x48, 0x97, 0x63, 0x37, 0x18, 0x35, 0x34, 0x6a, 0x75,
+	0x75, 0x83, 0x79, 0x8c, 0xd8, 0xa0, 0x47, 0xfd, 0x50, 0x9e, 0x05, 0x16, 0x71, 0xbe, 0xd8, 0xec,
+	0x98, 0xad, 0xeb, 0x99, 0xab, 0x01, 0x5d, 0xe9, 0xbf, 0x02, 0x90, 0x53, 0xca, 0xea, 0x8e, 0x2d,
+	0xdf, 0x50, 0x83, 0x29, 0xf4, 0x55, 0x9f, 0xda, 0x8c, 0x64, 0x61, 0x89, 0xba, 0xb2, 0x9d, 0x11,
+	0xf2, 0xe1, 0x83, 0x44, 0x39, 0x94, 0x11, 0x14, 0x3e, 0x43, 0x3e, 0x07, 0x18, 0xf1, 0xcb, 0x84,
+	0xf2, 0xc2, 0x41, 0xf2, 0x78, 0xaf, 0xc0, 0xf3, 0xef, 0x06, 0x53, 0xc0, 0x60, 0xfc, 0x7d, 0x28,
+	0xbc, 0x54, 0xdb, 0x94, 0xdb, 0x45, 0x3b, 0x01, 0x6d, 0xf2, 0x11, 0xc4, 0x4d, 0x4b, 0xa3, 0x56,
+	0xa3, 0x39, 0xc8, 0x84, 0xf3, 0xc2, 0xc1, 0xca, 0x71, 0xb6, 0x70, 0x67, 0x27, 0x0b, 0x17, 0x2e,
+	0xa4, 0x3c, 0x50, 0x62, 0xa6, 0x37, 0x20, 0x04, 0x22, 0x3d, 0xb5, 0x4d, 0x33, 0x91, 0xbc, 0x70,
+	0x10, 0x51, 0x70, 0x4c, 0xd2, 0x10, 0xed, 0xe8, 0x5d, 0x9d, 0x65, 0xa2, 0x38, 0xe9, 0x09, 0xee,
+	0x2c, 0xb2, 0xc9, 0x2c, 0xe5, 0x85, 0x83, 0x84, 0xe2, 0x09, 0xd2, 0xbf, 0x05, 0x78, 0x3c, 0x16,
+	0xb5, 0xdd, 0x33, 0x0d, 0x9b, 0x92, 0x7d, 0x08, 0x33, 0xc7, 0x8b, 0x39, 0x79, 0xfc, 0x64, 0x0a,
+	0x93, 0xba, 0xa3, 0xb8, 0x08, 0x72, 0x0a, 0x29, 0xe6, 0x34, 0x2c, 0xae, 0x67, 0x67, 0x42, 0xa8,
+	0xb1, 0x33, 0x96, 0x05, 0x3c, 0x2c, 0x01, 0x45, 0x0e, 0x56, 0x92, 0x6c, 0x38, 0xb6, 0xc9, 0xd9,
+	0x58, 0x32, 0xc3, 0x98, 0xcc, 0xfd, 0x85, 0xc9, 0xf4, 0xb4, 0xef, 0x64, 0x33, 0x0d, 0x51, 0x66,
+	0x32, 0xb5, 0xc3, 0xf3, 0xe2, 0x09, 0x12, 0x05, 0x52, 0xb6, 0x4c, 0x55, 0x6b, 0xa9, 0x36, 0xab,
+	0x3b, 0x7c, 0x27, 0xc8, 0x3a, 0xc4, 0x99, 0xd3, 0x68, 0x0e, 0x18, 0x75, 0xe3, 0x15, 0x0e, 0x52,
+	0x4a, 0x8c, 0x39, 0x65, 0x57, 0x24, 0x1f, 0x42, 0xa4, 0x6b, 0x6a, 0x14, 0xb7, 0x76, 0xe5, 0x38,
+	0x3f, 0x25, 0x0d, 0x43, 0x7b, 0x2f, 0x4c, 0x8d, 0x2a, 0x88, 0x96, 0x7e, 0x01, 0x8f, 0xc7, 0xdc,
+	0xf0, 0x94, 0xca, 0x90, 0x0c, 0x64, 0x0a, 0x5d, 0xdd, 0x37, 0x51, 0x30, 0x4a, 0x94, 0xf4, 0x0f,
+	0x01, 0x56, 0x6b, 0x7a, 0xb7, 0xdf, 0x51, 0x99, 0x7f, 0x98, 0xc8, 0x7b, 0x10, 0x62, 0x0e, 0xb7,
+	0x38, 0x7d, 0xb3, 0x30, 0x43, 0x21, 0xe6, 0x8c, 0x45, 0x1b, 0x1a, 0x8f, 0xb6, 0x02, 0xa9, 0xeb,
+	0x9b, 0x86, 0x79, 0x43, 0x2d, 0x4b, 0xd7, 0xa8, 0x9d, 0x09, 0xe3, 0x56, 0x6e, 0xf9, 0xf6, 0xf0,
+	0x7e, 0x0f, 0x4d, 0xd6, 0x5c, 0xe9, 0xec, 0xab, 0x97, 0xaa, 0x6e, 0x29, 0xc9, 0xeb, 0x9b, 0x0b,
+	0x5f, 0x8b, 0xfc, 0x04, 0x56, 0xdd, 0x6b, 0xa5, 0x05, 0x0c, 0x45, 0xd0, 0x50, 0xba, 0xe0, 0x15,
+	0x9a, 0x82, 0x5f, 0x68, 0x0a, 0x25, 0x63, 0xa0, 0xac, 0x20, 0x78, 0xa8, 0x2e, 0xfd, 0x55, 0x00,
+	0x71, 0x14, 0x1e, 0x4f, 0xdd, 0x8f, 0x21, 0xde, 0x56, 0xed, 0x86, 0x6e, 0x5c, 0x9a, 0x3c, 0xca,
+	0xad, 0xd9, 0x79, 0x3b, 0x55, 0xed, 0xaa, 0x71, 0x69, 0x2a, 0xb1, 0xb6, 0x37, 0x20, 0x1f, 0xc3,
+	0x92, 0x45, 0xed, 0x7e, 0x87, 0xf1, 0x2b, 0x9a, 0x9f, 0xad, 0xab, 0x20, 0x4e, 0xe1, 0x78, 0xf2,
+	0x33, 0x00, 0x9b, 0xa9, 0x8c, 0x36, 0x34, 0xfd, 0xf2, 0xf2, 0xfe, 0xf9, 0x48, 0xa0, 0x52, 0x45,
+	0xbf, 0xbc, 0x94, 0x24, 0x48, 0xe1, 0xf5, 0xf2, 0x77, 0x8a, 0x40, 0xe4, 0x4a, 0xb5, 0xaf, 0x30,
+	0x8a, 0x84, 0x82, 0x63, 0xe9, 0x16, 0x96, 0x39, 0x86, 0x87, 0xbb, 0xbb, 0x70, 0x3b, 0x71, 0x2b,
+	0x27, 0x0e, 0x54, 0xe8, 0x7b, 0x1e, 0x28, 0x07, 0xd6, 0x4e, 0x29, 0x2b, 0xbb, 0x85, 0xf2, 0x6b,
+	0x9d, 0x5d, 0xd5, 0x1d, 0xdb, 0x27, 0xbb, 0x06, 0x4b, 0x57, 0x54, 0x6f, 0x5f, 0x31, 0xe4, 0x12,
+	0x56, 0xb8, 0x44, 0x3e, 0xfd, 0xfe, 0x75, 0x2f, 0x78, 0x4b, 0xa5, 0xff, 0x08, 0xf0, 0xf4, 0x8e,
+	0xeb, 0x87, 0x16, 0xa0, 0x0f, 0x21, 0x8e, 0x45, 0xbe, 0xa1, 0x6b, 0x9c, 0xca, 0x7a, 0x61, 0x54,
+	0xe8, 0x0b, 0x5e, 0x89, 0x47, 0x17, 0xd5, 0x8a, 0x12, 0x43, 0x68, 0x55, 0x23, 0x47, 0x10, 0xc5,
+	0x21, 0x2f, 0x34, 0x4f, 0x67, 0xa8, 0x28, 0x1e, 0x8a, 0x9c, 0x8e, 0x45, 0x1c, 0x79, 0x50, 0x71,
+	0x1a, 0x0b, 0xf9, 0x7d, 0x58, 0xad, 0x3b, 0x15, 0xda, 0x32, 0x35, 0x3f, 0x23, 0x73, 0xea, 0x8f,
+	0xf4, 0x09, 0x88, 0x23, 0xf4, 0x83, 0x0e, 0x87, 0xf4, 0xb1, 0xeb, 0x48, 0x36, 0x82, 0x8e, 0xee,
+	0xa9, 0x79, 0x04, 0xe2, 0x48, 0x93, 0x3b, 0x9d, 0xc3, 0xf1, 0x23, 0x48, 0xfb, 0xf0, 0x52, 0x57,
+	0x37, 0x4c, 0xdf, 0xdb, 0xbb, 0x00, 0xaa, 0x2b, 0x37, 0x7e, 0x69, 0x9b, 0x06, 0x3f, 0xef, 0x09,
+	0x9c, 0xf9, 0xdc, 0x36, 0x0d, 0xe9, 0x39, 0x3c, 0x99, 0x50, 0xe3, 0xae, 0xb6, 0x20, 0xe5, 0xe9,
+	0x35, 0x75, 0x43, 0xb5, 0x06, 0xdc, 0x5d, 0x12, 0xe7, 0xca, 0x38, 0x25, 0x7d, 0x02, 0x69, 0x3f,
+	0x2d, 0x63, 0x2e, 0xef, 0xa1, 0xfa, 0x23, 0x78, 0x32, 0xa1, 0xca, 0xdd, 0x2e, 0xa0, 0xbb, 0x03,
+	0x2b, 0x75, 0x4b, 0x6d, 0xd1, 0xf9, 0x37, 0xf9, 0xdb, 0x10, 0xac, 0x0e, 0x61, 0xdc, 0xf0, 0xac,
+	0x4b, 0x94, 0x86, 0xa8, 0x6e, 0x68, 0xd4, 0xc1, 0x43, 0xbb, 0xac, 0x78, 0xc2, 0x58, 0xa5, 0x0b,
+	0xff, 0x1f, 0x95, 0x2e, 0xf2, 0xc0, 0x4a, 0x97, 0x86, 0x28, 0xb5, 0x2c, 0xd3, 0xc2, 0x9e, 0x21,
+	0xa1, 0x78, 0x02, 0x29, 0x01, 0x98, 0x3d, 0x6a, 0x79, 0xed, 0x62, 0x66, 0x69, 0xbc, 0xfe, 0x05,
+	0x4f, 0x8e, 0x1b, 0xf3, 0x85, 0x8f, 0x54, 0x02, 0x4a, 0xd2, 0x9f, 0xc3, 0xb0, 0x32, 0xbe, 0x4c,
+	0x9e, 0x43, 0xe4, 0x5a, 0x37, 0x34, 0xcc, 0xc7, 0xca, 0xf1, 0xde, 0x42, 0x7b, 0x85, 0x33, 0xdd,
+	0xd0, 0x14, 0xd4, 0x71, 0x79, 0x6a, 0xb4, 0xc7, 0xae, 0xfc, 0xac, 0xa1, 0x40, 0x44, 0x08, 0xb7,
+	0x55, 0x1b, 0x13, 0x16, 0x51, 0xdc, 0x21, 0xd9, 0x80, 0x04, 0xd6, 0xe7, 0xc6, 0x35, 0x1d, 0x60,
+	0x32, 0x12, 0x4a, 0x1c, 0x27, 0xce, 0xe8, 0xc0, 0x85, 0xbb, 0xd3, 0x51, 0x3c, 0x1e, 0xee, 0xd0,
+	0x35, 0x7b, 0xa3, 0x76, 0xfa, 0x14, 0x9b, 0xa3, 0x94, 0xe2, 0x09, 0x24, 0x0f, 0xa9, 0xae, 0xdd,
+	0x6e, 0xb8, 0xf5, 0xa0, 0xd1, 0xb7, 0x3a, 0x99, 0x18, 0xda, 0x81, 0xae, 0xdd, 0xae, 0x0f, 0x7a,
+	0xf4, 0x4b, 0xab, 0x33, 0x4a, 0x5b, 0x3c, 0x98, 0xb6, 0xf7, 0x21, 0x8a, 0x1d, 0x62, 0x26, 0x81,
+	0xbb, 0xb0, 0x16, 0x2c, 0x2e, 0xb8, 0x09, 0x5e, 0xaf, 0xe5, 0x81, 0xa4, 0xdf, 0x09, 0x10, 0x39,
+	0xf3, 0x62, 0x13, 0xcf, 0xaa, 0xe7, 0x95, 0xc6, 0x97, 0xe7, 0xb5, 0x97, 0xf2, 0x49, 0xf5, 0xd3,
+	0xaa, 0x5c, 0x11, 0x1f, 0x91, 0x65, 0x48, 0xe0, 0xac, 0x22, 0x97, 0x2a, 0xa2, 0x40, 0x52, 0x10,
+	0x47, 0xf1, 0xb3, 0x52, 0x4d, 0x0c, 0x91, 0x15, 0x00, 0x94, 0xbe, 0x56, 0xaa, 0x75, 0x59, 0x0c,
+	0x93, 0x55, 0x48, 0xa2, 0x5c, 0x91, 0xbf, 0x90, 0xeb, 0xb2, 0x18, 0x21, 0x22, 0xa4, 0x70, 0xa2,
+	0x5a, 0x97, 0x95, 0x52, 0x5d, 0x16, 0xa3, 0x43, 0x03, 0x2f, 0x6a, 0xa7, 0xe2, 0xd2, 0xd0, 0x80,
+	0xfc, 0x95, 0x7c, 0x5e, 0x17, 0x63, 0x87, 0x9f, 0x41, 0x8c, 0xf7, 0x98, 0x24, 0x03, 0xe9, 0x0b,
+	0xa5, 0x22, 0x2b, 0x8d, 0xf2, 0x37, 0x13, 0x94, 0x44, 0x48, 0x0d, 0x57, 0x4a, 0xb5, 0x13, 0x51,
+	0x20, 0xef, 0xc0, 0xf2, 0x70, 0xa6, 0x22, 0xd7, 0x4e, 0xc4, 0xd0, 0xe1, 0x6f, 0x04, 0x58, 0x1e,
+	0xeb, 0x8e, 0x48, 0x0e, 0xb2, 0x65, 0xe5, 0xa2, 0x54, 0x39, 0x29, 0xd5, 0xea, 0x8d, 0x17, 0x17,
+	0x15, 0x79, 0xc2, 0xec, 0x33, 0x48, 0x4f, 0xac, 0x97, 0xbf, 0xb8, 0x38, 0x39, 0x13, 0x85, 0x6c,
+	0x28, 0x2e, 0x90, 0xa7, 0xf0, 0x78, 0x62, 0xb5, 0xf6, 0xcd, 0xf9, 0x89, 0x18, 0x72, 0x79, 0x4e,
+	0x2c, 0x94, 0x70, 0x25, 0x7c, 0xfc, 0x27, 0x80, 0x58, 0xcd, 0xfb, 0x38, 0x22, 0xaf, 0x21, 0xee,
+	0xb7, 0x15, 0x44, 0x9a, 0x72, 0xe4, 0x26, 0x5a, 0xaa, 0xec, 0xf6, 0x5c, 0x0c, 0x7f, 0x3a, 0xf7,
+	0x7e, 0xfb, 0xb7, 0x7f, 0xfd, 0x21, 0x94, 0x7f, 0x2e, 0x1c, 0x4a, 0x1b, 0xc5, 0x29, 0x1f, 0x66,
+	0xbe, 0xc3, 0x57, 0x10, 0xc5, 0x17, 0x9e, 0x6c, 0x4e, 0xb1, 0x1a, 0xec, 0x0f, 0xb2, 0xf9, 0xd9,
+	0x00, 0xee, 0x73, 0x17, 0x7d, 0x6e, 0x92, 0x77, 0x8b, 0xd3, 0xbe, 0xa8, 0xec, 0xe2, 0x6b, 0xb7,
+	0x12, 0xdd, 0x92, 0x5f, 0x43, 0x32, 0xd0, 0x84, 0x92, 0xdd, 0x79, 0xbd, 0xeb, 0xc8, 0xfd, 0xde,
+	0x22, 0x18, 0x27, 0xb1, 0x85, 0x24, 0x36, 0xdc, 0xc0, 0xd7, 0xa6, 0xf3, 0x20, 0xbf, 0x82, 0x64,
+	0xe0, 0xc3, 0x62, 0x2a, 0x81, 0xbb, 0x9f, 0x5b, 0xd9, 0xbd, 0x45, 0x30, 0x4e, 0x20, 0x87, 0x04,
+	0x32, 0x64, 0x96, 0xf7, 0x3f, 0x0a, 0xb0, 0x3a, 0xd1, 0x5a, 0x90, 0xf7, 0xa6, 0xdb, 0x9e, 0xd2,
+	0xf9, 0x64, 0x0f, 0xef, 0x03, 0xe5, 0x54, 0x8e, 0x90, 0xca, 0x3e, 0xd9, 0x9d, 0xb1, 0x21, 0xd8,
+	0x41, 0x14, 0x5f, 0x7b, 0x65, 0xff, 0x96, 0x0c, 0x20, 0xee, 0xbf, 0x40, 0x53, 0x0f, 0xe2, 0x44,
+	0x7b, 0x90, 0xdd, 0x9e, 0x8b, 0xe1, 0x1c, 0x76, 0x90, 0x43, 0xce, 0xdd, 0x8f, 0xf5, 0x29, 0x34,
+	0x34, 0xcf, 0x1d, 0xba, 0x96, 0x8d, 0x39, 0xae, 0x65, 0x63, 0xb1, 0x6b, 0xd9, 0x78, 0x88, 0x6b,
+	0xea, 0xb9, 0xfb, 0xbd, 0x00, 0xcb, 0x63, 0xef, 0x3d, 0xd9, 0x9f, 0x63, 0x3c, 0xf8, 0xaa, 0x67,
+	0x0f, 0x16, 0x03, 0x39, 0x95, 0x43, 0xa4, 0xb2, 0xe3, 0x52, 0xd9, 0x9c, 0x49, 0xa5, 0x88, 0x8f,
+	0x3a, 0x27, 0x54, 0xa1, 0x8b, 0x08, 0x55, 0xe8, 0x3d, 0x09, 0x55, 0xe8, 0x83, 0x09, 0x69, 0x34,
+	0x40, 0xe8, 0x16, 0x62, 0xbc, 0x75, 0x20, 0x33, 0x9f, 0xd8, 0xd1, 0x45, 0x95, 0xe6, 0x41, 0xb8,
+	0xf7, 0x1f, 0xa0, 0xf7, 0x5d, 0xb2, 0x3d, 0xb7, 0x52, 0x14, 0x99, 0xab, 0x56, 0xfe, 0xe9, 0x5f,
+	0xde, 0xe4, 0x84, 0xef, 0xde, 0xe4, 0x84, 0x7f, 0xbe, 0xc9, 0x09, 0xdf, 0xbe, 0xcd, 0x3d, 0xfa,
+	0xee, 0x6d, 0xee, 0xd1, 0xdf, 0xdf, 0xe6, 0x1e, 0xfd, 0x7c, 0xb7, 0xad, 0xb3, 0xab, 0x7e, 0xb3,
+	0xd0, 0x32, 0xbb, 0xbe, 0x21, 0xef, 0xe7, 0xc8, 0xd6, 0xae, 0xfd, 0x3f, 0x52, 0x9c, 0xe6, 0x12,
+	0x7e, 0xd6, 0xfd, 0xf0, 0x7f, 0x03, 0x00, 0xf3, 0x7a, 0xa2, 0xae, 0xa1, 0x12, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1268,6 +1655,11 @@ type ServiceClient interface {
 	//
 	// Since: cosmos-sdk 0.47
 	TxDecodeAmino(ctx context.Context, in *TxDecodeAminoRequest, opts ...grpc.CallOption) (*TxDecodeAminoResponse, error)
+	// TraceTx re-executes a transaction of a past block on the state of the
+	// previous block, and returns a trace of its execution.
+	//
+	// Since: cosmos-sdk 0.51
+	TraceTx(ctx context.Context, in *TraceTxRequest, opts ...grpc.CallOption) (*TraceTxResponse, error)
 }
 
 type serviceClient struct {
@@ -1359,6 +1751,15 @@ func (c *serviceClient) TxDecodeAmino(ctx context.Context, in *TxDecodeAminoRequ
 	return out, nil
 }
 
+func (c *serviceClient) TraceTx(ctx context.Context, in *TraceTxRequest, opts ...grpc.CallOption) (*TraceTxResponse, error) {
+	out := new(TraceTxResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.tx.v1beta1.Service/TraceTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 type ServiceServer interface {
 	// Simulate simulates executing a transaction for estimating gas usage.
@@ -1389,6 +1790,11 @@ type ServiceServer interface {
 	//
 	// Since: cosmos-sdk 0.47
 	TxDecodeAmino(context.Context, *TxDecodeAminoRequest) (*TxDecodeAminoResponse, error)
+	// TraceTx re-executes a transaction of a past block on the state of the
+	// previous block, and returns a trace of its execution.
+	//
+	// Since: cosmos-sdk 0.51
+	TraceTx(context.Context, *TraceTxRequest) (*TraceTxResponse, error)
 }
 
 // UnimplementedServiceServer can be embedded to have forward compatible implementations.
@@ -1422,6 +1828,9 @@ func (*UnimplementedServiceServer) TxEncodeAmino(ctx context.Context, req *TxEnc
 func (*UnimplementedServiceServer) TxDecodeAmino(ctx context.Context, req *TxDecodeAminoRequest) (*TxDecodeAminoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TxDecodeAmino not implemented")
 }
+func (*UnimplementedServiceServer) TraceTx(ctx context.Context, req *TraceTxRequest) (*TraceTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TraceTx not implemented")
+}
 
 func RegisterServiceServer(s grpc1.Server, srv ServiceServer) {
 	s.RegisterService(&_Service_serviceDesc, srv)
@@ -1589,6 +1998,24 @@ func _Service_TxDecodeAmino_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_TraceTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).TraceTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.tx.v1beta1.Service/TraceTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).TraceTx(ctx, req.(*TraceTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Service_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cosmos.tx.v1beta1.Service",
 	HandlerType: (*ServiceServer)(nil),
@@ -1629,6 +2056,10 @@ var _Service_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TxDecodeAmino",
 			Handler:    _Service_TxDecodeAmino_Handler,
 		},
+		{
+			MethodName: "TraceTx",
+			Handler:    _Service_TraceTx_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cosmos/tx/v1beta1/service.proto",
@@ -1858,6 +2289,34 @@ func (m *SimulateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.TypedOverrides) > 0 {
+		for iNdEx := len(m.TypedOverrides) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TypedOverrides[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.KvOverrides) > 0 {
+		for iNdEx := len(m.KvOverrides) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.KvOverrides[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.TxBytes) > 0 {
 		i -= len(m.TxBytes)
 		copy(dAtA[i:], m.TxBytes)
@@ -1900,6 +2359,20 @@ func (m *SimulateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.StateDiff) > 0 {
+		for iNdEx := len(m.StateDiff) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StateDiff[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if m.Result != nil {
 		{
 			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
@@ -2367,24 +2840,217 @@ func (m *TxDecodeAminoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintService(dAtA []byte, offset int, v uint64) int {
-	offset -= sovService(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *TraceTxRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *GetTxsEventRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *TraceTxRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TraceTxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Events) > 0 {
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TraceTxResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraceTxResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TraceTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for iNdEx := len(m.Operations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Operations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.GasInfo != nil {
+		{
+			size, err := m.GasInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Index != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TraceOperation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraceOperation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TraceOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Event != nil {
+		{
+			size, err := m.Event.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.MsgTypeUrl) > 0 {
+		i -= len(m.MsgTypeUrl)
+		copy(dAtA[i:], m.MsgTypeUrl)
+		i = encodeVarintService(dAtA, i, uint64(len(m.MsgTypeUrl)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.StoreKey) > 0 {
+		i -= len(m.StoreKey)
+		copy(dAtA[i:], m.StoreKey)
+		i = encodeVarintService(dAtA, i, uint64(len(m.StoreKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Gas != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Gas))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Depth != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Depth))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Kind != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Kind))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintService(dAtA []byte, offset int, v uint64) int {
+	offset -= sovService(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetTxsEventRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
 		for _, s := range m.Events {
 			l = len(s)
 			n += 1 + l + sovService(uint64(l))
@@ -2481,6 +3147,18 @@ func (m *SimulateRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovService(uint64(l))
 	}
+	if len(m.KvOverrides) > 0 {
+		for _, e := range m.KvOverrides {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.TypedOverrides) > 0 {
+		for _, e := range m.TypedOverrides {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2498,6 +3176,12 @@ func (m *SimulateResponse) Size() (n int) {
 		l = m.Result.Size()
 		n += 1 + l + sovService(uint64(l))
 	}
+	if len(m.StateDiff) > 0 {
+		for _, e := range m.StateDiff {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2678,6 +3362,94 @@ func (m *TxDecodeAminoResponse) Size() (n int) {
 	return n
 }
 
+func (m *TraceTxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
+func (m *TraceTxResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovService(uint64(m.Height))
+	}
+	if m.Index != 0 {
+		n += 1 + sovService(uint64(m.Index))
+	}
+	if m.GasInfo != nil {
+		l = m.GasInfo.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Operations) > 0 {
+		for _, e := range m.Operations {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TraceOperation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Kind != 0 {
+		n += 1 + sovService(uint64(m.Kind))
+	}
+	if m.Depth != 0 {
+		n += 1 + sovService(uint64(m.Depth))
+	}
+	if m.Gas != 0 {
+		n += 1 + sovService(uint64(m.Gas))
+	}
+	l = len(m.StoreKey)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.MsgTypeUrl)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Event != nil {
+		l = m.Event.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	return n
+}
+
 func sovService(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -3352,23 +4124,91 @@ func (m *SimulateRequest) Unmarshal(dAtA []byte) error {
 				m.TxBytes = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KvOverrides", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
+			m.KvOverrides = append(m.KvOverrides, &types1.StoreKVPair{})
+			if err := m.KvOverrides[len(m.KvOverrides)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypedOverrides", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TypedOverrides = append(m.TypedOverrides, &any.Any{})
+			if err := m.TypedOverrides[len(m.TypedOverrides)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
@@ -3474,6 +4314,40 @@ func (m *SimulateResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateDiff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StateDiff = append(m.StateDiff, &types1.StoreKVPair{})
+			if err := m.StateDiff[len(m.StateDiff)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3897,7 +4771,7 @@ func (m *GetBlockWithTxsResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.BlockId == nil {
-				m.BlockId = &types1.BlockID{}
+				m.BlockId = &types2.BlockID{}
 			}
 			if err := m.BlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -3933,7 +4807,7 @@ func (m *GetBlockWithTxsResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Block == nil {
-				m.Block = &types1.Block{}
+				m.Block = &types2.Block{}
 			}
 			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -4668,6 +5542,621 @@ func (m *TxDecodeAminoResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TraceTxRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TraceTxRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TraceTxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TraceTxResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TraceTxResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TraceTxResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GasInfo == nil {
+				m.GasInfo = &types.GasInfo{}
+			}
+			if err := m.GasInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &types.Result{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operations = append(m.Operations, &TraceOperation{})
+			if err := m.Operations[len(m.Operations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TraceOperation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TraceOperation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TraceOperation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= TraceOperation_Kind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depth", wireType)
+			}
+			m.Depth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Depth |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gas", wireType)
+			}
+			m.Gas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StoreKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgTypeUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MsgTypeUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Event == nil {
+				m.Event = &types3.Event{}
+			}
+			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipService(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -13,6 +13,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/errors"
 	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/version"
 )
 
@@ -180,6 +181,41 @@ $ %s query tx --%s=%s <sig1_base64>,<sig2_base64...>
 	return cmd
 }
 
+// TraceTxCmd implements the command to trace the execution of a tx of a
+// committed block.
+func TraceTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace-tx [hash]",
+		Short: "Re-execute a transaction of a committed block and trace its store operations, messages and events",
+		Long: strings.TrimSpace(fmt.Sprintf(`Re-execute a transaction of a committed block on the state of the previous
+block, and trace its execution. The node must not have pruned the state of the
+previous block.
+
+Example:
+$ %s query trace-tx <hash>
+`,
+			version.AppName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			res, err := txtypes.NewServiceClient(clientCtx).TraceTx(cmd.Context(), &txtypes.TraceTxRequest{Hash: args[0]})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
 // ParseSigArgs parses comma-separated signatures from the CLI arguments.
 func ParseSigArgs(args []string) ([]string, error) {
 	if len(args) != 1 || args[0] == "" {
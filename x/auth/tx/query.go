@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"time"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -131,6 +134,92 @@ func getBlocksForTxResults(clientCtx client.Context, resTxs []*coretypes.ResultT
 	return resBlocks, nil
 }
 
+// queryFinalizeBlockRequest queries the block of the transaction with the given
+// hash, and returns the request with which it was finalized, along with the
+// index of the transaction in the block.
+func queryFinalizeBlockRequest(ctx context.Context, clientCtx client.Context, hash []byte) (*abci.RequestFinalizeBlock, int, error) {
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resTx, err := node.Tx(ctx, hash, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resBlock, err := node.Block(ctx, &resTx.Height)
+	if err != nil {
+		return nil, 0, err
+	}
+	block := resBlock.Block
+
+	// the last commit is signed by the validators of the previous block, in
+	// the order of the validator set
+	var votes []abci.VoteInfo
+	if block.LastCommit != nil && block.LastCommit.Height > 0 {
+		validators, err := queryAllValidators(ctx, node, block.LastCommit.Height)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(validators) != len(block.LastCommit.Signatures) {
+			return nil, 0, fmt.Errorf("last commit of block %d has %d signatures for %d validators",
+				block.Height, len(block.LastCommit.Signatures), len(validators))
+		}
+
+		votes = make([]abci.VoteInfo, len(validators))
+		for i, sig := range block.LastCommit.Signatures {
+			votes[i] = abci.VoteInfo{
+				Validator: abci.Validator{
+					Address: validators[i].Address,
+					Power:   validators[i].VotingPower,
+				},
+				BlockIdFlag: cmtproto.BlockIDFlag(sig.BlockIDFlag),
+			}
+		}
+	}
+
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+
+	var round int32
+	if block.LastCommit != nil {
+		round = block.LastCommit.Round
+	}
+
+	return &abci.RequestFinalizeBlock{
+		Txs:                txs,
+		DecidedLastCommit:  abci.CommitInfo{Round: round, Votes: votes},
+		Misbehavior:        block.Evidence.Evidence.ToABCI(),
+		Hash:               resBlock.BlockID.Hash,
+		Height:             block.Height,
+		Time:               block.Time,
+		NextValidatorsHash: block.NextValidatorsHash,
+		ProposerAddress:    block.ProposerAddress,
+	}, int(resTx.Index), nil
+}
+
+// queryAllValidators queries all the pages of the validator set at the given
+// height.
+func queryAllValidators(ctx context.Context, node client.CometRPC, height int64) ([]*cmttypes.Validator, error) {
+	var validators []*cmttypes.Validator
+	page, perPage := 1, 100
+	for {
+		res, err := node.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, res.Validators...)
+		if len(res.Validators) == 0 || len(validators) >= res.Total {
+			return validators, nil
+		}
+		page++
+	}
+}
+
 func mkTxResult(txConfig client.TxConfig, resTx *coretypes.ResultTx, resBlock *coretypes.ResultBlock) (*sdk.TxResponse, error) {
 	txb, err := txConfig.TxDecoder()(resTx.Tx)
 	if err != nil {
@@ -2,16 +2,20 @@ package tx
 
 import (
 	"context"
+	"encoding/hex"
 	"strings"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	"github.com/golang/protobuf/proto" //nolint:staticcheck // keep legacy for now
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	storetypes "cosmossdk.io/store/types"
 	"cosmossdk.io/x/auth/migrations/legacytx"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -24,19 +28,47 @@ import (
 // baseAppSimulateFn is the signature of the Baseapp#Simulate function.
 type baseAppSimulateFn func(txBytes []byte) (sdk.GasInfo, *sdk.Result, error)
 
+// baseAppSimulateWithOverridesFn is the signature of the Baseapp#SimulateWithOverrides function.
+type baseAppSimulateWithOverridesFn func(txBytes []byte, overrides baseapp.SimulationOverrides) (sdk.GasInfo, *sdk.Result, []*storetypes.StoreKVPair, error)
+
+// baseAppTraceTxFn is the signature of the Baseapp#TraceTx function.
+type baseAppTraceTxFn func(ctx context.Context, req *abci.RequestFinalizeBlock, txIndex int) (*txtypes.TraceTxResponse, error)
+
+// TxServerOptions define the optional features of a Tx service server when
+// calling NewTxServerWithOptions.
+type TxServerOptions struct {
+	// SimulateWithOverrides, if specified, is used instead of the Simulate
+	// function to simulate transactions, which enables the state overrides of
+	// the Simulate RPC method and the state diff of its response.
+	SimulateWithOverrides baseAppSimulateWithOverridesFn
+	// TraceTx, if specified, enables the TraceTx RPC method. If nil, the method
+	// returns an Unimplemented error.
+	TraceTx baseAppTraceTxFn
+}
+
 // txServer is the server for the protobuf Tx service.
 type txServer struct {
-	clientCtx         client.Context
-	simulate          baseAppSimulateFn
-	interfaceRegistry codectypes.InterfaceRegistry
+	clientCtx             client.Context
+	simulate              baseAppSimulateFn
+	simulateWithOverrides baseAppSimulateWithOverridesFn
+	traceTx               baseAppTraceTxFn
+	interfaceRegistry     codectypes.InterfaceRegistry
 }
 
 // NewTxServer creates a new Tx service server.
 func NewTxServer(clientCtx client.Context, simulate baseAppSimulateFn, interfaceRegistry codectypes.InterfaceRegistry) txtypes.ServiceServer {
+	return NewTxServerWithOptions(clientCtx, simulate, interfaceRegistry, TxServerOptions{})
+}
+
+// NewTxServerWithOptions creates a new Tx service server with the optional
+// features of the given options.
+func NewTxServerWithOptions(clientCtx client.Context, simulate baseAppSimulateFn, interfaceRegistry codectypes.InterfaceRegistry, opts TxServerOptions) txtypes.ServiceServer {
 	return txServer{
-		clientCtx:         clientCtx,
-		simulate:          simulate,
-		interfaceRegistry: interfaceRegistry,
+		clientCtx:             clientCtx,
+		simulate:              simulate,
+		simulateWithOverrides: opts.SimulateWithOverrides,
+		traceTx:               opts.TraceTx,
+		interfaceRegistry:     interfaceRegistry,
 	}
 }
 
@@ -95,14 +127,31 @@ func (s txServer) Simulate(ctx context.Context, req *txtypes.SimulateRequest) (*
 		return nil, status.Errorf(codes.InvalidArgument, "empty txBytes is not allowed")
 	}
 
-	gasInfo, result, err := s.simulate(txBytes)
+	var (
+		gasInfo   sdk.GasInfo
+		result    *sdk.Result
+		stateDiff []*storetypes.StoreKVPair
+		err       error
+	)
+	switch {
+	case s.simulateWithOverrides != nil:
+		gasInfo, result, stateDiff, err = s.simulateWithOverrides(txBytes, baseapp.SimulationOverrides{
+			KVPairs: req.KvOverrides,
+			Typed:   req.TypedOverrides,
+		})
+	case len(req.KvOverrides) != 0 || len(req.TypedOverrides) != 0:
+		return nil, status.Error(codes.Unimplemented, "state overrides are not supported")
+	default:
+		gasInfo, result, err = s.simulate(txBytes)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Unknown, "%v with gas used: '%d'", err, gasInfo.GasUsed)
 	}
 
 	return &txtypes.SimulateResponse{
-		GasInfo: &gasInfo,
-		Result:  result,
+		GasInfo:   &gasInfo,
+		Result:    result,
+		StateDiff: stateDiff,
 	}, nil
 }
 
@@ -310,6 +359,45 @@ func (s txServer) TxDecodeAmino(_ context.Context, req *txtypes.TxDecodeAminoReq
 	}, nil
 }
 
+// TraceTx implements the ServiceServer.TraceTx RPC method.
+func (s txServer) TraceTx(ctx context.Context, req *txtypes.TraceTxRequest) (*txtypes.TraceTxResponse, error) {
+	if s.traceTx == nil {
+		return nil, status.Error(codes.Unimplemented, "tx tracing is not enabled")
+	}
+
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+
+	if len(req.Hash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tx hash cannot be empty")
+	}
+
+	hash, err := hex.DecodeString(req.Hash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tx hash: %v", err)
+	}
+
+	blockReq, txIndex, err := queryFinalizeBlockRequest(ctx, s.clientCtx, hash)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Errorf(codes.NotFound, "tx not found: %s", req.Hash)
+		}
+
+		return nil, err
+	}
+
+	res, err := s.traceTx(ctx, blockReq, txIndex)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to trace tx %s: %v", req.Hash, err)
+	}
+
+	return res, nil
+}
+
 // RegisterTxService registers the tx service on the gRPC router.
 func RegisterTxService(
 	qrt gogogrpc.Server,
@@ -323,6 +411,21 @@ func RegisterTxService(
 	)
 }
 
+// RegisterTxServiceWithOptions registers the tx service on the gRPC router,
+// with the optional features of the given options.
+func RegisterTxServiceWithOptions(
+	qrt gogogrpc.Server,
+	clientCtx client.Context,
+	simulateFn baseAppSimulateFn,
+	interfaceRegistry codectypes.InterfaceRegistry,
+	opts TxServerOptions,
+) {
+	txtypes.RegisterServiceServer(
+		qrt,
+		NewTxServerWithOptions(clientCtx, simulateFn, interfaceRegistry, opts),
+	)
+}
+
 // RegisterGRPCGatewayRoutes mounts the tx service's GRPC-gateway routes on the
 // given Mux.
 func RegisterGRPCGatewayRoutes(clientConn gogogrpc.ClientConn, mux *runtime.ServeMux) {
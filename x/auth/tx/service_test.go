@@ -0,0 +1,49 @@
+package tx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+func TestTxServerOptions(t *testing.T) {
+	simulate := func([]byte) (sdk.GasInfo, *sdk.Result, error) {
+		return sdk.GasInfo{GasUsed: 1}, &sdk.Result{}, nil
+	}
+	overrides := []*storetypes.StoreKVPair{{StoreKey: "bank", Key: []byte("key"), Value: []byte("value")}}
+	ctx := context.Background()
+
+	s := NewTxServer(client.Context{}, simulate, codectypes.NewInterfaceRegistry())
+
+	res, err := s.Simulate(ctx, &txtypes.SimulateRequest{TxBytes: []byte("tx")})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.GasInfo.GasUsed)
+
+	_, err = s.Simulate(ctx, &txtypes.SimulateRequest{TxBytes: []byte("tx"), KvOverrides: overrides})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = s.TraceTx(ctx, &txtypes.TraceTxRequest{Hash: "00"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	s = NewTxServerWithOptions(client.Context{}, simulate, codectypes.NewInterfaceRegistry(), TxServerOptions{
+		SimulateWithOverrides: func(_ []byte, o baseapp.SimulationOverrides) (sdk.GasInfo, *sdk.Result, []*storetypes.StoreKVPair, error) {
+			return sdk.GasInfo{GasUsed: 2}, &sdk.Result{}, o.KVPairs, nil
+		},
+	})
+
+	res, err = s.Simulate(ctx, &txtypes.SimulateRequest{TxBytes: []byte("tx"), KvOverrides: overrides})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), res.GasInfo.GasUsed)
+	require.Equal(t, overrides, res.StateDiff)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/gasprofile"
 	"github.com/cosmos/cosmos-sdk/types/simulation"
 )
 
@@ -83,6 +84,12 @@ func SimulateFromSeed(
 	accs := randAccFn(r, params.NumKeys())
 	eventStats := NewEventStats()
 
+	var gasProfiler *gasprofile.Profiler
+	if config.GasProfilePath != "" {
+		gasProfiler = gasprofile.NewProfiler()
+		app.SetGasProfiler(gasProfiler)
+	}
+
 	// Second variable to keep pending validator set (delayed one block since
 	// TM 0.24) Initially this is the same as the initial validator set
 	validators, blockTime, accs, chainID := initChain(r, params, accs, app, appStateFn, config, cdc)
@@ -275,6 +282,12 @@ func SimulateFromSeed(
 			eventStats.Print(w)
 		}
 
+		if gasProfiler != nil {
+			if err := exportGasProfile(config.GasProfilePath, gasProfiler); err != nil {
+				return true, exportedParams, err
+			}
+		}
+
 		return true, exportedParams, err
 	}
 
@@ -291,9 +304,31 @@ func SimulateFromSeed(
 		eventStats.Print(w)
 	}
 
+	if gasProfiler != nil {
+		fmt.Println("Exporting gas profile...")
+		if err := exportGasProfile(config.GasProfilePath, gasProfiler); err != nil {
+			return false, exportedParams, err
+		}
+	}
+
 	return false, exportedParams, nil
 }
 
+// exportGasProfile saves the gas profile of the simulated txs on a given path,
+// in the folded stacks format of flame graph tools.
+func exportGasProfile(path string, profiler *gasprofile.Profiler) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := profiler.WriteFolded(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 type blockSimFn func(
 	r *rand.Rand,
 	app *baseapp.BaseApp,